@@ -39,6 +39,8 @@ func main() {
 
 	// Create authenticator
 	authenticator := auth.NewAuthenticator(cfg.CredentialsPath, cfg.TokenPath)
+	authenticator.TokenEncryption = cfg.TokenEncryption
+	authenticator.TokenPassphrase = cfg.TokenPassphrase
 
 	// Load credentials
 	fmt.Println("\nLoading credentials...")
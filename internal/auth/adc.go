@@ -0,0 +1,31 @@
+package auth
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"slices"
+
+	"golang.org/x/oauth2"
+	"golang.org/x/oauth2/google"
+)
+
+// ErrNoDefaultCredentials is returned when Application Default Credentials
+// can't be found.
+var ErrNoDefaultCredentials = errors.New("no application default credentials found (run gcloud auth application-default login or set GOOGLE_APPLICATION_CREDENTIALS)")
+
+// DefaultClient returns an HTTP client authorized with Application Default
+// Credentials for Scopes and any extraScopes, e.g. ManageCalendarsScope,
+// instead of a credentials file and saved token.
+// They are looked up as google.FindDefaultCredentials does: the file named
+// by GOOGLE_APPLICATION_CREDENTIALS, then the gcloud application default
+// login, then the metadata server on Google Cloud. Tokens are refreshed as
+// needed but never written to disk by calgo.
+func DefaultClient(ctx context.Context, extraScopes ...string) (*http.Client, error) {
+	scopes := append(slices.Clone(Scopes), extraScopes...)
+	creds, err := google.FindDefaultCredentials(ctx, scopes...)
+	if err != nil {
+		return nil, wrapAuthError(ErrNoDefaultCredentials, err)
+	}
+	return oauth2.NewClient(ctx, creds.TokenSource), nil
+}
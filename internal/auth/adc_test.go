@@ -0,0 +1,29 @@
+package auth
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestDefaultClient(t *testing.T) {
+	dir := t.TempDir()
+	credsPath := filepath.Join(dir, "adc.json")
+	creds := `{"type":"authorized_user","client_id":"id.apps.googleusercontent.com","client_secret":"secret","refresh_token":"refresh"}`
+	if err := os.WriteFile(credsPath, []byte(creds), 0600); err != nil {
+		t.Fatalf("Failed to write credentials: %v", err)
+	}
+
+	t.Setenv("GOOGLE_APPLICATION_CREDENTIALS", credsPath)
+	client, err := DefaultClient(context.Background())
+	if err != nil || client == nil {
+		t.Fatalf("DefaultClient() = %v, %v", client, err)
+	}
+
+	t.Setenv("GOOGLE_APPLICATION_CREDENTIALS", filepath.Join(dir, "missing.json"))
+	if _, err := DefaultClient(context.Background()); !errors.Is(err, ErrNoDefaultCredentials) {
+		t.Errorf("DefaultClient() with a missing file error = %v, want ErrNoDefaultCredentials", err)
+	}
+}
@@ -0,0 +1,83 @@
+package auth
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"golang.org/x/oauth2"
+	"golang.org/x/oauth2/google"
+)
+
+// deviceFlow performs the OAuth2 device authorization flow (RFC 8628): it
+// prints a URL and a short code for the user to enter on another device,
+// then polls until they approve, deny, or the code expires. No browser or
+// callback server is needed, but the credentials must be for an OAuth
+// client of type "TVs and Limited Input devices". Google doesn't support
+// Calendar scopes in this flow, so with Scopes it fails with errDeviceScope.
+func (a *Authenticator) deviceFlow(ctx context.Context) (*oauth2.Token, error) {
+	// Credentials files don't name the device endpoint
+	config := *a.config
+	if config.Endpoint.DeviceAuthURL == "" {
+		config.Endpoint.DeviceAuthURL = google.Endpoint.DeviceAuthURL
+	}
+
+	response, err := config.DeviceAuth(ctx)
+	if err != nil {
+		if cause := flowCancelled(ctx); cause != nil {
+			return nil, cause
+		}
+		var retrieveErr *oauth2.RetrieveError
+		if errors.As(err, &retrieveErr) && retrieveErr.ErrorCode == "invalid_scope" {
+			return nil, errDeviceScope
+		}
+		return nil, wrapAuthError(ErrAuthenticationFailed, err)
+	}
+
+	verificationURL := response.VerificationURIComplete
+	if verificationURL == "" {
+		verificationURL = response.VerificationURI
+	}
+	fmt.Fprintln(a.out, a.Messages.DevicePrompt)
+	fmt.Fprintf(a.out, "\n  %s\n\n  %s\n\n", verificationURL, response.UserCode)
+	fmt.Fprintln(a.out, a.Messages.Waiting)
+
+	// Polls at the interval the server asks for, until the code expires
+	token, err := config.DeviceAccessToken(ctx, response)
+	if err == nil {
+		return token, nil
+	}
+	if cause := flowCancelled(ctx); cause != nil {
+		return nil, cause
+	}
+
+	var retrieveErr *oauth2.RetrieveError
+	switch {
+	case errors.Is(err, context.DeadlineExceeded):
+		return nil, fmt.Errorf("%w: the code expired before it was entered", ErrAuthenticationFailed)
+	case errors.As(err, &retrieveErr) && retrieveErr.ErrorCode == "access_denied":
+		return nil, fmt.Errorf("%w: access was denied", ErrAuthenticationFailed)
+	case errors.As(err, &retrieveErr) && retrieveErr.ErrorCode == "expired_token":
+		return nil, fmt.Errorf("%w: the code expired before it was entered", ErrAuthenticationFailed)
+	case errors.As(err, &retrieveErr) && retrieveErr.ErrorCode == "invalid_scope":
+		return nil, errDeviceScope
+	}
+	return nil, wrapAuthError(ErrAuthenticationFailed, err)
+}
+
+// errDeviceScope is reported when Google refuses calgo's scopes in the
+// device flow, which doesn't support Calendar scopes.
+var errDeviceScope = fmt.Errorf("%w: Google doesn't support calendar access with the device flow; run calgo auth login --no-browser instead", ErrAuthenticationFailed)
+
+// flowCancelled returns the error to report when ctx, the context of an
+// authentication flow, was cancelled: ErrAuthCancelled after CancelAuth,
+// otherwise the context's error. It returns nil while ctx is live.
+func flowCancelled(ctx context.Context) error {
+	if ctx.Err() == nil {
+		return nil
+	}
+	if errors.Is(context.Cause(ctx), ErrAuthCancelled) {
+		return ErrAuthCancelled
+	}
+	return ctx.Err()
+}
@@ -0,0 +1,121 @@
+package auth
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+)
+
+// newDeviceAuthenticator returns an authenticator using the device flow
+// against a fake server that issues a code and answers polls with
+// tokenResponses in turn, repeating the last one.
+func newDeviceAuthenticator(t *testing.T, tokenResponses ...string) (*Authenticator, *bytes.Buffer) {
+	t.Helper()
+
+	var polls atomic.Int32
+	auth, _ := newRepairAuthenticator(t, func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		if r.URL.Path == "/device" {
+			fmt.Fprint(w, `{"device_code":"dev-code","user_code":"ABCD-EFGH","verification_url":"https://www.google.com/device","expires_in":60,"interval":1}`)
+			return
+		}
+
+		if err := r.ParseForm(); err != nil || r.Form.Get("device_code") != "dev-code" {
+			w.WriteHeader(http.StatusBadRequest)
+			fmt.Fprint(w, `{"error":"invalid_request"}`)
+			return
+		}
+		response := tokenResponses[min(int(polls.Add(1)), len(tokenResponses))-1]
+		if strings.Contains(response, `"error"`) {
+			w.WriteHeader(http.StatusBadRequest)
+		}
+		fmt.Fprint(w, response)
+	})
+	auth.config.Endpoint.DeviceAuthURL = strings.TrimSuffix(auth.config.Endpoint.TokenURL, "/") + "/device"
+	auth.Flow = FlowDevice
+
+	var out bytes.Buffer
+	auth.out = &out
+	return auth, &out
+}
+
+func TestLogin_Device(t *testing.T) {
+	// The client may try two ways of sending its secret on each poll
+	auth, out := newDeviceAuthenticator(t,
+		`{"error":"authorization_pending"}`,
+		`{"error":"authorization_pending"}`,
+		`{"access_token":"device-access-token","refresh_token":"device-refresh-token","token_type":"Bearer","expires_in":3600}`,
+	)
+
+	token, err := auth.Login(context.Background())
+	if err != nil {
+		t.Fatalf("Login() error = %v", err)
+	}
+	if token.AccessToken != "device-access-token" {
+		t.Errorf("Login() token = %q, want device-access-token", token.AccessToken)
+	}
+	if !strings.Contains(out.String(), "https://www.google.com/device") || !strings.Contains(out.String(), "ABCD-EFGH") {
+		t.Errorf("output doesn't show the URL and code:\n%s", out)
+	}
+
+	saved, err := auth.loadToken()
+	if err != nil {
+		t.Fatalf("loadToken failed: %v", err)
+	}
+	if saved.RefreshToken != "device-refresh-token" {
+		t.Errorf("saved refresh token = %q, want the new one", saved.RefreshToken)
+	}
+}
+
+func TestLogin_DeviceDenied(t *testing.T) {
+	auth, _ := newDeviceAuthenticator(t, `{"error":"access_denied"}`)
+
+	_, err := auth.Login(context.Background())
+	if !errors.Is(err, ErrAuthenticationFailed) || !strings.Contains(err.Error(), "denied") {
+		t.Errorf("Login() error = %v, want ErrAuthenticationFailed for denied access", err)
+	}
+
+	// The saved token is kept when login fails
+	if saved, err := auth.loadToken(); err != nil || saved.AccessToken != "old-access-token" {
+		t.Errorf("saved token after failed login = %+v, %v", saved, err)
+	}
+}
+
+func TestLogin_DeviceInvalidScope(t *testing.T) {
+	// Google refuses the scopes when issuing the code
+	auth, _ := newDeviceAuthenticator(t)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusBadRequest)
+		fmt.Fprint(w, `{"error":"invalid_scope","error_description":"Invalid device flow scope"}`)
+	}))
+	t.Cleanup(server.Close)
+	auth.config.Endpoint.DeviceAuthURL = server.URL
+
+	_, err := auth.Login(context.Background())
+	if !errors.Is(err, ErrAuthenticationFailed) || !strings.Contains(err.Error(), "--no-browser") {
+		t.Errorf("Login() error = %v, want ErrAuthenticationFailed suggesting --no-browser", err)
+	}
+
+	// Or when the code is redeemed
+	auth, _ = newDeviceAuthenticator(t, `{"error":"invalid_scope"}`)
+	_, err = auth.Login(context.Background())
+	if !errors.Is(err, ErrAuthenticationFailed) || !strings.Contains(err.Error(), "--no-browser") {
+		t.Errorf("Login() error = %v, want ErrAuthenticationFailed suggesting --no-browser", err)
+	}
+}
+
+func TestLogin_InvalidFlow(t *testing.T) {
+	auth, _ := newDeviceAuthenticator(t)
+	auth.Flow = "carrier-pigeon"
+
+	if _, err := auth.Login(context.Background()); !errors.Is(err, ErrInvalidFlow) {
+		t.Errorf("Login() error = %v, want ErrInvalidFlow", err)
+	}
+}
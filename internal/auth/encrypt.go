@@ -0,0 +1,234 @@
+package auth
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"os/user"
+	"regexp"
+	"runtime"
+	"strings"
+
+	"golang.org/x/crypto/scrypt"
+)
+
+// Token encryption modes for Authenticator.TokenEncryption.
+const (
+	// EncryptionPassphrase keys the token file with
+	// Authenticator.TokenPassphrase.
+	EncryptionPassphrase = "passphrase"
+
+	// EncryptionMachine keys the token file with a secret derived from
+	// this machine's ID and the current user, for unattended use. It
+	// keeps the token from being used if the file is copied elsewhere,
+	// but not from other programs running as the same user.
+	EncryptionMachine = "machine"
+)
+
+// Errors for token encryption.
+var (
+	ErrInvalidEncryption  = errors.New("invalid token encryption setting")
+	ErrPassphraseRequired = errors.New("token file is encrypted with a passphrase; set CALGO_TOKEN_PASSPHRASE")
+	ErrTokenDecryptFailed = errors.New("failed to decrypt token file")
+)
+
+// tokenCipher names the cipher of encrypted token files.
+const tokenCipher = "aes-256-gcm"
+
+// scrypt cost parameters for new token files. Each file records its own,
+// so they can be raised without breaking existing files.
+var (
+	scryptN = 1 << 15
+	scryptR = 8
+	scryptP = 1
+)
+
+// Bounds on the scrypt parameters read from a token file, so a tampered
+// file can't make loading it take unbounded time and memory. scrypt needs
+// 128*N*r bytes and time proportional to N*r*p; maxScryptWork caps that
+// product at 1 GiB of memory.
+const (
+	maxScryptN    = 1 << 20
+	maxScryptR    = 32
+	maxScryptP    = 16
+	maxScryptWork = 1 << 23
+)
+
+// encryptedTokenFile is the on-disk format of an encrypted token. The
+// plaintext is the storedToken JSON.
+type encryptedTokenFile struct {
+	Encryption string `json:"encryption"`
+	Key        string `json:"key"`
+	ScryptN    int    `json:"scrypt_n"`
+	ScryptR    int    `json:"scrypt_r"`
+	ScryptP    int    `json:"scrypt_p"`
+	Salt       []byte `json:"salt"`
+	Nonce      []byte `json:"nonce"`
+	Ciphertext []byte `json:"ciphertext"`
+}
+
+// tokenEncryption returns the mode data was saved with: "" for a
+// plaintext token, otherwise the key the encrypted file was keyed with.
+func tokenEncryption(data []byte) string {
+	var probe struct {
+		Encryption string `json:"encryption"`
+		Key        string `json:"key"`
+	}
+	if json.Unmarshal(data, &probe) != nil || probe.Encryption == "" {
+		return ""
+	}
+	return probe.Key
+}
+
+// encryptToken encrypts the token JSON in a.TokenEncryption mode.
+func (a *Authenticator) encryptToken(plaintext []byte) ([]byte, error) {
+	file := encryptedTokenFile{
+		Encryption: tokenCipher,
+		Key:        a.TokenEncryption,
+		ScryptN:    scryptN,
+		ScryptR:    scryptR,
+		ScryptP:    scryptP,
+		Salt:       make([]byte, 16),
+	}
+	if _, err := rand.Read(file.Salt); err != nil {
+		return nil, err
+	}
+
+	aead, err := a.tokenAEAD(file)
+	if err != nil {
+		return nil, err
+	}
+	file.Nonce = make([]byte, aead.NonceSize())
+	if _, err := rand.Read(file.Nonce); err != nil {
+		return nil, err
+	}
+	file.Ciphertext = aead.Seal(nil, file.Nonce, plaintext, []byte(file.Key))
+
+	return json.MarshalIndent(file, "", "  ")
+}
+
+// decryptToken returns the token JSON from an encrypted token file.
+func (a *Authenticator) decryptToken(data []byte) ([]byte, error) {
+	var file encryptedTokenFile
+	if err := json.Unmarshal(data, &file); err != nil {
+		return nil, fmt.Errorf("failed to parse token file: %w", err)
+	}
+	if file.Encryption != tokenCipher {
+		return nil, fmt.Errorf("%w: unsupported cipher %q", ErrTokenDecryptFailed, file.Encryption)
+	}
+	if err := checkScryptParams(file); err != nil {
+		return nil, err
+	}
+
+	aead, err := a.tokenAEAD(file)
+	if err != nil {
+		return nil, err
+	}
+	plaintext, err := aead.Open(nil, file.Nonce, file.Ciphertext, []byte(file.Key))
+	if err != nil {
+		if file.Key == EncryptionPassphrase {
+			return nil, fmt.Errorf("%w: wrong passphrase", ErrTokenDecryptFailed)
+		}
+		return nil, fmt.Errorf("%w: it was encrypted on another machine or by another user", ErrTokenDecryptFailed)
+	}
+	return plaintext, nil
+}
+
+// checkScryptParams rejects scrypt parameters outside the bounds above.
+func checkScryptParams(file encryptedTokenFile) error {
+	n, r, p := file.ScryptN, file.ScryptR, file.ScryptP
+	if n < 2 || r < 1 || p < 1 {
+		return fmt.Errorf("%w: invalid key derivation parameters", ErrTokenDecryptFailed)
+	}
+	if n > maxScryptN || r > maxScryptR || p > maxScryptP || n*r*p > maxScryptWork {
+		return fmt.Errorf("%w: key derivation cost is too high", ErrTokenDecryptFailed)
+	}
+	return nil
+}
+
+// tokenAEAD derives the key for file and returns its cipher.
+func (a *Authenticator) tokenAEAD(file encryptedTokenFile) (cipher.AEAD, error) {
+	var secret []byte
+	switch file.Key {
+	case EncryptionPassphrase:
+		if a.TokenPassphrase == "" {
+			return nil, ErrPassphraseRequired
+		}
+		secret = []byte(a.TokenPassphrase)
+	case EncryptionMachine:
+		id, err := a.machineSecret()
+		if err != nil {
+			return nil, err
+		}
+		secret = []byte(id)
+	default:
+		return nil, fmt.Errorf("%w: %q (use passphrase or machine)", ErrInvalidEncryption, file.Key)
+	}
+
+	key, err := scrypt.Key(secret, file.Salt, file.ScryptN, file.ScryptR, file.ScryptP, 32)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrTokenDecryptFailed, err)
+	}
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+	return cipher.NewGCM(block)
+}
+
+// machineSecret returns the secret EncryptionMachine keys are derived
+// from: the machine ID together with the current user.
+func (a *Authenticator) machineSecret() (string, error) {
+	id, err := a.machineID()
+	if err != nil {
+		return "", fmt.Errorf("%w: can't read the machine ID: %v", ErrInvalidEncryption, err)
+	}
+
+	username := ""
+	if u, err := user.Current(); err == nil {
+		username = u.Uid + ":" + u.Username
+	}
+	return "calgo\x00" + id + "\x00" + username, nil
+}
+
+// ioregUUIDPattern finds the hardware UUID in ioreg output on macOS.
+var ioregUUIDPattern = regexp.MustCompile(`"IOPlatformUUID" = "([^"]+)"`)
+
+// readMachineID returns an identifier that is stable for this machine
+// across reboots.
+func readMachineID() (string, error) {
+	switch runtime.GOOS {
+	case "darwin":
+		out, err := exec.Command("ioreg", "-rd1", "-c", "IOPlatformExpertDevice").Output()
+		if err != nil {
+			return "", err
+		}
+		if m := ioregUUIDPattern.FindSubmatch(out); m != nil {
+			return string(m[1]), nil
+		}
+		return "", errors.New("no IOPlatformUUID in ioreg output")
+	case "windows":
+		out, err := exec.Command("reg", "query", `HKLM\SOFTWARE\Microsoft\Cryptography`, "/v", "MachineGuid").Output()
+		if err != nil {
+			return "", err
+		}
+		fields := strings.Fields(string(out))
+		if len(fields) == 0 {
+			return "", errors.New("no MachineGuid in registry output")
+		}
+		return fields[len(fields)-1], nil
+	}
+
+	for _, path := range []string{"/etc/machine-id", "/var/lib/dbus/machine-id", "/etc/hostid"} {
+		data, err := os.ReadFile(path)
+		if id := strings.TrimSpace(string(data)); err == nil && id != "" {
+			return id, nil
+		}
+	}
+	return "", errors.New("no machine ID found")
+}
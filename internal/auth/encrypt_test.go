@@ -0,0 +1,186 @@
+package auth
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"os"
+	"strings"
+	"testing"
+	"time"
+
+	"golang.org/x/oauth2"
+)
+
+func init() {
+	// Keep key derivation fast in tests
+	scryptN = 1 << 10
+}
+
+// newEncryptAuthenticator returns an authenticator with a valid plaintext
+// token saved and a fixed machine ID.
+func newEncryptAuthenticator(t *testing.T) (*Authenticator, string) {
+	t.Helper()
+	auth, tokenPath := newRepairAuthenticator(t, nil)
+	auth.machineID = func() (string, error) { return "test-machine", nil }
+
+	token := &oauth2.Token{
+		AccessToken:  "access-token",
+		RefreshToken: "refresh-token",
+		TokenType:    "Bearer",
+		Expiry:       time.Now().Add(time.Hour),
+	}
+	if err := auth.saveToken(token.WithExtra(map[string]interface{}{"scope": strings.Join(auth.config.Scopes, " ")})); err != nil {
+		t.Fatalf("saveToken failed: %v", err)
+	}
+	return auth, tokenPath
+}
+
+func TestEncryptedToken_RoundTrip(t *testing.T) {
+	tests := []struct {
+		name       string
+		encryption string
+		passphrase string
+	}{
+		{"passphrase", EncryptionPassphrase, "correct horse"},
+		{"machine", EncryptionMachine, ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			auth, tokenPath := newEncryptAuthenticator(t)
+			auth.TokenEncryption = tt.encryption
+			auth.TokenPassphrase = tt.passphrase
+
+			token, _ := auth.loadToken()
+			if err := auth.saveToken(token); err != nil {
+				t.Fatalf("saveToken failed: %v", err)
+			}
+
+			data, err := os.ReadFile(tokenPath)
+			if err != nil {
+				t.Fatalf("ReadFile failed: %v", err)
+			}
+			if bytes.Contains(data, []byte("access-token")) || bytes.Contains(data, []byte("refresh-token")) {
+				t.Fatalf("token file holds the token in plaintext:\n%s", data)
+			}
+			if got := tokenEncryption(data); got != tt.encryption {
+				t.Errorf("tokenEncryption() = %q, want %q", got, tt.encryption)
+			}
+
+			loaded, err := auth.loadToken()
+			if err != nil {
+				t.Fatalf("loadToken failed: %v", err)
+			}
+			if loaded.AccessToken != "access-token" || loaded.RefreshToken != "refresh-token" {
+				t.Errorf("loadToken() = %+v, want the saved token", loaded)
+			}
+		})
+	}
+}
+
+func TestEncryptedToken_WrongKey(t *testing.T) {
+	auth, _ := newEncryptAuthenticator(t)
+	auth.TokenEncryption = EncryptionPassphrase
+	auth.TokenPassphrase = "correct horse"
+	token, _ := auth.loadToken()
+	if err := auth.saveToken(token); err != nil {
+		t.Fatalf("saveToken failed: %v", err)
+	}
+
+	auth.TokenPassphrase = "battery staple"
+	if _, err := auth.GetToken(context.Background()); !errors.Is(err, ErrTokenDecryptFailed) {
+		t.Errorf("GetToken() with the wrong passphrase error = %v, want ErrTokenDecryptFailed", err)
+	}
+	auth.TokenPassphrase = ""
+	if _, err := auth.GetToken(context.Background()); !errors.Is(err, ErrPassphraseRequired) {
+		t.Errorf("GetToken() without a passphrase error = %v, want ErrPassphraseRequired", err)
+	}
+
+	// A file from another machine can't be read
+	auth.TokenEncryption = EncryptionMachine
+	auth.TokenPassphrase = "correct horse"
+	if err := auth.saveToken(token); err != nil {
+		t.Fatalf("saveToken failed: %v", err)
+	}
+	auth.machineID = func() (string, error) { return "other-machine", nil }
+	if _, err := auth.loadToken(); !errors.Is(err, ErrTokenDecryptFailed) {
+		t.Errorf("loadToken() on another machine error = %v, want ErrTokenDecryptFailed", err)
+	}
+}
+
+func TestGetToken_MigratesEncryption(t *testing.T) {
+	auth, tokenPath := newEncryptAuthenticator(t)
+	auth.TokenEncryption = EncryptionMachine
+
+	token, err := auth.GetToken(context.Background())
+	if err != nil {
+		t.Fatalf("GetToken() error = %v", err)
+	}
+	if token.AccessToken != "access-token" {
+		t.Errorf("GetToken() = %+v, want the saved token", token)
+	}
+
+	data, err := os.ReadFile(tokenPath)
+	if err != nil {
+		t.Fatalf("ReadFile failed: %v", err)
+	}
+	if got := tokenEncryption(data); got != EncryptionMachine {
+		t.Errorf("plaintext token saved as %q after GetToken, want machine", got)
+	}
+
+	// Turning encryption off converts it back
+	auth.TokenEncryption = ""
+	if _, err := auth.GetToken(context.Background()); err != nil {
+		t.Fatalf("GetToken() error = %v", err)
+	}
+	data, _ = os.ReadFile(tokenPath)
+	if got := tokenEncryption(data); got != "" {
+		t.Errorf("token saved as %q after turning encryption off, want plaintext", got)
+	}
+}
+
+func TestEncryptedToken_ScryptBounds(t *testing.T) {
+	tests := []struct {
+		name    string
+		n, r, p int
+	}{
+		{"large N", 1 << 30, 8, 1},
+		{"large r", 1 << 10, 1 << 16, 1},
+		{"large p", 1 << 10, 8, 1 << 16},
+		{"large product", 1 << 20, 32, 16},
+		{"zero r", 1 << 10, 0, 1},
+		{"negative p", 1 << 10, 8, -1},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			auth, tokenPath := newEncryptAuthenticator(t)
+			auth.TokenEncryption = EncryptionMachine
+			token, _ := auth.loadToken()
+			if err := auth.saveToken(token); err != nil {
+				t.Fatalf("saveToken failed: %v", err)
+			}
+
+			// Tamper with the cost recorded in the file
+			data, err := os.ReadFile(tokenPath)
+			if err != nil {
+				t.Fatalf("ReadFile failed: %v", err)
+			}
+			var file encryptedTokenFile
+			if err := json.Unmarshal(data, &file); err != nil {
+				t.Fatalf("Unmarshal failed: %v", err)
+			}
+			file.ScryptN, file.ScryptR, file.ScryptP = tt.n, tt.r, tt.p
+			if data, err = json.Marshal(file); err != nil {
+				t.Fatalf("Marshal failed: %v", err)
+			}
+			if err := os.WriteFile(tokenPath, data, 0600); err != nil {
+				t.Fatalf("WriteFile failed: %v", err)
+			}
+
+			if _, err := auth.loadToken(); !errors.Is(err, ErrTokenDecryptFailed) {
+				t.Errorf("loadToken() error = %v, want ErrTokenDecryptFailed", err)
+			}
+		})
+	}
+}
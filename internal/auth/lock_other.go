@@ -0,0 +1,12 @@
+//go:build !(darwin || dragonfly || freebsd || linux || netbsd || openbsd)
+
+package auth
+
+import "context"
+
+// lockFile is a no-op where flock isn't available. Refreshes within one
+// process are still shared, and saveToken replaces the token file in one
+// step, so a reader never sees a partly written token.
+func lockFile(ctx context.Context, path string) (func(), error) {
+	return func() {}, nil
+}
@@ -0,0 +1,76 @@
+//go:build darwin || dragonfly || freebsd || linux || netbsd || openbsd
+
+package auth
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"golang.org/x/oauth2"
+)
+
+func TestGetToken_WaitsForOtherProcess(t *testing.T) {
+	var refreshes atomic.Int32
+	auth, tokenPath := newRepairAuthenticator(t, func(w http.ResponseWriter, r *http.Request) {
+		refreshes.Add(1)
+		w.WriteHeader(http.StatusInternalServerError)
+	})
+
+	// Hold the lock as another calgo process refreshing the token would
+	unlock, err := lockFile(context.Background(), tokenPath+".lock")
+	if err != nil {
+		t.Fatalf("lockFile() error = %v", err)
+	}
+
+	done := make(chan *oauth2.Token, 1)
+	go func() {
+		token, err := auth.GetToken(context.Background())
+		if err != nil {
+			t.Errorf("GetToken() error = %v", err)
+		}
+		done <- token
+	}()
+
+	time.Sleep(100 * time.Millisecond)
+	other := NewAuthenticator("", tokenPath)
+	if err := other.saveToken(&oauth2.Token{
+		AccessToken:  "other-access-token",
+		RefreshToken: "old-refresh-token",
+		TokenType:    "Bearer",
+		Expiry:       time.Now().Add(time.Hour),
+	}); err != nil {
+		t.Fatalf("saveToken failed: %v", err)
+	}
+	unlock()
+
+	select {
+	case token := <-done:
+		if token == nil || token.AccessToken != "other-access-token" {
+			t.Errorf("GetToken() = %+v, want the token saved by the other process", token)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("GetToken() didn't return after the lock was released")
+	}
+	if n := refreshes.Load(); n != 0 {
+		t.Errorf("token refreshed %d times, want none", n)
+	}
+}
+
+func TestLockFile_ContextCancelled(t *testing.T) {
+	path := t.TempDir() + "/token.json.lock"
+	unlock, err := lockFile(context.Background(), path)
+	if err != nil {
+		t.Fatalf("lockFile() error = %v", err)
+	}
+	defer unlock()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+	if _, err := lockFile(ctx, path); !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("lockFile() on a held lock error = %v, want context.DeadlineExceeded", err)
+	}
+}
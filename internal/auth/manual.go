@@ -0,0 +1,110 @@
+package auth
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"net/url"
+	"strings"
+	"time"
+
+	"golang.org/x/oauth2"
+)
+
+// manualRedirectURL is where Google sends the browser after the manual
+// flow. Nothing needs to listen there: the user copies the code from the
+// address of the page that fails to load.
+const manualRedirectURL = "http://localhost"
+
+// manualFlow performs the authorization code flow without a callback
+// server: the authorization URL is printed, and the code is read from a.in
+// once the user has authorized calgo in a browser anywhere. PKCE ties the
+// code to this process, since it travels through the clipboard.
+func (a *Authenticator) manualFlow(ctx context.Context) (*oauth2.Token, error) {
+	config := *a.config
+	config.RedirectURL = manualRedirectURL
+
+	verifier := oauth2.GenerateVerifier()
+	authURL := config.AuthCodeURL("state-token", oauth2.AccessTypeOffline, oauth2.S256ChallengeOption(verifier))
+
+	fmt.Fprintln(a.out, a.Messages.ManualPrompt)
+	fmt.Fprintf(a.out, "\n%s\n\n", authURL)
+	fmt.Fprintln(a.out, a.Messages.ManualPaste)
+
+	input, err := a.readPasted(ctx)
+	if err != nil {
+		return nil, err
+	}
+	code, err := parsePastedCode(input)
+	if err != nil {
+		return nil, err
+	}
+
+	token, err := config.Exchange(ctx, code, oauth2.VerifierOption(verifier))
+	if err != nil {
+		return nil, wrapAuthError(ErrAuthenticationFailed, err)
+	}
+	return token, nil
+}
+
+// readPasted reads a line from a.in, giving up when ctx is done or after
+// the same five minutes the browser flow waits.
+func (a *Authenticator) readPasted(ctx context.Context) (string, error) {
+	lines := make(chan string, 1)
+	errs := make(chan error, 1)
+	go func() {
+		line, err := bufio.NewReader(a.in).ReadString('\n')
+		if err != nil && line == "" {
+			errs <- err
+			return
+		}
+		lines <- line
+	}()
+
+	select {
+	case line := <-lines:
+		return line, nil
+	case err := <-errs:
+		return "", fmt.Errorf("%w: no code entered: %v", ErrAuthenticationFailed, err)
+	case <-ctx.Done():
+		return "", flowCancelled(ctx)
+	case <-time.After(5 * time.Minute):
+		return "", fmt.Errorf("%w: timeout waiting for the code", ErrAuthenticationFailed)
+	}
+}
+
+// parsePastedCode returns the authorization code from what the user
+// pasted: the code itself, or the URL it was redirected to.
+func parsePastedCode(input string) (string, error) {
+	input = strings.TrimSpace(input)
+	if input == "" {
+		return "", fmt.Errorf("%w: no code entered", ErrAuthenticationFailed)
+	}
+
+	// Browsers often hide the scheme of the address
+	address := input
+	if strings.HasPrefix(address, "localhost") {
+		address = "http://" + address
+	}
+	if !strings.Contains(address, "://") {
+		return input, nil
+	}
+
+	parsed, err := url.Parse(address)
+	if err != nil {
+		return "", fmt.Errorf("%w: can't read the pasted address: %v", ErrAuthenticationFailed, err)
+	}
+
+	query := parsed.Query()
+	if reason := query.Get("error"); reason != "" {
+		return "", fmt.Errorf("%w: %s", ErrAuthenticationFailed, reason)
+	}
+	if state := query.Get("state"); state != "" && state != "state-token" {
+		return "", fmt.Errorf("%w: the address is from another authorization attempt", ErrAuthenticationFailed)
+	}
+	code := query.Get("code")
+	if code == "" {
+		return "", fmt.Errorf("%w: no code in the pasted address; paste the address of the page your browser was sent to after authorizing", ErrAuthenticationFailed)
+	}
+	return code, nil
+}
@@ -0,0 +1,79 @@
+package auth
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"testing"
+)
+
+func TestParsePastedCode(t *testing.T) {
+	tests := []struct {
+		name    string
+		input   string
+		want    string
+		wantErr bool
+	}{
+		{"bare code", "  4/0AbCdEf-gh\n", "4/0AbCdEf-gh", false},
+		{"redirect URL", "http://localhost/?state=state-token&code=4%2F0AbC&scope=x", "4/0AbC", false},
+		{"without scheme", "localhost/?code=4/0AbC&state=state-token", "4/0AbC", false},
+		{"denied", "http://localhost/?error=access_denied&state=state-token", "", true},
+		{"other attempt", "http://localhost/?code=abc&state=other", "", true},
+		{"URL without code", "http://localhost/", "", true},
+		{"empty", "\n", "", true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := parsePastedCode(tt.input)
+			if tt.wantErr {
+				if !errors.Is(err, ErrAuthenticationFailed) {
+					t.Errorf("parsePastedCode() error = %v, want ErrAuthenticationFailed", err)
+				}
+				return
+			}
+			if err != nil || got != tt.want {
+				t.Errorf("parsePastedCode() = %q, %v, want %q", got, err, tt.want)
+			}
+		})
+	}
+}
+
+func TestLogin_Manual(t *testing.T) {
+	var exchanged url.Values
+	auth, _ := newRepairAuthenticator(t, func(w http.ResponseWriter, r *http.Request) {
+		r.ParseForm()
+		exchanged = r.Form
+		w.Header().Set("Content-Type", "application/json")
+		fmt.Fprint(w, `{"access_token":"manual-access-token","refresh_token":"manual-refresh-token","token_type":"Bearer","expires_in":3600}`)
+	})
+	auth.Flow = FlowManual
+	var out bytes.Buffer
+	auth.out = &out
+	auth.in = strings.NewReader("http://localhost/?state=state-token&code=pasted-code\n")
+
+	token, err := auth.Login(context.Background())
+	if err != nil {
+		t.Fatalf("Login() error = %v", err)
+	}
+	if token.AccessToken != "manual-access-token" {
+		t.Errorf("Login() token = %q, want manual-access-token", token.AccessToken)
+	}
+
+	if exchanged.Get("code") != "pasted-code" || exchanged.Get("code_verifier") == "" {
+		t.Errorf("exchange request = %v, want the pasted code and a PKCE verifier", exchanged)
+	}
+	if exchanged.Get("redirect_uri") != manualRedirectURL {
+		t.Errorf("redirect_uri = %q, want %q", exchanged.Get("redirect_uri"), manualRedirectURL)
+	}
+	if !strings.Contains(out.String(), "code_challenge=") || !strings.Contains(out.String(), "redirect_uri=http%3A%2F%2Flocalhost&") {
+		t.Errorf("printed authorization URL lacks the PKCE challenge or redirect:\n%s", out.String())
+	}
+
+	if saved, err := auth.loadToken(); err != nil || saved.RefreshToken != "manual-refresh-token" {
+		t.Errorf("saved token = %+v, %v, want the new token", saved, err)
+	}
+}
@@ -5,55 +5,216 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"html"
+	"io"
+	"io/fs"
 	"net"
 	"net/http"
+	"net/url"
 	"os"
 	"os/exec"
+	"path/filepath"
 	"runtime"
+	"sync"
 	"time"
 
 	"golang.org/x/oauth2"
 	"golang.org/x/oauth2/google"
+	"golang.org/x/sync/singleflight"
 	"google.golang.org/api/calendar/v3"
 )
 
-// Scopes required for Google Calendar access.
+// Scopes required for Google Calendar access: calendar.events to manage
+// events, and calendar.readonly to list calendars, look them up by name and
+// query busy time for busy, free and block --avoid-conflicts.
 var Scopes = []string{
 	calendar.CalendarEventsScope,
+	calendar.CalendarReadonlyScope,
 }
 
+// ManageCalendarsScope is the full calendar scope, requested on top of
+// Scopes only when Authenticator.ManageCalendars is set: creating, renaming
+// and deleting secondary calendars needs it, and nothing else does.
+const ManageCalendarsScope = calendar.CalendarScope
+
 // Errors for authentication.
 var (
-	ErrInvalidCredentials  = errors.New("invalid credentials file format")
-	ErrAuthenticationFailed = errors.New("authentication failed")
-	ErrTokenRefreshFailed   = errors.New("token refresh failed")
+	ErrInvalidCredentials     = errors.New("invalid credentials file format")
+	ErrAuthenticationFailed   = errors.New("authentication failed")
+	ErrTokenRefreshFailed     = errors.New("token refresh failed")
+	ErrInsufficientScope      = errors.New("saved token is missing required scopes")
+	ErrAuthCancelled          = errors.New("authentication cancelled")
+	ErrCredentialsNotFound    = errors.New("credentials file not found")
+	ErrReauthRequired         = errors.New("saved token was revoked or expired; re-authentication required")
+	ErrScopeMismatch          = errors.New("saved token scopes don't match the requested scopes")
+	ErrMissingCredentialsPath = errors.New("no credentials file path configured (set GOOGLE_CALENDAR_CREDENTIALS or credentials_path in config)")
+	ErrMissingTokenPath       = errors.New("no token file path configured (set GOOGLE_CALENDAR_TOKEN or token_path in config)")
+	ErrInvalidFlow            = errors.New("invalid authorization flow")
+)
+
+// Authorization flows for Authenticator.Flow.
+const (
+	// FlowBrowser opens a browser and receives the authorization on a
+	// local callback server.
+	FlowBrowser = "browser"
+
+	// FlowDevice prints a URL and a short code to enter on any device. It
+	// needs credentials for an OAuth client of type "TVs and Limited Input
+	// devices", and Google doesn't support Calendar scopes in it, so it
+	// fails for calgo's Scopes; use FlowManual on machines without a
+	// browser.
+	FlowDevice = "device"
+
+	// FlowManual prints the authorization URL to open anywhere and reads
+	// back the code, or the URL Google redirected to, pasted into the
+	// terminal. It works over SSH, where no browser is available.
+	FlowManual = "manual"
 )
 
+// Messages holds the user-facing text shown during the authentication flow,
+// so localized or branded builds can override it.
+type Messages struct {
+	// Opening is printed before the browser is opened.
+	Opening string
+
+	// Waiting is printed while waiting for the OAuth2 callback.
+	Waiting string
+
+	// Success is printed once the token has been obtained.
+	Success string
+
+	// SuccessPage is the heading of the page shown in the browser after
+	// the callback is received.
+	SuccessPage string
+
+	// DevicePrompt is printed before the verification URL and code of the
+	// device flow.
+	DevicePrompt string
+
+	// ManualPrompt is printed before the authorization URL of the manual
+	// flow, and ManualPaste after it, asking for the code.
+	ManualPrompt string
+	ManualPaste  string
+}
+
+// DefaultMessages returns the default English authentication messages.
+func DefaultMessages() Messages {
+	return Messages{
+		Opening:      "Opening browser for authentication...",
+		Waiting:      "Waiting for authorization...",
+		Success:      "Authentication successful!",
+		SuccessPage:  "Authorization Successful!",
+		DevicePrompt: "To authorize calgo, visit this URL on any device and enter the code:",
+		ManualPrompt: "To authorize calgo, open this URL in a browser on any machine:",
+		ManualPaste:  "Your browser is then sent to a localhost page, which may fail to load. Paste the code from its address, or the whole address:",
+	}
+}
+
+// CallbackTimeouts bounds how long the local callback server spends on a
+// single connection, so a stuck or malicious client can't hold it open.
+// Zero values disable the corresponding timeout.
+type CallbackTimeouts struct {
+	ReadHeader time.Duration
+	Read       time.Duration
+	Write      time.Duration
+}
+
+// DefaultCallbackTimeouts returns the callback server timeouts used by new
+// Authenticators.
+func DefaultCallbackTimeouts() CallbackTimeouts {
+	return CallbackTimeouts{
+		ReadHeader: 5 * time.Second,
+		Read:       10 * time.Second,
+		Write:      10 * time.Second,
+	}
+}
+
 // Authenticator handles OAuth2 authentication with Google.
 type Authenticator struct {
 	credentialsPath string
 	tokenPath       string
 	config          *oauth2.Config
+
+	// Messages is the text shown during the authentication flow.
+	Messages Messages
+
+	// StrictScopes requires the saved token to grant exactly Scopes, for
+	// audited environments. Tokens granting more or fewer scopes, or not
+	// recording their scopes at all, are rejected with ErrScopeMismatch.
+	StrictScopes bool
+
+	// ManageCalendars requests ManageCalendarsScope along with Scopes when
+	// authenticating, and requires saved tokens to grant it.
+	ManageCalendars bool
+
+	// CallbackTimeouts configures the OAuth2 callback server.
+	CallbackTimeouts CallbackTimeouts
+
+	// Flow is how a new token is obtained when none is saved: FlowBrowser,
+	// the default when empty, FlowDevice or FlowManual.
+	Flow string
+
+	// TokenEncryption encrypts the saved token with AES-GCM when set to
+	// EncryptionPassphrase or EncryptionMachine, for systems without a
+	// keyring. A token saved in another mode, e.g. an existing plaintext
+	// file, is loaded as it is and re-saved in this mode.
+	TokenEncryption string
+
+	// TokenPassphrase is the passphrase for EncryptionPassphrase.
+	TokenPassphrase string
+
+	// out receives progress messages; defaults to os.Stdout.
+	out io.Writer
+
+	// in is read for the pasted code of FlowManual; defaults to os.Stdin.
+	in io.Reader
+
+	// openURL opens the authorization URL; defaults to openBrowser.
+	openURL func(string) error
+
+	// machineID identifies this machine for EncryptionMachine; defaults
+	// to readMachineID.
+	machineID func() (string, error)
+
+	// mu guards cancel, which stops the in-progress authentication flow.
+	mu     sync.Mutex
+	cancel context.CancelCauseFunc
+
+	// refreshes shares one token refresh between concurrent callers.
+	refreshes singleflight.Group
 }
 
 // NewAuthenticator creates a new Authenticator with the given paths.
 func NewAuthenticator(credentialsPath, tokenPath string) *Authenticator {
 	return &Authenticator{
-		credentialsPath: credentialsPath,
-		tokenPath:       tokenPath,
+		credentialsPath:  credentialsPath,
+		tokenPath:        tokenPath,
+		Messages:         DefaultMessages(),
+		CallbackTimeouts: DefaultCallbackTimeouts(),
+		out:              os.Stdout,
+		in:               os.Stdin,
+		openURL:          openBrowser,
+		machineID:        readMachineID,
 	}
 }
 
 // LoadCredentials reads and parses the OAuth2 credentials file.
 func (a *Authenticator) LoadCredentials() error {
+	if a.credentialsPath == "" {
+		return ErrMissingCredentialsPath
+	}
+
 	data, err := os.ReadFile(a.credentialsPath)
+	if errors.Is(err, fs.ErrNotExist) {
+		return fmt.Errorf("%w: %s", ErrCredentialsNotFound, a.credentialsPath)
+	}
 	if err != nil {
 		return fmt.Errorf("failed to read credentials file: %w", err)
 	}
 
 	config, err := google.ConfigFromJSON(data, Scopes...)
 	if err != nil {
-		return fmt.Errorf("%w: %v", ErrInvalidCredentials, err)
+		return wrapAuthError(ErrInvalidCredentials, err)
 	}
 
 	a.config = config
@@ -62,6 +223,11 @@ func (a *Authenticator) LoadCredentials() error {
 
 // GetToken returns a valid OAuth2 token, either from cache or by authenticating.
 func (a *Authenticator) GetToken(ctx context.Context) (*oauth2.Token, error) {
+	// Without a token path the browser flow would succeed and then fail to save
+	if a.tokenPath == "" {
+		return nil, ErrMissingTokenPath
+	}
+
 	if a.config == nil {
 		if err := a.LoadCredentials(); err != nil {
 			return nil, err
@@ -69,26 +235,40 @@ func (a *Authenticator) GetToken(ctx context.Context) (*oauth2.Token, error) {
 	}
 
 	// Try to load existing token
-	token, err := a.loadToken()
+	token, encryption, err := a.readToken()
+	if errors.Is(err, ErrPassphraseRequired) || errors.Is(err, ErrTokenDecryptFailed) || errors.Is(err, ErrInvalidEncryption) {
+		// Authenticating again would replace a token that is still good
+		return nil, err
+	}
 	if err == nil {
+		// A token minted for fewer scopes would fail later with a confusing 403
+		if err := a.checkScopes(token); err != nil {
+			return nil, err
+		}
+
+		if encryption != a.TokenEncryption {
+			// Migrate to the configured encryption, e.g. from plaintext
+			if err := a.saveToken(token); err != nil {
+				fmt.Fprintf(os.Stderr, "Warning: failed to re-save token: %s\n", redact(err.Error()))
+			}
+		}
+
 		// Check if token needs refresh
 		if token.Valid() {
 			return token, nil
 		}
 
 		// Try to refresh the token
-		tokenSource := a.config.TokenSource(ctx, token)
-		newToken, err := tokenSource.Token()
+		newToken, err := a.refreshToken(ctx, token)
 		if err == nil {
-			// Save refreshed token
-			if saveErr := a.saveToken(newToken); saveErr != nil {
-				fmt.Fprintf(os.Stderr, "Warning: failed to save refreshed token: %v\n", saveErr)
-			}
 			return newToken, nil
 		}
+		if ctx.Err() != nil {
+			return nil, ctx.Err()
+		}
 
 		// Refresh failed, need to re-authenticate
-		fmt.Println("Token refresh failed. Re-authentication required.")
+		fmt.Fprintln(a.out, "Token refresh failed. Re-authentication required.")
 	}
 
 	// No valid token, need to authenticate
@@ -96,17 +276,187 @@ func (a *Authenticator) GetToken(ctx context.Context) (*oauth2.Token, error) {
 }
 
 // GetClient returns an HTTP client configured with OAuth2 credentials.
+// Tokens it refreshes are saved as by GetToken.
 func (a *Authenticator) GetClient(ctx context.Context) (*http.Client, error) {
 	token, err := a.GetToken(ctx)
 	if err != nil {
 		return nil, err
 	}
 
-	return a.config.Client(ctx, token), nil
+	source := &savingTokenSource{ctx: ctx, auth: a, last: token}
+	return oauth2.NewClient(ctx, oauth2.ReuseTokenSource(token, source)), nil
+}
+
+// CheckToken verifies that a saved token exists, covers the required scopes,
+// and is either valid or refreshable. Unlike GetToken it never starts the
+// browser flow or rewrites the token file.
+func (a *Authenticator) CheckToken(ctx context.Context) error {
+	if a.config == nil {
+		if err := a.LoadCredentials(); err != nil {
+			return err
+		}
+	}
+
+	token, err := a.loadToken()
+	if err != nil {
+		return fmt.Errorf("failed to load token: %w", err)
+	}
+
+	if err := a.checkScopes(token); err != nil {
+		return err
+	}
+
+	if token.Valid() {
+		return nil
+	}
+
+	if _, err := a.config.TokenSource(ctx, token).Token(); err != nil {
+		return wrapAuthError(ErrTokenRefreshFailed, err)
+	}
+	return nil
+}
+
+// RepairToken forces a refresh of the saved token and saves the result.
+// When Google rejects the refresh token with invalid_grant (revoked or
+// expired), the token file is removed and ErrReauthRequired is returned so
+// the caller can start a fresh authorization.
+func (a *Authenticator) RepairToken(ctx context.Context) (repaired bool, err error) {
+	if a.config == nil {
+		if err := a.LoadCredentials(); err != nil {
+			return false, err
+		}
+	}
+
+	token, err := a.loadToken()
+	if err != nil {
+		return false, fmt.Errorf("failed to load token: %w", err)
+	}
+
+	// Drop the access token so the token source always hits the endpoint
+	stale := *token
+	stale.AccessToken = ""
+	stale.Expiry = time.Time{}
+
+	refreshed, err := a.config.TokenSource(ctx, &stale).Token()
+	if err != nil {
+		var retrieveErr *oauth2.RetrieveError
+		if errors.As(err, &retrieveErr) && retrieveErr.ErrorCode == "invalid_grant" {
+			if err := a.ClearToken(); err != nil {
+				return false, err
+			}
+			return false, ErrReauthRequired
+		}
+		return false, wrapAuthError(ErrTokenRefreshFailed, err)
+	}
+
+	if err := a.saveToken(refreshed); err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+// DryRunAuth verifies that the credentials file parses and that a valid
+// authorization URL can be built, without starting the callback server,
+// opening a browser, or touching the saved token.
+func (a *Authenticator) DryRunAuth(ctx context.Context) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	if err := a.LoadCredentials(); err != nil {
+		return err
+	}
+
+	// Work on a copy so the placeholder redirect doesn't leak into a.config
+	config := *a.config
+	config.RedirectURL = "http://localhost"
+
+	authURL := config.AuthCodeURL("state-token", oauth2.AccessTypeOffline)
+	parsed, err := url.Parse(authURL)
+	if err != nil {
+		return fmt.Errorf("%w: failed to build authorization URL: %v", ErrInvalidCredentials, err)
+	}
+	if parsed.Scheme == "" || parsed.Host == "" {
+		return fmt.Errorf("%w: authorization URL is incomplete: %s", ErrInvalidCredentials, authURL)
+	}
+	if parsed.Query().Get("client_id") == "" {
+		return fmt.Errorf("%w: credentials are missing a client ID", ErrInvalidCredentials)
+	}
+
+	return nil
+}
+
+// CancelAuth stops an in-progress authentication flow, e.g. when a web
+// wrapper's user navigates away. The flow closes its callback server and
+// returns ErrAuthCancelled. It is a no-op when no flow is running.
+func (a *Authenticator) CancelAuth() {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	if a.cancel != nil {
+		a.cancel(ErrAuthCancelled)
+	}
 }
 
-// authenticate performs the OAuth2 authentication flow.
+// Login runs the authorization flow set by Flow and saves the new token,
+// replacing any saved one, e.g. to switch accounts.
+func (a *Authenticator) Login(ctx context.Context) (*oauth2.Token, error) {
+	if a.tokenPath == "" {
+		return nil, ErrMissingTokenPath
+	}
+
+	if a.config == nil {
+		if err := a.LoadCredentials(); err != nil {
+			return nil, err
+		}
+	}
+	return a.authenticate(ctx)
+}
+
+// authenticate obtains a new token with the flow set by Flow and saves it.
+// CancelAuth stops it.
 func (a *Authenticator) authenticate(ctx context.Context) (*oauth2.Token, error) {
+	ctx, cancel := context.WithCancelCause(ctx)
+	a.mu.Lock()
+	a.cancel = cancel
+	a.mu.Unlock()
+	defer func() {
+		a.mu.Lock()
+		a.cancel = nil
+		a.mu.Unlock()
+		cancel(nil)
+	}()
+
+	a.config.Scopes = a.scopes()
+
+	var token *oauth2.Token
+	var err error
+	switch a.Flow {
+	case "", FlowBrowser:
+		token, err = a.browserFlow(ctx)
+	case FlowDevice:
+		token, err = a.deviceFlow(ctx)
+	case FlowManual:
+		token, err = a.manualFlow(ctx)
+	default:
+		return nil, fmt.Errorf("%w: %q (use browser, device or manual)", ErrInvalidFlow, a.Flow)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	// Save the token
+	if err := a.saveToken(token); err != nil {
+		fmt.Fprintf(os.Stderr, "Warning: failed to save token: %s\n", redact(err.Error()))
+	}
+
+	fmt.Fprintln(a.out, a.Messages.Success)
+	return token, nil
+}
+
+// browserFlow performs the OAuth2 authorization code flow with a local
+// callback server.
+func (a *Authenticator) browserFlow(ctx context.Context) (*oauth2.Token, error) {
 	// Create a channel to receive the authorization code
 	codeChan := make(chan string, 1)
 	errChan := make(chan error, 1)
@@ -124,41 +474,45 @@ func (a *Authenticator) authenticate(ctx context.Context) (*oauth2.Token, error)
 	// Generate authorization URL
 	authURL := a.config.AuthCodeURL("state-token", oauth2.AccessTypeOffline)
 
-	fmt.Println("Opening browser for authentication...")
-	fmt.Printf("If the browser doesn't open, visit this URL:\n%s\n\n", authURL)
+	fmt.Fprintln(a.out, a.Messages.Opening)
+	fmt.Fprintf(a.out, "If the browser doesn't open, visit this URL:\n%s\n\n", authURL)
 
 	// Open browser
-	if err := openBrowser(authURL); err != nil {
+	if err := a.openURL(authURL); err != nil {
 		fmt.Fprintf(os.Stderr, "Warning: failed to open browser: %v\n", err)
 	}
 
 	// Wait for the authorization code
-	fmt.Println("Waiting for authorization...")
-	var code string
-	select {
-	case code = <-codeChan:
-		// Got the code
-	case err := <-errChan:
-		return nil, fmt.Errorf("%w: %v", ErrAuthenticationFailed, err)
-	case <-ctx.Done():
-		return nil, ctx.Err()
-	case <-time.After(5 * time.Minute):
-		return nil, fmt.Errorf("%w: timeout waiting for authorization", ErrAuthenticationFailed)
+	code, err := a.waitForCode(ctx, codeChan, errChan)
+	if err != nil {
+		return nil, err
 	}
 
 	// Exchange code for token
 	token, err := a.config.Exchange(ctx, code)
 	if err != nil {
-		return nil, fmt.Errorf("%w: %v", ErrAuthenticationFailed, err)
+		return nil, wrapAuthError(ErrAuthenticationFailed, err)
 	}
+	return token, nil
+}
 
-	// Save the token
-	if err := a.saveToken(token); err != nil {
-		fmt.Fprintf(os.Stderr, "Warning: failed to save token: %v\n", err)
-	}
+// waitForCode waits for the authorization code from the callback server.
+func (a *Authenticator) waitForCode(ctx context.Context, codeChan <-chan string, errChan <-chan error) (string, error) {
+	fmt.Fprintln(a.out, a.Messages.Waiting)
 
-	fmt.Println("Authentication successful!")
-	return token, nil
+	select {
+	case code := <-codeChan:
+		return code, nil
+	case err := <-errChan:
+		return "", wrapAuthError(ErrAuthenticationFailed, err)
+	case <-ctx.Done():
+		if errors.Is(context.Cause(ctx), ErrAuthCancelled) {
+			return "", ErrAuthCancelled
+		}
+		return "", ctx.Err()
+	case <-time.After(5 * time.Minute):
+		return "", fmt.Errorf("%w: timeout waiting for authorization", ErrAuthenticationFailed)
+	}
 }
 
 // startCallbackServer starts a local HTTP server to handle the OAuth2 callback.
@@ -186,19 +540,25 @@ func (a *Authenticator) startCallbackServer(codeChan chan<- string, errChan chan
 
 		codeChan <- code
 		w.Header().Set("Content-Type", "text/html")
-		fmt.Fprint(w, `
+		heading := html.EscapeString(a.Messages.SuccessPage)
+		fmt.Fprintf(w, `
 <!DOCTYPE html>
 <html>
-<head><title>Authorization Successful</title></head>
+<head><title>%s</title></head>
 <body style="font-family: sans-serif; text-align: center; padding: 50px;">
-<h1>Authorization Successful!</h1>
+<h1>%s</h1>
 <p>You can close this window and return to the terminal.</p>
 </body>
 </html>
-`)
+`, heading, heading)
 	})
 
-	server := &http.Server{Handler: mux}
+	server := &http.Server{
+		Handler:           mux,
+		ReadHeaderTimeout: a.CallbackTimeouts.ReadHeader,
+		ReadTimeout:       a.CallbackTimeouts.Read,
+		WriteTimeout:      a.CallbackTimeouts.Write,
+	}
 
 	go func() {
 		if err := server.Serve(listener); err != nil && err != http.ErrServerClosed {
@@ -209,35 +569,106 @@ func (a *Authenticator) startCallbackServer(codeChan chan<- string, errChan chan
 	return server, port, nil
 }
 
-// loadToken reads the OAuth2 token from the token file.
+// storedToken is the on-disk token format. It extends oauth2.Token with the
+// granted scopes, which oauth2.Token only keeps in its unexported raw data.
+type storedToken struct {
+	oauth2.Token
+	Scope string `json:"scope,omitempty"`
+}
+
+// loadToken reads the OAuth2 token from the token file, decrypting it if
+// it was saved encrypted.
 func (a *Authenticator) loadToken() (*oauth2.Token, error) {
+	token, _, err := a.readToken()
+	return token, err
+}
+
+// readToken is loadToken, also returning the encryption mode the file was
+// saved with, or "" for plaintext.
+func (a *Authenticator) readToken() (*oauth2.Token, string, error) {
+	if a.tokenPath == "" {
+		return nil, "", ErrMissingTokenPath
+	}
+
 	data, err := os.ReadFile(a.tokenPath)
 	if err != nil {
-		return nil, err
+		return nil, "", err
+	}
+
+	encryption := tokenEncryption(data)
+	if encryption != "" {
+		if data, err = a.decryptToken(data); err != nil {
+			return nil, encryption, err
+		}
+	}
+
+	var stored storedToken
+	if err := json.Unmarshal(data, &stored); err != nil {
+		return nil, encryption, fmt.Errorf("failed to parse token file: %w", err)
 	}
 
-	var token oauth2.Token
-	if err := json.Unmarshal(data, &token); err != nil {
-		return nil, fmt.Errorf("failed to parse token file: %w", err)
+	token := &stored.Token
+	if stored.Scope != "" {
+		token = token.WithExtra(map[string]interface{}{"scope": stored.Scope})
 	}
 
-	return &token, nil
+	return token, encryption, nil
 }
 
 // saveToken writes the OAuth2 token to the token file.
 func (a *Authenticator) saveToken(token *oauth2.Token) error {
-	data, err := json.MarshalIndent(token, "", "  ")
+	if a.tokenPath == "" {
+		return ErrMissingTokenPath
+	}
+
+	stored := storedToken{Token: *token}
+	if scope, ok := token.Extra("scope").(string); ok {
+		stored.Scope = scope
+	}
+
+	data, err := json.MarshalIndent(stored, "", "  ")
 	if err != nil {
 		return fmt.Errorf("failed to marshal token: %w", err)
 	}
 
-	if err := os.WriteFile(a.tokenPath, data, 0600); err != nil {
+	if a.TokenEncryption != "" {
+		if data, err = a.encryptToken(data); err != nil {
+			return fmt.Errorf("failed to encrypt token: %w", err)
+		}
+	}
+
+	// Write a temporary file and rename it over the token file, so that a
+	// concurrent loadToken sees the old token or the new one, never half
+	if err := writeFileAtomic(a.tokenPath, data, 0600); err != nil {
 		return fmt.Errorf("failed to write token file: %w", err)
 	}
 
 	return nil
 }
 
+// writeFileAtomic writes data to path with the given permissions by
+// renaming a temporary file from the same directory over it.
+func writeFileAtomic(path string, data []byte, perm fs.FileMode) error {
+	tmp, err := os.CreateTemp(filepath.Dir(path), "."+filepath.Base(path)+".tmp-*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+
+	if err := tmp.Chmod(perm); err != nil {
+		tmp.Close()
+		return err
+	}
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	return os.Rename(tmp.Name(), path)
+}
+
 // openBrowser opens the specified URL in the default browser.
 func openBrowser(url string) error {
 	var cmd *exec.Cmd
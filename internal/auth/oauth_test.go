@@ -1,18 +1,24 @@
 package auth
 
 import (
+	"bytes"
 	"context"
 	"encoding/json"
 	"errors"
 	"fmt"
+	"io"
 	"net/http"
 	"net/http/httptest"
+	"net/url"
 	"os"
 	"path/filepath"
+	"slices"
+	"strings"
 	"testing"
 	"time"
 
 	"golang.org/x/oauth2"
+	"google.golang.org/api/calendar/v3"
 )
 
 // Sample credentials for testing (not real credentials)
@@ -67,6 +73,12 @@ func TestLoadCredentials_FileNotFound(t *testing.T) {
 	if err == nil {
 		t.Error("Expected error for nonexistent credentials file")
 	}
+	if !errors.Is(err, ErrCredentialsNotFound) {
+		t.Errorf("Expected ErrCredentialsNotFound, got: %v", err)
+	}
+	if errors.Is(err, ErrInvalidCredentials) {
+		t.Error("A missing file should not be reported as ErrInvalidCredentials")
+	}
 }
 
 func TestLoadCredentials_InvalidFormat(t *testing.T) {
@@ -239,21 +251,14 @@ func TestClearToken_NonexistentFile(t *testing.T) {
 }
 
 func TestScopes(t *testing.T) {
-	if len(Scopes) == 0 {
-		t.Error("Scopes should not be empty")
+	// Every API method calgo calls must be covered: events for creating
+	// and editing events, readonly for the calendar list and busy time
+	want := []string{
+		"https://www.googleapis.com/auth/calendar.events",
+		"https://www.googleapis.com/auth/calendar.readonly",
 	}
-
-	// Should include calendar events scope
-	found := false
-	for _, scope := range Scopes {
-		if scope == "https://www.googleapis.com/auth/calendar.events" {
-			found = true
-			break
-		}
-	}
-
-	if !found {
-		t.Error("Scopes should include calendar.events scope")
+	if strings.Join(Scopes, " ") != strings.Join(want, " ") {
+		t.Errorf("Scopes = %v, want %v", Scopes, want)
 	}
 }
 
@@ -274,6 +279,41 @@ func TestStartCallbackServer(t *testing.T) {
 	}
 }
 
+func TestStartCallbackServer_Timeouts(t *testing.T) {
+	tests := []struct {
+		name     string
+		timeouts *CallbackTimeouts
+		want     CallbackTimeouts
+	}{
+		{"defaults", nil, DefaultCallbackTimeouts()},
+		{"custom", &CallbackTimeouts{ReadHeader: time.Second, Read: 2 * time.Second, Write: 3 * time.Second}, CallbackTimeouts{ReadHeader: time.Second, Read: 2 * time.Second, Write: 3 * time.Second}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			auth := NewAuthenticator("/path/to/creds.json", "/path/to/token.json")
+			if tt.timeouts != nil {
+				auth.CallbackTimeouts = *tt.timeouts
+			}
+
+			server, _, err := auth.startCallbackServer(make(chan string, 1), make(chan error, 1))
+			if err != nil {
+				t.Fatalf("startCallbackServer failed: %v", err)
+			}
+			defer server.Close()
+
+			if server.ReadHeaderTimeout != tt.want.ReadHeader || server.ReadTimeout != tt.want.Read || server.WriteTimeout != tt.want.Write {
+				t.Errorf("server timeouts = %v/%v/%v, want %v/%v/%v",
+					server.ReadHeaderTimeout, server.ReadTimeout, server.WriteTimeout,
+					tt.want.ReadHeader, tt.want.Read, tt.want.Write)
+			}
+			if server.ReadHeaderTimeout <= 0 {
+				t.Error("ReadHeaderTimeout should be set")
+			}
+		})
+	}
+}
+
 func TestCallbackServer_SuccessfulCode(t *testing.T) {
 	auth := NewAuthenticator("/path/to/creds.json", "/path/to/token.json")
 
@@ -997,3 +1037,572 @@ func TestLoadCredentials_PermissionDenied(t *testing.T) {
 		t.Error("Expected error for permission denied")
 	}
 }
+
+func TestDryRunAuth_ValidCredentials(t *testing.T) {
+	tmpDir := t.TempDir()
+	credPath := filepath.Join(tmpDir, "credentials.json")
+	tokenPath := filepath.Join(tmpDir, "token.json")
+
+	if err := os.WriteFile(credPath, []byte(testCredentials), 0644); err != nil {
+		t.Fatalf("Failed to write test credentials: %v", err)
+	}
+
+	auth := NewAuthenticator(credPath, tokenPath)
+	if err := auth.DryRunAuth(context.Background()); err != nil {
+		t.Errorf("DryRunAuth failed: %v", err)
+	}
+
+	// Dry run must not create a token
+	if auth.HasSavedToken() {
+		t.Error("DryRunAuth should not save a token")
+	}
+}
+
+func TestDryRunAuth_InvalidCredentials(t *testing.T) {
+	tmpDir := t.TempDir()
+	credPath := filepath.Join(tmpDir, "credentials.json")
+
+	if err := os.WriteFile(credPath, []byte(`{"invalid": "format"}`), 0644); err != nil {
+		t.Fatalf("Failed to write test credentials: %v", err)
+	}
+
+	auth := NewAuthenticator(credPath, filepath.Join(tmpDir, "token.json"))
+	err := auth.DryRunAuth(context.Background())
+	if !errors.Is(err, ErrInvalidCredentials) {
+		t.Errorf("Expected ErrInvalidCredentials, got: %v", err)
+	}
+}
+
+func TestDryRunAuth_MissingCredentials(t *testing.T) {
+	auth := NewAuthenticator("/nonexistent/credentials.json", "/nonexistent/token.json")
+
+	if err := auth.DryRunAuth(context.Background()); err == nil {
+		t.Error("Expected error for missing credentials")
+	}
+}
+
+func TestDefaultMessages(t *testing.T) {
+	auth := NewAuthenticator("/path/to/creds.json", "/path/to/token.json")
+
+	if auth.Messages != DefaultMessages() {
+		t.Errorf("Expected default messages, got %+v", auth.Messages)
+	}
+	if auth.Messages.Waiting != "Waiting for authorization..." {
+		t.Errorf("Unexpected default Waiting message: %q", auth.Messages.Waiting)
+	}
+}
+
+func TestWaitForCode_UsesCustomWaitingMessage(t *testing.T) {
+	auth := NewAuthenticator("/path/to/creds.json", "/path/to/token.json")
+	auth.Messages.Waiting = "Esperando autorización..."
+
+	var out bytes.Buffer
+	auth.out = &out
+
+	codeChan := make(chan string, 1)
+	errChan := make(chan error, 1)
+	codeChan <- "test-code"
+
+	code, err := auth.waitForCode(context.Background(), codeChan, errChan)
+	if err != nil {
+		t.Fatalf("waitForCode failed: %v", err)
+	}
+	if code != "test-code" {
+		t.Errorf("Expected code 'test-code', got '%s'", code)
+	}
+
+	if !strings.Contains(out.String(), "Esperando autorización...") {
+		t.Errorf("Expected custom waiting message in output, got %q", out.String())
+	}
+	if strings.Contains(out.String(), "Waiting for authorization") {
+		t.Errorf("Default waiting message should not be printed, got %q", out.String())
+	}
+}
+
+func TestCallbackServer_CustomSuccessPage(t *testing.T) {
+	auth := NewAuthenticator("/path/to/creds.json", "/path/to/token.json")
+	auth.Messages.SuccessPage = "Acme <Calendar> connected"
+
+	codeChan := make(chan string, 1)
+	errChan := make(chan error, 1)
+
+	server, port, err := auth.startCallbackServer(codeChan, errChan)
+	if err != nil {
+		t.Fatalf("startCallbackServer failed: %v", err)
+	}
+	defer server.Close()
+
+	resp, err := http.Get(fmt.Sprintf("http://localhost:%d/?code=test-code", port))
+	if err != nil {
+		t.Fatalf("Failed to make request: %v", err)
+	}
+	defer resp.Body.Close()
+
+	body, _ := io.ReadAll(resp.Body)
+	if !strings.Contains(string(body), "Acme &lt;Calendar&gt; connected") {
+		t.Errorf("Expected escaped custom success heading in page, got %q", string(body))
+	}
+}
+
+func TestGetToken_InsufficientScope(t *testing.T) {
+	tmpDir := t.TempDir()
+	credPath := filepath.Join(tmpDir, "credentials.json")
+	tokenPath := filepath.Join(tmpDir, "token.json")
+
+	if err := os.WriteFile(credPath, []byte(testCredentials), 0600); err != nil {
+		t.Fatalf("Failed to write credentials: %v", err)
+	}
+
+	auth := NewAuthenticator(credPath, tokenPath)
+
+	token := (&oauth2.Token{
+		AccessToken: "readonly-token",
+		TokenType:   "Bearer",
+		Expiry:      time.Now().Add(time.Hour),
+	}).WithExtra(map[string]interface{}{"scope": calendar.CalendarEventsReadonlyScope})
+	if err := auth.saveToken(token); err != nil {
+		t.Fatalf("saveToken failed: %v", err)
+	}
+
+	_, err := auth.GetToken(context.Background())
+	if !errors.Is(err, ErrInsufficientScope) {
+		t.Fatalf("GetToken() error = %v, want ErrInsufficientScope", err)
+	}
+	for _, scope := range Scopes {
+		if !strings.Contains(err.Error(), scope) {
+			t.Errorf("error %q should name the missing scope %s", err, scope)
+		}
+	}
+}
+
+func TestGetToken_EventsOnlyToken(t *testing.T) {
+	tmpDir := t.TempDir()
+	credPath := filepath.Join(tmpDir, "credentials.json")
+	if err := os.WriteFile(credPath, []byte(testCredentials), 0600); err != nil {
+		t.Fatalf("Failed to write credentials: %v", err)
+	}
+	auth := NewAuthenticator(credPath, filepath.Join(tmpDir, "token.json"))
+
+	// Tokens saved before calendar.readonly was requested can't list
+	// calendars; they must fail up front rather than with a 403 later
+	token := (&oauth2.Token{
+		AccessToken: "events-token",
+		TokenType:   "Bearer",
+		Expiry:      time.Now().Add(time.Hour),
+	}).WithExtra(map[string]interface{}{"scope": calendar.CalendarEventsScope})
+	if err := auth.saveToken(token); err != nil {
+		t.Fatalf("saveToken failed: %v", err)
+	}
+
+	_, err := auth.GetToken(context.Background())
+	if !errors.Is(err, ErrInsufficientScope) || !strings.Contains(err.Error(), "calgo auth login") {
+		t.Errorf("GetToken() error = %v, want ErrInsufficientScope telling to run calgo auth login", err)
+	}
+}
+
+func TestGetToken_ManageCalendars(t *testing.T) {
+	tests := []struct {
+		name    string
+		scope   string
+		manage  bool
+		wantErr bool
+	}{
+		{"default scopes", strings.Join(Scopes, " "), false, false},
+		{"default scopes can't manage calendars", strings.Join(Scopes, " "), true, true},
+		{"full scope", strings.Join(Scopes, " ") + " " + ManageCalendarsScope, true, false},
+		{"full scope serves other commands", ManageCalendarsScope, false, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tmpDir := t.TempDir()
+			credPath := filepath.Join(tmpDir, "credentials.json")
+			if err := os.WriteFile(credPath, []byte(testCredentials), 0600); err != nil {
+				t.Fatalf("Failed to write credentials: %v", err)
+			}
+			auth := NewAuthenticator(credPath, filepath.Join(tmpDir, "token.json"))
+			auth.ManageCalendars = tt.manage
+
+			token := (&oauth2.Token{
+				AccessToken: "token",
+				TokenType:   "Bearer",
+				Expiry:      time.Now().Add(time.Hour),
+			}).WithExtra(map[string]interface{}{"scope": tt.scope})
+			if err := auth.saveToken(token); err != nil {
+				t.Fatalf("saveToken failed: %v", err)
+			}
+
+			_, err := auth.GetToken(context.Background())
+			if tt.wantErr {
+				if !errors.Is(err, ErrInsufficientScope) || !strings.Contains(err.Error(), "calgo auth login --manage-calendars") {
+					t.Errorf("GetToken() error = %v, want ErrInsufficientScope telling to run calgo auth login --manage-calendars", err)
+				}
+				return
+			}
+			if err != nil {
+				t.Errorf("GetToken() error = %v", err)
+			}
+		})
+	}
+}
+
+func TestLogin_ManageCalendarsRequestsFullScope(t *testing.T) {
+	tmpDir := t.TempDir()
+	credPath := filepath.Join(tmpDir, "credentials.json")
+	if err := os.WriteFile(credPath, []byte(testCredentials), 0600); err != nil {
+		t.Fatalf("Failed to write credentials: %v", err)
+	}
+
+	for _, manage := range []bool{false, true} {
+		auth := NewAuthenticator(credPath, filepath.Join(tmpDir, "token.json"))
+		auth.out = io.Discard
+		auth.ManageCalendars = manage
+
+		authURL := make(chan string, 1)
+		auth.openURL = func(u string) error {
+			authURL <- u
+			auth.CancelAuth()
+			return nil
+		}
+		if _, err := auth.Login(context.Background()); !errors.Is(err, ErrAuthCancelled) {
+			t.Fatalf("Login() error = %v, want ErrAuthCancelled", err)
+		}
+
+		parsed, err := url.Parse(<-authURL)
+		if err != nil {
+			t.Fatalf("invalid authorization URL: %v", err)
+		}
+		scopes := strings.Fields(parsed.Query().Get("scope"))
+		if got := slices.Contains(scopes, ManageCalendarsScope); got != manage {
+			t.Errorf("ManageCalendars = %v: requested scopes %v", manage, scopes)
+		}
+	}
+}
+
+func TestSaveToken_PreservesScope(t *testing.T) {
+	tokenPath := filepath.Join(t.TempDir(), "token.json")
+	auth := NewAuthenticator("/path/to/creds.json", tokenPath)
+
+	token := (&oauth2.Token{AccessToken: "token"}).
+		WithExtra(map[string]interface{}{"scope": calendar.CalendarEventsScope})
+	if err := auth.saveToken(token); err != nil {
+		t.Fatalf("saveToken failed: %v", err)
+	}
+
+	loaded, err := auth.loadToken()
+	if err != nil {
+		t.Fatalf("loadToken failed: %v", err)
+	}
+	if got := loaded.Extra("scope"); got != calendar.CalendarEventsScope {
+		t.Errorf("loaded scope = %v, want %q", got, calendar.CalendarEventsScope)
+	}
+}
+
+func TestMissingScopes(t *testing.T) {
+	tests := []struct {
+		name     string
+		granted  []string
+		required []string
+		want     int
+	}{
+		{"exact match", []string{calendar.CalendarEventsScope}, []string{calendar.CalendarEventsScope}, 0},
+		{"broader scope covers", []string{calendar.CalendarScope}, []string{calendar.CalendarEventsScope}, 0},
+		{"full scope covers freebusy", []string{calendar.CalendarScope}, []string{calendar.CalendarFreebusyScope}, 0},
+		{"events scope doesn't cover freebusy", []string{calendar.CalendarEventsScope}, []string{calendar.CalendarFreebusyScope}, 1},
+		{"events scope doesn't cover the calendar list", []string{calendar.CalendarEventsScope}, Scopes, 1},
+		{"readonly is not enough", []string{calendar.CalendarEventsReadonlyScope}, []string{calendar.CalendarEventsScope}, 1},
+		{"unrelated scope", []string{"openid"}, []string{calendar.CalendarEventsScope}, 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := missingScopes(tt.granted, tt.required); len(got) != tt.want {
+				t.Errorf("missingScopes() = %v, want %d missing", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCheckToken_ValidToken(t *testing.T) {
+	tmpDir := t.TempDir()
+	credPath := filepath.Join(tmpDir, "credentials.json")
+	tokenPath := filepath.Join(tmpDir, "token.json")
+
+	if err := os.WriteFile(credPath, []byte(testCredentials), 0600); err != nil {
+		t.Fatalf("Failed to write credentials: %v", err)
+	}
+
+	auth := NewAuthenticator(credPath, tokenPath)
+	token := &oauth2.Token{
+		AccessToken: "valid-token",
+		TokenType:   "Bearer",
+		Expiry:      time.Now().Add(time.Hour),
+	}
+	if err := auth.saveToken(token); err != nil {
+		t.Fatalf("saveToken failed: %v", err)
+	}
+	before, _ := os.ReadFile(tokenPath)
+
+	if err := auth.CheckToken(context.Background()); err != nil {
+		t.Errorf("CheckToken() error = %v", err)
+	}
+
+	after, _ := os.ReadFile(tokenPath)
+	if !bytes.Equal(before, after) {
+		t.Error("CheckToken() should not rewrite the token file")
+	}
+}
+
+func TestCheckToken_MissingToken(t *testing.T) {
+	tmpDir := t.TempDir()
+	credPath := filepath.Join(tmpDir, "credentials.json")
+
+	if err := os.WriteFile(credPath, []byte(testCredentials), 0600); err != nil {
+		t.Fatalf("Failed to write credentials: %v", err)
+	}
+
+	auth := NewAuthenticator(credPath, filepath.Join(tmpDir, "token.json"))
+
+	if err := auth.CheckToken(context.Background()); err == nil {
+		t.Error("CheckToken() should fail without a saved token")
+	}
+}
+
+func TestCancelAuth_StopsInProgressFlow(t *testing.T) {
+	tmpDir := t.TempDir()
+	credPath := filepath.Join(tmpDir, "credentials.json")
+
+	if err := os.WriteFile(credPath, []byte(testCredentials), 0600); err != nil {
+		t.Fatalf("Failed to write credentials: %v", err)
+	}
+
+	auth := NewAuthenticator(credPath, filepath.Join(tmpDir, "token.json"))
+	auth.out = io.Discard
+
+	// The flow is waiting for the callback once it tries to open the browser
+	waiting := make(chan struct{})
+	auth.openURL = func(string) error {
+		close(waiting)
+		return nil
+	}
+
+	done := make(chan error, 1)
+	go func() {
+		_, err := auth.GetToken(context.Background())
+		done <- err
+	}()
+
+	select {
+	case <-waiting:
+	case <-time.After(5 * time.Second):
+		t.Fatal("authentication flow did not start")
+	}
+
+	auth.CancelAuth()
+
+	select {
+	case err := <-done:
+		if !errors.Is(err, ErrAuthCancelled) {
+			t.Errorf("GetToken() error = %v, want ErrAuthCancelled", err)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("GetToken() did not return promptly after CancelAuth")
+	}
+}
+
+func TestCancelAuth_NoFlowRunning(t *testing.T) {
+	auth := NewAuthenticator("/path/to/creds.json", "/path/to/token.json")
+
+	// Must not panic or block
+	auth.CancelAuth()
+}
+
+func TestLoadCredentials_MalformedIsNotNotFound(t *testing.T) {
+	credPath := filepath.Join(t.TempDir(), "credentials.json")
+	if err := os.WriteFile(credPath, []byte("not json"), 0600); err != nil {
+		t.Fatalf("Failed to write credentials: %v", err)
+	}
+
+	auth := NewAuthenticator(credPath, "/path/to/token.json")
+	err := auth.LoadCredentials()
+
+	if !errors.Is(err, ErrInvalidCredentials) {
+		t.Errorf("Expected ErrInvalidCredentials, got: %v", err)
+	}
+	if errors.Is(err, ErrCredentialsNotFound) {
+		t.Error("A malformed file should not be reported as ErrCredentialsNotFound")
+	}
+}
+
+// newRepairAuthenticator returns an Authenticator with a saved expired token
+// whose token endpoint is served by handler.
+func newRepairAuthenticator(t *testing.T, handler http.HandlerFunc) (*Authenticator, string) {
+	t.Helper()
+	tmpDir := t.TempDir()
+	credPath := filepath.Join(tmpDir, "credentials.json")
+	tokenPath := filepath.Join(tmpDir, "token.json")
+
+	if err := os.WriteFile(credPath, []byte(testCredentials), 0600); err != nil {
+		t.Fatalf("Failed to write credentials: %v", err)
+	}
+
+	server := httptest.NewServer(handler)
+	t.Cleanup(server.Close)
+
+	auth := NewAuthenticator(credPath, tokenPath)
+	if err := auth.LoadCredentials(); err != nil {
+		t.Fatalf("LoadCredentials failed: %v", err)
+	}
+	auth.config.Endpoint.TokenURL = server.URL
+
+	token := &oauth2.Token{
+		AccessToken:  "old-access-token",
+		RefreshToken: "old-refresh-token",
+		TokenType:    "Bearer",
+		Expiry:       time.Now().Add(-time.Hour),
+	}
+	if err := auth.saveToken(token); err != nil {
+		t.Fatalf("saveToken failed: %v", err)
+	}
+	return auth, tokenPath
+}
+
+func TestRepairToken_Refreshable(t *testing.T) {
+	auth, tokenPath := newRepairAuthenticator(t, func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		fmt.Fprint(w, `{"access_token":"new-access-token","token_type":"Bearer","expires_in":3600}`)
+	})
+
+	repaired, err := auth.RepairToken(context.Background())
+	if err != nil {
+		t.Fatalf("RepairToken() error = %v", err)
+	}
+	if !repaired {
+		t.Error("RepairToken() repaired = false, want true")
+	}
+
+	saved, err := auth.loadToken()
+	if err != nil {
+		t.Fatalf("loadToken failed: %v", err)
+	}
+	if saved.AccessToken != "new-access-token" {
+		t.Errorf("Saved AccessToken = %q, want new-access-token", saved.AccessToken)
+	}
+	if saved.RefreshToken != "old-refresh-token" {
+		t.Errorf("Saved RefreshToken = %q, want the original refresh token kept", saved.RefreshToken)
+	}
+	if _, err := os.Stat(tokenPath); err != nil {
+		t.Errorf("Token file should exist: %v", err)
+	}
+}
+
+func TestRepairToken_InvalidGrant(t *testing.T) {
+	auth, tokenPath := newRepairAuthenticator(t, func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusBadRequest)
+		fmt.Fprint(w, `{"error":"invalid_grant","error_description":"Token has been expired or revoked."}`)
+	})
+
+	repaired, err := auth.RepairToken(context.Background())
+	if !errors.Is(err, ErrReauthRequired) {
+		t.Errorf("RepairToken() error = %v, want ErrReauthRequired", err)
+	}
+	if repaired {
+		t.Error("RepairToken() repaired = true, want false")
+	}
+	if _, err := os.Stat(tokenPath); !os.IsNotExist(err) {
+		t.Error("Token file should be removed after invalid_grant")
+	}
+}
+
+func TestRepairToken_OtherFailureKeepsToken(t *testing.T) {
+	auth, tokenPath := newRepairAuthenticator(t, func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusInternalServerError)
+	})
+
+	repaired, err := auth.RepairToken(context.Background())
+	if !errors.Is(err, ErrTokenRefreshFailed) {
+		t.Errorf("RepairToken() error = %v, want ErrTokenRefreshFailed", err)
+	}
+	if repaired {
+		t.Error("RepairToken() repaired = true, want false")
+	}
+	if _, err := os.Stat(tokenPath); err != nil {
+		t.Errorf("Token file should be kept on a transient failure: %v", err)
+	}
+}
+
+func TestCheckToken_StrictScopes(t *testing.T) {
+	tests := []struct {
+		name    string
+		scope   string
+		strict  bool
+		wantErr bool
+	}{
+		{"exact scopes", strings.Join(Scopes, " "), true, false},
+		{"superset", calendar.CalendarScope, true, true},
+		{"superset allowed when not strict", calendar.CalendarScope, false, false},
+		{"extra scope", strings.Join(Scopes, " ") + " " + calendar.CalendarSettingsReadonlyScope, true, true},
+		{"subset", calendar.CalendarEventsReadonlyScope, true, true},
+		{"no recorded scopes", "", true, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tmpDir := t.TempDir()
+			credPath := filepath.Join(tmpDir, "credentials.json")
+			if err := os.WriteFile(credPath, []byte(testCredentials), 0600); err != nil {
+				t.Fatalf("Failed to write credentials: %v", err)
+			}
+
+			auth := NewAuthenticator(credPath, filepath.Join(tmpDir, "token.json"))
+			auth.StrictScopes = tt.strict
+
+			token := &oauth2.Token{
+				AccessToken: "valid-token",
+				TokenType:   "Bearer",
+				Expiry:      time.Now().Add(time.Hour),
+			}
+			if tt.scope != "" {
+				token = token.WithExtra(map[string]interface{}{"scope": tt.scope})
+			}
+			if err := auth.saveToken(token); err != nil {
+				t.Fatalf("saveToken failed: %v", err)
+			}
+
+			err := auth.CheckToken(context.Background())
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("CheckToken() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr && tt.strict && !errors.Is(err, ErrScopeMismatch) {
+				t.Errorf("CheckToken() error = %v, want ErrScopeMismatch", err)
+			}
+		})
+	}
+}
+
+func TestEmptyPaths_ClearErrors(t *testing.T) {
+	auth := NewAuthenticator("", "")
+
+	if err := auth.LoadCredentials(); !errors.Is(err, ErrMissingCredentialsPath) {
+		t.Errorf("LoadCredentials() error = %v, want ErrMissingCredentialsPath", err)
+	}
+	if _, err := auth.GetToken(context.Background()); !errors.Is(err, ErrMissingTokenPath) {
+		t.Errorf("GetToken() error = %v, want ErrMissingTokenPath", err)
+	}
+	if err := auth.saveToken(&oauth2.Token{AccessToken: "a"}); !errors.Is(err, ErrMissingTokenPath) {
+		t.Errorf("saveToken() error = %v, want ErrMissingTokenPath", err)
+	}
+	if _, err := auth.loadToken(); !errors.Is(err, ErrMissingTokenPath) {
+		t.Errorf("loadToken() error = %v, want ErrMissingTokenPath", err)
+	}
+}
+
+func TestGetToken_EmptyCredentialsPath(t *testing.T) {
+	auth := NewAuthenticator("", filepath.Join(t.TempDir(), "token.json"))
+
+	_, err := auth.GetToken(context.Background())
+	if !errors.Is(err, ErrMissingCredentialsPath) {
+		t.Errorf("GetToken() error = %v, want ErrMissingCredentialsPath", err)
+	}
+}
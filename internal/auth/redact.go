@@ -0,0 +1,46 @@
+package auth
+
+import (
+	"fmt"
+	"regexp"
+)
+
+// tokenPatterns match substrings that look like OAuth secrets. Each pattern's
+// last capture group is the secret to mask.
+var tokenPatterns = []*regexp.Regexp{
+	// key=value and "key": "value" pairs, as found in token responses and URLs
+	regexp.MustCompile(`(?i)(\b(?:access_token|refresh_token|id_token|client_secret)["']?\s*[:=]\s*["']?)([^\s"'&,}]+)`),
+	// Authorization codes in callback URLs
+	regexp.MustCompile(`(\bcode=)([^\s"'&]+)`),
+	// Authorization headers
+	regexp.MustCompile(`(?i)(bearer\s+)([A-Za-z0-9._~+/=-]+)`),
+	// Google access and refresh tokens appearing on their own
+	regexp.MustCompile(`()(ya29\.[A-Za-z0-9._-]+|1//[A-Za-z0-9._-]+)`),
+}
+
+// redact masks token-like substrings in s, keeping a short prefix of each so
+// that messages stay useful for debugging.
+func redact(s string) string {
+	for _, pattern := range tokenPatterns {
+		s = pattern.ReplaceAllStringFunc(s, func(match string) string {
+			groups := pattern.FindStringSubmatch(match)
+			return groups[1] + mask(groups[2])
+		})
+	}
+	return s
+}
+
+// mask hides all but the first few characters of a secret.
+func mask(secret string) string {
+	const visible = 4
+	if len(secret) <= visible*2 {
+		return "****"
+	}
+	return secret[:visible] + "****"
+}
+
+// wrapAuthError wraps err with sentinel, redacting secrets from its message.
+// The original error is not kept in the chain since its message may leak.
+func wrapAuthError(sentinel error, err error) error {
+	return fmt.Errorf("%w: %s", sentinel, redact(err.Error()))
+}
@@ -0,0 +1,55 @@
+package auth
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestRedact(t *testing.T) {
+	tests := []struct {
+		name   string
+		input  string
+		secret string
+	}{
+		{"json access token", `{"access_token": "ya29.a0AfH6SMBx-secret-value", "expires_in": 3599}`, "ya29.a0AfH6SMBx-secret-value"},
+		{"json refresh token", `"refresh_token":"1//0gLongRefreshTokenValue"`, "1//0gLongRefreshTokenValue"},
+		{"bare access token", "token ya29.a0AfH6SMBxSecretValue expired", "ya29.a0AfH6SMBxSecretValue"},
+		{"bearer header", "Authorization: Bearer abcdefghijklmnop", "abcdefghijklmnop"},
+		{"callback code", "http://localhost:8080/?state=x&code=4/0AX4XfWh-secret", "4/0AX4XfWh-secret"},
+		{"client secret", "client_secret=GOCSPX-supersecret", "GOCSPX-supersecret"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := redact(tt.input)
+			if strings.Contains(got, tt.secret) {
+				t.Errorf("redact(%q) = %q, still contains the secret", tt.input, got)
+			}
+			if !strings.Contains(got, "****") {
+				t.Errorf("redact(%q) = %q, want a masked value", tt.input, got)
+			}
+		})
+	}
+}
+
+func TestRedact_LeavesOrdinaryTextAlone(t *testing.T) {
+	input := "oauth2: cannot fetch token: 400 Bad Request (code: 400)"
+	if got := redact(input); got != input {
+		t.Errorf("redact(%q) = %q, want unchanged", input, got)
+	}
+}
+
+func TestWrapAuthError_DoesNotLeakToken(t *testing.T) {
+	const token = "ya29.a0AfH6SMBxFakeAccessTokenForTesting"
+	cause := errors.New(`oauth2: server response: {"access_token":"` + token + `"}`)
+
+	err := wrapAuthError(ErrTokenRefreshFailed, cause)
+
+	if !errors.Is(err, ErrTokenRefreshFailed) {
+		t.Errorf("wrapAuthError() = %v, want ErrTokenRefreshFailed", err)
+	}
+	if strings.Contains(err.Error(), token) {
+		t.Errorf("error message leaks the token: %v", err)
+	}
+}
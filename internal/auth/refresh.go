@@ -0,0 +1,89 @@
+package auth
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"time"
+
+	"golang.org/x/oauth2"
+)
+
+// refreshTimeout bounds a shared refresh, which doesn't stop when the
+// caller that started it gives up.
+const refreshTimeout = time.Minute
+
+// refreshToken returns a fresh token in place of stale, saving it to the
+// token file. Concurrent calls in this process share one refresh, and a
+// lock on the token file keeps other calgo processes from refreshing at the
+// same time: whoever waits re-reads the file and uses the token saved
+// there, so a newer token is never overwritten with an older one.
+func (a *Authenticator) refreshToken(ctx context.Context, stale *oauth2.Token) (*oauth2.Token, error) {
+	ch := a.refreshes.DoChan(a.tokenPath, func() (any, error) {
+		// The refresh is shared, so one caller cancelling mustn't fail the
+		// others; each stops waiting on its own context below
+		ctx, cancel := context.WithTimeout(context.WithoutCancel(ctx), refreshTimeout)
+		defer cancel()
+		return a.refreshLocked(ctx, stale)
+	})
+
+	select {
+	case res := <-ch:
+		if res.Err != nil {
+			return nil, res.Err
+		}
+		return res.Val.(*oauth2.Token), nil
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+}
+
+// refreshLocked refreshes the token while holding the token file's lock.
+func (a *Authenticator) refreshLocked(ctx context.Context, stale *oauth2.Token) (*oauth2.Token, error) {
+	unlock, err := lockFile(ctx, a.tokenPath+".lock")
+	if err != nil {
+		if ctx.Err() != nil {
+			return nil, ctx.Err()
+		}
+		// Refreshing unlocked beats failing, e.g. in a read-only directory
+		fmt.Fprintf(os.Stderr, "Warning: failed to lock token file: %s\n", redact(err.Error()))
+		unlock = func() {}
+	}
+	defer unlock()
+
+	// Another process may have refreshed while we waited for the lock
+	token := stale
+	if saved, err := a.loadToken(); err == nil {
+		if saved.Valid() {
+			return saved, nil
+		}
+		token = saved
+	}
+
+	refreshed, err := a.config.TokenSource(ctx, token).Token()
+	if err != nil {
+		return nil, wrapAuthError(ErrTokenRefreshFailed, err)
+	}
+	if err := a.saveToken(refreshed); err != nil {
+		fmt.Fprintf(os.Stderr, "Warning: failed to save refreshed token: %s\n", redact(err.Error()))
+	}
+	return refreshed, nil
+}
+
+// savingTokenSource refreshes through the Authenticator, so that clients
+// from GetClient save the tokens they refresh, with the same locking as
+// GetToken, instead of keeping them in memory only.
+type savingTokenSource struct {
+	ctx  context.Context
+	auth *Authenticator
+	last *oauth2.Token
+}
+
+func (s *savingTokenSource) Token() (*oauth2.Token, error) {
+	token, err := s.auth.refreshToken(s.ctx, s.last)
+	if err != nil {
+		return nil, err
+	}
+	s.last = token
+	return token, nil
+}
@@ -0,0 +1,131 @@
+package auth
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestGetToken_ConcurrentRefreshOnce(t *testing.T) {
+	var refreshes atomic.Int32
+	auth, _ := newRepairAuthenticator(t, func(w http.ResponseWriter, r *http.Request) {
+		n := refreshes.Add(1)
+		// Keep the refresh in flight while the other callers arrive
+		time.Sleep(50 * time.Millisecond)
+		w.Header().Set("Content-Type", "application/json")
+		fmt.Fprintf(w, `{"access_token":"new-access-token-%d","token_type":"Bearer","expires_in":3600}`, n)
+	})
+
+	const callers = 10
+	tokens := make([]string, callers)
+	var wg sync.WaitGroup
+	for i := range callers {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			token, err := auth.GetToken(context.Background())
+			if err != nil {
+				t.Errorf("GetToken() error = %v", err)
+				return
+			}
+			tokens[i] = token.AccessToken
+		}()
+	}
+	wg.Wait()
+
+	if n := refreshes.Load(); n != 1 {
+		t.Errorf("token refreshed %d times, want once", n)
+	}
+	for i, token := range tokens {
+		if token != "new-access-token-1" {
+			t.Errorf("caller %d got %q, want new-access-token-1", i, token)
+		}
+	}
+
+	saved, err := auth.loadToken()
+	if err != nil {
+		t.Fatalf("loadToken failed: %v", err)
+	}
+	if saved.AccessToken != "new-access-token-1" || saved.RefreshToken != "old-refresh-token" {
+		t.Errorf("saved token = %+v, want the refreshed token with the original refresh token", saved)
+	}
+}
+
+func TestGetToken_FirstCallerCancels(t *testing.T) {
+	started := make(chan struct{})
+	release := make(chan struct{})
+	var refreshes atomic.Int32
+	auth, _ := newRepairAuthenticator(t, func(w http.ResponseWriter, r *http.Request) {
+		if refreshes.Add(1) == 1 {
+			close(started)
+		}
+		<-release
+		w.Header().Set("Content-Type", "application/json")
+		fmt.Fprint(w, `{"access_token":"new-access-token","token_type":"Bearer","expires_in":3600}`)
+	})
+
+	// The first caller starts the refresh, then gives up on it
+	firstCtx, cancelFirst := context.WithCancel(context.Background())
+	firstErr := make(chan error, 1)
+	go func() {
+		_, err := auth.GetToken(firstCtx)
+		firstErr <- err
+	}()
+	<-started
+
+	secondCtx, cancelSecond := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancelSecond()
+	type result struct {
+		token string
+		err   error
+	}
+	second := make(chan result, 1)
+	go func() {
+		token, err := auth.GetToken(secondCtx)
+		if err != nil {
+			second <- result{err: err}
+			return
+		}
+		second <- result{token: token.AccessToken}
+	}()
+	// Let the second caller join the refresh in flight
+	time.Sleep(20 * time.Millisecond)
+
+	cancelFirst()
+	if err := <-firstErr; !errors.Is(err, context.Canceled) {
+		t.Errorf("first GetToken() error = %v, want context.Canceled", err)
+	}
+	close(release)
+
+	res := <-second
+	if res.err != nil {
+		t.Fatalf("second GetToken() error = %v, want the refreshed token", res.err)
+	}
+	if res.token != "new-access-token" {
+		t.Errorf("second caller got %q, want new-access-token", res.token)
+	}
+	if n := refreshes.Load(); n != 1 {
+		t.Errorf("token refreshed %d times, want once", n)
+	}
+}
+
+func TestGetToken_RefreshFailureKeepsToken(t *testing.T) {
+	auth, _ := newRepairAuthenticator(t, func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusInternalServerError)
+	})
+
+	_, err := auth.refreshToken(context.Background(), nil)
+	if err == nil {
+		t.Fatal("refreshToken() expected error, got nil")
+	}
+
+	saved, err := auth.loadToken()
+	if err != nil || saved.AccessToken != "old-access-token" {
+		t.Errorf("saved token = %+v, %v, want the old token kept", saved, err)
+	}
+}
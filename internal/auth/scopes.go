@@ -0,0 +1,139 @@
+package auth
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	"golang.org/x/oauth2"
+	"google.golang.org/api/calendar/v3"
+)
+
+// scopeImplies lists, for broad scopes, the narrower scopes they include.
+var scopeImplies = map[string][]string{
+	calendar.CalendarScope: {
+		calendar.CalendarEventsScope,
+		calendar.CalendarReadonlyScope,
+		calendar.CalendarEventsReadonlyScope,
+		calendar.CalendarFreebusyScope,
+		calendar.CalendarCalendarlistReadonlyScope,
+	},
+	calendar.CalendarEventsScope: {
+		calendar.CalendarEventsReadonlyScope,
+	},
+	calendar.CalendarReadonlyScope: {
+		calendar.CalendarEventsReadonlyScope,
+		calendar.CalendarFreebusyScope,
+		calendar.CalendarCalendarlistReadonlyScope,
+	},
+}
+
+// tokenScopes returns the scopes granted to a token, or nil if the token
+// doesn't record them (e.g. tokens saved by older versions).
+func tokenScopes(token *oauth2.Token) []string {
+	scope, ok := token.Extra("scope").(string)
+	if !ok || scope == "" {
+		return nil
+	}
+	return strings.Fields(scope)
+}
+
+// missingScopes returns the required scopes not covered by granted.
+func missingScopes(granted, required []string) []string {
+	covered := make(map[string]bool)
+	for _, scope := range granted {
+		covered[scope] = true
+		for _, implied := range scopeImplies[scope] {
+			covered[implied] = true
+		}
+	}
+
+	var missing []string
+	for _, scope := range required {
+		if !covered[scope] {
+			missing = append(missing, scope)
+		}
+	}
+	return missing
+}
+
+// checkTokenScopes returns ErrInsufficientScope when the token's granted
+// scopes are known and don't cover required, naming the login command that
+// grants them. Tokens without scope information are assumed to be
+// sufficient.
+func checkTokenScopes(token *oauth2.Token, required []string, login string) error {
+	granted := tokenScopes(token)
+	if granted == nil {
+		return nil
+	}
+
+	if missing := missingScopes(granted, required); len(missing) > 0 {
+		return fmt.Errorf("%w: %s. Run %s to grant them", ErrInsufficientScope, strings.Join(missing, ", "), login)
+	}
+	return nil
+}
+
+// scopes returns the scopes to request and require: Scopes, plus
+// ManageCalendarsScope when ManageCalendars is set.
+func (a *Authenticator) scopes() []string {
+	if a.ManageCalendars {
+		return append(slices.Clone(Scopes), ManageCalendarsScope)
+	}
+	return Scopes
+}
+
+// checkScopes verifies the token's scopes against scopes, exactly when
+// StrictScopes is set and otherwise with checkTokenScopes.
+func (a *Authenticator) checkScopes(token *oauth2.Token) error {
+	login := "calgo auth login"
+	if a.ManageCalendars {
+		login += " --manage-calendars"
+	}
+	if a.StrictScopes {
+		return checkExactScopes(token, a.scopes(), login)
+	}
+	return checkTokenScopes(token, a.scopes(), login)
+}
+
+// checkExactScopes returns ErrScopeMismatch unless the token records
+// exactly the required scopes. Broad scopes don't count as covering
+// narrower ones here, since they grant more than was asked for.
+func checkExactScopes(token *oauth2.Token, required []string, login string) error {
+	granted := tokenScopes(token)
+	if granted == nil {
+		return fmt.Errorf("%w: the token doesn't record its scopes. Run %s", ErrScopeMismatch, login)
+	}
+
+	grantedSet := make(map[string]bool)
+	for _, scope := range granted {
+		grantedSet[scope] = true
+	}
+	requiredSet := make(map[string]bool)
+	for _, scope := range required {
+		requiredSet[scope] = true
+	}
+
+	var extra, missing []string
+	for _, scope := range granted {
+		if !requiredSet[scope] {
+			extra = append(extra, scope)
+		}
+	}
+	for _, scope := range required {
+		if !grantedSet[scope] {
+			missing = append(missing, scope)
+		}
+	}
+
+	var problems []string
+	if len(missing) > 0 {
+		problems = append(problems, "missing "+strings.Join(missing, ", "))
+	}
+	if len(extra) > 0 {
+		problems = append(problems, "unexpected "+strings.Join(extra, ", "))
+	}
+	if len(problems) > 0 {
+		return fmt.Errorf("%w: %s. Run %s", ErrScopeMismatch, strings.Join(problems, "; "), login)
+	}
+	return nil
+}
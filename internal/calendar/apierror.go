@@ -0,0 +1,65 @@
+package calendar
+
+import (
+	"fmt"
+
+	"google.golang.org/api/googleapi"
+)
+
+// APIError describes a failed Calendar API request in a form callers can
+// branch on. The client's errors for API failures wrap one; get it with
+// errors.As. It matches, with errors.Is, the sentinel error it was reported
+// as, such as ErrEventNotFound, and unwraps to the original *googleapi.Error.
+type APIError struct {
+	// Code is the HTTP status code of the response, e.g. 404.
+	Code int
+
+	// Reason is the first machine-readable reason the API gave, e.g.
+	// "quotaExceeded" or "notFound", or empty if it gave none.
+	Reason string
+
+	// Retryable reports whether the request may succeed if tried again
+	// later, as for rate limits and server errors.
+	Retryable bool
+
+	// Err is the error returned by the API client.
+	Err *googleapi.Error
+
+	sentinel error
+	message  string
+}
+
+// newAPIError describes apiErr as the sentinel error, with detail added to
+// the sentinel's message.
+func newAPIError(apiErr *googleapi.Error, sentinel error, detail string) *APIError {
+	e := &APIError{
+		Code:      apiErr.Code,
+		Retryable: isTransient(apiErr),
+		Err:       apiErr,
+		sentinel:  sentinel,
+		message:   fmt.Sprintf("%v: %s", sentinel, detail),
+	}
+	if len(apiErr.Errors) > 0 {
+		e.Reason = apiErr.Errors[0].Reason
+	}
+	return e
+}
+
+func (e *APIError) Error() string {
+	if e.message == "" {
+		return fmt.Sprintf("calendar API error (code: %d)", e.Code)
+	}
+	return e.message
+}
+
+func (e *APIError) Unwrap() []error {
+	var errs []error
+	if e.sentinel != nil {
+		errs = append(errs, e.sentinel)
+	}
+	// A nil *googleapi.Error would panic in errors.Is
+	if e.Err != nil {
+		errs = append(errs, e.Err)
+	}
+	return errs
+}
@@ -0,0 +1,101 @@
+package calendar
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+
+	"google.golang.org/api/googleapi"
+)
+
+func TestAPIError(t *testing.T) {
+	tests := []struct {
+		name          string
+		err           error
+		wantSentinel  error
+		wantCode      int
+		wantReason    string
+		wantRetryable bool
+	}{
+		{
+			name:         "event not found",
+			err:          wrapEventError(&googleapi.Error{Code: 404, Errors: []googleapi.ErrorItem{{Reason: "notFound"}}}, "abc123"),
+			wantSentinel: ErrEventNotFound,
+			wantCode:     404,
+			wantReason:   "notFound",
+		},
+		{
+			name:          "rate limited",
+			err:           wrapAPIError(&googleapi.Error{Code: 403, Errors: []googleapi.ErrorItem{{Reason: "rateLimitExceeded"}}}),
+			wantSentinel:  ErrQuotaExceeded,
+			wantCode:      403,
+			wantReason:    "rateLimitExceeded",
+			wantRetryable: true,
+		},
+		{
+			name:          "server error",
+			err:           wrapAPIErrorAs(&googleapi.Error{Code: 503, Message: "Backend Error"}, ErrEventListFailed),
+			wantSentinel:  ErrEventListFailed,
+			wantCode:      503,
+			wantRetryable: true,
+		},
+		{
+			name:         "bad request",
+			err:          wrapAPIError(&googleapi.Error{Code: 400, Message: "Invalid start"}),
+			wantSentinel: ErrEventCreationFailed,
+			wantCode:     400,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var apiErr *APIError
+			if !errors.As(tt.err, &apiErr) {
+				t.Fatalf("error %v doesn't wrap an *APIError", tt.err)
+			}
+			if apiErr.Code != tt.wantCode || apiErr.Reason != tt.wantReason || apiErr.Retryable != tt.wantRetryable {
+				t.Errorf("APIError = {Code: %d, Reason: %q, Retryable: %v}, want {%d, %q, %v}",
+					apiErr.Code, apiErr.Reason, apiErr.Retryable, tt.wantCode, tt.wantReason, tt.wantRetryable)
+			}
+			if !errors.Is(tt.err, tt.wantSentinel) {
+				t.Errorf("errors.Is(%v, %v) = false", tt.err, tt.wantSentinel)
+			}
+
+			var original *googleapi.Error
+			if !errors.As(tt.err, &original) || original != apiErr.Err {
+				t.Error("original *googleapi.Error not in the chain")
+			}
+		})
+	}
+}
+
+func TestAPIError_NotRewrapped(t *testing.T) {
+	inner := wrapEventError(&googleapi.Error{Code: 404}, "abc123")
+	err := wrapAPIErrorAs(fmt.Errorf("fetching: %w", inner), ErrEventListFailed)
+
+	if !errors.Is(err, ErrEventNotFound) || !errors.Is(err, ErrEventListFailed) {
+		t.Errorf("error = %v, want both ErrEventNotFound and ErrEventListFailed", err)
+	}
+	var apiErr *APIError
+	if !errors.As(err, &apiErr) || apiErr != inner {
+		t.Errorf("outer error doesn't keep the original *APIError")
+	}
+
+	if errors.As(wrapAPIError(errors.New("network error")), &apiErr) {
+		t.Error("non-API error wrapped as an *APIError")
+	}
+}
+
+func TestGetEvent_APIError(t *testing.T) {
+	client, _ := newFakeClient(t)
+
+	_, err := client.GetEvent(context.Background(), "missing1")
+	var apiErr *APIError
+	if !errors.As(err, &apiErr) || apiErr.Code != 404 || apiErr.Retryable {
+		t.Fatalf("GetEvent() error = %v, want a non-retryable 404 *APIError", err)
+	}
+	if !errors.Is(err, ErrEventNotFound) {
+		t.Errorf("GetEvent() error = %v, want ErrEventNotFound", err)
+	}
+}
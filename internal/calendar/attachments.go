@@ -0,0 +1,28 @@
+package calendar
+
+import "google.golang.org/api/calendar/v3"
+
+// Attachment is a file, usually on Google Drive, attached to an event.
+type Attachment struct {
+	FileURL  string `json:"file_url"`
+	Title    string `json:"title,omitempty"`
+	MimeType string `json:"mime_type,omitempty"`
+	IconLink string `json:"icon_link,omitempty"`
+}
+
+// eventAttachments returns the event's attachments, or nil if it has none.
+func eventAttachments(event *calendar.Event) []Attachment {
+	var attachments []Attachment
+	for _, attachment := range event.Attachments {
+		if attachment == nil {
+			continue
+		}
+		attachments = append(attachments, Attachment{
+			FileURL:  attachment.FileUrl,
+			Title:    attachment.Title,
+			MimeType: attachment.MimeType,
+			IconLink: attachment.IconLink,
+		})
+	}
+	return attachments
+}
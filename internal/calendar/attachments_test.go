@@ -0,0 +1,66 @@
+package calendar
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	"google.golang.org/api/calendar/v3"
+)
+
+func TestGetEvent_Attachments(t *testing.T) {
+	client, fake := newFakeClient(t)
+	event := fake.addEvent(&calendar.Event{
+		Summary: "Planning",
+		Start:   &calendar.EventDateTime{DateTime: "2024-01-15T14:00:00Z"},
+		End:     &calendar.EventDateTime{DateTime: "2024-01-15T15:00:00Z"},
+		Attachments: []*calendar.EventAttachment{
+			{
+				FileUrl:  "https://drive.google.com/file/d/agenda",
+				Title:    "Agenda",
+				MimeType: "application/vnd.google-apps.document",
+				IconLink: "https://drive-thirdparty.googleusercontent.com/16/type/document",
+			},
+			{
+				FileUrl:  "https://drive.google.com/file/d/budget",
+				Title:    "Budget.xlsx",
+				MimeType: "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet",
+			},
+		},
+	})
+
+	result, err := client.GetEvent(context.Background(), event.Id)
+	if err != nil {
+		t.Fatalf("GetEvent() error = %v", err)
+	}
+
+	want := []Attachment{
+		{
+			FileURL:  "https://drive.google.com/file/d/agenda",
+			Title:    "Agenda",
+			MimeType: "application/vnd.google-apps.document",
+			IconLink: "https://drive-thirdparty.googleusercontent.com/16/type/document",
+		},
+		{
+			FileURL:  "https://drive.google.com/file/d/budget",
+			Title:    "Budget.xlsx",
+			MimeType: "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet",
+		},
+	}
+	if !reflect.DeepEqual(result.Attachments, want) {
+		t.Errorf("Attachments = %+v, want %+v", result.Attachments, want)
+	}
+}
+
+func TestParseEventResult_NoAttachments(t *testing.T) {
+	result, err := parseEventResult(&calendar.Event{
+		Start: &calendar.EventDateTime{DateTime: "2024-01-15T14:00:00Z"},
+		End:   &calendar.EventDateTime{DateTime: "2024-01-15T15:00:00Z"},
+	})
+	if err != nil {
+		t.Fatalf("parseEventResult() error = %v", err)
+	}
+	if result.Attachments != nil {
+		t.Errorf("Attachments = %v, want nil", result.Attachments)
+	}
+}
@@ -0,0 +1,358 @@
+package calendar
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/mail"
+	"sort"
+	"strings"
+	"time"
+
+	"google.golang.org/api/calendar/v3"
+)
+
+// Errors for RSVP responses.
+var (
+	ErrInvalidResponse = errors.New("invalid response status")
+	ErrNotAttendee     = errors.New("not an attendee of the event")
+)
+
+// ErrInvalidAttendee is returned when an attendee isn't a valid email address.
+var ErrInvalidAttendee = errors.New("invalid attendee")
+
+// ErrInvalidSendUpdates is returned for an unknown EventParams.SendUpdates.
+var ErrInvalidSendUpdates = errors.New("invalid send updates option")
+
+// Guest notification options for EventParams.SendUpdates.
+const (
+	SendUpdatesAll          = "all"
+	SendUpdatesExternalOnly = "externalOnly"
+	SendUpdatesNone         = "none"
+)
+
+// ParseSendUpdates parses a notification option as given to --notify:
+// "all", "external" (or "externalOnly") or "none", in any case. It returns
+// the API value for EventParams.SendUpdates.
+func ParseSendUpdates(input string) (string, error) {
+	switch strings.ToLower(strings.TrimSpace(input)) {
+	case "all":
+		return SendUpdatesAll, nil
+	case "external", "externalonly", "external-only":
+		return SendUpdatesExternalOnly, nil
+	case "none":
+		return SendUpdatesNone, nil
+	default:
+		return "", fmt.Errorf("%w: %q (use all, external or none)", ErrInvalidSendUpdates, input)
+	}
+}
+
+// ParseAttendees parses a comma-separated list of email addresses, as given
+// to --attendees. Duplicates are removed case-insensitively, keeping the
+// first spelling.
+func ParseAttendees(input string) ([]string, error) {
+	var attendees []string
+
+	for _, field := range strings.Split(input, ",") {
+		field = strings.TrimSpace(field)
+		if field == "" {
+			continue
+		}
+
+		addr, err := mail.ParseAddress(field)
+		if err != nil {
+			return nil, fmt.Errorf("%w: %q", ErrInvalidAttendee, field)
+		}
+		attendees = append(attendees, addr.Address)
+	}
+
+	return dedupeAttendees(attendees, ""), nil
+}
+
+// dedupeAttendees removes duplicate emails case-insensitively, keeping the
+// first spelling, and drops self when it is non-empty.
+func dedupeAttendees(attendees []string, self string) []string {
+	seen := make(map[string]bool, len(attendees))
+	if self != "" {
+		seen[strings.ToLower(self)] = true
+	}
+
+	var result []string
+	for _, email := range attendees {
+		key := strings.ToLower(strings.TrimSpace(email))
+		if key == "" || seen[key] {
+			continue
+		}
+		seen[key] = true
+		result = append(result, email)
+	}
+	return result
+}
+
+// normalizeAttendees dedupes params.Attendees and, when DropSelfFromAttendees
+// is set, removes the client's own address since the organizer attends
+// implicitly.
+func (c *Client) normalizeAttendees(params EventParams) EventParams {
+	self := ""
+	if c.DropSelfFromAttendees {
+		self = c.selfEmail()
+	}
+	params.Attendees = dedupeAttendees(params.Attendees, self)
+	return params
+}
+
+// selfEmail returns the authenticated user's email: SelfEmail if set,
+// otherwise the calendar ID when it is an email address.
+func (c *Client) selfEmail() string {
+	if c.SelfEmail != "" {
+		return c.SelfEmail
+	}
+	if strings.Contains(c.calendarID, "@") {
+		return c.calendarID
+	}
+	return ""
+}
+
+// Attendee response statuses reported by the API.
+const (
+	ResponseNeedsAction = "needsAction"
+	ResponseDeclined    = "declined"
+	ResponseTentative   = "tentative"
+	ResponseAccepted    = "accepted"
+)
+
+// AttendeeResponse is an attendee's reply to an event invitation.
+type AttendeeResponse struct {
+	Email          string
+	ResponseStatus string
+	Optional       bool
+	Organizer      bool
+}
+
+// GetAttendeeResponses returns each attendee's response to an event.
+func (c *Client) GetAttendeeResponses(ctx context.Context, eventID string) ([]AttendeeResponse, error) {
+	if eventID == "" {
+		return nil, fmt.Errorf("%w: event ID is required", ErrInvalidEventTime)
+	}
+
+	event, err := c.fetchEvent(ctx, eventID)
+	if err != nil {
+		return nil, err
+	}
+
+	return attendeeResponses(event), nil
+}
+
+// PendingResponders returns the emails of attendees who haven't responded to
+// an event, so a reminder bot can nudge them. Nobody is reported until the
+// event is at least PendingResponseAge old, giving guests time to reply.
+// The organizer is never reported.
+func (c *Client) PendingResponders(ctx context.Context, eventID string) ([]string, error) {
+	if eventID == "" {
+		return nil, fmt.Errorf("%w: event ID is required", ErrInvalidEventTime)
+	}
+
+	event, err := c.fetchEvent(ctx, eventID)
+	if err != nil {
+		return nil, err
+	}
+
+	if c.PendingResponseAge > 0 {
+		created, err := time.Parse(time.RFC3339, event.Created)
+		if err == nil && c.now().Sub(created) < c.PendingResponseAge {
+			return nil, nil
+		}
+	}
+
+	var pending []string
+	for _, response := range attendeeResponses(event) {
+		if response.ResponseStatus == ResponseNeedsAction && !response.Organizer {
+			pending = append(pending, response.Email)
+		}
+	}
+	return pending, nil
+}
+
+// attendeeResponses converts an event's attendees to AttendeeResponses. A
+// missing status is reported as ResponseNeedsAction, the API's default.
+func attendeeResponses(event *calendar.Event) []AttendeeResponse {
+	var responses []AttendeeResponse
+	for _, attendee := range event.Attendees {
+		status := attendee.ResponseStatus
+		if status == "" {
+			status = ResponseNeedsAction
+		}
+		responses = append(responses, AttendeeResponse{
+			Email:          attendee.Email,
+			ResponseStatus: status,
+			Optional:       attendee.Optional,
+			Organizer:      attendee.Organizer,
+		})
+	}
+	return responses
+}
+
+// RecentAttendees returns the emails of attendees invited to events between
+// since and now, most frequently invited first, for attendee completion.
+// Emails are compared case-insensitively and the user's own address is left
+// out. Ties are broken alphabetically. A limit of zero or less returns all
+// of them.
+func (c *Client) RecentAttendees(ctx context.Context, limit int, since time.Time) ([]string, error) {
+	events, err := c.ListEvents(ctx, ListOptions{TimeMin: since, TimeMax: c.now()})
+	if err != nil {
+		return nil, err
+	}
+
+	self := strings.ToLower(c.selfEmail())
+	counts := make(map[string]int)
+	spelling := make(map[string]string)
+	for _, event := range events {
+		for _, email := range event.Attendees {
+			key := strings.ToLower(email)
+			if key == "" || key == self {
+				continue
+			}
+			if _, ok := spelling[key]; !ok {
+				spelling[key] = email
+			}
+			counts[key]++
+		}
+	}
+
+	keys := make([]string, 0, len(counts))
+	for key := range counts {
+		keys = append(keys, key)
+	}
+	sort.Slice(keys, func(i, j int) bool {
+		if counts[keys[i]] != counts[keys[j]] {
+			return counts[keys[i]] > counts[keys[j]]
+		}
+		return keys[i] < keys[j]
+	})
+
+	if limit > 0 && len(keys) > limit {
+		keys = keys[:limit]
+	}
+	attendees := make([]string, len(keys))
+	for i, key := range keys {
+		attendees[i] = spelling[key]
+	}
+	return attendees, nil
+}
+
+// RespondToEvents sets the user's RSVP on each event to response, which
+// must be ResponseAccepted, ResponseDeclined or ResponseTentative. When
+// myEmail is empty the user's address is resolved from SelfEmail or the
+// calendar ID, falling back to the attendee the API marks as self. Events
+// are processed in order, in chunks per the client's Batch policy, and each
+// gets its own outcome, so one failure doesn't stop the rest. The returned
+// error is only set when ctx is cancelled or response is invalid.
+func (c *Client) RespondToEvents(ctx context.Context, eventIDs []string, response string, myEmail string) ([]EventCreateOutcome, error) {
+	if err := validateResponse(response); err != nil {
+		return nil, err
+	}
+
+	if myEmail == "" {
+		myEmail = c.selfEmail()
+	}
+
+	return c.forEachEvent(ctx, eventIDs, func(eventID string) (*EventResult, error) {
+		return c.respondToEvent(ctx, eventID, response, "", myEmail)
+	})
+}
+
+// RespondToEvent sets the user's RSVP on a single event, resolving the user
+// as RespondToEvents does. A non-empty comment is attached to the response
+// for the organizer to see; an empty one keeps any earlier comment.
+func (c *Client) RespondToEvent(ctx context.Context, eventID, response, comment string) (*EventResult, error) {
+	if err := validateResponse(response); err != nil {
+		return nil, err
+	}
+	return c.respondToEvent(ctx, eventID, response, comment, c.selfEmail())
+}
+
+// ParseResponse parses an RSVP as given on the command line: "accept",
+// "decline" or "tentative", also accepting "yes", "no" and "maybe" and the
+// API's own values. It returns the API value.
+func ParseResponse(input string) (string, error) {
+	switch strings.ToLower(strings.TrimSpace(input)) {
+	case "accept", "accepted", "yes":
+		return ResponseAccepted, nil
+	case "decline", "declined", "no":
+		return ResponseDeclined, nil
+	case "tentative", "maybe":
+		return ResponseTentative, nil
+	}
+	return "", fmt.Errorf("%w: %q (use accept, decline or tentative)", ErrInvalidResponse, input)
+}
+
+// validateResponse checks that response is one a user can give.
+func validateResponse(response string) error {
+	switch response {
+	case ResponseAccepted, ResponseDeclined, ResponseTentative:
+		return nil
+	}
+	return fmt.Errorf("%w: %q (use %s, %s or %s)", ErrInvalidResponse, response, ResponseAccepted, ResponseDeclined, ResponseTentative)
+}
+
+// respondToEvent sets myEmail's response, and comment if it is set, on a
+// single event.
+func (c *Client) respondToEvent(ctx context.Context, eventID, response, comment, myEmail string) (*EventResult, error) {
+	if eventID == "" {
+		return nil, fmt.Errorf("%w: event ID is required", ErrInvalidEventTime)
+	}
+
+	ctx, cancel := c.withRequestTimeout(ctx)
+	defer cancel()
+
+	existing, err := c.fetchEvent(ctx, eventID)
+	if err != nil {
+		return nil, err
+	}
+
+	// Patching attendees replaces the whole list, so send it all back, and
+	// only if nobody changed it in between
+	found := false
+	for _, attendee := range existing.Attendees {
+		if isMe(attendee, myEmail) {
+			attendee.ResponseStatus = response
+			if comment != "" {
+				attendee.Comment = comment
+			}
+			found = true
+		}
+	}
+	if !found {
+		who := myEmail
+		if who == "" {
+			who = "you"
+		}
+		return nil, fmt.Errorf("%w: %s isn't invited to %s", ErrNotAttendee, who, eventID)
+	}
+
+	patch := &calendar.Event{Attendees: existing.Attendees}
+	var updated *calendar.Event
+	err = c.retryPatch(ctx, existing.Etag != "", func() error {
+		call := c.service.Events.Patch(c.calendarID, eventID, patch).Context(ctx)
+		if existing.Etag != "" {
+			call.Header().Set("If-Match", existing.Etag)
+		}
+		var err error
+		updated, err = call.Do()
+		return err
+	})
+	c.InvalidateCache()
+	if err != nil {
+		return nil, wrapEventError(err, eventID)
+	}
+	return parseEventResult(updated)
+}
+
+// isMe reports whether attendee is the user: the attendee with myEmail when
+// it is known, otherwise the one the API flags as self.
+func isMe(attendee *calendar.EventAttendee, myEmail string) bool {
+	if myEmail != "" {
+		return strings.EqualFold(attendee.Email, myEmail)
+	}
+	return attendee.Self
+}
@@ -0,0 +1,445 @@
+package calendar
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"sort"
+	"testing"
+	"time"
+
+	"google.golang.org/api/calendar/v3"
+)
+
+func TestParseAttendees(t *testing.T) {
+	tests := []struct {
+		name    string
+		input   string
+		want    []string
+		wantErr bool
+	}{
+		{"single", "alice@example.com", []string{"alice@example.com"}, false},
+		{"trims spaces", " alice@example.com , bob@example.com ", []string{"alice@example.com", "bob@example.com"}, false},
+		{"dedupes case-insensitively", "Alice@Example.com,bob@example.com,alice@example.com", []string{"Alice@Example.com", "bob@example.com"}, false},
+		{"skips empty entries", "alice@example.com,,", []string{"alice@example.com"}, false},
+		{"invalid email", "alice@example.com,not-an-email", nil, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParseAttendees(tt.input)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ParseAttendees() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr {
+				if !errors.Is(err, ErrInvalidAttendee) {
+					t.Errorf("ParseAttendees() error = %v, want ErrInvalidAttendee", err)
+				}
+				return
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("ParseAttendees() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCreateEvent_DedupesAttendees(t *testing.T) {
+	client, fake := newFakeClient(t)
+
+	result, err := client.CreateEvent(context.Background(), EventParams{
+		Title:     "Meeting",
+		StartTime: time.Date(2024, 1, 15, 14, 0, 0, 0, time.UTC),
+		Duration:  time.Hour,
+		Attendees: []string{"bob@example.com", "BOB@example.com", "carol@example.com"},
+	})
+	if err != nil {
+		t.Fatalf("CreateEvent() error = %v", err)
+	}
+
+	want := []string{"bob@example.com", "carol@example.com"}
+	if len(fake.inserted[0].Attendees) != len(want) {
+		t.Fatalf("Expected %d attendees sent, got %d", len(want), len(fake.inserted[0].Attendees))
+	}
+	if !reflect.DeepEqual(result.Attendees, want) {
+		t.Errorf("result Attendees = %v, want %v", result.Attendees, want)
+	}
+}
+
+func TestCreateEvent_DropSelfFromAttendees(t *testing.T) {
+	client, _ := newFakeClient(t)
+	client.DropSelfFromAttendees = true
+	client.SelfEmail = "me@example.com"
+
+	params := EventParams{
+		Title:     "Meeting",
+		StartTime: time.Date(2024, 1, 15, 14, 0, 0, 0, time.UTC),
+		Duration:  time.Hour,
+		Attendees: []string{"Me@Example.com", "bob@example.com"},
+	}
+
+	result, err := client.CreateEvent(context.Background(), params)
+	if err != nil {
+		t.Fatalf("CreateEvent() error = %v", err)
+	}
+	if !reflect.DeepEqual(result.Attendees, []string{"bob@example.com"}) {
+		t.Errorf("result Attendees = %v, want only bob@example.com", result.Attendees)
+	}
+
+	// Without the option the user's own address is kept
+	client.DropSelfFromAttendees = false
+	result, err = client.CreateEvent(context.Background(), params)
+	if err != nil {
+		t.Fatalf("CreateEvent() error = %v", err)
+	}
+	if len(result.Attendees) != 2 {
+		t.Errorf("result Attendees = %v, want both addresses", result.Attendees)
+	}
+}
+
+func TestSelfEmail_FallsBackToCalendarID(t *testing.T) {
+	client := newClientWithService(nil, "me@example.com")
+	if got := client.selfEmail(); got != "me@example.com" {
+		t.Errorf("selfEmail() = %q, want calendar ID", got)
+	}
+
+	client = newClientWithService(nil, "primary")
+	if got := client.selfEmail(); got != "" {
+		t.Errorf("selfEmail() = %q, want empty for primary", got)
+	}
+}
+
+func TestValidateEventParams_InvalidAttendee(t *testing.T) {
+	err := validateEventParams(EventParams{
+		Title:     "Meeting",
+		StartTime: time.Date(2024, 1, 15, 14, 0, 0, 0, time.UTC),
+		Duration:  time.Hour,
+		Attendees: []string{"nope"},
+	})
+	if !errors.Is(err, ErrInvalidAttendee) {
+		t.Errorf("validateEventParams() error = %v, want ErrInvalidAttendee", err)
+	}
+}
+
+func TestCreateEvent_SendUpdates(t *testing.T) {
+	tests := []struct {
+		name        string
+		sendUpdates string
+		want        string
+	}{
+		{name: "unset leaves API default", sendUpdates: "", want: ""},
+		{name: "all", sendUpdates: SendUpdatesAll, want: "all"},
+		{name: "external only", sendUpdates: SendUpdatesExternalOnly, want: "externalOnly"},
+		{name: "none", sendUpdates: SendUpdatesNone, want: "none"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client, fake := newFakeClient(t)
+
+			_, err := client.CreateEvent(context.Background(), EventParams{
+				Title:       "Meeting",
+				StartTime:   time.Date(2024, 1, 15, 14, 0, 0, 0, time.UTC),
+				Duration:    time.Hour,
+				Attendees:   []string{"bob@example.com"},
+				SendUpdates: tt.sendUpdates,
+			})
+			if err != nil {
+				t.Fatalf("CreateEvent() error = %v", err)
+			}
+			if got := fake.queries[0].Get("sendUpdates"); got != tt.want {
+				t.Errorf("sendUpdates = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestValidateEventParams_InvalidSendUpdates(t *testing.T) {
+	err := validateEventParams(EventParams{
+		Title:       "Meeting",
+		StartTime:   time.Date(2024, 1, 15, 14, 0, 0, 0, time.UTC),
+		Duration:    time.Hour,
+		SendUpdates: "everyone",
+	})
+	if !errors.Is(err, ErrInvalidSendUpdates) {
+		t.Errorf("validateEventParams() error = %v, want ErrInvalidSendUpdates", err)
+	}
+}
+
+func TestParseSendUpdates(t *testing.T) {
+	tests := []struct {
+		input   string
+		want    string
+		wantErr bool
+	}{
+		{input: "all", want: SendUpdatesAll},
+		{input: "ALL", want: SendUpdatesAll},
+		{input: "external", want: SendUpdatesExternalOnly},
+		{input: "externalOnly", want: SendUpdatesExternalOnly},
+		{input: " none ", want: SendUpdatesNone},
+		{input: "", wantErr: true},
+		{input: "some", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			got, err := ParseSendUpdates(tt.input)
+			if tt.wantErr {
+				if !errors.Is(err, ErrInvalidSendUpdates) {
+					t.Errorf("ParseSendUpdates(%q) error = %v, want ErrInvalidSendUpdates", tt.input, err)
+				}
+				return
+			}
+			if err != nil || got != tt.want {
+				t.Errorf("ParseSendUpdates(%q) = %q, %v; want %q", tt.input, got, err, tt.want)
+			}
+		})
+	}
+}
+
+// addEventWithResponses seeds the fake with an event created at created whose
+// attendees have the given response statuses.
+func addEventWithResponses(fake *fakeCalendar, created time.Time, responses map[string]string) string {
+	event := &calendar.Event{
+		Summary: "All Hands",
+		Created: created.Format(time.RFC3339),
+		Start:   &calendar.EventDateTime{DateTime: "2024-01-20T14:00:00Z"},
+		End:     &calendar.EventDateTime{DateTime: "2024-01-20T15:00:00Z"},
+	}
+	for email, status := range responses {
+		event.Attendees = append(event.Attendees, &calendar.EventAttendee{Email: email, ResponseStatus: status})
+	}
+	return fake.addEvent(event).Id
+}
+
+func TestPendingResponders(t *testing.T) {
+	client, fake := newFakeClient(t)
+	now := time.Date(2024, 1, 15, 12, 0, 0, 0, time.UTC)
+	client.now = func() time.Time { return now }
+	client.PendingResponseAge = 24 * time.Hour
+
+	id := addEventWithResponses(fake, now.Add(-48*time.Hour), map[string]string{
+		"alice@example.com": ResponseAccepted,
+		"bob@example.com":   ResponseNeedsAction,
+		"carol@example.com": ResponseDeclined,
+		"dave@example.com":  ResponseTentative,
+		"erin@example.com":  ResponseNeedsAction,
+	})
+
+	pending, err := client.PendingResponders(context.Background(), id)
+	if err != nil {
+		t.Fatalf("PendingResponders() error = %v", err)
+	}
+
+	sort.Strings(pending)
+	want := []string{"bob@example.com", "erin@example.com"}
+	if !reflect.DeepEqual(pending, want) {
+		t.Errorf("PendingResponders() = %v, want %v", pending, want)
+	}
+}
+
+func TestPendingResponders_TooRecent(t *testing.T) {
+	client, fake := newFakeClient(t)
+	now := time.Date(2024, 1, 15, 12, 0, 0, 0, time.UTC)
+	client.now = func() time.Time { return now }
+	client.PendingResponseAge = 24 * time.Hour
+
+	id := addEventWithResponses(fake, now.Add(-time.Hour), map[string]string{
+		"bob@example.com": ResponseNeedsAction,
+	})
+
+	pending, err := client.PendingResponders(context.Background(), id)
+	if err != nil {
+		t.Fatalf("PendingResponders() error = %v", err)
+	}
+	if len(pending) != 0 {
+		t.Errorf("PendingResponders() = %v, want none before PendingResponseAge", pending)
+	}
+}
+
+func TestGetAttendeeResponses_NotFound(t *testing.T) {
+	client, _ := newFakeClient(t)
+
+	_, err := client.GetAttendeeResponses(context.Background(), "missing")
+	if !errors.Is(err, ErrEventNotFound) {
+		t.Errorf("GetAttendeeResponses() error = %v, want ErrEventNotFound", err)
+	}
+}
+
+func TestRecentAttendees(t *testing.T) {
+	client, fake := newFakeClient(t)
+	now := time.Date(2024, 1, 15, 12, 0, 0, 0, time.UTC)
+	client.now = func() time.Time { return now }
+	client.SelfEmail = "me@example.com"
+
+	addMeeting := func(start time.Time, attendees ...string) {
+		event := &calendar.Event{
+			Summary: "Meeting",
+			Start:   &calendar.EventDateTime{DateTime: start.Format(time.RFC3339)},
+			End:     &calendar.EventDateTime{DateTime: start.Add(time.Hour).Format(time.RFC3339)},
+		}
+		for _, email := range attendees {
+			event.Attendees = append(event.Attendees, &calendar.EventAttendee{Email: email})
+		}
+		fake.addEvent(event)
+	}
+
+	addMeeting(now.Add(-72*time.Hour), "alice@example.com", "bob@example.com", "me@example.com")
+	addMeeting(now.Add(-48*time.Hour), "Alice@Example.com", "carol@example.com")
+	addMeeting(now.Add(-24*time.Hour), "alice@example.com", "carol@example.com", "dave@example.com")
+	// Outside the window on both sides
+	addMeeting(now.Add(-30*24*time.Hour), "erin@example.com")
+	addMeeting(now.Add(24*time.Hour), "frank@example.com")
+
+	got, err := client.RecentAttendees(context.Background(), 0, now.Add(-7*24*time.Hour))
+	if err != nil {
+		t.Fatalf("RecentAttendees() error = %v", err)
+	}
+	want := []string{"alice@example.com", "carol@example.com", "bob@example.com", "dave@example.com"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("RecentAttendees() = %v, want %v", got, want)
+	}
+
+	got, err = client.RecentAttendees(context.Background(), 2, now.Add(-7*24*time.Hour))
+	if err != nil {
+		t.Fatalf("RecentAttendees() error = %v", err)
+	}
+	if want := want[:2]; !reflect.DeepEqual(got, want) {
+		t.Errorf("RecentAttendees(limit 2) = %v, want %v", got, want)
+	}
+}
+
+func TestRespondToEvents(t *testing.T) {
+	client, fake := newFakeClient(t)
+	created := time.Date(2024, 1, 10, 12, 0, 0, 0, time.UTC)
+
+	first := addEventWithResponses(fake, created, map[string]string{
+		"me@example.com":    ResponseNeedsAction,
+		"alice@example.com": ResponseAccepted,
+	})
+	second := addEventWithResponses(fake, created, map[string]string{
+		"Me@Example.com": ResponseTentative,
+	})
+	notInvited := addEventWithResponses(fake, created, map[string]string{
+		"bob@example.com": ResponseNeedsAction,
+	})
+
+	outcomes, err := client.RespondToEvents(context.Background(), []string{first, second, notInvited}, ResponseDeclined, "me@example.com")
+	if err != nil {
+		t.Fatalf("RespondToEvents() error = %v", err)
+	}
+	if len(outcomes) != 3 {
+		t.Fatalf("RespondToEvents() returned %d outcomes, want 3", len(outcomes))
+	}
+
+	for _, id := range []string{first, second} {
+		for _, attendee := range fake.events[id].Attendees {
+			want := ResponseAccepted
+			if attendee.Email != "alice@example.com" {
+				want = ResponseDeclined
+			}
+			if attendee.ResponseStatus != want {
+				t.Errorf("event %s: %s responseStatus = %q, want %q", id, attendee.Email, attendee.ResponseStatus, want)
+			}
+		}
+	}
+
+	if outcomes[0].Err != nil || outcomes[1].Err != nil {
+		t.Errorf("unexpected errors: %v, %v", outcomes[0].Err, outcomes[1].Err)
+	}
+	if !errors.Is(outcomes[2].Err, ErrNotAttendee) {
+		t.Errorf("outcomes[2].Err = %v, want ErrNotAttendee", outcomes[2].Err)
+	}
+}
+
+func TestRespondToEvents_ResolvesSelf(t *testing.T) {
+	client, fake := newFakeClient(t)
+	event := fake.addEvent(&calendar.Event{
+		Summary: "Sync",
+		Start:   &calendar.EventDateTime{DateTime: "2024-01-20T14:00:00Z"},
+		End:     &calendar.EventDateTime{DateTime: "2024-01-20T15:00:00Z"},
+		Attendees: []*calendar.EventAttendee{
+			{Email: "owner@example.com", ResponseStatus: ResponseNeedsAction, Self: true},
+			{Email: "alice@example.com", ResponseStatus: ResponseNeedsAction},
+		},
+	})
+
+	outcomes, err := client.RespondToEvents(context.Background(), []string{event.Id}, ResponseAccepted, "")
+	if err != nil || outcomes[0].Err != nil {
+		t.Fatalf("RespondToEvents() error = %v, outcome error = %v", err, outcomes[0].Err)
+	}
+
+	statuses := map[string]string{}
+	for _, attendee := range fake.events[event.Id].Attendees {
+		statuses[attendee.Email] = attendee.ResponseStatus
+	}
+	if statuses["owner@example.com"] != ResponseAccepted || statuses["alice@example.com"] != ResponseNeedsAction {
+		t.Errorf("responses = %v, want only owner accepted", statuses)
+	}
+}
+
+func TestRespondToEvents_InvalidResponse(t *testing.T) {
+	client, _ := newFakeClient(t)
+
+	_, err := client.RespondToEvents(context.Background(), []string{"abc"}, ResponseNeedsAction, "me@example.com")
+	if !errors.Is(err, ErrInvalidResponse) {
+		t.Errorf("RespondToEvents() error = %v, want ErrInvalidResponse", err)
+	}
+}
+
+func TestRespondToEvent_Comment(t *testing.T) {
+	client, fake := newFakeClient(t)
+	client.SelfEmail = "me@example.com"
+	id := addEventWithResponses(fake, time.Date(2024, 1, 10, 12, 0, 0, 0, time.UTC), map[string]string{
+		"me@example.com":    ResponseNeedsAction,
+		"alice@example.com": ResponseAccepted,
+	})
+
+	if _, err := client.RespondToEvent(context.Background(), id, ResponseDeclined, "On holiday"); err != nil {
+		t.Fatalf("RespondToEvent() error = %v", err)
+	}
+	for _, attendee := range fake.events[id].Attendees {
+		if attendee.Email == "me@example.com" && (attendee.ResponseStatus != ResponseDeclined || attendee.Comment != "On holiday") {
+			t.Errorf("my response = %q %q, want declined with the comment", attendee.ResponseStatus, attendee.Comment)
+		}
+		if attendee.Email == "alice@example.com" && attendee.Comment != "" {
+			t.Errorf("alice's comment = %q, want none", attendee.Comment)
+		}
+	}
+
+	if _, err := client.RespondToEvent(context.Background(), id, ResponseNeedsAction, ""); !errors.Is(err, ErrInvalidResponse) {
+		t.Errorf("RespondToEvent(needsAction) error = %v, want ErrInvalidResponse", err)
+	}
+}
+
+func TestParseResponse(t *testing.T) {
+	tests := []struct {
+		input   string
+		want    string
+		wantErr bool
+	}{
+		{input: "accept", want: ResponseAccepted},
+		{input: " Yes ", want: ResponseAccepted},
+		{input: "declined", want: ResponseDeclined},
+		{input: "no", want: ResponseDeclined},
+		{input: "maybe", want: ResponseTentative},
+		{input: "TENTATIVE", want: ResponseTentative},
+		{input: "needsAction", wantErr: true},
+		{input: "", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			got, err := ParseResponse(tt.input)
+			if tt.wantErr {
+				if !errors.Is(err, ErrInvalidResponse) {
+					t.Fatalf("ParseResponse() error = %v, want ErrInvalidResponse", err)
+				}
+				return
+			}
+			if err != nil || got != tt.want {
+				t.Errorf("ParseResponse(%q) = %q, %v; want %q", tt.input, got, err, tt.want)
+			}
+		})
+	}
+}
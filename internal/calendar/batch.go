@@ -0,0 +1,71 @@
+package calendar
+
+import (
+	"context"
+	"time"
+)
+
+// BatchPolicy controls how bulk operations such as CreateEvents split large
+// inputs, to bound memory use and spread quota usage over time.
+type BatchPolicy struct {
+	// Size is the number of items per chunk. Values below 1 process the
+	// whole input as one chunk.
+	Size int
+
+	// Pause is the wait between chunks.
+	Pause time.Duration
+}
+
+// DefaultBatchPolicy returns the batch policy used by new clients.
+func DefaultBatchPolicy() BatchPolicy {
+	return BatchPolicy{
+		Size:  50,
+		Pause: 100 * time.Millisecond,
+	}
+}
+
+// inChunks calls fn with the bounds [start, end) of successive chunks of n
+// items, pausing between chunks per the client's Batch policy. It stops and
+// returns ctx's error if ctx is done before a chunk starts.
+func (c *Client) inChunks(ctx context.Context, n int, fn func(start, end int)) error {
+	size := c.Batch.Size
+	if size < 1 {
+		size = n
+	}
+
+	for start := 0; start < n; start += size {
+		if start > 0 && c.Batch.Pause > 0 {
+			select {
+			case <-time.After(c.Batch.Pause):
+			case <-ctx.Done():
+				return ctx.Err()
+			}
+		}
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+
+		fn(start, min(start+size, n))
+	}
+	return nil
+}
+
+// CreateEvents creates each event in order, in chunks per the client's
+// Batch policy. Every item gets its own outcome, in input order, so one
+// failure doesn't stop the rest. The returned error is only set when ctx is
+// cancelled, in which case the outcomes so far are returned.
+func (c *Client) CreateEvents(ctx context.Context, params []EventParams) ([]EventCreateOutcome, error) {
+	outcomes := make([]EventCreateOutcome, 0, len(params))
+
+	err := c.inChunks(ctx, len(params), func(start, end int) {
+		for i := start; i < end; i++ {
+			result, err := c.CreateEvent(ctx, params[i])
+			outcome := EventCreateOutcome{Index: i, Result: result, Err: err}
+			if result != nil {
+				outcome.EventID = result.ID
+			}
+			outcomes = append(outcomes, outcome)
+		}
+	})
+	return outcomes, err
+}
@@ -0,0 +1,110 @@
+package calendar
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestInChunks(t *testing.T) {
+	client, _ := newFakeClient(t)
+	client.Batch = BatchPolicy{Size: 50, Pause: time.Millisecond}
+
+	var chunks [][2]int
+	err := client.inChunks(context.Background(), 120, func(start, end int) {
+		chunks = append(chunks, [2]int{start, end})
+	})
+	if err != nil {
+		t.Fatalf("inChunks() error = %v", err)
+	}
+
+	want := [][2]int{{0, 50}, {50, 100}, {100, 120}}
+	if !reflect.DeepEqual(chunks, want) {
+		t.Errorf("chunks = %v, want %v", chunks, want)
+	}
+}
+
+func TestInChunks_DefaultPolicy(t *testing.T) {
+	client, err := NewClient(context.Background(), http.DefaultClient, "primary")
+	if err != nil {
+		t.Fatalf("NewClient() error = %v", err)
+	}
+	if client.Batch != DefaultBatchPolicy() {
+		t.Fatalf("new client Batch = %+v, want %+v", client.Batch, DefaultBatchPolicy())
+	}
+
+	client.Batch.Pause = time.Millisecond
+	var chunks int
+	if err := client.inChunks(context.Background(), 120, func(start, end int) { chunks++ }); err != nil {
+		t.Fatalf("inChunks() error = %v", err)
+	}
+	if chunks != 3 {
+		t.Errorf("inChunks() ran %d chunks of the default size, want 3", chunks)
+	}
+}
+
+func TestInChunks_StopsWhenCancelled(t *testing.T) {
+	client, _ := newFakeClient(t)
+	client.Batch = BatchPolicy{Size: 10, Pause: time.Hour}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	calls := 0
+	err := client.inChunks(ctx, 30, func(start, end int) {
+		calls++
+		cancel()
+	})
+
+	if !errors.Is(err, context.Canceled) {
+		t.Errorf("inChunks() error = %v, want context.Canceled", err)
+	}
+	if calls != 1 {
+		t.Errorf("inChunks() ran %d chunks, want 1", calls)
+	}
+}
+
+func TestCreateEvents_Chunked(t *testing.T) {
+	client, fake := newFakeClient(t)
+	client.Batch = BatchPolicy{Size: 50, Pause: time.Millisecond}
+	start := time.Date(2024, 1, 15, 9, 0, 0, 0, time.UTC)
+
+	params := make([]EventParams, 120)
+	for i := range params {
+		params[i] = EventParams{
+			Title:     fmt.Sprintf("Event %d", i),
+			StartTime: start.Add(time.Duration(i) * time.Hour),
+			Duration:  30 * time.Minute,
+		}
+	}
+	// One invalid item fails without stopping the rest
+	params[70].Title = ""
+
+	outcomes, err := client.CreateEvents(context.Background(), params)
+	if err != nil {
+		t.Fatalf("CreateEvents() error = %v", err)
+	}
+	if len(outcomes) != 120 {
+		t.Fatalf("CreateEvents() returned %d outcomes, want 120", len(outcomes))
+	}
+
+	for i, outcome := range outcomes {
+		if outcome.Index != i {
+			t.Fatalf("outcomes[%d].Index = %d, want input order", i, outcome.Index)
+		}
+		if i == 70 {
+			if outcome.Err == nil {
+				t.Error("outcomes[70] should fail")
+			}
+			continue
+		}
+		if outcome.Err != nil || outcome.Result.Title != params[i].Title {
+			t.Errorf("outcomes[%d] = %+v, err %v", i, outcome.Result, outcome.Err)
+		}
+	}
+	if n := fake.requestCount("POST", "/calendars/primary/events"); n != 119 {
+		t.Errorf("made %d inserts, want 119", n)
+	}
+}
@@ -0,0 +1,118 @@
+package calendar
+
+import (
+	"maps"
+	"slices"
+	"sync"
+	"time"
+)
+
+// listCacheKey identifies a ListEvents call for caching. The range bounds are
+// stored formatted as sent to the API, so equal instants in different
+// locations share an entry.
+type listCacheKey struct {
+	calendarID string
+	timeMin    string
+	timeMax    string
+	maxResults int
+	query      string
+
+	// tags holds ListOptions.Tags as sorted filters joined by NUL bytes.
+	tags string
+}
+
+// listCacheEntry is a cached ListEvents result.
+type listCacheEntry struct {
+	events  []*EventResult
+	expires time.Time
+}
+
+// eventCache is an in-memory cache of ListEvents results with a fixed TTL.
+type eventCache struct {
+	mu      sync.Mutex
+	ttl     time.Duration
+	now     func() time.Time
+	entries map[listCacheKey]listCacheEntry
+}
+
+func newEventCache(ttl time.Duration) *eventCache {
+	return &eventCache{
+		ttl:     ttl,
+		now:     time.Now,
+		entries: make(map[listCacheKey]listCacheEntry),
+	}
+}
+
+// get returns a copy of the cached events for key, if present and fresh.
+func (c *eventCache) get(key listCacheKey) ([]*EventResult, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	entry, ok := c.entries[key]
+	if !ok {
+		return nil, false
+	}
+	if !c.now().Before(entry.expires) {
+		delete(c.entries, key)
+		return nil, false
+	}
+	return cloneEvents(entry.events), true
+}
+
+// put caches events for key.
+func (c *eventCache) put(key listCacheKey, events []*EventResult) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.entries[key] = listCacheEntry{
+		events:  cloneEvents(events),
+		expires: c.now().Add(c.ttl),
+	}
+}
+
+// cloneEvents copies events, so callers changing the events they get, as
+// ListEventsIn does, don't change the cached ones.
+func cloneEvents(events []*EventResult) []*EventResult {
+	if events == nil {
+		return nil
+	}
+	clones := make([]*EventResult, len(events))
+	for i, event := range events {
+		clone := *event
+		clone.Attendees = slices.Clone(event.Attendees)
+		clone.Attachments = slices.Clone(event.Attachments)
+		clone.Tags = maps.Clone(event.Tags)
+		clone.Buffers = cloneEvents(event.Buffers)
+		clone.Instances = cloneEvents(event.Instances)
+		clone.Warnings = slices.Clone(event.Warnings)
+		clones[i] = &clone
+	}
+	return clones
+}
+
+// clear removes all cached entries.
+func (c *eventCache) clear() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.entries = make(map[listCacheKey]listCacheEntry)
+}
+
+// EnableCache caches ListEvents results for ttl, so repeated identical
+// listings (e.g. a dashboard polling every few seconds) don't hit the API.
+// The cache is cleared whenever the client modifies an event. A ttl of zero
+// or less disables caching.
+func (c *Client) EnableCache(ttl time.Duration) {
+	if ttl <= 0 {
+		c.cache = nil
+		return
+	}
+	c.cache = newEventCache(ttl)
+}
+
+// InvalidateCache drops all cached listings.
+func (c *Client) InvalidateCache() {
+	if c.cache != nil {
+		c.cache.clear()
+	}
+}
@@ -0,0 +1,129 @@
+package calendar
+
+import (
+	"context"
+	"testing"
+	"time"
+)
+
+func TestListEvents_CacheHitWithinTTL(t *testing.T) {
+	client, fake := newFakeClient(t)
+	base := time.Date(2024, 1, 15, 8, 0, 0, 0, time.UTC)
+	seedHourlyEvents(fake, base, 3)
+
+	client.EnableCache(time.Minute)
+	opts := ListOptions{TimeMin: base, TimeMax: base.Add(24 * time.Hour)}
+
+	first, err := client.ListEvents(context.Background(), opts)
+	if err != nil {
+		t.Fatalf("ListEvents() error = %v", err)
+	}
+	second, err := client.ListEvents(context.Background(), opts)
+	if err != nil {
+		t.Fatalf("ListEvents() error = %v", err)
+	}
+
+	if got := fake.requestCount("GET", ""); got != 1 {
+		t.Errorf("Expected 1 list request, got %d", got)
+	}
+	if len(first) != 3 || len(second) != 3 {
+		t.Errorf("Expected 3 events from both calls, got %d and %d", len(first), len(second))
+	}
+
+	// A different window is a different cache entry
+	if _, err := client.ListEvents(context.Background(), ListOptions{TimeMin: base}); err != nil {
+		t.Fatalf("ListEvents() error = %v", err)
+	}
+	if got := fake.requestCount("GET", ""); got != 2 {
+		t.Errorf("Expected 2 list requests, got %d", got)
+	}
+}
+
+func TestListEvents_CacheExpires(t *testing.T) {
+	client, fake := newFakeClient(t)
+	base := time.Date(2024, 1, 15, 8, 0, 0, 0, time.UTC)
+	seedHourlyEvents(fake, base, 1)
+
+	client.EnableCache(time.Minute)
+	now := time.Now()
+	client.cache.now = func() time.Time { return now }
+
+	opts := ListOptions{TimeMin: base}
+	if _, err := client.ListEvents(context.Background(), opts); err != nil {
+		t.Fatalf("ListEvents() error = %v", err)
+	}
+
+	now = now.Add(2 * time.Minute)
+	if _, err := client.ListEvents(context.Background(), opts); err != nil {
+		t.Fatalf("ListEvents() error = %v", err)
+	}
+
+	if got := fake.requestCount("GET", ""); got != 2 {
+		t.Errorf("Expected 2 list requests after expiry, got %d", got)
+	}
+}
+
+func TestListEvents_CacheInvalidatedByCreate(t *testing.T) {
+	client, fake := newFakeClient(t)
+	base := time.Date(2024, 1, 15, 8, 0, 0, 0, time.UTC)
+	seedHourlyEvents(fake, base, 1)
+
+	client.EnableCache(time.Minute)
+	opts := ListOptions{TimeMin: base}
+
+	if _, err := client.ListEvents(context.Background(), opts); err != nil {
+		t.Fatalf("ListEvents() error = %v", err)
+	}
+
+	_, err := client.CreateEvent(context.Background(), EventParams{
+		Title:     "New",
+		StartTime: base.Add(5 * time.Hour),
+		Duration:  time.Hour,
+	})
+	if err != nil {
+		t.Fatalf("CreateEvent() error = %v", err)
+	}
+
+	events, err := client.ListEvents(context.Background(), opts)
+	if err != nil {
+		t.Fatalf("ListEvents() error = %v", err)
+	}
+	if len(events) != 2 {
+		t.Errorf("Expected 2 events after create, got %d", len(events))
+	}
+}
+
+func TestListEvents_CachedEventsAreCopies(t *testing.T) {
+	client, fake := newFakeClient(t)
+	base := time.Date(2024, 1, 15, 8, 0, 0, 0, time.UTC)
+	seedHourlyEvents(fake, base, 2)
+
+	client.EnableCache(time.Minute)
+	opts := ListOptions{TimeMin: base, TimeMax: base.Add(24 * time.Hour)}
+
+	// ListEventsIn sets CalendarID on the events it gets from the cache
+	if _, err := client.ListEventsIn(context.Background(), nil, opts); err != nil {
+		t.Fatalf("ListEventsIn() error = %v", err)
+	}
+	first, err := client.ListEvents(context.Background(), opts)
+	if err != nil {
+		t.Fatalf("ListEvents() error = %v", err)
+	}
+	first[0].Title = "Changed"
+
+	second, err := client.ListEvents(context.Background(), opts)
+	if err != nil {
+		t.Fatalf("ListEvents() error = %v", err)
+	}
+	if got := fake.requestCount("GET", ""); got != 1 {
+		t.Errorf("Expected 1 list request, got %d", got)
+	}
+	for _, event := range second {
+		if event.CalendarID != "" {
+			t.Errorf("cached event %s has CalendarID %q, want it unset", event.ID, event.CalendarID)
+		}
+		if event.Title == "Changed" {
+			t.Errorf("cached event %s was changed through an earlier result", event.ID)
+		}
+	}
+}
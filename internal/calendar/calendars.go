@@ -0,0 +1,226 @@
+package calendar
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"google.golang.org/api/calendar/v3"
+)
+
+// Errors for listing, resolving and managing calendars.
+var (
+	ErrCalendarListFailed   = errors.New("failed to list calendars")
+	ErrAmbiguousCalendar    = errors.New("calendar name is ambiguous")
+	ErrCalendarUpdateFailed = errors.New("failed to update calendar")
+)
+
+// CalendarInfo describes a calendar in the user's calendar list.
+type CalendarInfo struct {
+	ID string `json:"id"`
+
+	// Name is the calendar's display name: the user's own name for it if
+	// they set one, otherwise its title.
+	Name        string `json:"name"`
+	Description string `json:"description,omitempty"`
+	TimeZone    string `json:"timezone,omitempty"`
+
+	// AccessRole is the user's access: "owner", "writer", "reader" or
+	// "freeBusyReader".
+	AccessRole string `json:"access_role"`
+
+	// Primary reports whether this is the user's primary calendar.
+	Primary bool `json:"primary,omitempty"`
+}
+
+// ListCalendars returns the calendars in the user's calendar list, in the
+// order the API returns them. Hidden calendars are left out.
+func (c *Client) ListCalendars(ctx context.Context) ([]CalendarInfo, error) {
+	ctx, cancel := c.withRequestTimeout(ctx)
+	defer cancel()
+
+	var calendars []CalendarInfo
+	pageToken := ""
+	for {
+		call := c.service.CalendarList.List().MaxResults(maxPageSize).Context(ctx)
+		if pageToken != "" {
+			call = call.PageToken(pageToken)
+		}
+
+		var page *calendar.CalendarList
+		err := c.retry(ctx, true, func() error {
+			var err error
+			page, err = call.Do()
+			return err
+		})
+		if err != nil {
+			return nil, wrapAPIErrorAs(err, ErrCalendarListFailed)
+		}
+
+		for _, entry := range page.Items {
+			calendars = append(calendars, parseCalendarInfo(entry))
+		}
+
+		pageToken = page.NextPageToken
+		if pageToken == "" {
+			return calendars, nil
+		}
+	}
+}
+
+// parseCalendarInfo converts a calendar list entry to a CalendarInfo.
+func parseCalendarInfo(entry *calendar.CalendarListEntry) CalendarInfo {
+	name := entry.SummaryOverride
+	if name == "" {
+		name = entry.Summary
+	}
+
+	return CalendarInfo{
+		ID:          entry.Id,
+		Name:        name,
+		Description: entry.Description,
+		TimeZone:    entry.TimeZone,
+		AccessRole:  entry.AccessRole,
+		Primary:     entry.Primary,
+	}
+}
+
+// ResolveCalendarID turns a calendar name or ID into an ID. "primary" and
+// anything that looks like a calendar ID, which always contain an "@", are
+// returned as is without a request; anything else is looked up by name in
+// the calendar list. See ResolveCalendar.
+func (c *Client) ResolveCalendarID(ctx context.Context, nameOrID string) (string, error) {
+	nameOrID = strings.TrimSpace(nameOrID)
+	if nameOrID == "" || nameOrID == "primary" || strings.Contains(nameOrID, "@") {
+		return nameOrID, nil
+	}
+
+	calendars, err := c.ListCalendars(ctx)
+	if err != nil {
+		return "", err
+	}
+	return ResolveCalendar(calendars, nameOrID)
+}
+
+// ResolveCalendar finds a calendar in calendars by ID or, failing that, by
+// display name, ignoring case. It returns ErrAmbiguousCalendar when several
+// calendars share the name and ErrCalendarNotFound when none match.
+func ResolveCalendar(calendars []CalendarInfo, nameOrID string) (string, error) {
+	nameOrID = strings.TrimSpace(nameOrID)
+
+	var matches []string
+	for _, cal := range calendars {
+		if cal.ID == nameOrID {
+			return cal.ID, nil
+		}
+		if strings.EqualFold(cal.Name, nameOrID) {
+			matches = append(matches, cal.ID)
+		}
+	}
+
+	switch len(matches) {
+	case 0:
+		return "", fmt.Errorf("%w: no calendar named %q", ErrCalendarNotFound, nameOrID)
+	case 1:
+		return matches[0], nil
+	}
+	return "", fmt.Errorf("%w: %q matches %s; use the calendar ID", ErrAmbiguousCalendar, nameOrID, strings.Join(matches, ", "))
+}
+
+// UseCalendar switches the client to the calendar with the given ID, e.g.
+// one found with ResolveCalendarID. Cached listings are dropped.
+func (c *Client) UseCalendar(calendarID string) {
+	if calendarID == "" {
+		calendarID = "primary"
+	}
+	c.calendarID = calendarID
+	c.InvalidateCache()
+}
+
+// CreateCalendar creates a secondary calendar owned by the user. An empty
+// timezone leaves it to the API, which uses the user's default.
+func (c *Client) CreateCalendar(ctx context.Context, name, timezone string) (*CalendarInfo, error) {
+	name = strings.TrimSpace(name)
+	if name == "" {
+		return nil, fmt.Errorf("%w: a calendar name is required", ErrCalendarUpdateFailed)
+	}
+	if timezone != "" {
+		if _, err := time.LoadLocation(timezone); err != nil {
+			return nil, fmt.Errorf("%w: %s", ErrInvalidTimezone, timezone)
+		}
+	}
+
+	ctx, cancel := c.withRequestTimeout(ctx)
+	defer cancel()
+
+	// Inserting twice would create two calendars, so this isn't retried
+	created, err := c.service.Calendars.Insert(&calendar.Calendar{
+		Summary:  name,
+		TimeZone: timezone,
+	}).Context(ctx).Do()
+	if err != nil {
+		return nil, wrapAPIErrorAs(err, ErrCalendarUpdateFailed)
+	}
+
+	info := parseCalendar(created)
+	info.AccessRole = "owner"
+	return info, nil
+}
+
+// RenameCalendar changes the title of a calendar the user owns.
+func (c *Client) RenameCalendar(ctx context.Context, calendarID, name string) (*CalendarInfo, error) {
+	name = strings.TrimSpace(name)
+	if calendarID == "" || name == "" {
+		return nil, fmt.Errorf("%w: a calendar ID and a new name are required", ErrCalendarUpdateFailed)
+	}
+
+	ctx, cancel := c.withRequestTimeout(ctx)
+	defer cancel()
+
+	var updated *calendar.Calendar
+	err := c.retry(ctx, true, func() error {
+		var err error
+		updated, err = c.service.Calendars.Patch(calendarID, &calendar.Calendar{Summary: name}).Context(ctx).Do()
+		return err
+	})
+	if err != nil {
+		return nil, wrapAPIErrorAs(err, ErrCalendarUpdateFailed)
+	}
+	return parseCalendar(updated), nil
+}
+
+// DeleteCalendar permanently deletes a secondary calendar and all its
+// events. The primary calendar can't be deleted.
+func (c *Client) DeleteCalendar(ctx context.Context, calendarID string) error {
+	if calendarID == "" || calendarID == "primary" {
+		return fmt.Errorf("%w: the primary calendar can't be deleted", ErrCalendarUpdateFailed)
+	}
+
+	ctx, cancel := c.withRequestTimeout(ctx)
+	defer cancel()
+
+	err := c.retry(ctx, true, func() error {
+		return c.service.Calendars.Delete(calendarID).Context(ctx).Do()
+	})
+	if err != nil {
+		return wrapAPIErrorAs(err, ErrCalendarUpdateFailed)
+	}
+
+	if calendarID == c.calendarID {
+		c.InvalidateCache()
+	}
+	return nil
+}
+
+// parseCalendar converts a calendar's metadata to a CalendarInfo. The
+// user's access role isn't part of it.
+func parseCalendar(cal *calendar.Calendar) *CalendarInfo {
+	return &CalendarInfo{
+		ID:          cal.Id,
+		Name:        cal.Summary,
+		Description: cal.Description,
+		TimeZone:    cal.TimeZone,
+	}
+}
@@ -0,0 +1,185 @@
+package calendar
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+
+	"google.golang.org/api/calendar/v3"
+)
+
+func TestListCalendars(t *testing.T) {
+	client, fake := newFakeClient(t)
+	fake.calendars = []*calendar.CalendarListEntry{
+		{Id: "me@example.com", Summary: "me@example.com", SummaryOverride: "Personal", AccessRole: "owner", Primary: true, TimeZone: "Europe/Paris"},
+		{Id: "team@group.calendar.google.com", Summary: "Team", AccessRole: "writer"},
+	}
+
+	calendars, err := client.ListCalendars(context.Background())
+	if err != nil {
+		t.Fatalf("ListCalendars() error = %v", err)
+	}
+	if len(calendars) != 2 {
+		t.Fatalf("got %d calendars, want 2", len(calendars))
+	}
+
+	want := CalendarInfo{ID: "me@example.com", Name: "Personal", TimeZone: "Europe/Paris", AccessRole: "owner", Primary: true}
+	if calendars[0] != want {
+		t.Errorf("calendars[0] = %+v, want %+v", calendars[0], want)
+	}
+	if calendars[1].Name != "Team" || calendars[1].Primary {
+		t.Errorf("calendars[1] = %+v, want the summary as name", calendars[1])
+	}
+}
+
+func TestListCalendars_Paginates(t *testing.T) {
+	client, fake := newFakeClient(t)
+	for i := 0; i < maxPageSize+10; i++ {
+		fake.calendars = append(fake.calendars, &calendar.CalendarListEntry{
+			Id:      fmt.Sprintf("cal-%d@group.calendar.google.com", i),
+			Summary: fmt.Sprintf("Calendar %d", i),
+		})
+	}
+
+	calendars, err := client.ListCalendars(context.Background())
+	if err != nil {
+		t.Fatalf("ListCalendars() error = %v", err)
+	}
+	if len(calendars) != maxPageSize+10 {
+		t.Errorf("got %d calendars, want %d", len(calendars), maxPageSize+10)
+	}
+	if got := fake.requestCount("GET", "/users/me/calendarList"); got != 2 {
+		t.Errorf("made %d list requests, want 2", got)
+	}
+}
+
+func TestResolveCalendar(t *testing.T) {
+	calendars := []CalendarInfo{
+		{ID: "me@example.com", Name: "Personal", Primary: true},
+		{ID: "team@group.calendar.google.com", Name: "Team"},
+		{ID: "a@group.calendar.google.com", Name: "Shared"},
+		{ID: "b@group.calendar.google.com", Name: "shared"},
+	}
+
+	tests := []struct {
+		name    string
+		input   string
+		want    string
+		wantErr error
+	}{
+		{name: "by ID", input: "team@group.calendar.google.com", want: "team@group.calendar.google.com"},
+		{name: "by name ignoring case", input: "  team ", want: "team@group.calendar.google.com"},
+		{name: "by name override", input: "Personal", want: "me@example.com"},
+		{name: "ambiguous name", input: "Shared", wantErr: ErrAmbiguousCalendar},
+		{name: "unknown name", input: "Holidays", wantErr: ErrCalendarNotFound},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ResolveCalendar(calendars, tt.input)
+			if tt.wantErr != nil {
+				if !errors.Is(err, tt.wantErr) {
+					t.Fatalf("ResolveCalendar() error = %v, want %v", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("ResolveCalendar() error = %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("ResolveCalendar() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestResolveCalendarID(t *testing.T) {
+	client, fake := newFakeClient(t)
+	fake.calendars = []*calendar.CalendarListEntry{
+		{Id: "team@group.calendar.google.com", Summary: "Team"},
+	}
+	ctx := context.Background()
+
+	for _, id := range []string{"primary", "someone@example.com"} {
+		got, err := client.ResolveCalendarID(ctx, id)
+		if err != nil || got != id {
+			t.Errorf("ResolveCalendarID(%q) = %q, %v; want it unchanged", id, got, err)
+		}
+	}
+	if got := fake.requestCount("GET", "/users/me/calendarList"); got != 0 {
+		t.Errorf("made %d list requests for IDs, want 0", got)
+	}
+
+	got, err := client.ResolveCalendarID(ctx, "Team")
+	if err != nil || got != "team@group.calendar.google.com" {
+		t.Errorf("ResolveCalendarID(Team) = %q, %v", got, err)
+	}
+}
+
+func TestUseCalendar(t *testing.T) {
+	client, fake := newFakeClient(t)
+
+	client.UseCalendar("team@group.calendar.google.com")
+	if _, err := client.ListEvents(context.Background(), ListOptions{}); err != nil {
+		t.Fatalf("ListEvents() error = %v", err)
+	}
+	if got := fake.requestCount("GET", "/calendars/team@group.calendar.google.com/events"); got != 1 {
+		t.Errorf("made %d list requests to the new calendar, want 1", got)
+	}
+}
+
+func TestCalendarLifecycle(t *testing.T) {
+	client, fake := newFakeClient(t)
+	ctx := context.Background()
+
+	created, err := client.CreateCalendar(ctx, "  Automation ", "Europe/Paris")
+	if err != nil {
+		t.Fatalf("CreateCalendar() error = %v", err)
+	}
+	if created.ID == "" || created.Name != "Automation" || created.TimeZone != "Europe/Paris" || created.AccessRole != "owner" {
+		t.Errorf("CreateCalendar() = %+v", created)
+	}
+
+	renamed, err := client.RenameCalendar(ctx, created.ID, "Scripts")
+	if err != nil {
+		t.Fatalf("RenameCalendar() error = %v", err)
+	}
+	if renamed.ID != created.ID || renamed.Name != "Scripts" {
+		t.Errorf("RenameCalendar() = %+v", renamed)
+	}
+	if id, err := client.ResolveCalendarID(ctx, "scripts"); err != nil || id != created.ID {
+		t.Errorf("ResolveCalendarID(scripts) = %q, %v; want the renamed calendar", id, err)
+	}
+
+	if err := client.DeleteCalendar(ctx, created.ID); err != nil {
+		t.Fatalf("DeleteCalendar() error = %v", err)
+	}
+	if len(fake.calendars) != 0 {
+		t.Errorf("calendar list still has %d calendars", len(fake.calendars))
+	}
+	if err := client.DeleteCalendar(ctx, created.ID); !errors.Is(err, ErrCalendarNotFound) {
+		t.Errorf("second DeleteCalendar() error = %v, want ErrCalendarNotFound", err)
+	}
+}
+
+func TestCalendarManagement_Validation(t *testing.T) {
+	client, fake := newFakeClient(t)
+	ctx := context.Background()
+
+	if _, err := client.CreateCalendar(ctx, " ", ""); !errors.Is(err, ErrCalendarUpdateFailed) {
+		t.Errorf("CreateCalendar(blank) error = %v, want ErrCalendarUpdateFailed", err)
+	}
+	if _, err := client.CreateCalendar(ctx, "Automation", "Mars/Olympus"); !errors.Is(err, ErrInvalidTimezone) {
+		t.Errorf("CreateCalendar(bad timezone) error = %v, want ErrInvalidTimezone", err)
+	}
+	if _, err := client.RenameCalendar(ctx, "cal@group.calendar.google.com", ""); !errors.Is(err, ErrCalendarUpdateFailed) {
+		t.Errorf("RenameCalendar(blank) error = %v, want ErrCalendarUpdateFailed", err)
+	}
+	if err := client.DeleteCalendar(ctx, "primary"); !errors.Is(err, ErrCalendarUpdateFailed) {
+		t.Errorf("DeleteCalendar(primary) error = %v, want ErrCalendarUpdateFailed", err)
+	}
+	if len(fake.requests) != 0 {
+		t.Errorf("made requests %v for invalid input, want none", fake.requests)
+	}
+}
@@ -2,9 +2,14 @@ package calendar
 
 import (
 	"context"
+	"crypto/rand"
+	"encoding/hex"
 	"errors"
 	"fmt"
 	"net/http"
+	"net/mail"
+	"strconv"
+	"strings"
 	"time"
 
 	"google.golang.org/api/calendar/v3"
@@ -19,32 +24,279 @@ var (
 	ErrCalendarNotFound    = errors.New("calendar not found")
 	ErrPermissionDenied    = errors.New("permission denied")
 	ErrQuotaExceeded       = errors.New("API quota exceeded")
+	ErrEventNotFound       = errors.New("event not found")
+	ErrEventListFailed     = errors.New("failed to list events")
+	ErrEventDeleteFailed   = errors.New("failed to delete event")
+	ErrConflict            = errors.New("event was changed by someone else")
 )
 
 // Client wraps the Google Calendar API service.
 type Client struct {
 	service    *calendar.Service
 	calendarID string
+
+	// DefaultAddConference requests a Google Meet link for new events whose
+	// EventParams.AddConference is unset.
+	DefaultAddConference bool
+
+	// DefaultDescriptionPrefix and DefaultDescriptionSuffix are added to
+	// the description of every new event, each on its own line.
+	DefaultDescriptionPrefix string
+	DefaultDescriptionSuffix string
+
+	// RejectPast makes CreateEvent refuse events that start in the past.
+	// Starts within PastGrace of now are still accepted to tolerate clock
+	// skew and "just now" events.
+	RejectPast bool
+	PastGrace  time.Duration
+
+	// DropSelfFromAttendees removes the user's own address from attendee
+	// lists, since the organizer attends implicitly. The address is taken
+	// from SelfEmail, or from the calendar ID when it is an email address.
+	DropSelfFromAttendees bool
+	SelfEmail             string
+
+	// PendingResponseAge is how old an event must be before
+	// PendingResponders reports guests who haven't replied.
+	PendingResponseAge time.Duration
+
+	// Retry controls automatic retries of transient failures. Reads are
+	// always retryable; CreateEvent is only retried when EventParams.ID is
+	// set, since the ID lets a retry detect an insert that already went
+	// through.
+	Retry RetryPolicy
+
+	// Batch controls how bulk operations such as CreateEvents, ShiftEvents
+	// and RespondToEvents split their input into chunks.
+	Batch BatchPolicy
+
+	// RequestTimeout bounds each client operation, such as a create or a
+	// full listing, including retries. Zero means no timeout beyond the
+	// caller's context. The CLI sets it from Config.CommandTimeout.
+	RequestTimeout time.Duration
+
+	// MinDuration is the shortest duration CreateEvent accepts, catching
+	// near-zero durations from parsing mistakes. Zero disables the check.
+	MinDuration time.Duration
+
+	// WorkingHours bounds the part of each day FindSlots searches when the
+	// query doesn't set its own. The CLI sets it from Config.WorkingHours.
+	WorkingHours WorkingHours
+
+	// InclusiveEndDate treats EventParams.EndDate of all-day events as the
+	// last day of the event, so an event "on Jan 15" ends on Jan 15. The
+	// API's end date is exclusive, so a day is added when building the
+	// event. Clear it to pass the exclusive end date verbatim. New clients
+	// default to true.
+	InclusiveEndDate bool
+
+	// MaxClockSkew is how far the local clock may drift from Google's
+	// before CheckClockSkew reports ErrClockSkew.
+	MaxClockSkew time.Duration
+
+	// cache holds ListEvents results when enabled via EnableCache.
+	cache *eventCache
+
+	// now returns the current time; replaced in tests.
+	now func() time.Time
 }
 
+// DefaultPastGrace is the default grace period used by RejectPast.
+const DefaultPastGrace = 5 * time.Minute
+
+// DefaultMinDuration is the default minimum event duration.
+const DefaultMinDuration = time.Minute
+
+// DefaultMaxClockSkew is the default clock skew tolerated by CheckClockSkew.
+const DefaultMaxClockSkew = time.Minute
+
 // EventParams holds the parameters for creating a calendar event.
 type EventParams struct {
+	// ID optionally sets the event ID instead of letting Google assign one.
+	// It must be 5-1024 characters from a-v and 0-9. Setting it makes
+	// CreateEvent safe to retry.
+	ID string
+
 	Title       string
 	StartTime   time.Time
 	Duration    time.Duration
 	Description string
 	Location    string
+
+	// EndTime gives when the event ends, as an alternative to Duration;
+	// set one or the other. It must be after StartTime. All-day events use
+	// EndDate instead.
+	EndTime time.Time
+
+	// EndTimeZone sets the timezone of the event's end, e.g. for a flight
+	// departing New York and arriving in Los Angeles. The end instant is
+	// still StartTime plus Duration, or EndTime. Defaults to StartTime's
+	// location.
+	EndTimeZone string
+
+	// Recurrence holds RRULE/EXRULE/RDATE/EXDATE lines for recurring events,
+	// e.g. "RRULE:FREQ=WEEKLY;BYDAY=MO". See ParseRecurrence.
+	Recurrence []string
+
+	// Transparent marks the event as not blocking time on the calendar.
+	Transparent bool
+
+	// BufferBefore and BufferAfter create separate transparent "buffer"
+	// events immediately before and after the event, repeating with it if
+	// it recurs. A buffer that can't be created is reported in
+	// EventResult.Warnings.
+	BufferBefore time.Duration
+	BufferAfter  time.Duration
+
+	// AddConference requests a Google Meet link for the event. When nil,
+	// the client's DefaultAddConference is used.
+	AddConference *bool
+
+	// Attendees holds the email addresses of guests to invite. See
+	// ParseAttendees.
+	Attendees []string
+
+	// SendUpdates controls which guests are emailed the invitation:
+	// SendUpdatesAll, SendUpdatesExternalOnly or SendUpdatesNone. Empty
+	// leaves it to the API, which sends none.
+	SendUpdates string
+
+	// Priority ranks the event from MaxPriority (1, most important) to
+	// MinPriority (5). Google Calendar has no native priority, so it is kept
+	// in a private extended property. Zero means no priority.
+	Priority int
+
+	// OriginalCreated records when an imported event was first created.
+	// Google always sets Created to the insert time, so this is kept in a
+	// private extended property instead. See SortByOriginalCreated.
+	OriginalCreated time.Time
+
+	// Reminders are popup reminders given as how long before the start
+	// they go off, e.g. 10 * time.Minute. Nil uses the calendar's default
+	// reminders.
+	Reminders []time.Duration
+
+	// EventType is EventTypeFocusTime or EventTypeOutOfOffice for those
+	// kinds of event. Empty creates a regular event.
+	EventType string
+
+	// AutoDecline and DeclineMessage control how a focus time or
+	// out-of-office event declines conflicting invitations. AutoDecline is
+	// one of the AutoDecline constants; see ParseAutoDecline. Empty leaves
+	// it to Google Calendar.
+	AutoDecline    string
+	DeclineMessage string
+
+	// Color is a friendly color name, e.g. "tomato" or "red", or an event
+	// color ID. See ResolveColorID and Client.ListColors. Empty uses the
+	// calendar's color.
+	Color string
+
+	// Status is StatusConfirmed, StatusTentative or StatusCancelled. Empty
+	// leaves it to the API, which confirms the event.
+	Status string
+
+	// Tags are machine-readable labels, e.g. "calgo:source" = "script",
+	// kept in private extended properties so they are invisible to guests.
+	// List events by tag with ListOptions.Tags. See ParseTags.
+	Tags map[string]string
+
+	// SkipWeekends keeps the event off Saturdays and Sundays. A daily
+	// recurrence is rewritten to repeat Monday through Friday, and a
+	// weekend start is moved to the following Monday.
+	SkipWeekends bool
+
+	// AllDay creates a date-only event. Only the date of StartTime is used
+	// and Duration is ignored.
+	AllDay bool
+
+	// EndDate is the end date of a multi-day all-day event, read according
+	// to Client.InclusiveEndDate. Zero makes a single-day event.
+	EndDate time.Time
+
+	// RejectConflicts makes CreateEvent fail with a *ConflictError when the
+	// event would overlap existing busy events. See Client.Conflicts.
+	RejectConflicts bool
+
+	// PreviewInstances makes CreateEvent fetch the first N occurrences of
+	// a recurring event into EventResult.Instances. It is ignored for
+	// events without Recurrence.
+	PreviewInstances int
 }
 
 // EventResult contains the result of a successful event creation.
 type EventResult struct {
-	ID          string
-	Title       string
-	StartTime   time.Time
-	EndTime     time.Time
-	Description string
-	Location    string
-	Link        string
+	ID          string    `json:"id"`
+	Title       string    `json:"title"`
+	StartTime   time.Time `json:"start_time"`
+	EndTime     time.Time `json:"end_time"`
+	Description string    `json:"description,omitempty"`
+	Location    string    `json:"location,omitempty"`
+	Link        string    `json:"link,omitempty"`
+
+	// Status is the event's status, e.g. StatusTentative.
+	Status string `json:"status,omitempty"`
+
+	// EventType is the kind of event, e.g. EventTypeFocusTime, or empty for
+	// a regular event.
+	EventType string `json:"event_type,omitempty"`
+
+	// CalendarID is the calendar the event was listed from. It is only set
+	// by ListEventsIn.
+	CalendarID string `json:"calendar_id,omitempty"`
+
+	// Tags are the event's private extended properties, other than the
+	// ones calgo uses itself. See EventParams.Tags.
+	Tags map[string]string `json:"tags,omitempty"`
+
+	// ETag identifies this version of the event. Passing it back with an
+	// update or delete makes the change fail with ErrConflict if the event
+	// has changed since.
+	ETag string `json:"etag,omitempty"`
+
+	// ColorID is the event's color ID, empty when it uses the calendar color.
+	ColorID string `json:"color_id,omitempty"`
+
+	// MeetLink is the video conference URL, if the event has one.
+	MeetLink string `json:"meet_link,omitempty"`
+
+	// Attendees holds the email addresses of the event's guests.
+	Attendees []string `json:"attendees,omitempty"`
+
+	// Attachments holds the files attached to the event.
+	Attachments []Attachment `json:"attachments,omitempty"`
+
+	// Organizer and Creator are the email addresses of the event's
+	// organizer and of whoever created it, empty when unknown.
+	Organizer string `json:"organizer,omitempty"`
+	Creator   string `json:"creator,omitempty"`
+
+	// Created is when the event was created in Google Calendar.
+	Created time.Time `json:"created,omitzero"`
+
+	// AllDay reports whether the event is date-only. Its StartTime and
+	// EndTime are then midnight UTC, with EndTime exclusive.
+	AllDay bool `json:"all_day,omitempty"`
+
+	// Transparent reports whether the event doesn't block time.
+	Transparent bool `json:"transparent,omitempty"`
+
+	// Priority is the event's priority, or zero if it has none.
+	Priority int `json:"priority,omitempty"`
+
+	// OriginalCreated is the creation time preserved by an import, if any.
+	OriginalCreated time.Time `json:"original_created,omitzero"`
+
+	// Buffers holds the buffer events created around this event, if any.
+	Buffers []*EventResult `json:"buffers,omitempty"`
+
+	// Instances holds the first occurrences of a recurring event when
+	// requested with EventParams.PreviewInstances.
+	Instances []*EventResult `json:"instances,omitempty"`
+
+	// Warnings describes what CreateEvent failed to do after creating the
+	// event, e.g. adding a buffer. The event itself was created.
+	Warnings []string `json:"warnings,omitempty"`
 }
 
 // NewClient creates a new Calendar client using the provided HTTP client.
@@ -55,25 +307,266 @@ func NewClient(ctx context.Context, httpClient *http.Client, calendarID string)
 		return nil, fmt.Errorf("failed to create calendar service: %w", err)
 	}
 
+	return newClientWithService(service, calendarID), nil
+}
+
+// newClientWithService creates a Client with default settings around an
+// existing calendar service.
+func newClientWithService(service *calendar.Service, calendarID string) *Client {
 	if calendarID == "" {
 		calendarID = "primary"
 	}
 
 	return &Client{
-		service:    service,
-		calendarID: calendarID,
-	}, nil
+		service:          service,
+		calendarID:       calendarID,
+		PastGrace:        DefaultPastGrace,
+		MinDuration:      DefaultMinDuration,
+		MaxClockSkew:     DefaultMaxClockSkew,
+		InclusiveEndDate: true,
+		Retry:            DefaultRetryPolicy(),
+		Batch:            DefaultBatchPolicy(),
+		WorkingHours:     DefaultWorkingHours(),
+		now:              time.Now,
+	}
 }
 
 // CreateEvent creates a new event in the calendar.
 func (c *Client) CreateEvent(ctx context.Context, params EventParams) (*EventResult, error) {
-	if err := validateEventParams(params); err != nil {
+	event, prepared, err := c.prepareEvent(params)
+	if err != nil {
+		return nil, err
+	}
+
+	ctx, cancel := c.withRequestTimeout(ctx)
+	defer cancel()
+
+	if params.RejectConflicts {
+		conflicts, err := c.Conflicts(ctx, params)
+		if err != nil {
+			return nil, err
+		}
+		if len(conflicts) > 0 {
+			return nil, &ConflictError{Conflicts: conflicts}
+		}
+	}
+	params = prepared
+
+	insertCall := c.service.Events.Insert(c.calendarID, event)
+	if params.SendUpdates != "" {
+		insertCall = insertCall.SendUpdates(params.SendUpdates)
+	}
+	if event.ConferenceData != nil {
+		insertCall = insertCall.ConferenceDataVersion(1)
+	}
+
+	// A failed insert may still have gone through, so always invalidate,
+	// once the buffers are in too
+	defer c.InvalidateCache()
+	createdEvent, err := c.insertEvent(ctx, insertCall, params.ID)
+	if err != nil {
+		return nil, wrapAPIError(err)
+	}
+
+	result, err := parseEventResult(createdEvent)
+	if err != nil {
 		return nil, err
 	}
 
+	// The main event exists at this point, so failures from here on are
+	// reported as warnings on the result: returning an error would have
+	// callers treat the event as not created, and perhaps create it again.
+	for _, buffer := range bufferParams(params) {
+		bufferEvent, err := c.service.Events.Insert(c.calendarID, buildEvent(buffer)).Context(ctx).Do()
+		if err != nil {
+			result.Warnings = append(result.Warnings, fmt.Sprintf("failed to create buffer: %v", wrapAPIError(err)))
+			continue
+		}
+
+		bufferResult, err := parseEventResult(bufferEvent)
+		if err != nil {
+			result.Warnings = append(result.Warnings, fmt.Sprintf("failed to parse buffer: %v", err))
+			continue
+		}
+		result.Buffers = append(result.Buffers, bufferResult)
+	}
+
+	if params.PreviewInstances > 0 && len(params.Recurrence) > 0 {
+		instances, err := c.ExpandInstances(ctx, result.ID, params.PreviewInstances)
+		if err != nil {
+			result.Warnings = append(result.Warnings, fmt.Sprintf("failed to fetch instances: %v", err))
+		}
+		result.Instances = instances
+	}
+
+	return result, nil
+}
+
+// EventPayload returns the event CreateEvent would send to the API for
+// params, without making any request, e.g. to check timezone and recurrence
+// handling first. Conflicts aren't checked, and buffer events aren't
+// included.
+func (c *Client) EventPayload(params EventParams) (*calendar.Event, error) {
+	event, _, err := c.prepareEvent(params)
+	return event, err
+}
+
+// prepareEvent validates params and builds the event to insert, applying
+// the client's settings. It also returns params as adjusted on the way,
+// e.g. by SkipWeekends, for the buffers and other follow-up requests.
+func (c *Client) prepareEvent(params EventParams) (*calendar.Event, EventParams, error) {
+	if err := c.validateEventParams(params); err != nil {
+		return nil, params, err
+	}
+	params = withDuration(params)
+
+	if c.RejectPast {
+		if err := checkNotPast(params.StartTime, c.now(), c.PastGrace); err != nil {
+			return nil, params, err
+		}
+	}
+
+	params = skipWeekends(params)
+	if params.AllDay {
+		// buildEvent takes the exclusive end date
+		params.EndDate = c.allDayEndDate(params)
+	}
+	params = c.normalizeAttendees(params)
+	params.Description = c.decorateDescription(params.Description)
+	event := buildEvent(params)
+
+	if c.wantsConference(params) {
+		requestID, err := newRequestID()
+		if err != nil {
+			return nil, params, err
+		}
+		event.ConferenceData = &calendar.ConferenceData{
+			CreateRequest: &calendar.CreateConferenceRequest{
+				RequestId: requestID,
+				ConferenceSolutionKey: &calendar.ConferenceSolutionKey{
+					Type: "hangoutsMeet",
+				},
+			},
+		}
+	}
+	return event, params, nil
+}
+
+// withRequestTimeout bounds ctx by RequestTimeout when it is set.
+func (c *Client) withRequestTimeout(ctx context.Context) (context.Context, context.CancelFunc) {
+	if c.RequestTimeout <= 0 {
+		return ctx, func() {}
+	}
+	return context.WithTimeout(ctx, c.RequestTimeout)
+}
+
+// insertEvent runs insertCall, retrying transient failures only when the
+// event has a client-supplied ID. A conflict on a retry means an earlier
+// attempt created the event, so the existing event is returned instead.
+func (c *Client) insertEvent(ctx context.Context, insertCall *calendar.EventsInsertCall, eventID string) (*calendar.Event, error) {
+	var created *calendar.Event
+	attempt := 0
+
+	err := c.retry(ctx, eventID != "", func() error {
+		attempt++
+
+		var err error
+		created, err = insertCall.Context(ctx).Do()
+		if err != nil && attempt > 1 && isConflict(err) {
+			created, err = c.service.Events.Get(c.calendarID, eventID).Context(ctx).Do()
+		}
+		return err
+	})
+
+	return created, err
+}
+
+// decorateDescription adds the client's default prefix and suffix to a
+// description, separated by newlines. Empty parts are skipped.
+func (c *Client) decorateDescription(description string) string {
+	var parts []string
+	for _, part := range []string{c.DefaultDescriptionPrefix, description, c.DefaultDescriptionSuffix} {
+		if part != "" {
+			parts = append(parts, part)
+		}
+	}
+	return strings.Join(parts, "\n")
+}
+
+// wantsConference reports whether a conference should be requested for the
+// event, falling back to the client default when the params leave it unset.
+func (c *Client) wantsConference(params EventParams) bool {
+	if params.AddConference != nil {
+		return *params.AddConference
+	}
+	return c.DefaultAddConference
+}
+
+// newRequestID returns a random ID for idempotent conference creation requests.
+func newRequestID() (string, error) {
+	buf := make([]byte, 16)
+	if _, err := rand.Read(buf); err != nil {
+		return "", fmt.Errorf("failed to generate request ID: %w", err)
+	}
+	return hex.EncodeToString(buf), nil
+}
+
+// bufferParams returns the parameters for the transparent buffer events
+// requested around an event. The buffers of a recurring event repeat with
+// it.
+func bufferParams(params EventParams) []EventParams {
+	var buffers []EventParams
+
+	if params.BufferBefore > 0 {
+		buffers = append(buffers, EventParams{
+			Title:       "Buffer: " + params.Title,
+			StartTime:   params.StartTime.Add(-params.BufferBefore),
+			Duration:    params.BufferBefore,
+			Recurrence:  params.Recurrence,
+			Transparent: true,
+		})
+	}
+
+	if params.BufferAfter > 0 {
+		buffers = append(buffers, EventParams{
+			Title:       "Buffer: " + params.Title,
+			StartTime:   params.StartTime.Add(params.Duration),
+			Duration:    params.BufferAfter,
+			Recurrence:  params.Recurrence,
+			Transparent: true,
+		})
+	}
+
+	return buffers
+}
+
+// checkNotPast returns an error when start is earlier than now by more than grace.
+func checkNotPast(start, now time.Time, grace time.Duration) error {
+	if start.Before(now.Add(-grace)) {
+		return fmt.Errorf("%w: start time %s is in the past", ErrInvalidEventTime, start.Format(time.RFC3339))
+	}
+	return nil
+}
+
+// buildEvent converts validated event parameters into a Google Calendar event.
+// For all-day events params.EndDate is taken as the exclusive end date; see
+// Client.allDayEndDate.
+func buildEvent(params EventParams) *calendar.Event {
 	endTime := params.StartTime.Add(params.Duration)
+	if params.EndTimeZone != "" {
+		// validateEventParams has already checked the zone loads
+		if loc, err := time.LoadLocation(params.EndTimeZone); err == nil {
+			endTime = endTime.In(loc)
+		}
+	}
+
+	transparency := ""
+	if params.Transparent {
+		transparency = "transparent"
+	}
 
 	event := &calendar.Event{
+		Id:          params.ID,
 		Summary:     params.Title,
 		Description: params.Description,
 		Location:    params.Location,
@@ -85,14 +578,108 @@ func (c *Client) CreateEvent(ctx context.Context, params EventParams) (*EventRes
 			DateTime: endTime.Format(time.RFC3339),
 			TimeZone: endTime.Location().String(),
 		},
+		Recurrence:   params.Recurrence,
+		Transparency: transparency,
+		Status:       params.Status,
 	}
 
-	createdEvent, err := c.service.Events.Insert(c.calendarID, event).Context(ctx).Do()
-	if err != nil {
-		return nil, wrapAPIError(err)
+	if params.AllDay {
+		start := dateOf(params.StartTime)
+		end := dateOf(params.EndDate)
+		if params.EndDate.IsZero() {
+			end = start.AddDate(0, 0, 1)
+		}
+		event.Start = &calendar.EventDateTime{Date: start.Format(allDayLayout)}
+		event.End = &calendar.EventDateTime{Date: end.Format(allDayLayout)}
+	}
+
+	for _, email := range params.Attendees {
+		event.Attendees = append(event.Attendees, &calendar.EventAttendee{Email: email})
+	}
+
+	if params.EventType != "" {
+		setEventType(event, params)
+	}
+
+	if params.Reminders != nil {
+		setReminders(event, params.Reminders)
+	}
+
+	if params.Color != "" {
+		// validateEventParams has already checked the color resolves
+		event.ColorId, _ = ResolveColorID(params.Color)
 	}
 
-	return parseEventResult(createdEvent)
+	if params.Priority != 0 {
+		setPrivateProperty(event, propPriority, strconv.Itoa(params.Priority))
+	}
+
+	if !params.OriginalCreated.IsZero() {
+		setPrivateProperty(event, propImportedCreatedAt, params.OriginalCreated.UTC().Format(time.RFC3339))
+	}
+
+	for key, value := range params.Tags {
+		setPrivateProperty(event, key, value)
+	}
+
+	return event
+}
+
+// validateEventParams validates the event parameters, including the
+// client's minimum duration.
+func (c *Client) validateEventParams(params EventParams) error {
+	if err := validateEventParams(params); err != nil {
+		return err
+	}
+	params = withDuration(params)
+
+	if params.AllDay {
+		if !c.allDayEndDate(params).After(dateOf(params.StartTime)) {
+			return fmt.Errorf("%w: end date must not be before the start date", ErrInvalidEventTime)
+		}
+		return nil
+	}
+
+	if c.MinDuration > 0 && params.Duration < c.MinDuration {
+		return fmt.Errorf("%w: duration %s is shorter than the minimum of %s", ErrInvalidEventTime, params.Duration, c.MinDuration)
+	}
+
+	return nil
+}
+
+// allDayEndDate returns the exclusive end date of an all-day event, as the
+// API expects it, applying InclusiveEndDate to params.EndDate.
+func (c *Client) allDayEndDate(params EventParams) time.Time {
+	if params.EndDate.IsZero() {
+		return dateOf(params.StartTime).AddDate(0, 0, 1)
+	}
+	end := dateOf(params.EndDate)
+	if c.InclusiveEndDate {
+		end = end.AddDate(0, 0, 1)
+	}
+	return end
+}
+
+// dateOf returns t's calendar date in its own location as midnight UTC.
+func dateOf(t time.Time) time.Time {
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC)
+}
+
+// withDuration returns params with Duration worked out from EndTime, if
+// set, so that the rest of the client only has to deal with durations.
+func withDuration(params EventParams) EventParams {
+	if !params.EndTime.IsZero() && !params.AllDay {
+		params.Duration = params.EndTime.Sub(params.StartTime)
+		params.EndTime = time.Time{}
+	}
+	return params
+}
+
+// ValidateEventParams checks params the way CreateEvent does, without
+// making any request. Checks that depend on client settings, such as
+// MinDuration, are left to CreateEvent.
+func ValidateEventParams(params EventParams) error {
+	return validateEventParams(params)
 }
 
 // validateEventParams validates the event parameters.
@@ -101,14 +688,96 @@ func validateEventParams(params EventParams) error {
 		return fmt.Errorf("%w: title is required", ErrInvalidEventTime)
 	}
 
+	if params.ID != "" && !validEventID(params.ID) {
+		return fmt.Errorf("%w: event ID must be 5-1024 characters from a-v and 0-9", ErrInvalidEventTime)
+	}
+
 	if params.StartTime.IsZero() {
 		return fmt.Errorf("%w: start time is required", ErrInvalidEventTime)
 	}
 
-	if params.Duration <= 0 {
+	if !params.EndTime.IsZero() {
+		switch {
+		case params.AllDay:
+			return fmt.Errorf("%w: all-day events take an end date, not an end time", ErrInvalidEventTime)
+		case params.Duration != 0:
+			return fmt.Errorf("%w: end time and duration can't both be set", ErrInvalidEventTime)
+		case !params.EndTime.After(params.StartTime):
+			return fmt.Errorf("%w: end time must be after start time", ErrInvalidEventTime)
+		}
+		params = withDuration(params)
+	}
+
+	if !params.AllDay && params.Duration <= 0 {
 		return fmt.Errorf("%w: duration must be positive", ErrInvalidEventTime)
 	}
 
+	if params.AllDay && (params.BufferBefore > 0 || params.BufferAfter > 0) {
+		return fmt.Errorf("%w: all-day events can't have buffers", ErrInvalidEventTime)
+	}
+
+	if params.EndTimeZone != "" {
+		if _, err := time.LoadLocation(params.EndTimeZone); err != nil {
+			return fmt.Errorf("%w: end timezone %s", ErrInvalidTimezone, params.EndTimeZone)
+		}
+	}
+
+	if params.BufferBefore < 0 || params.BufferAfter < 0 {
+		return fmt.Errorf("%w: buffer durations cannot be negative", ErrInvalidEventTime)
+	}
+
+	if params.PreviewInstances < 0 {
+		return fmt.Errorf("%w: instance preview count cannot be negative", ErrInvalidEventTime)
+	}
+
+	if err := validateRecurrence(params.Recurrence); err != nil {
+		return err
+	}
+
+	if !params.AllDay {
+		if err := checkRecurrenceOverlap(params.Recurrence, params.Duration); err != nil {
+			return err
+		}
+	}
+
+	if err := validatePriority(params.Priority); err != nil {
+		return err
+	}
+
+	if err := validateTags(params.Tags); err != nil {
+		return err
+	}
+
+	if err := validateStatus(params.Status); err != nil {
+		return err
+	}
+
+	if params.Color != "" {
+		if _, err := ResolveColorID(params.Color); err != nil {
+			return err
+		}
+	}
+
+	if err := validateEventType(params); err != nil {
+		return err
+	}
+
+	if err := validateReminders(params.Reminders); err != nil {
+		return err
+	}
+
+	for _, email := range params.Attendees {
+		if _, err := mail.ParseAddress(email); err != nil {
+			return fmt.Errorf("%w: %q", ErrInvalidAttendee, email)
+		}
+	}
+
+	switch params.SendUpdates {
+	case "", SendUpdatesAll, SendUpdatesExternalOnly, SendUpdatesNone:
+	default:
+		return fmt.Errorf("%w: %q", ErrInvalidSendUpdates, params.SendUpdates)
+	}
+
 	return nil
 }
 
@@ -132,41 +801,142 @@ func parseEventResult(event *calendar.Event) (*EventResult, error) {
 		}
 	}
 
+	// Created is informational, so a missing or malformed value is ignored
+	created, _ := time.Parse(time.RFC3339, event.Created)
+
+	// The API may return sub-second precision; drop it so output and
+	// comparisons are stable
+	startTime = startTime.Truncate(time.Second)
+	endTime = endTime.Truncate(time.Second)
+	created = created.Truncate(time.Second)
+
 	return &EventResult{
-		ID:          event.Id,
-		Title:       event.Summary,
-		StartTime:   startTime,
-		EndTime:     endTime,
-		Description: event.Description,
-		Location:    event.Location,
-		Link:        event.HtmlLink,
+		ID:              event.Id,
+		Title:           event.Summary,
+		StartTime:       startTime,
+		EndTime:         endTime,
+		Description:     event.Description,
+		Location:        event.Location,
+		Link:            event.HtmlLink,
+		Status:          event.Status,
+		EventType:       eventType(event),
+		ETag:            event.Etag,
+		ColorID:         event.ColorId,
+		MeetLink:        meetLink(event),
+		Attendees:       attendeeEmails(event),
+		Attachments:     eventAttachments(event),
+		Organizer:       organizerEmail(event),
+		Creator:         creatorEmail(event),
+		AllDay:          isAllDay(event),
+		Transparent:     event.Transparency == "transparent",
+		Priority:        parsePriorityProperty(event),
+		Tags:            parseTags(event),
+		Created:         created,
+		OriginalCreated: parseTimeProperty(event, propImportedCreatedAt).Truncate(time.Second),
 	}, nil
 }
 
-// wrapAPIError wraps Google API errors with user-friendly messages.
+// attendeeEmails returns the email addresses of the event's attendees.
+func attendeeEmails(event *calendar.Event) []string {
+	var emails []string
+	for _, attendee := range event.Attendees {
+		emails = append(emails, attendee.Email)
+	}
+	return emails
+}
+
+// organizerEmail returns the event organizer's email, or "" if unknown.
+func organizerEmail(event *calendar.Event) string {
+	if event.Organizer == nil {
+		return ""
+	}
+	return event.Organizer.Email
+}
+
+// creatorEmail returns the event creator's email, or "" if unknown.
+func creatorEmail(event *calendar.Event) string {
+	if event.Creator == nil {
+		return ""
+	}
+	return event.Creator.Email
+}
+
+// meetLink returns the event's video conference URL. Newer events carry it in
+// ConferenceData; older events only have the legacy HangoutLink field.
+func meetLink(event *calendar.Event) string {
+	if event.ConferenceData != nil {
+		for _, entry := range event.ConferenceData.EntryPoints {
+			if entry.EntryPointType == "video" && entry.Uri != "" {
+				return entry.Uri
+			}
+		}
+	}
+	return event.HangoutLink
+}
+
+// wrapAPIError wraps Google API errors with user-friendly messages. API
+// failures are returned as an *APIError.
 func wrapAPIError(err error) error {
-	var apiErr *googleapi.Error
-	if errors.As(err, &apiErr) {
+	return wrapAPIErrorAs(err, ErrEventCreationFailed)
+}
+
+// wrapAPIErrorAs is like wrapAPIError but uses failed as the sentinel for
+// errors that don't map to a more specific one.
+func wrapAPIErrorAs(err error, failed error) error {
+	if apiErr, ok := unwrappedAPIError(err); ok {
 		switch apiErr.Code {
 		case 400:
-			return fmt.Errorf("%w: invalid request - %s", ErrEventCreationFailed, apiErr.Message)
+			return newAPIError(apiErr, failed, "invalid request - "+apiErr.Message)
 		case 401:
-			return fmt.Errorf("%w: authentication expired, please re-authenticate", ErrPermissionDenied)
+			return newAPIError(apiErr, ErrPermissionDenied, "authentication expired, please re-authenticate")
 		case 403:
 			if containsQuotaError(apiErr) {
-				return fmt.Errorf("%w: please try again later", ErrQuotaExceeded)
+				return newAPIError(apiErr, ErrQuotaExceeded, "please try again later")
 			}
-			return fmt.Errorf("%w: you don't have permission to access this calendar", ErrPermissionDenied)
+			return newAPIError(apiErr, ErrPermissionDenied, "you don't have permission to access this calendar")
 		case 404:
-			return fmt.Errorf("%w: check that the calendar ID is correct", ErrCalendarNotFound)
+			return newAPIError(apiErr, ErrCalendarNotFound, "check that the calendar ID is correct")
 		case 429:
-			return fmt.Errorf("%w: too many requests, please try again later", ErrQuotaExceeded)
+			return newAPIError(apiErr, ErrQuotaExceeded, "too many requests, please try again later")
 		default:
-			return fmt.Errorf("%w: %s (code: %d)", ErrEventCreationFailed, apiErr.Message, apiErr.Code)
+			return newAPIError(apiErr, failed, fmt.Sprintf("%s (code: %d)", apiErr.Message, apiErr.Code))
 		}
 	}
 
-	return fmt.Errorf("%w: %v", ErrEventCreationFailed, err)
+	// Keep err in the chain so callers can detect context deadlines
+	return fmt.Errorf("%w: %w", failed, err)
+}
+
+// wrapEventError wraps errors from operations on a single existing event,
+// reporting 404 and 410 responses as ErrEventNotFound.
+func wrapEventError(err error, eventID string) error {
+	return wrapEventErrorAs(err, eventID, ErrEventCreationFailed)
+}
+
+// wrapEventErrorAs is like wrapEventError but uses failed as the sentinel
+// for errors that don't map to a more specific one. A 412 response, to a
+// request with an ETag the event no longer has, is reported as ErrConflict.
+func wrapEventErrorAs(err error, eventID string, failed error) error {
+	if apiErr, ok := unwrappedAPIError(err); ok {
+		switch apiErr.Code {
+		case 404, 410:
+			return newAPIError(apiErr, ErrEventNotFound, eventID)
+		case 412:
+			return newAPIError(apiErr, ErrConflict, eventID+" was modified after it was read")
+		}
+	}
+	return wrapAPIErrorAs(err, failed)
+}
+
+// unwrappedAPIError returns the Google API error in err's chain, unless
+// err already wraps one in an *APIError.
+func unwrappedAPIError(err error) (*googleapi.Error, bool) {
+	var wrapped *APIError
+	if errors.As(err, &wrapped) {
+		return nil, false
+	}
+	var apiErr *googleapi.Error
+	return apiErr, errors.As(err, &apiErr)
 }
 
 // containsQuotaError checks if the API error is related to quota.
@@ -1,7 +1,10 @@
 package calendar
 
 import (
+	"context"
 	"errors"
+	"slices"
+	"strings"
 	"testing"
 	"time"
 
@@ -10,6 +13,8 @@ import (
 )
 
 func TestValidateEventParams(t *testing.T) {
+	now := time.Now()
+
 	tests := []struct {
 		name    string
 		params  EventParams
@@ -74,6 +79,47 @@ func TestValidateEventParams(t *testing.T) {
 			wantErr: true,
 			errMsg:  "duration must be positive",
 		},
+		{
+			name: "end time instead of duration",
+			params: EventParams{
+				Title:     "Test Event",
+				StartTime: now,
+				EndTime:   now.Add(90 * time.Minute),
+			},
+			wantErr: false,
+		},
+		{
+			name: "end time before start",
+			params: EventParams{
+				Title:     "Test Event",
+				StartTime: now,
+				EndTime:   now.Add(-time.Hour),
+			},
+			wantErr: true,
+			errMsg:  "end time must be after start time",
+		},
+		{
+			name: "end time and duration",
+			params: EventParams{
+				Title:     "Test Event",
+				StartTime: now,
+				EndTime:   now.Add(time.Hour),
+				Duration:  time.Hour,
+			},
+			wantErr: true,
+			errMsg:  "can't both be set",
+		},
+		{
+			name: "all-day with end time",
+			params: EventParams{
+				Title:     "Test Event",
+				StartTime: now,
+				EndTime:   now.Add(time.Hour),
+				AllDay:    true,
+			},
+			wantErr: true,
+			errMsg:  "all-day events take an end date",
+		},
 	}
 
 	for _, tt := range tests {
@@ -193,6 +239,116 @@ func TestParseEventResult(t *testing.T) {
 	}
 }
 
+func TestParseEventResult_TruncatesSubSecond(t *testing.T) {
+	result, err := parseEventResult(&calendar.Event{
+		Created: "2024-01-10T08:00:00.987Z",
+		Start:   &calendar.EventDateTime{DateTime: "2024-01-15T14:00:00.123456+00:00"},
+		End:     &calendar.EventDateTime{DateTime: "2024-01-15T15:00:00.5Z"},
+	})
+	if err != nil {
+		t.Fatalf("parseEventResult() error = %v", err)
+	}
+
+	checks := []struct {
+		name string
+		got  time.Time
+		want time.Time
+	}{
+		{"StartTime", result.StartTime, time.Date(2024, 1, 15, 14, 0, 0, 0, time.UTC)},
+		{"EndTime", result.EndTime, time.Date(2024, 1, 15, 15, 0, 0, 0, time.UTC)},
+		{"Created", result.Created, time.Date(2024, 1, 10, 8, 0, 0, 0, time.UTC)},
+	}
+	for _, check := range checks {
+		if !check.got.Equal(check.want) || check.got.Nanosecond() != 0 {
+			t.Errorf("%s = %v, want %v", check.name, check.got, check.want)
+		}
+	}
+	if got := result.StartTime.Format(time.RFC3339Nano); got != "2024-01-15T14:00:00Z" {
+		t.Errorf("StartTime formats as %q, want no fractional seconds", got)
+	}
+}
+
+func TestParseEventResult_MeetLink(t *testing.T) {
+	tests := []struct {
+		name  string
+		event *calendar.Event
+		want  string
+	}{
+		{
+			name: "legacy hangout link only",
+			event: &calendar.Event{
+				Id:          "legacy",
+				HangoutLink: "https://meet.google.com/abc-defg-hij",
+			},
+			want: "https://meet.google.com/abc-defg-hij",
+		},
+		{
+			name: "conference data takes precedence",
+			event: &calendar.Event{
+				Id:          "modern",
+				HangoutLink: "https://meet.google.com/old-link",
+				ConferenceData: &calendar.ConferenceData{
+					EntryPoints: []*calendar.EntryPoint{
+						{EntryPointType: "phone", Uri: "tel:+1-555-0100"},
+						{EntryPointType: "video", Uri: "https://meet.google.com/new-link"},
+					},
+				},
+			},
+			want: "https://meet.google.com/new-link",
+		},
+		{
+			name:  "no conference",
+			event: &calendar.Event{Id: "plain"},
+			want:  "",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.event.Start = &calendar.EventDateTime{DateTime: "2024-01-15T14:00:00Z"}
+			tt.event.End = &calendar.EventDateTime{DateTime: "2024-01-15T15:00:00Z"}
+
+			got, err := parseEventResult(tt.event)
+			if err != nil {
+				t.Fatalf("parseEventResult() error = %v", err)
+			}
+			if got.MeetLink != tt.want {
+				t.Errorf("parseEventResult() MeetLink = %q, want %q", got.MeetLink, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseEventResult_OrganizerAndCreator(t *testing.T) {
+	event := &calendar.Event{
+		Id:        "delegated",
+		Start:     &calendar.EventDateTime{DateTime: "2024-01-15T14:00:00Z"},
+		End:       &calendar.EventDateTime{DateTime: "2024-01-15T15:00:00Z"},
+		Organizer: &calendar.EventOrganizer{Email: "boss@example.com"},
+		Creator:   &calendar.EventCreator{Email: "assistant@example.com"},
+	}
+
+	got, err := parseEventResult(event)
+	if err != nil {
+		t.Fatalf("parseEventResult() error = %v", err)
+	}
+	if got.Organizer != "boss@example.com" {
+		t.Errorf("Organizer = %q, want boss@example.com", got.Organizer)
+	}
+	if got.Creator != "assistant@example.com" {
+		t.Errorf("Creator = %q, want assistant@example.com", got.Creator)
+	}
+
+	event.Organizer, event.Creator = nil, nil
+	got, err = parseEventResult(event)
+	if err != nil {
+		t.Fatalf("parseEventResult() error = %v", err)
+	}
+	if got.Organizer != "" || got.Creator != "" {
+		t.Errorf("Expected empty Organizer and Creator, got %q and %q", got.Organizer, got.Creator)
+	}
+}
+
 func TestWrapAPIError(t *testing.T) {
 	tests := []struct {
 		name       string
@@ -351,6 +507,387 @@ func TestEventParamsDefaults(t *testing.T) {
 	}
 }
 
+func TestCreateEvent_WithBuffers(t *testing.T) {
+	client, fake := newFakeClient(t)
+
+	start := time.Date(2024, 1, 15, 14, 0, 0, 0, time.UTC)
+	result, err := client.CreateEvent(context.Background(), EventParams{
+		Title:        "Design Review",
+		StartTime:    start,
+		Duration:     time.Hour,
+		BufferBefore: 10 * time.Minute,
+		BufferAfter:  15 * time.Minute,
+	})
+	if err != nil {
+		t.Fatalf("CreateEvent() error = %v", err)
+	}
+
+	if len(fake.inserted) != 3 {
+		t.Fatalf("Expected 3 inserted events, got %d", len(fake.inserted))
+	}
+
+	main := fake.inserted[0]
+	if main.Transparency != "" {
+		t.Errorf("Main event transparency = %q, want opaque", main.Transparency)
+	}
+
+	tests := []struct {
+		name      string
+		event     *calendar.Event
+		wantStart time.Time
+		wantEnd   time.Time
+	}{
+		{
+			name:      "buffer before",
+			event:     fake.inserted[1],
+			wantStart: start.Add(-10 * time.Minute),
+			wantEnd:   start,
+		},
+		{
+			name:      "buffer after",
+			event:     fake.inserted[2],
+			wantStart: start.Add(time.Hour),
+			wantEnd:   start.Add(time.Hour + 15*time.Minute),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if tt.event.Transparency != "transparent" {
+				t.Errorf("Transparency = %q, want %q", tt.event.Transparency, "transparent")
+			}
+			if tt.event.Start.DateTime != tt.wantStart.Format(time.RFC3339) {
+				t.Errorf("Start = %s, want %s", tt.event.Start.DateTime, tt.wantStart.Format(time.RFC3339))
+			}
+			if tt.event.End.DateTime != tt.wantEnd.Format(time.RFC3339) {
+				t.Errorf("End = %s, want %s", tt.event.End.DateTime, tt.wantEnd.Format(time.RFC3339))
+			}
+		})
+	}
+
+	if len(result.Buffers) != 2 {
+		t.Errorf("Expected 2 buffers on result, got %d", len(result.Buffers))
+	}
+}
+
+func TestCreateEvent_RecurringBuffers(t *testing.T) {
+	client, fake := newFakeClient(t)
+
+	rrule := []string{"RRULE:FREQ=WEEKLY;BYDAY=MO"}
+	_, err := client.CreateEvent(context.Background(), EventParams{
+		Title:        "Design Review",
+		StartTime:    time.Date(2024, 1, 15, 14, 0, 0, 0, time.UTC),
+		Duration:     time.Hour,
+		Recurrence:   rrule,
+		BufferBefore: 10 * time.Minute,
+		BufferAfter:  15 * time.Minute,
+	})
+	if err != nil {
+		t.Fatalf("CreateEvent() error = %v", err)
+	}
+
+	if len(fake.inserted) != 3 {
+		t.Fatalf("Expected 3 inserted events, got %d", len(fake.inserted))
+	}
+	for _, buffer := range fake.inserted[1:] {
+		if !slices.Equal(buffer.Recurrence, rrule) {
+			t.Errorf("buffer %q recurrence = %v, want %v", buffer.Summary, buffer.Recurrence, rrule)
+		}
+	}
+}
+
+func TestCreateEvent_BufferFailure(t *testing.T) {
+	client, fake := newFakeClient(t)
+	fake.rejectInsertPrefix = "Buffer:"
+
+	result, err := client.CreateEvent(context.Background(), EventParams{
+		Title:        "Design Review",
+		StartTime:    time.Date(2024, 1, 15, 14, 0, 0, 0, time.UTC),
+		Duration:     time.Hour,
+		BufferBefore: 10 * time.Minute,
+		BufferAfter:  15 * time.Minute,
+	})
+	// The event was created, so it must be reported as such
+	if err != nil {
+		t.Fatalf("CreateEvent() error = %v, want the event with warnings", err)
+	}
+	if result == nil || result.ID == "" {
+		t.Fatalf("CreateEvent() result = %+v, want the created event", result)
+	}
+	if len(result.Warnings) != 2 || !strings.Contains(result.Warnings[0], "buffer") {
+		t.Errorf("Warnings = %q, want one per failed buffer", result.Warnings)
+	}
+	if len(result.Buffers) != 0 {
+		t.Errorf("Buffers = %v, want none", result.Buffers)
+	}
+	if len(fake.events) != 1 {
+		t.Errorf("Expected only the main event stored, got %d events", len(fake.events))
+	}
+}
+
+func TestCreateEvent_DefaultAddConference(t *testing.T) {
+	disabled := false
+	enabled := true
+
+	tests := []struct {
+		name           string
+		clientDefault  bool
+		addConference  *bool
+		wantConference bool
+	}{
+		{name: "default on, unset", clientDefault: true, addConference: nil, wantConference: true},
+		{name: "default on, explicit false", clientDefault: true, addConference: &disabled, wantConference: false},
+		{name: "default off, unset", clientDefault: false, addConference: nil, wantConference: false},
+		{name: "default off, explicit true", clientDefault: false, addConference: &enabled, wantConference: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client, fake := newFakeClient(t)
+			client.DefaultAddConference = tt.clientDefault
+
+			result, err := client.CreateEvent(context.Background(), EventParams{
+				Title:         "Sync",
+				StartTime:     time.Date(2024, 1, 15, 14, 0, 0, 0, time.UTC),
+				Duration:      30 * time.Minute,
+				AddConference: tt.addConference,
+			})
+			if err != nil {
+				t.Fatalf("CreateEvent() error = %v", err)
+			}
+
+			inserted := fake.inserted[0]
+			gotConference := inserted.ConferenceData != nil && inserted.ConferenceData.CreateRequest != nil
+			if gotConference != tt.wantConference {
+				t.Errorf("conference requested = %v, want %v", gotConference, tt.wantConference)
+			}
+
+			if tt.wantConference {
+				if inserted.ConferenceData.CreateRequest.ConferenceSolutionKey.Type != "hangoutsMeet" {
+					t.Errorf("conference type = %q, want hangoutsMeet", inserted.ConferenceData.CreateRequest.ConferenceSolutionKey.Type)
+				}
+				if fake.queries[0].Get("conferenceDataVersion") != "1" {
+					t.Errorf("conferenceDataVersion = %q, want 1", fake.queries[0].Get("conferenceDataVersion"))
+				}
+				if result.MeetLink == "" {
+					t.Error("Expected MeetLink on result")
+				}
+			}
+		})
+	}
+}
+
+func TestCreateEvent_RejectPastGrace(t *testing.T) {
+	tests := []struct {
+		name    string
+		grace   time.Duration
+		wantErr bool
+	}{
+		{name: "within 5 minute grace", grace: 5 * time.Minute, wantErr: false},
+		{name: "outside 1 minute grace", grace: time.Minute, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client, fake := newFakeClient(t)
+			client.RejectPast = true
+			client.PastGrace = tt.grace
+
+			_, err := client.CreateEvent(context.Background(), EventParams{
+				Title:     "Just now",
+				StartTime: time.Now().Add(-2 * time.Minute),
+				Duration:  30 * time.Minute,
+			})
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("CreateEvent() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr {
+				if !errors.Is(err, ErrInvalidEventTime) {
+					t.Errorf("CreateEvent() error = %v, want ErrInvalidEventTime", err)
+				}
+				if len(fake.inserted) != 0 {
+					t.Error("Expected no event to be inserted")
+				}
+			}
+		})
+	}
+}
+
+func TestCreateEvent_PastAllowedByDefault(t *testing.T) {
+	client, _ := newFakeClient(t)
+
+	if client.PastGrace != DefaultPastGrace {
+		t.Errorf("PastGrace = %v, want %v", client.PastGrace, DefaultPastGrace)
+	}
+
+	_, err := client.CreateEvent(context.Background(), EventParams{
+		Title:     "Backfill",
+		StartTime: time.Now().Add(-48 * time.Hour),
+		Duration:  30 * time.Minute,
+	})
+	if err != nil {
+		t.Errorf("CreateEvent() error = %v, want nil when RejectPast is off", err)
+	}
+}
+
+func TestCreateEvent_NegativeBuffer(t *testing.T) {
+	client, fake := newFakeClient(t)
+
+	_, err := client.CreateEvent(context.Background(), EventParams{
+		Title:        "Design Review",
+		StartTime:    time.Now(),
+		Duration:     time.Hour,
+		BufferBefore: -5 * time.Minute,
+	})
+	if !errors.Is(err, ErrInvalidEventTime) {
+		t.Errorf("CreateEvent() error = %v, want ErrInvalidEventTime", err)
+	}
+	if len(fake.inserted) != 0 {
+		t.Errorf("Expected no inserted events, got %d", len(fake.inserted))
+	}
+}
+
+func TestCreateEvent_MinDuration(t *testing.T) {
+	tests := []struct {
+		name        string
+		minDuration time.Duration
+		duration    time.Duration
+		wantErr     bool
+	}{
+		{"30 seconds under 1 minute minimum", time.Minute, 30 * time.Second, true},
+		{"exactly the minimum", time.Minute, time.Minute, false},
+		{"minimum disabled", 0, 30 * time.Second, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client, fake := newFakeClient(t)
+			client.MinDuration = tt.minDuration
+
+			_, err := client.CreateEvent(context.Background(), EventParams{
+				Title:     "Quick Sync",
+				StartTime: time.Date(2024, 1, 15, 14, 0, 0, 0, time.UTC),
+				Duration:  tt.duration,
+			})
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("CreateEvent() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr {
+				if !errors.Is(err, ErrInvalidEventTime) || !contains(err.Error(), "minimum") {
+					t.Errorf("CreateEvent() error = %v, want a minimum duration error", err)
+				}
+				if len(fake.inserted) != 0 {
+					t.Errorf("Expected no inserted events, got %d", len(fake.inserted))
+				}
+			}
+		})
+	}
+}
+
+func TestNewClient_DefaultMinDuration(t *testing.T) {
+	client := newClientWithService(nil, "")
+	if client.MinDuration != DefaultMinDuration {
+		t.Errorf("MinDuration = %v, want %v", client.MinDuration, DefaultMinDuration)
+	}
+}
+
+func TestCreateEvent_DefaultDescription(t *testing.T) {
+	tests := []struct {
+		name        string
+		prefix      string
+		description string
+		want        string
+	}{
+		{"suffix appended", "", "Agenda: roadmap", "Agenda: roadmap\nCreated via calgo"},
+		{"empty description", "", "", "Created via calgo"},
+		{"prefix and suffix", "[team]", "Agenda: roadmap", "[team]\nAgenda: roadmap\nCreated via calgo"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client, fake := newFakeClient(t)
+			client.DefaultDescriptionPrefix = tt.prefix
+			client.DefaultDescriptionSuffix = "Created via calgo"
+
+			result, err := client.CreateEvent(context.Background(), EventParams{
+				Title:       "Planning",
+				StartTime:   time.Date(2024, 1, 15, 14, 0, 0, 0, time.UTC),
+				Duration:    time.Hour,
+				Description: tt.description,
+			})
+			if err != nil {
+				t.Fatalf("CreateEvent() error = %v", err)
+			}
+
+			if fake.inserted[0].Description != tt.want {
+				t.Errorf("sent Description = %q, want %q", fake.inserted[0].Description, tt.want)
+			}
+			if result.Description != tt.want {
+				t.Errorf("result Description = %q, want %q", result.Description, tt.want)
+			}
+		})
+	}
+}
+
+func TestBuildEvent_EndTimeZone(t *testing.T) {
+	newYork, err := time.LoadLocation("America/New_York")
+	if err != nil {
+		t.Fatalf("Failed to load location: %v", err)
+	}
+
+	event := buildEvent(EventParams{
+		Title:       "Flight JFK to LAX",
+		StartTime:   time.Date(2024, 1, 15, 17, 0, 0, 0, newYork),
+		Duration:    6 * time.Hour,
+		EndTimeZone: "America/Los_Angeles",
+	})
+
+	if event.Start.TimeZone != "America/New_York" {
+		t.Errorf("Start.TimeZone = %q, want America/New_York", event.Start.TimeZone)
+	}
+	if event.End.TimeZone != "America/Los_Angeles" {
+		t.Errorf("End.TimeZone = %q, want America/Los_Angeles", event.End.TimeZone)
+	}
+
+	// 5pm in New York plus six hours is 8pm in Los Angeles
+	if event.End.DateTime != "2024-01-15T20:00:00-08:00" {
+		t.Errorf("End.DateTime = %q, want 2024-01-15T20:00:00-08:00", event.End.DateTime)
+	}
+
+	start, _ := time.Parse(time.RFC3339, event.Start.DateTime)
+	end, _ := time.Parse(time.RFC3339, event.End.DateTime)
+	if end.Sub(start) != 6*time.Hour {
+		t.Errorf("elapsed = %v, want 6h", end.Sub(start))
+	}
+}
+
+func TestValidateEventParams_InvalidEndTimeZone(t *testing.T) {
+	err := validateEventParams(EventParams{
+		Title:       "Flight",
+		StartTime:   time.Date(2024, 1, 15, 17, 0, 0, 0, time.UTC),
+		Duration:    time.Hour,
+		EndTimeZone: "Nowhere/Special",
+	})
+	if !errors.Is(err, ErrInvalidTimezone) {
+		t.Errorf("validateEventParams() error = %v, want ErrInvalidTimezone", err)
+	}
+}
+
+func TestCreateEvent_RequestTimeout(t *testing.T) {
+	client, fake := newFakeClient(t)
+	fake.delay = 200 * time.Millisecond
+	client.RequestTimeout = 20 * time.Millisecond
+
+	_, err := client.CreateEvent(context.Background(), EventParams{
+		Title:     "Meeting",
+		StartTime: time.Date(2024, 1, 15, 14, 0, 0, 0, time.UTC),
+		Duration:  time.Hour,
+	})
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("CreateEvent() error = %v, want context.DeadlineExceeded", err)
+	}
+}
+
 // contains checks if a string contains a substring (case-insensitive would need strings.Contains with ToLower).
 func contains(s, substr string) bool {
 	return len(s) >= len(substr) && (s == substr || len(substr) == 0 ||
@@ -365,3 +902,125 @@ func findSubstring(s, substr string) bool {
 	}
 	return false
 }
+
+func TestCreateEvent_EndTime(t *testing.T) {
+	client, fake := newFakeClient(t)
+	client.MinDuration = time.Hour
+
+	start := time.Date(2024, 1, 15, 14, 0, 0, 0, time.UTC)
+	result, err := client.CreateEvent(context.Background(), EventParams{
+		Title:     "Review",
+		StartTime: start,
+		EndTime:   start.Add(150 * time.Minute),
+	})
+	if err != nil {
+		t.Fatalf("CreateEvent() error = %v", err)
+	}
+	if stored := fake.events[result.ID]; stored.End.DateTime != "2024-01-15T16:30:00Z" {
+		t.Errorf("End = %+v, want 16:30", stored.End)
+	}
+
+	// The minimum duration applies to the span up to EndTime
+	_, err = client.CreateEvent(context.Background(), EventParams{
+		Title:     "Review",
+		StartTime: start,
+		EndTime:   start.Add(30 * time.Minute),
+	})
+	if !errors.Is(err, ErrInvalidEventTime) {
+		t.Errorf("CreateEvent() with a 30 minute span error = %v, want ErrInvalidEventTime", err)
+	}
+}
+
+func TestEventPayload(t *testing.T) {
+	client, fake := newFakeClient(t)
+	client.DefaultAddConference = true
+	client.DefaultDescriptionPrefix = "[calgo]"
+
+	// A Saturday start moves to Monday, as CreateEvent would
+	start := time.Date(2024, 1, 13, 9, 0, 0, 0, time.UTC)
+	event, err := client.EventPayload(EventParams{
+		Title:        "Planning",
+		StartTime:    start,
+		Duration:     time.Hour,
+		Description:  "Agenda",
+		SkipWeekends: true,
+	})
+	if err != nil {
+		t.Fatalf("EventPayload() error = %v", err)
+	}
+
+	if event.Start.DateTime != "2024-01-15T09:00:00Z" || event.End.DateTime != "2024-01-15T10:00:00Z" {
+		t.Errorf("Start, End = %+v, %+v, want Monday 9:00-10:00", event.Start, event.End)
+	}
+	if !contains(event.Description, "[calgo]") {
+		t.Errorf("Description = %q, want the default prefix", event.Description)
+	}
+	if event.ConferenceData == nil || event.ConferenceData.CreateRequest == nil {
+		t.Error("ConferenceData not set with DefaultAddConference")
+	}
+	if n := len(fake.requests); n != 0 {
+		t.Errorf("EventPayload() made %d requests, want none", n)
+	}
+
+	if _, err := client.EventPayload(EventParams{Title: "Planning"}); !errors.Is(err, ErrInvalidEventTime) {
+		t.Errorf("EventPayload() without a start error = %v, want ErrInvalidEventTime", err)
+	}
+}
+
+func TestCreateEvent_AllDayEndDate(t *testing.T) {
+	jan15 := time.Date(2024, 1, 15, 0, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name      string
+		inclusive bool
+		endDate   time.Time
+		wantEnd   string
+	}{
+		{"inclusive single day", true, time.Time{}, "2024-01-16"},
+		{"inclusive end on same day", true, jan15, "2024-01-16"},
+		{"inclusive multi-day", true, jan15.AddDate(0, 0, 2), "2024-01-18"},
+		{"exclusive single day", false, time.Time{}, "2024-01-16"},
+		{"exclusive end verbatim", false, jan15.AddDate(0, 0, 1), "2024-01-16"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client, fake := newFakeClient(t)
+			client.InclusiveEndDate = tt.inclusive
+
+			result, err := client.CreateEvent(context.Background(), EventParams{
+				Title:     "Offsite",
+				StartTime: jan15,
+				AllDay:    true,
+				EndDate:   tt.endDate,
+			})
+			if err != nil {
+				t.Fatalf("CreateEvent() error = %v", err)
+			}
+
+			stored := fake.events[result.ID]
+			if stored.Start.Date != "2024-01-15" || stored.Start.DateTime != "" {
+				t.Errorf("Start = %+v, want date 2024-01-15", stored.Start)
+			}
+			if stored.End.Date != tt.wantEnd {
+				t.Errorf("End.Date = %q, want %q", stored.End.Date, tt.wantEnd)
+			}
+		})
+	}
+}
+
+func TestCreateEvent_AllDayExclusiveEndOnStart(t *testing.T) {
+	client, _ := newFakeClient(t)
+	client.InclusiveEndDate = false
+	jan15 := time.Date(2024, 1, 15, 0, 0, 0, 0, time.UTC)
+
+	_, err := client.CreateEvent(context.Background(), EventParams{
+		Title:     "Offsite",
+		StartTime: jan15,
+		AllDay:    true,
+		EndDate:   jan15,
+	})
+	if !errors.Is(err, ErrInvalidEventTime) {
+		t.Errorf("CreateEvent() error = %v, want ErrInvalidEventTime", err)
+	}
+}
@@ -0,0 +1,64 @@
+package calendar
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+
+	"google.golang.org/api/calendar/v3"
+)
+
+// ErrClockSkew is returned when the local clock differs from Google's by
+// more than Client.MaxClockSkew. Token expiry checks rely on the local
+// clock, so a skewed machine sees spurious "expired" tokens.
+var ErrClockSkew = errors.New("local clock is out of sync with Google")
+
+// CheckClockSkew estimates how far the local clock is from Google's by
+// comparing it with the Date header of a one-event listing. A positive skew
+// means the local clock is ahead. The skew is returned alongside
+// ErrClockSkew when it exceeds MaxClockSkew. The Date header has a
+// resolution of one second, so the estimate is only good to about a second.
+func (c *Client) CheckClockSkew(ctx context.Context) (time.Duration, error) {
+	ctx, cancel := c.withRequestTimeout(ctx)
+	defer cancel()
+
+	var page *calendar.Events
+	var sent, received time.Time
+	err := c.retry(ctx, true, func() error {
+		var err error
+		sent = c.now()
+		page, err = c.service.Events.List(c.calendarID).MaxResults(1).Context(ctx).Do()
+		received = c.now()
+		return err
+	})
+	if err != nil {
+		return 0, wrapAPIErrorAs(err, ErrEventListFailed)
+	}
+
+	header := page.Header.Get("Date")
+	serverTime, err := http.ParseTime(header)
+	if err != nil {
+		return 0, fmt.Errorf("failed to read server time from Date header %q: %w", header, err)
+	}
+
+	// The header truncates to the second, so take the middle of that
+	// second, and compare against the middle of the round trip.
+	serverTime = serverTime.Add(500 * time.Millisecond)
+	local := sent.Add(received.Sub(sent) / 2)
+	skew := local.Sub(serverTime)
+
+	if c.MaxClockSkew > 0 && (skew > c.MaxClockSkew || skew < -c.MaxClockSkew) {
+		return skew, fmt.Errorf("%w: local clock is %s %s", ErrClockSkew, skew.Abs().Round(time.Second), skewDirection(skew))
+	}
+	return skew, nil
+}
+
+// skewDirection describes the sign of a clock skew.
+func skewDirection(skew time.Duration) string {
+	if skew > 0 {
+		return "ahead"
+	}
+	return "behind"
+}
@@ -0,0 +1,42 @@
+package calendar
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestCheckClockSkew(t *testing.T) {
+	tests := []struct {
+		name       string
+		dateOffset time.Duration
+		wantErr    bool
+	}{
+		{"in sync", 0, false},
+		{"local clock ahead", -10 * time.Minute, true},
+		{"local clock behind", 10 * time.Minute, true},
+		{"within tolerance", -20 * time.Second, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client, fake := newFakeClient(t)
+			fake.dateOffset = tt.dateOffset
+
+			skew, err := client.CheckClockSkew(context.Background())
+			if errors.Is(err, ErrClockSkew) != tt.wantErr {
+				t.Fatalf("CheckClockSkew() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !tt.wantErr && err != nil {
+				t.Fatalf("CheckClockSkew() error = %v", err)
+			}
+
+			// The local clock is off by the opposite of the server offset
+			want := -tt.dateOffset
+			if diff := (skew - want).Abs(); diff > 2*time.Second {
+				t.Errorf("CheckClockSkew() skew = %s, want about %s", skew, want)
+			}
+		})
+	}
+}
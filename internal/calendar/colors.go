@@ -0,0 +1,147 @@
+package calendar
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	"google.golang.org/api/calendar/v3"
+)
+
+// Errors for event colors.
+var (
+	ErrUnknownColor = errors.New("unknown color")
+	ErrColorsFailed = errors.New("failed to fetch colors")
+)
+
+// EventColors maps friendly color names to Google Calendar event color IDs.
+// The IDs match the "event" palette returned by the Colors API.
+var EventColors = map[string]string{
+	"lavender":  "1",
+	"sage":      "2",
+	"grape":     "3",
+	"flamingo":  "4",
+	"banana":    "5",
+	"tangerine": "6",
+	"peacock":   "7",
+	"graphite":  "8",
+	"blueberry": "9",
+	"basil":     "10",
+	"tomato":    "11",
+
+	// Common aliases
+	"purple": "3",
+	"pink":   "4",
+	"yellow": "5",
+	"orange": "6",
+	"cyan":   "7",
+	"gray":   "8",
+	"grey":   "8",
+	"blue":   "9",
+	"green":  "10",
+	"red":    "11",
+}
+
+// ResolveColorID returns the event color ID for a friendly color name.
+// Numeric IDs that exist in the palette are accepted as-is.
+func ResolveColorID(name string) (string, error) {
+	name = strings.ToLower(strings.TrimSpace(name))
+
+	if id, ok := EventColors[name]; ok {
+		return id, nil
+	}
+
+	for _, id := range EventColors {
+		if id == name {
+			return id, nil
+		}
+	}
+
+	return "", fmt.Errorf("%w: '%s'. Available colors: %s", ErrUnknownColor, name, strings.Join(colorNames(), ", "))
+}
+
+// FilterByColor returns the events whose color matches the given friendly
+// color name or color ID. It is applied to already-fetched events.
+func FilterByColor(events []*EventResult, name string) ([]*EventResult, error) {
+	colorID, err := ResolveColorID(name)
+	if err != nil {
+		return nil, err
+	}
+
+	var filtered []*EventResult
+	for _, event := range events {
+		if event.ColorID == colorID {
+			filtered = append(filtered, event)
+		}
+	}
+
+	return filtered, nil
+}
+
+// colorNames returns the sorted list of known color names.
+func colorNames() []string {
+	names := make([]string, 0, len(EventColors))
+	for name := range EventColors {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// EventColor is a color of the event palette.
+type EventColor struct {
+	ID string `json:"id"`
+
+	// Names are the friendly names ResolveColorID accepts for the color.
+	Names []string `json:"names"`
+
+	// Background and Foreground are the hex colors Google Calendar uses
+	// for the event and its text, e.g. "#dc2127".
+	Background string `json:"background"`
+	Foreground string `json:"foreground"`
+}
+
+// ListColors returns the event palette from the Colors API, ordered by ID,
+// with the names each color can be given by.
+func (c *Client) ListColors(ctx context.Context) ([]EventColor, error) {
+	ctx, cancel := c.withRequestTimeout(ctx)
+	defer cancel()
+
+	var palette *calendar.Colors
+	err := c.retry(ctx, true, func() error {
+		var err error
+		palette, err = c.service.Colors.Get().Context(ctx).Do()
+		return err
+	})
+	if err != nil {
+		return nil, wrapAPIErrorAs(err, ErrColorsFailed)
+	}
+
+	names := make(map[string][]string)
+	for _, name := range colorNames() {
+		id := EventColors[name]
+		names[id] = append(names[id], name)
+	}
+
+	colors := make([]EventColor, 0, len(palette.Event))
+	for id, def := range palette.Event {
+		colors = append(colors, EventColor{
+			ID:         id,
+			Names:      names[id],
+			Background: def.Background,
+			Foreground: def.Foreground,
+		})
+	}
+	sort.Slice(colors, func(i, j int) bool {
+		a, errA := strconv.Atoi(colors[i].ID)
+		b, errB := strconv.Atoi(colors[j].ID)
+		if errA != nil || errB != nil {
+			return colors[i].ID < colors[j].ID
+		}
+		return a < b
+	})
+	return colors, nil
+}
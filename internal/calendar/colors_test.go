@@ -0,0 +1,124 @@
+package calendar
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+
+	"google.golang.org/api/calendar/v3"
+)
+
+func TestResolveColorID(t *testing.T) {
+	tests := []struct {
+		name  string
+		input string
+		want  string
+	}{
+		{name: "palette name", input: "tomato", want: "11"},
+		{name: "alias", input: "red", want: "11"},
+		{name: "mixed case", input: " Blueberry ", want: "9"},
+		{name: "numeric id", input: "7", want: "7"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ResolveColorID(tt.input)
+			if err != nil {
+				t.Fatalf("ResolveColorID(%q) error = %v", tt.input, err)
+			}
+			if got != tt.want {
+				t.Errorf("ResolveColorID(%q) = %q, want %q", tt.input, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestResolveColorID_Unknown(t *testing.T) {
+	_, err := ResolveColorID("chartreuse")
+	if !errors.Is(err, ErrUnknownColor) {
+		t.Errorf("ResolveColorID() error = %v, want ErrUnknownColor", err)
+	}
+}
+
+func TestFilterByColor(t *testing.T) {
+	events := []*EventResult{
+		{ID: "a", Title: "Launch", ColorID: "11"},
+		{ID: "b", Title: "Focus", ColorID: "9"},
+		{ID: "c", Title: "Incident review", ColorID: "11"},
+		{ID: "d", Title: "Lunch"},
+	}
+
+	got, err := FilterByColor(events, "red")
+	if err != nil {
+		t.Fatalf("FilterByColor() error = %v", err)
+	}
+
+	if len(got) != 2 {
+		t.Fatalf("FilterByColor() returned %d events, want 2", len(got))
+	}
+	if got[0].ID != "a" || got[1].ID != "c" {
+		t.Errorf("FilterByColor() = [%s %s], want [a c]", got[0].ID, got[1].ID)
+	}
+}
+
+func TestParseEventResult_ColorID(t *testing.T) {
+	result, err := parseEventResult(&calendar.Event{
+		Id:      "colored",
+		ColorId: "5",
+		Start:   &calendar.EventDateTime{DateTime: "2024-01-15T14:00:00Z"},
+		End:     &calendar.EventDateTime{DateTime: "2024-01-15T15:00:00Z"},
+	})
+	if err != nil {
+		t.Fatalf("parseEventResult() error = %v", err)
+	}
+
+	if result.ColorID != "5" {
+		t.Errorf("parseEventResult() ColorID = %q, want %q", result.ColorID, "5")
+	}
+}
+
+func TestCreateEvent_Color(t *testing.T) {
+	client, fake := newFakeClient(t)
+	ctx := context.Background()
+	params := EventParams{
+		Title:     "Reset window",
+		StartTime: time.Date(2024, 1, 15, 14, 0, 0, 0, time.UTC),
+		Duration:  time.Hour,
+		Color:     "red",
+	}
+
+	result, err := client.CreateEvent(ctx, params)
+	if err != nil {
+		t.Fatalf("CreateEvent() error = %v", err)
+	}
+	if fake.inserted[0].ColorId != "11" || result.ColorID != "11" {
+		t.Errorf("color ID sent %q, got back %q; want 11", fake.inserted[0].ColorId, result.ColorID)
+	}
+
+	params.Color = "chartreuse"
+	if _, err := client.CreateEvent(ctx, params); !errors.Is(err, ErrUnknownColor) {
+		t.Errorf("CreateEvent() with unknown color error = %v, want ErrUnknownColor", err)
+	}
+}
+
+func TestListColors(t *testing.T) {
+	client, _ := newFakeClient(t)
+
+	colors, err := client.ListColors(context.Background())
+	if err != nil {
+		t.Fatalf("ListColors() error = %v", err)
+	}
+	if len(colors) != 11 {
+		t.Fatalf("ListColors() returned %d colors, want 11", len(colors))
+	}
+	// Ordered numerically, so 10 and 11 come last
+	if colors[0].ID != "1" || colors[10].ID != "11" {
+		t.Errorf("IDs run %s..%s, want 1..11", colors[0].ID, colors[10].ID)
+	}
+	tomato := colors[10]
+	if !reflect.DeepEqual(tomato.Names, []string{"red", "tomato"}) || tomato.Background != "#dc2127" {
+		t.Errorf("color 11 = %+v, want red/tomato #dc2127", tomato)
+	}
+}
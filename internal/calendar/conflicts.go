@@ -0,0 +1,55 @@
+package calendar
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// ErrScheduleConflict is returned by CreateEvent when EventParams.RejectConflicts
+// is set and the new event would overlap existing ones.
+var ErrScheduleConflict = errors.New("event conflicts with existing events")
+
+// ConflictError lists the existing events a new event would overlap. It
+// wraps ErrScheduleConflict.
+type ConflictError struct {
+	Conflicts []*EventResult
+}
+
+func (e *ConflictError) Error() string {
+	titles := make([]string, len(e.Conflicts))
+	for i, event := range e.Conflicts {
+		titles[i] = fmt.Sprintf("%q at %s", event.Title, FormatTimeShort(event.StartTime))
+	}
+	return fmt.Sprintf("%v: %s", ErrScheduleConflict, strings.Join(titles, ", "))
+}
+
+func (e *ConflictError) Unwrap() error {
+	return ErrScheduleConflict
+}
+
+// Conflicts returns the existing events that the event described by params
+// would overlap, to warn before double-booking. Transparent events don't
+// count, and for recurring events only the first occurrence is checked.
+func (c *Client) Conflicts(ctx context.Context, params EventParams) ([]*EventResult, error) {
+	start, end := c.eventWindow(params)
+	_, conflicts, err := c.IsTimeFree(ctx, start, end)
+	return conflicts, err
+}
+
+// eventWindow returns the span params would occupy once CreateEvent has
+// applied SkipWeekends and, for all-day events, the end date rules. All-day
+// events span whole days in StartTime's location.
+func (c *Client) eventWindow(params EventParams) (time.Time, time.Time) {
+	params = skipWeekends(withDuration(params))
+	if !params.AllDay {
+		return params.StartTime, params.StartTime.Add(params.Duration)
+	}
+
+	s := params.StartTime
+	start := time.Date(s.Year(), s.Month(), s.Day(), 0, 0, 0, 0, s.Location())
+	days := int(c.allDayEndDate(params).Sub(dateOf(s)).Hours() / 24)
+	return start, start.AddDate(0, 0, days)
+}
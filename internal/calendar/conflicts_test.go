@@ -0,0 +1,89 @@
+package calendar
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestConflicts(t *testing.T) {
+	day := time.Date(2024, 1, 15, 0, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name   string
+		params EventParams
+		want   []string
+	}{
+		{
+			name:   "overlaps one event",
+			params: EventParams{Title: "New", StartTime: day.Add(9*time.Hour + 30*time.Minute), Duration: time.Hour},
+			want:   []string{"Standup"},
+		},
+		{
+			name:   "abutting is not a conflict",
+			params: EventParams{Title: "New", StartTime: day.Add(10 * time.Hour), Duration: time.Hour},
+		},
+		{
+			name:   "transparent events don't count",
+			params: EventParams{Title: "New", StartTime: day.Add(13 * time.Hour), Duration: time.Hour},
+		},
+		{
+			name:   "all-day covers the whole day",
+			params: EventParams{Title: "Offsite", StartTime: day, AllDay: true},
+			want:   []string{"Standup"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client, fake := newFakeClient(t)
+			addTimedEvent(fake, "Standup", day.Add(9*time.Hour), day.Add(10*time.Hour), false)
+			addTimedEvent(fake, "Focus (free)", day.Add(13*time.Hour), day.Add(15*time.Hour), true)
+
+			conflicts, err := client.Conflicts(context.Background(), tt.params)
+			if err != nil {
+				t.Fatalf("Conflicts() error = %v", err)
+			}
+			var got []string
+			for _, event := range conflicts {
+				got = append(got, event.Title)
+			}
+			if strings.Join(got, ",") != strings.Join(tt.want, ",") {
+				t.Errorf("Conflicts() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCreateEvent_RejectConflicts(t *testing.T) {
+	client, fake := newFakeClient(t)
+	day := time.Date(2024, 1, 15, 0, 0, 0, 0, time.UTC)
+	addTimedEvent(fake, "Standup", day.Add(9*time.Hour), day.Add(10*time.Hour), false)
+
+	params := EventParams{
+		Title:           "Overlapping",
+		StartTime:       day.Add(9*time.Hour + 30*time.Minute),
+		Duration:        time.Hour,
+		RejectConflicts: true,
+	}
+
+	_, err := client.CreateEvent(context.Background(), params)
+	if !errors.Is(err, ErrScheduleConflict) {
+		t.Fatalf("CreateEvent() error = %v, want ErrScheduleConflict", err)
+	}
+	var conflictErr *ConflictError
+	if !errors.As(err, &conflictErr) || len(conflictErr.Conflicts) != 1 || conflictErr.Conflicts[0].Title != "Standup" {
+		t.Errorf("CreateEvent() error = %#v, want a ConflictError listing Standup", err)
+	}
+	if len(fake.inserted) != 0 {
+		t.Errorf("inserted %d events, want 0", len(fake.inserted))
+	}
+
+	// Without the option the event is created anyway
+	params.RejectConflicts = false
+	if _, err := client.CreateEvent(context.Background(), params); err != nil {
+		t.Errorf("CreateEvent() without RejectConflicts error = %v", err)
+	}
+}
@@ -3,6 +3,7 @@ package calendar
 import (
 	"errors"
 	"fmt"
+	"math"
 	"os"
 	"regexp"
 	"strconv"
@@ -22,8 +23,11 @@ var (
 // Supported formats:
 //   - ISO 8601: "2024-01-15T14:00:00", "2024-01-15T14:00:00Z", "2024-01-15T14:00:00+05:00"
 //   - Natural: "2024-01-15 14:00", "2024-01-15 14:00:00"
-//   - Time only: "14:00", "14:00:00" (assumes today)
-//   - Relative: "tomorrow 14:00", "today 14:00", "in 2 hours", "in 30 minutes"
+//   - Time only: "14:00", "14:00:00", "2pm", "9:30am" (assumes today)
+//   - Relative: "tomorrow 14:00", "today at 2pm", "in 2 hours", "in 30 minutes",
+//     "in 3 days at 2pm"
+//   - Day of month: "on the 15th at 2pm", "the 3rd 09:00" (this month, or
+//     the next month with that day once it has passed)
 //
 // The timezone is determined by:
 //  1. Timezone embedded in the input string (for ISO 8601 with offset)
@@ -56,6 +60,80 @@ func ParseTime(input string, timezone string) (time.Time, error) {
 	return parseStandard(input, loc)
 }
 
+// ParseEndTime parses the end of an event that starts at start. A time of
+// day on its own, e.g. "16:30" or "5pm", is taken on start's date in start's
+// location rather than today; anything else is parsed as by ParseTime.
+func ParseEndTime(input string, start time.Time, timezone string) (time.Time, error) {
+	if hour, minute, second, ok := parseClock(strings.TrimSpace(input)); ok {
+		return time.Date(start.Year(), start.Month(), start.Day(), hour, minute, second, 0, start.Location()), nil
+	}
+	return ParseTime(input, timezone)
+}
+
+// relativeDaysRegex matches day offsets like "+3d" or "+2w".
+var relativeDaysRegex = regexp.MustCompile(`^\+(\d+)\s*([dw])$`)
+
+// ParseDate parses a day, returning midnight at its start in the timezone.
+// Supported formats:
+//   - "today", "tomorrow", "yesterday"
+//   - Day names: "friday", "next friday" (the next such day; today for
+//     a plain name that matches today)
+//   - Offsets: "+3d", "+2w"
+//   - Anything ParseTime accepts, e.g. "2024-07-01", with the time dropped
+//
+// The timezone is resolved as in ParseTime.
+func ParseDate(input string, timezone string) (time.Time, error) {
+	loc, err := getLocation(timezone)
+	if err != nil {
+		return time.Time{}, err
+	}
+	return parseDate(input, timezone, time.Now().In(loc))
+}
+
+// parseDate implements ParseDate relative to now.
+func parseDate(input string, timezone string, now time.Time) (time.Time, error) {
+	phrase := strings.Join(strings.Fields(strings.ToLower(input)), " ")
+	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, now.Location())
+
+	switch phrase {
+	case "":
+		return time.Time{}, fmt.Errorf("%w: empty input", ErrInvalidDateFormat)
+	case "today":
+		return today, nil
+	case "tomorrow":
+		return today.AddDate(0, 0, 1), nil
+	case "yesterday":
+		return today.AddDate(0, 0, -1), nil
+	}
+
+	if matches := relativeDaysRegex.FindStringSubmatch(phrase); matches != nil {
+		n, err := strconv.Atoi(matches[1])
+		if err != nil {
+			return time.Time{}, fmt.Errorf("%w: %s", ErrInvalidDateFormat, input)
+		}
+		if matches[2] == "w" {
+			n *= 7
+		}
+		return today.AddDate(0, 0, n), nil
+	}
+
+	name, next := strings.CutPrefix(phrase, "next ")
+	if code, ok := weekdayCodes[name]; ok {
+		days := (dayIndex[code] - (int(today.Weekday())+6)%7 + 7) % 7
+		if days == 0 && next {
+			days = 7
+		}
+		return today.AddDate(0, 0, days), nil
+	}
+
+	t, err := ParseTime(input, timezone)
+	if err != nil {
+		return time.Time{}, err
+	}
+	t = t.In(now.Location())
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, t.Location()), nil
+}
+
 // getLocation returns the time.Location based on the provided timezone string,
 // falling back to TZ environment variable, then system local timezone.
 func getLocation(timezone string) (*time.Location, error) {
@@ -86,6 +164,8 @@ func getLocation(timezone string) (*time.Location, error) {
 //   - "today 14:00", "today at 14:00"
 //   - "tomorrow 14:00", "tomorrow at 14:00"
 //   - "in 2 hours", "in 30 minutes", "in 1 hour"
+//   - "in 3 days", "in 2 weeks at 09:00", "in 3 days at 2pm"
+//   - "on the 15th at 2pm", "the 1st 09:00"
 func parseRelative(input string, loc *time.Location) (time.Time, bool) {
 	input = strings.ToLower(input)
 	now := time.Now().In(loc)
@@ -95,6 +175,9 @@ func parseRelative(input string, loc *time.Location) (time.Time, bool) {
 		if t, ok := parseInDuration(input, now); ok {
 			return t, true
 		}
+		if t, ok := parseInDays(input, now); ok {
+			return t, true
+		}
 	}
 
 	// Pattern: "today [at] HH:MM"
@@ -111,11 +194,55 @@ func parseRelative(input string, loc *time.Location) (time.Time, bool) {
 		}
 	}
 
+	// Pattern: "[on] the Nth [at] TIME"
+	if strings.HasPrefix(input, "on the ") || strings.HasPrefix(input, "the ") {
+		if t, ok := parseDayOfMonth(input, now, loc); ok {
+			return t, true
+		}
+	}
+
+	return time.Time{}, false
+}
+
+// dayOfMonthRegex matches days of the month like "the 15th at 2pm".
+var dayOfMonthRegex = regexp.MustCompile(`^(?:on\s+)?the\s+(\d{1,2})(?:st|nd|rd|th)\s+(?:at\s+)?(.+)$`)
+
+// parseDayOfMonth parses "[on] the Nth [at] TIME", resolving to the Nth of
+// the current month, or of the next month that has an Nth day when that
+// moment has already passed.
+func parseDayOfMonth(input string, now time.Time, loc *time.Location) (time.Time, bool) {
+	matches := dayOfMonthRegex.FindStringSubmatch(input)
+	if matches == nil {
+		return time.Time{}, false
+	}
+
+	day, err := strconv.Atoi(matches[1])
+	if err != nil || day < 1 || day > 31 {
+		return time.Time{}, false
+	}
+
+	hour, minute, second, ok := parseClock(matches[2])
+	if !ok {
+		return time.Time{}, false
+	}
+
+	// Months without an Nth day are skipped; every day up to 31 occurs
+	// within a year.
+	for months := 0; months <= 12; months++ {
+		first := time.Date(now.Year(), now.Month()+time.Month(months), 1, hour, minute, second, 0, loc)
+		candidate := first.AddDate(0, 0, day-1)
+		if candidate.Month() != first.Month() {
+			continue
+		}
+		if !candidate.Before(now) {
+			return candidate, true
+		}
+	}
 	return time.Time{}, false
 }
 
-// parseInDuration parses "in X hours/minutes" format.
-var inDurationRegex = regexp.MustCompile(`^in\s+(\d+)\s*(hours?|minutes?|mins?|hrs?)$`)
+// parseInDuration parses "in X hours/minutes/seconds" format.
+var inDurationRegex = regexp.MustCompile(`^in\s+(\d+)\s*(hours?|minutes?|mins?|hrs?|seconds?|secs?|s)$`)
 
 func parseInDuration(input string, now time.Time) (time.Time, bool) {
 	matches := inDurationRegex.FindStringSubmatch(input)
@@ -129,45 +256,81 @@ func parseInDuration(input string, now time.Time) (time.Time, bool) {
 	}
 
 	unit := matches[2]
-	var duration time.Duration
+	var scale time.Duration
 
 	switch {
 	case strings.HasPrefix(unit, "hour"), strings.HasPrefix(unit, "hr"):
-		duration = time.Duration(amount) * time.Hour
+		scale = time.Hour
 	case strings.HasPrefix(unit, "min"):
-		duration = time.Duration(amount) * time.Minute
+		scale = time.Minute
+	case strings.HasPrefix(unit, "s"):
+		scale = time.Second
 	default:
 		return time.Time{}, false
 	}
 
-	return now.Add(duration), true
+	// Reject amounts that would overflow time.Duration
+	if int64(amount) > math.MaxInt64/int64(scale) {
+		return time.Time{}, false
+	}
+
+	return now.Add(time.Duration(amount) * scale), true
 }
 
-// parseDayWithTime parses "today/tomorrow [at] HH:MM" format.
-var dayTimeRegex = regexp.MustCompile(`^(?:today|tomorrow)\s*(?:at\s+)?(\d{1,2}):(\d{2})(?::(\d{2}))?$`)
+// parseInDays parses "in X days/weeks [at TIME]". With a time, the result is
+// that wall-clock time on the offset date; without one, now's time of day is
+// kept.
+var inDaysRegex = regexp.MustCompile(`^in\s+(\d+)\s*(days?|weeks?)(?:\s+(?:at\s+)?(.+))?$`)
 
-func parseDayWithTime(input string, now time.Time, daysOffset int, loc *time.Location) (time.Time, bool) {
-	matches := dayTimeRegex.FindStringSubmatch(input)
+// maxInDays caps "in X days/weeks" offsets at roughly a century.
+const maxInDays = 36525
+
+func parseInDays(input string, now time.Time) (time.Time, bool) {
+	matches := inDaysRegex.FindStringSubmatch(input)
 	if matches == nil {
 		return time.Time{}, false
 	}
 
-	hour, err := strconv.Atoi(matches[1])
-	if err != nil || hour < 0 || hour > 23 {
+	days, err := strconv.Atoi(matches[1])
+	if err != nil {
+		return time.Time{}, false
+	}
+	if strings.HasPrefix(matches[2], "week") {
+		if days > maxInDays/7 {
+			return time.Time{}, false
+		}
+		days *= 7
+	}
+	if days > maxInDays {
 		return time.Time{}, false
 	}
 
-	minute, err := strconv.Atoi(matches[2])
-	if err != nil || minute < 0 || minute > 59 {
+	target := now.AddDate(0, 0, days)
+	if matches[3] == "" {
+		return target, true
+	}
+
+	hour, minute, second, ok := parseClock(matches[3])
+	if !ok {
 		return time.Time{}, false
 	}
+	return time.Date(target.Year(), target.Month(), target.Day(),
+		hour, minute, second, 0, now.Location()), true
+}
 
-	second := 0
-	if matches[3] != "" {
-		second, err = strconv.Atoi(matches[3])
-		if err != nil || second < 0 || second > 59 {
-			return time.Time{}, false
-		}
+// parseDayWithTime parses "today/tomorrow [at] TIME" format, where TIME is
+// anything parseClock accepts, e.g. "14:00" or "2pm".
+var dayTimeRegex = regexp.MustCompile(`^(?:today|tomorrow)\s*(?:at\s+)?(.+)$`)
+
+func parseDayWithTime(input string, now time.Time, daysOffset int, loc *time.Location) (time.Time, bool) {
+	matches := dayTimeRegex.FindStringSubmatch(input)
+	if matches == nil {
+		return time.Time{}, false
+	}
+
+	hour, minute, second, ok := parseClock(matches[1])
+	if !ok {
+		return time.Time{}, false
 	}
 
 	targetDate := now.AddDate(0, 0, daysOffset)
@@ -175,37 +338,76 @@ func parseDayWithTime(input string, now time.Time, daysOffset int, loc *time.Loc
 		hour, minute, second, 0, loc), true
 }
 
-// parseTimeOnly attempts to parse time-only formats like "14:00" or "14:00:00".
-// Returns a time.Time for today at the specified time.
+// parseTimeOnly attempts to parse time-only formats like "14:00", "14:00:00"
+// or "2pm". Returns a time.Time for today at the specified time.
 var timeOnlyRegex = regexp.MustCompile(`^(\d{1,2}):(\d{2})(?::(\d{2}))?$`)
 
 func parseTimeOnly(input string, loc *time.Location) (time.Time, bool) {
+	hour, minute, second, ok := parseClock(input)
+	if !ok {
+		return time.Time{}, false
+	}
+
+	now := time.Now().In(loc)
+	return time.Date(now.Year(), now.Month(), now.Day(),
+		hour, minute, second, 0, loc), true
+}
+
+// parseClock parses a wall-clock time in 24-hour ("14:00", "14:00:00") or
+// 12-hour ("2pm", "9:30 am") form.
+func parseClock(input string) (hour, minute, second int, ok bool) {
 	matches := timeOnlyRegex.FindStringSubmatch(input)
 	if matches == nil {
-		return time.Time{}, false
+		hour, minute, ok := parseClock12(input)
+		return hour, minute, 0, ok
 	}
 
 	hour, err := strconv.Atoi(matches[1])
 	if err != nil || hour < 0 || hour > 23 {
-		return time.Time{}, false
+		return 0, 0, 0, false
 	}
 
-	minute, err := strconv.Atoi(matches[2])
+	minute, err = strconv.Atoi(matches[2])
 	if err != nil || minute < 0 || minute > 59 {
-		return time.Time{}, false
+		return 0, 0, 0, false
 	}
 
-	second := 0
 	if matches[3] != "" {
 		second, err = strconv.Atoi(matches[3])
 		if err != nil || second < 0 || second > 59 {
-			return time.Time{}, false
+			return 0, 0, 0, false
 		}
 	}
 
-	now := time.Now().In(loc)
-	return time.Date(now.Year(), now.Month(), now.Day(),
-		hour, minute, second, 0, loc), true
+	return hour, minute, second, true
+}
+
+// parseClock12 parses 12-hour clock times like "2pm" or "9:30 am".
+var clock12Regex = regexp.MustCompile(`^(\d{1,2})(?::(\d{2}))?\s*(am|pm)$`)
+
+func parseClock12(input string) (hour, minute int, ok bool) {
+	matches := clock12Regex.FindStringSubmatch(strings.ToLower(input))
+	if matches == nil {
+		return 0, 0, false
+	}
+
+	hour, err := strconv.Atoi(matches[1])
+	if err != nil || hour < 1 || hour > 12 {
+		return 0, 0, false
+	}
+
+	if matches[2] != "" {
+		minute, err = strconv.Atoi(matches[2])
+		if err != nil || minute > 59 {
+			return 0, 0, false
+		}
+	}
+
+	hour %= 12
+	if matches[3] == "pm" {
+		hour += 12
+	}
+	return hour, minute, true
 }
 
 // parseStandard uses the dateparse library to parse standard date/time formats.
@@ -249,6 +451,15 @@ func FormatTime(t time.Time) string {
 	return t.Format("Mon, Jan 2, 2006 at 3:04 PM MST")
 }
 
+// FormatTimeIn formats t for display in loc, e.g. the configured display
+// timezone. A nil loc leaves t in its own location.
+func FormatTimeIn(t time.Time, loc *time.Location) string {
+	if loc != nil {
+		t = t.In(loc)
+	}
+	return FormatTime(t)
+}
+
 // FormatTimeShort formats a time.Time value in a shorter format.
 func FormatTimeShort(t time.Time) string {
 	return t.Format("2006-01-02 15:04")
@@ -143,6 +143,21 @@ func TestParseTime_TimeOnly(t *testing.T) {
 			input:    "23:59",
 			wantHour: 23, wantMin: 59, wantSec: 0,
 		},
+		{
+			name:     "12-hour pm",
+			input:    "2pm",
+			wantHour: 14, wantMin: 0, wantSec: 0,
+		},
+		{
+			name:     "12-hour with minutes",
+			input:    "9:30 AM",
+			wantHour: 9, wantMin: 30, wantSec: 0,
+		},
+		{
+			name:     "12am is midnight",
+			input:    "12am",
+			wantHour: 0, wantMin: 0, wantSec: 0,
+		},
 	}
 
 	for _, tt := range tests {
@@ -179,6 +194,7 @@ func TestParseTime_RelativeFormats(t *testing.T) {
 		checkOffset bool // if true, check relative to now
 		hourOffset  int
 		minOffset   int
+		secOffset   int
 	}{
 		{
 			name:     "today 14:00",
@@ -204,6 +220,12 @@ func TestParseTime_RelativeFormats(t *testing.T) {
 			wantDay:  tomorrow.Day(),
 			wantHour: 9, wantMin: 0,
 		},
+		{
+			name:     "tomorrow at 2pm",
+			input:    "tomorrow at 2pm",
+			wantDay:  tomorrow.Day(),
+			wantHour: 14, wantMin: 0,
+		},
 		{
 			name:        "in 2 hours",
 			input:       "in 2 hours",
@@ -228,6 +250,18 @@ func TestParseTime_RelativeFormats(t *testing.T) {
 			checkOffset: true,
 			minOffset:   45,
 		},
+		{
+			name:        "in 90 seconds",
+			input:       "in 90 seconds",
+			checkOffset: true,
+			secOffset:   90,
+		},
+		{
+			name:        "in 30 secs",
+			input:       "in 30 secs",
+			checkOffset: true,
+			secOffset:   30,
+		},
 	}
 
 	for _, tt := range tests {
@@ -238,7 +272,9 @@ func TestParseTime_RelativeFormats(t *testing.T) {
 			}
 
 			if tt.checkOffset {
-				expected := now.Add(time.Duration(tt.hourOffset)*time.Hour + time.Duration(tt.minOffset)*time.Minute)
+				expected := now.Add(time.Duration(tt.hourOffset)*time.Hour +
+					time.Duration(tt.minOffset)*time.Minute +
+					time.Duration(tt.secOffset)*time.Second)
 				// Allow 2 second tolerance for test execution time
 				diff := got.Sub(expected)
 				if diff < -2*time.Second || diff > 2*time.Second {
@@ -257,6 +293,57 @@ func TestParseTime_RelativeFormats(t *testing.T) {
 	}
 }
 
+func TestParseTime_InDaysAtTime(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    string
+		days     int
+		wantHour int
+		wantMin  int
+	}{
+		{"days with 12-hour time", "in 3 days at 2pm", 3, 14, 0},
+		{"weeks with 24-hour time", "in 2 weeks at 09:00", 14, 9, 0},
+		{"single day without at", "in 1 day 17:45", 1, 17, 45},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			now := time.Now().In(time.UTC)
+			got, err := ParseTime(tt.input, "UTC")
+			if err != nil {
+				t.Fatalf("ParseTime() error = %v", err)
+			}
+
+			want := now.AddDate(0, 0, tt.days)
+			if got.Year() != want.Year() || got.Month() != want.Month() || got.Day() != want.Day() {
+				t.Errorf("ParseTime() date = %s, want %s", got.Format("2006-01-02"), want.Format("2006-01-02"))
+			}
+			if got.Hour() != tt.wantHour || got.Minute() != tt.wantMin || got.Second() != 0 {
+				t.Errorf("ParseTime() time = %s, want %02d:%02d:00", got.Format("15:04:05"), tt.wantHour, tt.wantMin)
+			}
+		})
+	}
+}
+
+func TestParseTime_InDaysKeepsTimeOfDay(t *testing.T) {
+	now := time.Now()
+	got, err := ParseTime("in 3 days", "")
+	if err != nil {
+		t.Fatalf("ParseTime() error = %v", err)
+	}
+
+	diff := got.Sub(now.AddDate(0, 0, 3))
+	if diff < -2*time.Second || diff > 2*time.Second {
+		t.Errorf("ParseTime() = %v, want approximately 3 days from now", got)
+	}
+}
+
+func TestParseInDuration_Overflow(t *testing.T) {
+	if _, ok := parseInDuration("in 9999999999999 hours", time.Now()); ok {
+		t.Error("parseInDuration() accepted an amount that overflows time.Duration")
+	}
+}
+
 func TestParseTime_Timezone(t *testing.T) {
 	tests := []struct {
 		name     string
@@ -466,6 +553,25 @@ func TestFormatTime(t *testing.T) {
 	}
 }
 
+func TestFormatTimeIn(t *testing.T) {
+	testTime := time.Date(2024, time.January, 15, 14, 30, 0, 0, time.UTC)
+
+	tokyo, err := time.LoadLocation("Asia/Tokyo")
+	if err != nil {
+		t.Fatalf("Failed to load location: %v", err)
+	}
+
+	got := FormatTimeIn(testTime, tokyo)
+	want := "Mon, Jan 15, 2024 at 11:30 PM JST"
+	if got != want {
+		t.Errorf("FormatTimeIn() = %q, want %q", got, want)
+	}
+
+	if got := FormatTimeIn(testTime, nil); got != FormatTime(testTime) {
+		t.Errorf("FormatTimeIn(nil) = %q, want %q", got, FormatTime(testTime))
+	}
+}
+
 func TestFormatTimeShort(t *testing.T) {
 	loc, _ := time.LoadLocation("UTC")
 	testTime := time.Date(2024, time.January, 15, 14, 30, 0, 0, loc)
@@ -499,3 +605,131 @@ func TestParseTimeOnly_InvalidTimes(t *testing.T) {
 		})
 	}
 }
+
+func TestParseDayOfMonth(t *testing.T) {
+	loc := time.UTC
+	now := time.Date(2024, time.January, 20, 12, 0, 0, 0, loc)
+
+	tests := []struct {
+		name  string
+		input string
+		want  time.Time
+	}{
+		{"future day this month", "on the 25th at 2pm", time.Date(2024, time.January, 25, 14, 0, 0, 0, loc)},
+		{"past day rolls to next month", "on the 15th at 2pm", time.Date(2024, time.February, 15, 14, 0, 0, 0, loc)},
+		{"without on or at", "the 3rd 09:30", time.Date(2024, time.February, 3, 9, 30, 0, 0, loc)},
+		{"later today", "on the 20th at 15:00", time.Date(2024, time.January, 20, 15, 0, 0, 0, loc)},
+		{"earlier today", "on the 20th at 9am", time.Date(2024, time.February, 20, 9, 0, 0, 0, loc)},
+		{"skips months without the day", "on the 30th at 10:00", time.Date(2024, time.January, 30, 10, 0, 0, 0, loc)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, ok := parseDayOfMonth(tt.input, now, loc)
+			if !ok {
+				t.Fatalf("parseDayOfMonth(%q) failed", tt.input)
+			}
+			if !got.Equal(tt.want) {
+				t.Errorf("parseDayOfMonth(%q) = %v, want %v", tt.input, got, tt.want)
+			}
+		})
+	}
+
+	// The 30th has passed in January and February has none
+	lateJanuary := time.Date(2024, time.January, 31, 12, 0, 0, 0, loc)
+	got, ok := parseDayOfMonth("on the 30th at 10:00", lateJanuary, loc)
+	if want := time.Date(2024, time.March, 30, 10, 0, 0, 0, loc); !ok || !got.Equal(want) {
+		t.Errorf("parseDayOfMonth() = %v, %v, want %v", got, ok, want)
+	}
+
+	for _, input := range []string{"on the 32nd at 10:00", "on the 0th at 10:00", "on the 15th", "on the 15th at noonish"} {
+		if _, ok := parseDayOfMonth(input, now, loc); ok {
+			t.Errorf("parseDayOfMonth(%q) should fail", input)
+		}
+	}
+}
+
+func TestParseTime_DayOfMonth(t *testing.T) {
+	got, err := ParseTime("on the 15th at 2pm", "UTC")
+	if err != nil {
+		t.Fatalf("ParseTime() error = %v", err)
+	}
+	if got.Day() != 15 || got.Hour() != 14 || got.Before(time.Now()) {
+		t.Errorf("ParseTime() = %v, want the next 15th at 14:00", got)
+	}
+}
+
+func TestParseDate(t *testing.T) {
+	// Wednesday, March 6, 2024
+	now := time.Date(2024, 3, 6, 15, 30, 0, 0, time.UTC)
+	day := func(m time.Month, d int) time.Time { return time.Date(2024, m, d, 0, 0, 0, 0, time.UTC) }
+
+	tests := []struct {
+		input string
+		want  time.Time
+	}{
+		{"today", day(3, 6)},
+		{"Tomorrow", day(3, 7)},
+		{"yesterday", day(3, 5)},
+		{"friday", day(3, 8)},
+		{"wednesday", day(3, 6)},
+		{"next wednesday", day(3, 13)},
+		{"mon", day(3, 11)},
+		{"+3d", day(3, 9)},
+		{"+2w", day(3, 20)},
+		{"2024-07-01", day(7, 1)},
+		{"2024-07-01 14:00", day(7, 1)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			got, err := parseDate(tt.input, "UTC", now)
+			if err != nil {
+				t.Fatalf("parseDate(%q) error = %v", tt.input, err)
+			}
+			if !got.Equal(tt.want) {
+				t.Errorf("parseDate(%q) = %v, want %v", tt.input, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseDate_Invalid(t *testing.T) {
+	now := time.Date(2024, 3, 6, 15, 30, 0, 0, time.UTC)
+
+	for _, input := range []string{"", "someday", "next someday", "+d"} {
+		if _, err := parseDate(input, "UTC", now); err == nil {
+			t.Errorf("parseDate(%q) expected error, got nil", input)
+		}
+	}
+}
+
+func TestParseEndTime(t *testing.T) {
+	berlin, _ := time.LoadLocation("Europe/Berlin")
+	start := time.Date(2030, 3, 4, 14, 0, 0, 0, berlin)
+
+	tests := []struct {
+		input string
+		want  time.Time
+	}{
+		{"16:30", time.Date(2030, 3, 4, 16, 30, 0, 0, berlin)},
+		{"5pm", time.Date(2030, 3, 4, 17, 0, 0, 0, berlin)},
+		{"2030-03-05 09:00", time.Date(2030, 3, 5, 9, 0, 0, 0, berlin)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			got, err := ParseEndTime(tt.input, start, "Europe/Berlin")
+			if err != nil {
+				t.Fatalf("ParseEndTime() error = %v", err)
+			}
+			if !got.Equal(tt.want) {
+				t.Errorf("ParseEndTime() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+
+	if _, err := ParseEndTime("whenever", start, "Europe/Berlin"); err == nil {
+		t.Error("ParseEndTime(whenever) expected error, got nil")
+	}
+}
@@ -0,0 +1,51 @@
+package calendar
+
+import (
+	"context"
+	"fmt"
+)
+
+// DeleteEvent removes an event from the calendar. An event that doesn't
+// exist or was already deleted is reported as ErrEventNotFound.
+func (c *Client) DeleteEvent(ctx context.Context, eventID string) error {
+	return c.DeleteEventIfMatch(ctx, eventID, "")
+}
+
+// DeleteEventIfMatch is like DeleteEvent, but when etag is set it only
+// deletes the event if it is still at that version, e.g. the one shown to
+// the user, and returns ErrConflict otherwise.
+func (c *Client) DeleteEventIfMatch(ctx context.Context, eventID, etag string) error {
+	if eventID == "" {
+		return fmt.Errorf("%w: event ID is required", ErrInvalidEventTime)
+	}
+
+	ctx, cancel := c.withRequestTimeout(ctx)
+	defer cancel()
+
+	call := c.service.Events.Delete(c.calendarID, eventID).Context(ctx)
+	transient := isTransient
+	if etag != "" {
+		call.Header().Set("If-Match", etag)
+		// As with conditional patches, a retry after a server error could
+		// fail with 412 because the first attempt went through
+		transient = isRateLimit
+	}
+
+	// An event gone on a retry was deleted by an earlier attempt whose
+	// response was lost
+	attempt := 0
+	err := c.retryIf(ctx, true, transient, func() error {
+		attempt++
+		err := call.Do()
+		if err != nil && attempt > 1 && isGone(err) {
+			return nil
+		}
+		return err
+	})
+	if err != nil {
+		return wrapEventErrorAs(err, eventID, ErrEventDeleteFailed)
+	}
+
+	c.InvalidateCache()
+	return nil
+}
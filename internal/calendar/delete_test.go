@@ -0,0 +1,115 @@
+package calendar
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"google.golang.org/api/calendar/v3"
+)
+
+func TestDeleteEvent(t *testing.T) {
+	client, fake := newFakeClient(t)
+	fake.addEvent(&calendar.Event{Id: "doomed", Summary: "Old meeting"})
+
+	if err := client.DeleteEvent(context.Background(), "doomed"); err != nil {
+		t.Fatalf("DeleteEvent() error = %v", err)
+	}
+
+	if _, err := client.GetEvent(context.Background(), "doomed"); !errors.Is(err, ErrEventNotFound) {
+		t.Errorf("GetEvent() after delete error = %v, want ErrEventNotFound", err)
+	}
+}
+
+func TestDeleteEvent_NotFound(t *testing.T) {
+	tests := []struct {
+		name  string
+		setup func(t *testing.T, client *Client, fake *fakeCalendar)
+	}{
+		{
+			name:  "never existed (404)",
+			setup: func(t *testing.T, client *Client, fake *fakeCalendar) {},
+		},
+		{
+			name: "already deleted (410)",
+			setup: func(t *testing.T, client *Client, fake *fakeCalendar) {
+				fake.addEvent(&calendar.Event{Id: "gone"})
+				if err := client.DeleteEvent(context.Background(), "gone"); err != nil {
+					t.Fatalf("first DeleteEvent() error = %v", err)
+				}
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client, fake := newFakeClient(t)
+			tt.setup(t, client, fake)
+
+			err := client.DeleteEvent(context.Background(), "gone")
+			if !errors.Is(err, ErrEventNotFound) {
+				t.Errorf("DeleteEvent() error = %v, want ErrEventNotFound", err)
+			}
+		})
+	}
+}
+
+func TestDeleteEvent_EmptyID(t *testing.T) {
+	client, fake := newFakeClient(t)
+
+	if err := client.DeleteEvent(context.Background(), ""); err == nil {
+		t.Error("DeleteEvent(\"\") expected error, got nil")
+	}
+	if n := fake.requestCount("DELETE", ""); n != 0 {
+		t.Errorf("made %d DELETE requests, want 0", n)
+	}
+}
+
+func TestDeleteEventIfMatch(t *testing.T) {
+	client, fake := newFakeClient(t)
+	ctx := context.Background()
+	stored := fake.addEvent(&calendar.Event{Id: "doomed", Summary: "Old meeting"})
+	shown := stored.Etag
+
+	fake.addEvent(&calendar.Event{Id: "doomed", Summary: "Moved meeting"})
+	if err := client.DeleteEventIfMatch(ctx, "doomed", shown); !errors.Is(err, ErrConflict) {
+		t.Fatalf("DeleteEventIfMatch() with stale ETag error = %v, want ErrConflict", err)
+	}
+	if _, ok := fake.events["doomed"]; !ok {
+		t.Fatal("event deleted despite the conflict")
+	}
+
+	current := fake.events["doomed"].Etag
+	if err := client.DeleteEventIfMatch(ctx, "doomed", current); err != nil {
+		t.Fatalf("DeleteEventIfMatch() with current ETag error = %v", err)
+	}
+}
+
+func TestDeleteEvent_ResponseLost(t *testing.T) {
+	client, fake := newFakeClient(t)
+	fake.addEvent(&calendar.Event{Id: "doomed", Summary: "Old meeting"})
+	fake.timeoutDeletes = 1
+
+	// The retry finds the event gone (404) because the first attempt
+	// deleted it
+	if err := client.DeleteEvent(context.Background(), "doomed"); err != nil {
+		t.Fatalf("DeleteEvent() error = %v, want success", err)
+	}
+	if n := fake.requestCount("DELETE", ""); n != 2 {
+		t.Errorf("made %d DELETE requests, want 2", n)
+	}
+}
+
+func TestDeleteEventIfMatch_NotRetriedAfterServerError(t *testing.T) {
+	client, fake := newFakeClient(t)
+	stored := fake.addEvent(&calendar.Event{Id: "doomed", Summary: "Old meeting"})
+	fake.timeoutDeletes = 1
+
+	err := client.DeleteEventIfMatch(context.Background(), "doomed", stored.Etag)
+	if err == nil || errors.Is(err, ErrConflict) {
+		t.Errorf("DeleteEventIfMatch() error = %v, want the timeout rather than a conflict", err)
+	}
+	if n := fake.requestCount("DELETE", ""); n != 1 {
+		t.Errorf("conditional delete sent %d times after a 504, want once", n)
+	}
+}
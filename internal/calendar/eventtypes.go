@@ -0,0 +1,107 @@
+package calendar
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"google.golang.org/api/calendar/v3"
+)
+
+// ErrInvalidEventType is returned for an unknown event type or auto-decline
+// mode, or settings an event type doesn't allow.
+var ErrInvalidEventType = errors.New("invalid event type")
+
+// Event types for EventParams.EventType.
+const (
+	EventTypeDefault = "default"
+
+	// EventTypeFocusTime blocks time for focused work. Google Calendar can
+	// decline meetings during it and mutes chat notifications.
+	EventTypeFocusTime = "focusTime"
+
+	// EventTypeOutOfOffice marks time away. Google Calendar can decline
+	// meetings during it with a message.
+	EventTypeOutOfOffice = "outOfOffice"
+)
+
+// Auto-decline modes for EventParams.AutoDecline.
+const (
+	AutoDeclineNone = "declineNone"
+	AutoDeclineAll  = "declineAllConflictingInvitations"
+	AutoDeclineNew  = "declineOnlyNewConflictingInvitations"
+)
+
+// ParseAutoDecline parses an auto-decline mode as given to --decline:
+// "none", "all" (existing and new conflicting invitations) or "new" (only
+// invitations received afterwards), in any case.
+func ParseAutoDecline(input string) (string, error) {
+	switch strings.ToLower(strings.TrimSpace(input)) {
+	case "none":
+		return AutoDeclineNone, nil
+	case "all":
+		return AutoDeclineAll, nil
+	case "new":
+		return AutoDeclineNew, nil
+	default:
+		return "", fmt.Errorf("%w: auto-decline mode %q (use all, new or none)", ErrInvalidEventType, input)
+	}
+}
+
+// validateEventType checks the event type settings of params.
+func validateEventType(params EventParams) error {
+	switch params.EventType {
+	case "", EventTypeDefault:
+		if params.AutoDecline != "" || params.DeclineMessage != "" {
+			return fmt.Errorf("%w: only focus time and out-of-office events can decline invitations", ErrInvalidEventType)
+		}
+		return nil
+	case EventTypeFocusTime, EventTypeOutOfOffice:
+	default:
+		return fmt.Errorf("%w: %q", ErrInvalidEventType, params.EventType)
+	}
+
+	switch params.AutoDecline {
+	case "", AutoDeclineNone, AutoDeclineAll, AutoDeclineNew:
+	default:
+		return fmt.Errorf("%w: auto-decline mode %q", ErrInvalidEventType, params.AutoDecline)
+	}
+
+	// The API rejects these for focus time and out-of-office events
+	if params.AllDay {
+		return fmt.Errorf("%w: %s events can't be all-day; give start and end times", ErrInvalidEventType, params.EventType)
+	}
+	if len(params.Attendees) > 0 {
+		return fmt.Errorf("%w: %s events can't have guests", ErrInvalidEventType, params.EventType)
+	}
+	if params.Transparent {
+		return fmt.Errorf("%w: %s events always block time", ErrInvalidEventType, params.EventType)
+	}
+	return nil
+}
+
+// setEventType sets the event type and its auto-decline settings on event.
+func setEventType(event *calendar.Event, params EventParams) {
+	event.EventType = params.EventType
+
+	switch params.EventType {
+	case EventTypeFocusTime:
+		event.FocusTimeProperties = &calendar.EventFocusTimeProperties{
+			AutoDeclineMode: params.AutoDecline,
+			DeclineMessage:  params.DeclineMessage,
+		}
+	case EventTypeOutOfOffice:
+		event.OutOfOfficeProperties = &calendar.EventOutOfOfficeProperties{
+			AutoDeclineMode: params.AutoDecline,
+			DeclineMessage:  params.DeclineMessage,
+		}
+	}
+}
+
+// eventType returns the event's type, or "" for a regular event.
+func eventType(event *calendar.Event) string {
+	if event.EventType == EventTypeDefault {
+		return ""
+	}
+	return event.EventType
+}
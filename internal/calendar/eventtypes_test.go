@@ -0,0 +1,109 @@
+package calendar
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestParseAutoDecline(t *testing.T) {
+	tests := []struct {
+		input   string
+		want    string
+		wantErr bool
+	}{
+		{input: "all", want: AutoDeclineAll},
+		{input: " New ", want: AutoDeclineNew},
+		{input: "none", want: AutoDeclineNone},
+		{input: "some", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			got, err := ParseAutoDecline(tt.input)
+			if tt.wantErr {
+				if !errors.Is(err, ErrInvalidEventType) {
+					t.Errorf("ParseAutoDecline(%q) error = %v, want ErrInvalidEventType", tt.input, err)
+				}
+				return
+			}
+			if err != nil || got != tt.want {
+				t.Errorf("ParseAutoDecline(%q) = %q, %v; want %q", tt.input, got, err, tt.want)
+			}
+		})
+	}
+}
+
+func TestCreateEvent_EventTypes(t *testing.T) {
+	client, fake := newFakeClient(t)
+	ctx := context.Background()
+	start := time.Date(2024, 1, 15, 9, 0, 0, 0, time.UTC)
+
+	focus, err := client.CreateEvent(ctx, EventParams{
+		Title:          "Focus time",
+		StartTime:      start,
+		Duration:       2 * time.Hour,
+		EventType:      EventTypeFocusTime,
+		AutoDecline:    AutoDeclineNew,
+		DeclineMessage: "Heads down",
+	})
+	if err != nil {
+		t.Fatalf("CreateEvent(focus) error = %v", err)
+	}
+	sent := fake.inserted[0]
+	if sent.EventType != EventTypeFocusTime || sent.FocusTimeProperties == nil ||
+		sent.FocusTimeProperties.AutoDeclineMode != AutoDeclineNew || sent.FocusTimeProperties.DeclineMessage != "Heads down" {
+		t.Errorf("sent focus event = %+v, %+v", sent, sent.FocusTimeProperties)
+	}
+	if focus.EventType != EventTypeFocusTime {
+		t.Errorf("EventType = %q, want focusTime", focus.EventType)
+	}
+
+	if _, err := client.CreateEvent(ctx, EventParams{
+		Title:       "Out of office",
+		StartTime:   start.AddDate(0, 0, 1),
+		Duration:    24 * time.Hour,
+		EventType:   EventTypeOutOfOffice,
+		AutoDecline: AutoDeclineAll,
+	}); err != nil {
+		t.Fatalf("CreateEvent(ooo) error = %v", err)
+	}
+	sent = fake.inserted[1]
+	if sent.OutOfOfficeProperties == nil || sent.OutOfOfficeProperties.AutoDeclineMode != AutoDeclineAll || sent.FocusTimeProperties != nil {
+		t.Errorf("sent out-of-office properties = %+v", sent.OutOfOfficeProperties)
+	}
+}
+
+func TestValidateEventParams_EventType(t *testing.T) {
+	base := EventParams{
+		Title:     "Focus time",
+		StartTime: time.Date(2024, 1, 15, 9, 0, 0, 0, time.UTC),
+		Duration:  time.Hour,
+		EventType: EventTypeFocusTime,
+	}
+
+	tests := []struct {
+		name   string
+		modify func(p *EventParams)
+	}{
+		{name: "unknown type", modify: func(p *EventParams) { p.EventType = "birthday" }},
+		{name: "unknown decline mode", modify: func(p *EventParams) { p.AutoDecline = "declineSome" }},
+		{name: "guests", modify: func(p *EventParams) { p.Attendees = []string{"a@example.com"} }},
+		{name: "all day", modify: func(p *EventParams) { p.AllDay = true }},
+		{name: "transparent", modify: func(p *EventParams) { p.Transparent = true }},
+		{name: "decline on regular event", modify: func(p *EventParams) { p.EventType = ""; p.AutoDecline = AutoDeclineAll }},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			params := base
+			tt.modify(&params)
+			if err := ValidateEventParams(params); !errors.Is(err, ErrInvalidEventType) {
+				t.Errorf("ValidateEventParams() error = %v, want ErrInvalidEventType", err)
+			}
+		})
+	}
+
+	if err := ValidateEventParams(base); err != nil {
+		t.Errorf("ValidateEventParams() of valid focus time error = %v", err)
+	}
+}
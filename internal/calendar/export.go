@@ -0,0 +1,218 @@
+package calendar
+
+import (
+	"fmt"
+	"io"
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+// DayGroup holds the events starting on one calendar day.
+type DayGroup struct {
+	// Date is midnight of the day in the grouping location.
+	Date   time.Time
+	Events []*EventResult
+}
+
+// GroupByDay groups events by their start date in loc, in order of first
+// appearance. Events should already be sorted by start time. A nil loc uses
+// each event's own location.
+func GroupByDay(events []*EventResult, loc *time.Location) []DayGroup {
+	var groups []DayGroup
+	index := make(map[string]int)
+
+	for _, event := range events {
+		start := event.StartTime
+		if loc != nil {
+			start = start.In(loc)
+		}
+		key := start.Format(allDayLayout)
+
+		i, ok := index[key]
+		if !ok {
+			i = len(groups)
+			index[key] = i
+			groups = append(groups, DayGroup{
+				Date: time.Date(start.Year(), start.Month(), start.Day(), 0, 0, 0, 0, start.Location()),
+			})
+		}
+		groups[i].Events = append(groups[i].Events, event)
+	}
+
+	return groups
+}
+
+// ExportMarkdown writes events as markdown for pasting into notes: one
+// heading per day followed by a table of times, linked titles and locations.
+func ExportMarkdown(w io.Writer, events []*EventResult, loc *time.Location) error {
+	for i, group := range GroupByDay(events, loc) {
+		if i > 0 {
+			if _, err := fmt.Fprintln(w); err != nil {
+				return err
+			}
+		}
+
+		if _, err := fmt.Fprintf(w, "## %s\n\n| Time | Event | Location |\n| --- | --- | --- |\n", group.Date.Format("Mon, Jan 2, 2006")); err != nil {
+			return err
+		}
+
+		for _, event := range group.Events {
+			start, end := event.StartTime, event.EndTime
+			if loc != nil {
+				start, end = start.In(loc), end.In(loc)
+			}
+
+			title := escapeMarkdownCell(event.Title)
+			if event.Link != "" {
+				title = fmt.Sprintf("[%s](%s)", title, event.Link)
+			}
+
+			_, err := fmt.Fprintf(w, "| %s–%s | %s | %s |\n",
+				start.Format("15:04"), end.Format("15:04"), title, escapeMarkdownCell(event.Location))
+			if err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// markdownCellEscaper escapes text for use inside a markdown table cell.
+var markdownCellEscaper = strings.NewReplacer(
+	`|`, `\|`,
+	"\n", " ",
+	`[`, `\[`,
+	`]`, `\]`,
+)
+
+// escapeMarkdownCell escapes characters that would break a table cell or a
+// link around the text.
+func escapeMarkdownCell(s string) string {
+	return markdownCellEscaper.Replace(s)
+}
+
+// icsLineLimit is the longest content line, in octets, before it is folded.
+const icsLineLimit = 75
+
+// ExportICS writes events as an iCalendar (.ics) file that other calendar
+// applications can import. Recurring events are written as the individual
+// instances ListEvents returns. The output can be read back with ParseICS.
+func ExportICS(w io.Writer, events []*EventResult) error {
+	return exportICS(w, events, time.Now())
+}
+
+// exportICS is ExportICS with the DTSTAMP given by stamp.
+func exportICS(w io.Writer, events []*EventResult, stamp time.Time) error {
+	lines := []string{
+		"BEGIN:VCALENDAR",
+		"VERSION:2.0",
+		"PRODID:-//calgo//calgo//EN",
+		"CALSCALE:GREGORIAN",
+		"METHOD:PUBLISH",
+	}
+	for _, event := range events {
+		lines = append(lines, icsEventLines(event, stamp)...)
+	}
+	lines = append(lines, "END:VCALENDAR")
+
+	for _, line := range lines {
+		if _, err := io.WriteString(w, foldICSLine(line)+"\r\n"); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// icsEventLines returns the unfolded content lines of one VEVENT.
+func icsEventLines(event *EventResult, stamp time.Time) []string {
+	const utcLayout = "20060102T150405Z"
+
+	lines := []string{
+		"BEGIN:VEVENT",
+		"UID:" + event.ID + "@google.com",
+		"DTSTAMP:" + stamp.UTC().Format(utcLayout),
+	}
+	if event.AllDay {
+		lines = append(lines,
+			"DTSTART;VALUE=DATE:"+event.StartTime.Format("20060102"),
+			"DTEND;VALUE=DATE:"+event.EndTime.Format("20060102"),
+		)
+	} else {
+		lines = append(lines,
+			"DTSTART:"+event.StartTime.UTC().Format(utcLayout),
+			"DTEND:"+event.EndTime.UTC().Format(utcLayout),
+		)
+	}
+
+	lines = append(lines, "SUMMARY:"+escapeICSText(event.Title))
+	if event.Description != "" {
+		lines = append(lines, "DESCRIPTION:"+escapeICSText(event.Description))
+	}
+	if event.Location != "" {
+		lines = append(lines, "LOCATION:"+escapeICSText(event.Location))
+	}
+	if event.Link != "" {
+		lines = append(lines, "URL:"+event.Link)
+	}
+	if event.Organizer != "" {
+		lines = append(lines, "ORGANIZER:mailto:"+event.Organizer)
+	}
+	for _, email := range event.Attendees {
+		lines = append(lines, "ATTENDEE:mailto:"+email)
+	}
+	if event.Transparent {
+		lines = append(lines, "TRANSP:TRANSPARENT")
+	}
+	if event.Priority > 0 {
+		// iCalendar ranks 1 (highest) to 9; see ParseICS for the reverse
+		lines = append(lines, fmt.Sprintf("PRIORITY:%d", event.Priority*2-1))
+	}
+
+	created := event.OriginalCreated
+	if created.IsZero() {
+		created = event.Created
+	}
+	if !created.IsZero() {
+		lines = append(lines, "CREATED:"+created.UTC().Format(utcLayout))
+	}
+
+	return append(lines, "END:VEVENT")
+}
+
+// icsTextEscaper escapes TEXT values; the reverse of unescapeICSText.
+var icsTextEscaper = strings.NewReplacer(
+	`\`, `\\`,
+	";", `\;`,
+	",", `\,`,
+	"\r\n", `\n`,
+	"\n", `\n`,
+)
+
+// escapeICSText escapes s for use as an iCalendar TEXT value.
+func escapeICSText(s string) string {
+	return icsTextEscaper.Replace(s)
+}
+
+// foldICSLine splits a content line longer than icsLineLimit octets into
+// continuation lines starting with a space, without splitting characters.
+func foldICSLine(line string) string {
+	if len(line) <= icsLineLimit {
+		return line
+	}
+
+	var b strings.Builder
+	limit := icsLineLimit
+	for len(line) > limit {
+		cut := limit
+		for cut > 0 && !utf8.RuneStart(line[cut]) {
+			cut--
+		}
+		b.WriteString(line[:cut] + "\r\n ")
+		line = line[cut:]
+		// Continuation lines lose one octet to the leading space
+		limit = icsLineLimit - 1
+	}
+	b.WriteString(line)
+	return b.String()
+}
@@ -0,0 +1,160 @@
+package calendar
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"time"
+	"unicode/utf8"
+)
+
+func TestGroupByDay(t *testing.T) {
+	day := time.Date(2024, 1, 15, 0, 0, 0, 0, time.UTC)
+	events := []*EventResult{
+		{ID: "a", StartTime: day.Add(9 * time.Hour)},
+		{ID: "b", StartTime: day.Add(23 * time.Hour)},
+		{ID: "c", StartTime: day.Add(33 * time.Hour)},
+	}
+
+	groups := GroupByDay(events, time.UTC)
+	if len(groups) != 2 {
+		t.Fatalf("Expected 2 groups, got %d", len(groups))
+	}
+	if len(groups[0].Events) != 2 || len(groups[1].Events) != 1 {
+		t.Errorf("Unexpected group sizes %d and %d", len(groups[0].Events), len(groups[1].Events))
+	}
+
+	// 23:00 UTC is already the next day in Tokyo
+	tokyo, err := time.LoadLocation("Asia/Tokyo")
+	if err != nil {
+		t.Fatalf("Failed to load location: %v", err)
+	}
+	groups = GroupByDay(events, tokyo)
+	if len(groups) != 2 || len(groups[1].Events) != 2 {
+		t.Errorf("Expected events b and c together in Tokyo, got %+v", groups)
+	}
+}
+
+func TestExportMarkdown(t *testing.T) {
+	day := time.Date(2024, 1, 15, 0, 0, 0, 0, time.UTC)
+	events := []*EventResult{
+		{
+			Title:     "Design | Review",
+			StartTime: day.Add(9 * time.Hour),
+			EndTime:   day.Add(10 * time.Hour),
+			Location:  "Room 1",
+			Link:      "https://calendar.google.com/event?eid=abc",
+		},
+		{
+			Title:     "Retro",
+			StartTime: day.Add(33 * time.Hour),
+			EndTime:   day.Add(34 * time.Hour),
+		},
+	}
+
+	var buf bytes.Buffer
+	if err := ExportMarkdown(&buf, events, time.UTC); err != nil {
+		t.Fatalf("ExportMarkdown() error = %v", err)
+	}
+	out := buf.String()
+
+	for _, want := range []string{
+		"## Mon, Jan 15, 2024\n",
+		"## Tue, Jan 16, 2024\n",
+		"| Time | Event | Location |\n| --- | --- | --- |\n",
+		"| 09:00–10:00 | [Design \\| Review](https://calendar.google.com/event?eid=abc) | Room 1 |\n",
+		"| 09:00–10:00 | Retro |  |\n",
+	} {
+		if !strings.Contains(out, want) {
+			t.Errorf("ExportMarkdown() output missing %q\n%s", want, out)
+		}
+	}
+
+	if strings.Contains(out, "Design | Review") {
+		t.Error("pipe in title was not escaped")
+	}
+}
+
+func TestExportICS(t *testing.T) {
+	day := time.Date(2024, 1, 15, 0, 0, 0, 0, time.UTC)
+	events := []*EventResult{
+		{
+			ID:          "abc123",
+			Title:       "Design; Review",
+			StartTime:   day.Add(9 * time.Hour),
+			EndTime:     day.Add(10 * time.Hour),
+			Description: "Agenda:\nslides, demo",
+			Attendees:   []string{"alice@example.com"},
+			Priority:    MaxPriority,
+		},
+		{
+			ID:          "def456",
+			Title:       "Offsite",
+			AllDay:      true,
+			StartTime:   day,
+			EndTime:     day.AddDate(0, 0, 3),
+			Transparent: true,
+		},
+	}
+	stamp := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+
+	var buf bytes.Buffer
+	if err := exportICS(&buf, events, stamp); err != nil {
+		t.Fatalf("exportICS() error = %v", err)
+	}
+	out := buf.String()
+
+	for _, want := range []string{
+		"BEGIN:VCALENDAR\r\nVERSION:2.0\r\n",
+		"UID:abc123@google.com\r\nDTSTAMP:20240101T120000Z\r\nDTSTART:20240115T090000Z\r\nDTEND:20240115T100000Z\r\n",
+		`SUMMARY:Design\; Review` + "\r\n",
+		`DESCRIPTION:Agenda:\nslides\, demo` + "\r\n",
+		"ATTENDEE:mailto:alice@example.com\r\n",
+		"PRIORITY:1\r\n",
+		"DTSTART;VALUE=DATE:20240115\r\nDTEND;VALUE=DATE:20240118\r\n",
+		"TRANSP:TRANSPARENT\r\n",
+	} {
+		if !strings.Contains(out, want) {
+			t.Errorf("output missing %q:\n%s", want, out)
+		}
+	}
+	if !strings.HasSuffix(out, "END:VEVENT\r\nEND:VCALENDAR\r\n") {
+		t.Errorf("output doesn't end the calendar:\n%s", out)
+	}
+
+	parsed, err := ParseICS(strings.NewReader(out), "UTC")
+	if err != nil {
+		t.Fatalf("ParseICS() of export error = %v", err)
+	}
+	if len(parsed) != 2 {
+		t.Fatalf("round trip got %d events, want 2", len(parsed))
+	}
+	timed, allDay := parsed[0].Params, parsed[1].Params
+	if timed.Title != "Design; Review" || timed.Description != "Agenda:\nslides, demo" || timed.Duration != time.Hour || timed.Priority != MaxPriority {
+		t.Errorf("timed event round trip = %+v", timed)
+	}
+	if !allDay.AllDay || !allDay.EndDate.Equal(day.AddDate(0, 0, 2)) || !allDay.Transparent {
+		t.Errorf("all-day event round trip = %+v", allDay)
+	}
+}
+
+func TestFoldICSLine(t *testing.T) {
+	short := "SUMMARY:Standup"
+	if got := foldICSLine(short); got != short {
+		t.Errorf("foldICSLine(%q) = %q, want unchanged", short, got)
+	}
+
+	long := "DESCRIPTION:" + strings.Repeat("é", 80)
+	folded := foldICSLine(long)
+	for i, line := range strings.Split(folded, "\r\n") {
+		if len(line) > icsLineLimit {
+			t.Errorf("line %d is %d octets, want at most %d", i, len(line), icsLineLimit)
+		}
+		if !utf8.ValidString(line) {
+			t.Errorf("line %d splits a character: %q", i, line)
+		}
+	}
+	if got := strings.ReplaceAll(folded, "\r\n ", ""); got != long {
+		t.Errorf("unfolded = %q, want %q", got, long)
+	}
+}
@@ -0,0 +1,103 @@
+package calendar
+
+import (
+	"strings"
+	"time"
+	"unicode"
+)
+
+// maxTimeWords is the longest run of words ExtractTime tries as a single
+// time expression, e.g. "January 15, 2024 14:00".
+const maxTimeWords = 5
+
+// timeConnectors are words dropped from the remainder when they directly
+// precede an extracted time, as in "lunch at 1pm".
+var timeConnectors = map[string]bool{
+	"at": true,
+	"on": true,
+	"@":  true,
+}
+
+// ExtractTime finds the first time expression anywhere in input, such as the
+// "1pm" in "lunch at 1pm with Bob", using the same formats as ParseTime. It
+// returns the input with the expression (and a preceding "at" or "on")
+// removed. At each position the longest matching run of words wins, so
+// "tomorrow at 9:00" is taken whole rather than as "9:00". The error is only
+// set for an invalid timezone.
+func ExtractTime(input, timezone string) (remainder string, t time.Time, found bool, err error) {
+	loc, err := getLocation(timezone)
+	if err != nil {
+		return input, time.Time{}, false, err
+	}
+
+	words := strings.Fields(input)
+	for start := range words {
+		for end := min(len(words), start+maxTimeWords); end > start; end-- {
+			span := strings.Join(words[start:end], " ")
+			if !isTimeCandidate(span) {
+				continue
+			}
+
+			parsed, ok := parseTimeExpression(span, loc)
+			if !ok {
+				continue
+			}
+
+			before := words[:start]
+			if len(before) > 0 && timeConnectors[strings.ToLower(before[len(before)-1])] {
+				before = before[:len(before)-1]
+			}
+			rest := append(append([]string{}, before...), words[end:]...)
+			return strings.Join(rest, " "), parsed, true, nil
+		}
+	}
+
+	return input, time.Time{}, false, nil
+}
+
+// parseTimeExpression tries the ParseTime formats on s in loc.
+func parseTimeExpression(s string, loc *time.Location) (time.Time, bool) {
+	if t, ok := parseRelative(s, loc); ok {
+		return t, true
+	}
+	if t, ok := parseTimeOnly(s, loc); ok {
+		return t, true
+	}
+	if t, err := parseStandard(s, loc); err == nil {
+		return t, true
+	}
+	return time.Time{}, false
+}
+
+// timeEndWords are non-numeric words a time expression may end with, as in
+// "9:30 am" or "in 2 hours".
+var timeEndWords = map[string]bool{
+	"am": true, "pm": true,
+	"hour": true, "hours": true, "hr": true, "hrs": true,
+	"minute": true, "minutes": true, "min": true, "mins": true,
+	"second": true, "seconds": true, "sec": true, "secs": true,
+	"day": true, "days": true, "week": true, "weeks": true,
+}
+
+// isTimeCandidate filters out spans that can't be a time expression. Every
+// supported format contains a digit, and bare numbers like "2" or "2024"
+// are more likely counts than times. Spans must also end in a number or a
+// time word, since dateparse tolerates trailing words and would otherwise
+// swallow text following the time.
+func isTimeCandidate(span string) bool {
+	hasDigit, allDigits := false, true
+	for _, r := range span {
+		if unicode.IsDigit(r) {
+			hasDigit = true
+		} else {
+			allDigits = false
+		}
+	}
+	if !hasDigit || allDigits {
+		return false
+	}
+
+	words := strings.Fields(span)
+	last := words[len(words)-1]
+	return strings.IndexFunc(last, unicode.IsDigit) >= 0 || timeEndWords[strings.ToLower(last)]
+}
@@ -0,0 +1,66 @@
+package calendar
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestExtractTime(t *testing.T) {
+	now := time.Now().In(time.UTC)
+	tomorrow := now.AddDate(0, 0, 1)
+
+	tests := []struct {
+		name          string
+		input         string
+		wantRemainder string
+		wantDay       int
+		wantHour      int
+		wantMin       int
+	}{
+		{"leading", "14:00 design review", "design review", now.Day(), 14, 0},
+		{"middle", "lunch at 1pm with Bob", "lunch with Bob", now.Day(), 13, 0},
+		{"trailing", "call with Alice tomorrow at 9:30", "call with Alice", tomorrow.Day(), 9, 30},
+		{"full date", "dentist on 2024-03-05 10:15 downtown", "dentist downtown", 5, 10, 15},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			remainder, got, found, err := ExtractTime(tt.input, "UTC")
+			if err != nil {
+				t.Fatalf("ExtractTime() error = %v", err)
+			}
+			if !found {
+				t.Fatalf("ExtractTime(%q) found no time", tt.input)
+			}
+			if remainder != tt.wantRemainder {
+				t.Errorf("ExtractTime() remainder = %q, want %q", remainder, tt.wantRemainder)
+			}
+			if got.Day() != tt.wantDay || got.Hour() != tt.wantHour || got.Minute() != tt.wantMin {
+				t.Errorf("ExtractTime() time = %v, want day %d %02d:%02d", got, tt.wantDay, tt.wantHour, tt.wantMin)
+			}
+		})
+	}
+}
+
+func TestExtractTime_NotFound(t *testing.T) {
+	input := "buy 2 coffees for the team"
+
+	remainder, _, found, err := ExtractTime(input, "UTC")
+	if err != nil {
+		t.Fatalf("ExtractTime() error = %v", err)
+	}
+	if found {
+		t.Errorf("ExtractTime(%q) unexpectedly found a time", input)
+	}
+	if remainder != input {
+		t.Errorf("ExtractTime() remainder = %q, want input unchanged", remainder)
+	}
+}
+
+func TestExtractTime_InvalidTimezone(t *testing.T) {
+	_, _, _, err := ExtractTime("lunch at 1pm", "Invalid/Zone")
+	if !errors.Is(err, ErrInvalidTimezone) {
+		t.Errorf("ExtractTime() error = %v, want ErrInvalidTimezone", err)
+	}
+}
@@ -0,0 +1,791 @@
+package calendar
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"google.golang.org/api/calendar/v3"
+	"google.golang.org/api/option"
+)
+
+// fakeCalendar is an in-memory stand-in for the Google Calendar events API.
+// It serves the subset of endpoints the Client uses over an httptest server.
+type fakeCalendar struct {
+	mu       sync.Mutex
+	events   map[string]*calendar.Event
+	order    []string
+	nextID   int
+	inserted []*calendar.Event
+	requests []string
+	queries  []url.Values
+
+	// failListPage makes the Nth list page (1-based) return a server error.
+	failListPage int
+
+	// delay is added before every response, to simulate a slow server. Set
+	// it before issuing requests.
+	delay time.Duration
+
+	// timeoutInserts makes the next N inserts store the event but respond
+	// with a gateway timeout, as if the response had been lost.
+	timeoutInserts int
+
+	// timeoutDeletes makes the next N deletes remove the event, forgetting
+	// it so it is reported as not found, but respond with a gateway
+	// timeout, as if the response had been lost.
+	timeoutDeletes int
+
+	// rejectInsertPrefix makes inserts of events whose title starts with
+	// it fail with a server error, without storing them.
+	rejectInsertPrefix string
+
+	// rejectPatches holds status codes the next patches fail with, one per
+	// patch, without changing the event.
+	rejectPatches []int
+
+	// patches records the body of every PATCH request, keyed by field.
+	patches []map[string]json.RawMessage
+
+	// otherBusy holds the free/busy periods of calendars other than
+	// "primary", whose busy time is derived from the stored events.
+	otherBusy map[string][]*calendar.TimePeriod
+
+	// deleted holds the IDs of deleted events, which are reported as gone.
+	deleted map[string]bool
+
+	// calendars is the user's calendar list, paginated like events.
+	calendars []*calendar.CalendarListEntry
+
+	// seq counts changes to events; changed maps each event ID, including
+	// deleted ones, to the seq of its last change. Sync tokens are seqs.
+	seq     int
+	changed map[string]int
+
+	// expireSyncTokens makes every incremental sync fail with 410 Gone.
+	expireSyncTokens bool
+
+	// channels holds the open watch channels by ID. Channels expire after
+	// channelTTL when it is set, regardless of the requested ttl.
+	channels   map[string]*calendar.Channel
+	channelTTL time.Duration
+
+	// dateOffset shifts the Date header of every response from the real
+	// time, to simulate a skewed local clock.
+	dateOffset time.Duration
+}
+
+// newFakeClient starts a fake calendar server and returns a Client wired to it.
+func newFakeClient(t *testing.T) (*Client, *fakeCalendar) {
+	t.Helper()
+
+	fake := &fakeCalendar{events: make(map[string]*calendar.Event)}
+	server := httptest.NewServer(http.HandlerFunc(fake.serveHTTP))
+	t.Cleanup(server.Close)
+
+	service, err := calendar.NewService(context.Background(),
+		option.WithHTTPClient(server.Client()),
+		option.WithEndpoint(server.URL+"/"),
+	)
+	if err != nil {
+		t.Fatalf("Failed to create calendar service: %v", err)
+	}
+
+	client := newClientWithService(service, "primary")
+	client.Retry.BaseDelay = time.Millisecond
+	client.Batch.Pause = time.Millisecond
+	return client, fake
+}
+
+// addEvent seeds the fake with an existing event, assigning an ID if needed.
+func (f *fakeCalendar) addEvent(event *calendar.Event) *calendar.Event {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	return f.store(event)
+}
+
+// requestCount returns how many requests matched the given method and path prefix.
+func (f *fakeCalendar) requestCount(method, pathPrefix string) int {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	count := 0
+	for _, r := range f.requests {
+		if strings.HasPrefix(r, method+" "+pathPrefix) {
+			count++
+		}
+	}
+	return count
+}
+
+func (f *fakeCalendar) store(event *calendar.Event) *calendar.Event {
+	if event.Id == "" {
+		f.nextID++
+		event.Id = fmt.Sprintf("event-%d", f.nextID)
+	}
+	if event.HtmlLink == "" {
+		event.HtmlLink = "https://www.google.com/calendar/event?eid=" + event.Id
+	}
+	if _, exists := f.events[event.Id]; !exists {
+		f.order = append(f.order, event.Id)
+	}
+	f.events[event.Id] = event
+	f.markChanged(event.Id)
+	event.Etag = fmt.Sprintf("%q", strconv.Itoa(f.seq))
+	return event
+}
+
+// remove deletes a stored event, remembering its ID as deleted.
+func (f *fakeCalendar) remove(id string) {
+	delete(f.events, id)
+	for i, existing := range f.order {
+		if existing == id {
+			f.order = append(f.order[:i], f.order[i+1:]...)
+			break
+		}
+	}
+	if f.deleted == nil {
+		f.deleted = make(map[string]bool)
+	}
+	f.deleted[id] = true
+	f.markChanged(id)
+}
+
+// markChanged records a change to the event with the given ID for syncs.
+func (f *fakeCalendar) markChanged(id string) {
+	if f.changed == nil {
+		f.changed = make(map[string]int)
+	}
+	f.seq++
+	f.changed[id] = f.seq
+}
+
+// syncChanges returns the events changed after the sync token, in order of
+// change, with deleted events as cancelled stubs.
+func (f *fakeCalendar) syncChanges(token int) []*calendar.Event {
+	ids := make([]string, 0, len(f.changed))
+	for id, seq := range f.changed {
+		if seq > token {
+			ids = append(ids, id)
+		}
+	}
+	sort.Slice(ids, func(i, j int) bool { return f.changed[ids[i]] < f.changed[ids[j]] })
+
+	changes := make([]*calendar.Event, 0, len(ids))
+	for _, id := range ids {
+		if event, ok := f.events[id]; ok {
+			changes = append(changes, event)
+		} else {
+			changes = append(changes, &calendar.Event{Id: id, Status: "cancelled"})
+		}
+	}
+	return changes
+}
+
+func (f *fakeCalendar) serveHTTP(w http.ResponseWriter, r *http.Request) {
+	if f.delay > 0 {
+		time.Sleep(f.delay)
+	}
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	f.requests = append(f.requests, r.Method+" "+r.URL.Path)
+	if f.dateOffset != 0 {
+		w.Header().Set("Date", time.Now().Add(f.dateOffset).UTC().Format(http.TimeFormat))
+	}
+	f.queries = append(f.queries, r.URL.Query())
+
+	// Paths look like /calendars/{calendarId}/events[/{eventId}]
+	if r.URL.Path == "/freeBusy" && r.Method == http.MethodPost {
+		f.serveFreeBusy(w, r)
+		return
+	}
+
+	if r.URL.Path == "/users/me/calendarList" && r.Method == http.MethodGet {
+		f.serveCalendarList(w, r.URL.Query())
+		return
+	}
+
+	if r.URL.Path == "/colors" && r.Method == http.MethodGet {
+		writeFakeJSON(w, fakeColors())
+		return
+	}
+
+	if r.URL.Path == "/channels/stop" && r.Method == http.MethodPost {
+		f.serveStopChannel(w, r)
+		return
+	}
+
+	parts := strings.Split(strings.Trim(r.URL.Path, "/"), "/")
+	if parts[0] == "calendars" && len(parts) <= 2 {
+		f.serveCalendar(w, r, parts[1:])
+		return
+	}
+	if len(parts) < 3 || parts[0] != "calendars" || parts[2] != "events" {
+		writeFakeError(w, http.StatusNotFound, "notFound")
+		return
+	}
+
+	switch {
+	case len(parts) == 4 && parts[3] == "watch" && r.Method == http.MethodPost:
+		f.serveWatch(w, r, parts[1])
+
+	case len(parts) == 4 && parts[3] == "quickAdd" && r.Method == http.MethodPost:
+		// Real quick add parses the text; the fake only takes it as the
+		// title of an event at a fixed time
+		f.nextID++
+		writeFakeJSON(w, f.store(&calendar.Event{
+			Id:      fmt.Sprintf("quick%d", f.nextID),
+			Summary: r.URL.Query().Get("text"),
+			Start:   &calendar.EventDateTime{DateTime: "2024-01-15T09:00:00Z"},
+			End:     &calendar.EventDateTime{DateTime: "2024-01-15T10:00:00Z"},
+		}))
+
+	case len(parts) == 3 && r.Method == http.MethodPost:
+		var event calendar.Event
+		if err := json.NewDecoder(r.Body).Decode(&event); err != nil {
+			writeFakeError(w, http.StatusBadRequest, "badRequest")
+			return
+		}
+		if _, exists := f.events[event.Id]; event.Id != "" && exists {
+			writeFakeError(w, http.StatusConflict, "duplicate")
+			return
+		}
+		if f.rejectInsertPrefix != "" && strings.HasPrefix(event.Summary, f.rejectInsertPrefix) {
+			writeFakeError(w, http.StatusInternalServerError, "backendError")
+			return
+		}
+		f.inserted = append(f.inserted, &event)
+		stored := f.store(&event)
+		if f.timeoutInserts > 0 {
+			f.timeoutInserts--
+			writeFakeError(w, http.StatusGatewayTimeout, "timeout")
+			return
+		}
+		if stored.ConferenceData != nil && stored.ConferenceData.CreateRequest != nil {
+			stored.ConferenceData.EntryPoints = []*calendar.EntryPoint{
+				{EntryPointType: "video", Uri: "https://meet.google.com/" + stored.Id},
+			}
+		}
+		writeFakeJSON(w, stored)
+
+	case len(parts) == 3 && r.Method == http.MethodGet:
+		f.serveList(w, r.URL.Query())
+
+	case len(parts) == 4 && r.Method == http.MethodGet:
+		event, ok := f.lookup(parts[3])
+		if !ok {
+			writeFakeError(w, http.StatusNotFound, "notFound")
+			return
+		}
+		writeFakeJSON(w, event)
+
+	case len(parts) == 5 && parts[4] == "instances" && r.Method == http.MethodGet:
+		event, ok := f.events[parts[3]]
+		if !ok {
+			writeFakeError(w, http.StatusNotFound, "notFound")
+			return
+		}
+		f.serveInstances(w, event, r.URL.Query())
+
+	case len(parts) == 4 && r.Method == http.MethodDelete:
+		id := parts[3]
+		if f.deleted[id] {
+			writeFakeError(w, http.StatusGone, "deleted")
+			return
+		}
+		event, ok := f.lookup(id)
+		if !ok {
+			writeFakeError(w, http.StatusNotFound, "notFound")
+			return
+		}
+		if !fakeETagMatches(r, event) {
+			writeFakeError(w, http.StatusPreconditionFailed, "conditionNotMet")
+			return
+		}
+		f.remove(id)
+		if f.timeoutDeletes > 0 {
+			f.timeoutDeletes--
+			delete(f.deleted, id)
+			writeFakeError(w, http.StatusGatewayTimeout, "timeout")
+			return
+		}
+		w.WriteHeader(http.StatusNoContent)
+
+	case len(parts) == 4 && r.Method == http.MethodPatch:
+		if len(f.rejectPatches) > 0 {
+			code := f.rejectPatches[0]
+			f.rejectPatches = f.rejectPatches[1:]
+			writeFakeError(w, code, "rejected")
+			return
+		}
+		event, ok := f.lookup(parts[3])
+		if !ok {
+			writeFakeError(w, http.StatusNotFound, "notFound")
+			return
+		}
+		if !fakeETagMatches(r, event) {
+			writeFakeError(w, http.StatusPreconditionFailed, "conditionNotMet")
+			return
+		}
+		patched, patch, err := patchFakeEvent(event, r)
+		if err != nil {
+			writeFakeError(w, http.StatusBadRequest, "badRequest")
+			return
+		}
+		f.patches = append(f.patches, patch)
+		writeFakeJSON(w, f.store(patched))
+
+	default:
+		writeFakeError(w, http.StatusNotFound, "notFound")
+	}
+}
+
+// fakeColors returns the event palette as the Colors API does.
+func fakeColors() *calendar.Colors {
+	backgrounds := []string{
+		"#a4bdfc", "#7ae7bf", "#dbadff", "#ff887c", "#fbd75b", "#ffb878",
+		"#46d6db", "#e1e1e1", "#5484ed", "#51b749", "#dc2127",
+	}
+	colors := &calendar.Colors{Event: make(map[string]calendar.ColorDefinition)}
+	for i, background := range backgrounds {
+		colors.Event[strconv.Itoa(i+1)] = calendar.ColorDefinition{Background: background, Foreground: "#1d1d1d"}
+	}
+	return colors
+}
+
+// fakeETagMatches reports whether the request's If-Match header, if any,
+// matches the event's current ETag.
+func fakeETagMatches(r *http.Request, event *calendar.Event) bool {
+	etag := r.Header.Get("If-Match")
+	return etag == "" || etag == event.Etag
+}
+
+// serveWatch opens a watch channel on the calendar.
+func (f *fakeCalendar) serveWatch(w http.ResponseWriter, r *http.Request, calendarID string) {
+	var channel calendar.Channel
+	if err := json.NewDecoder(r.Body).Decode(&channel); err != nil || channel.Id == "" || channel.Address == "" {
+		writeFakeError(w, http.StatusBadRequest, "badRequest")
+		return
+	}
+	if _, exists := f.channels[channel.Id]; exists {
+		writeFakeError(w, http.StatusBadRequest, "channelIdNotUnique")
+		return
+	}
+
+	ttl := f.channelTTL
+	if ttl == 0 {
+		seconds, _ := strconv.Atoi(channel.Params["ttl"])
+		ttl = time.Duration(seconds) * time.Second
+	}
+	channel.ResourceId = "resource-" + calendarID
+	channel.Expiration = time.Now().Add(ttl).UnixMilli()
+
+	if f.channels == nil {
+		f.channels = make(map[string]*calendar.Channel)
+	}
+	f.channels[channel.Id] = &channel
+	writeFakeJSON(w, &channel)
+}
+
+// serveStopChannel closes a watch channel.
+func (f *fakeCalendar) serveStopChannel(w http.ResponseWriter, r *http.Request) {
+	var channel calendar.Channel
+	if err := json.NewDecoder(r.Body).Decode(&channel); err != nil {
+		writeFakeError(w, http.StatusBadRequest, "badRequest")
+		return
+	}
+	open, ok := f.channels[channel.Id]
+	if !ok || open.ResourceId != channel.ResourceId {
+		writeFakeError(w, http.StatusNotFound, "notFound")
+		return
+	}
+	delete(f.channels, channel.Id)
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// openChannels returns the open watch channels.
+func (f *fakeCalendar) openChannels() []*calendar.Channel {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	var channels []*calendar.Channel
+	for _, channel := range f.channels {
+		channels = append(channels, channel)
+	}
+	return channels
+}
+
+// serveFreeBusy reports the opaque stored events as the busy time of
+// "primary", otherBusy for the calendars it holds, and notFound for the rest.
+func (f *fakeCalendar) serveFreeBusy(w http.ResponseWriter, r *http.Request) {
+	var req calendar.FreeBusyRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeFakeError(w, http.StatusBadRequest, "badRequest")
+		return
+	}
+	timeMin, _ := time.Parse(time.RFC3339, req.TimeMin)
+	timeMax, _ := time.Parse(time.RFC3339, req.TimeMax)
+
+	resp := &calendar.FreeBusyResponse{Calendars: make(map[string]calendar.FreeBusyCalendar)}
+	for _, item := range req.Items {
+		if busy, ok := f.otherBusy[item.Id]; ok {
+			resp.Calendars[item.Id] = calendar.FreeBusyCalendar{Busy: busy}
+			continue
+		}
+		if item.Id != "primary" {
+			resp.Calendars[item.Id] = calendar.FreeBusyCalendar{
+				Errors: []*calendar.Error{{Domain: "global", Reason: "notFound"}},
+			}
+			continue
+		}
+
+		var busy []*calendar.TimePeriod
+		for _, id := range f.order {
+			event := f.events[id]
+			start, end := fakeEventBounds(event)
+			if event.Transparency == "transparent" || !end.After(timeMin) || !start.Before(timeMax) {
+				continue
+			}
+			busy = append(busy, &calendar.TimePeriod{
+				Start: start.Format(time.RFC3339),
+				End:   end.Format(time.RFC3339),
+			})
+		}
+		resp.Calendars[item.Id] = calendar.FreeBusyCalendar{Busy: busy}
+	}
+	writeFakeJSON(w, resp)
+}
+
+// serveCalendar handles inserting, patching and deleting calendars, keeping
+// the calendar list in step.
+func (f *fakeCalendar) serveCalendar(w http.ResponseWriter, r *http.Request, id []string) {
+	index := -1
+	if len(id) == 1 {
+		for i, entry := range f.calendars {
+			if entry.Id == id[0] {
+				index = i
+			}
+		}
+		if index < 0 {
+			writeFakeError(w, http.StatusNotFound, "notFound")
+			return
+		}
+	}
+
+	switch {
+	case len(id) == 0 && r.Method == http.MethodPost:
+		var cal calendar.Calendar
+		if err := json.NewDecoder(r.Body).Decode(&cal); err != nil || cal.Summary == "" {
+			writeFakeError(w, http.StatusBadRequest, "badRequest")
+			return
+		}
+		f.nextID++
+		cal.Id = fmt.Sprintf("cal-%d@group.calendar.google.com", f.nextID)
+		f.calendars = append(f.calendars, &calendar.CalendarListEntry{
+			Id: cal.Id, Summary: cal.Summary, TimeZone: cal.TimeZone, AccessRole: "owner",
+		})
+		writeFakeJSON(w, &cal)
+
+	case index >= 0 && r.Method == http.MethodPatch:
+		var cal calendar.Calendar
+		if err := json.NewDecoder(r.Body).Decode(&cal); err != nil {
+			writeFakeError(w, http.StatusBadRequest, "badRequest")
+			return
+		}
+		entry := f.calendars[index]
+		if cal.Summary != "" {
+			entry.Summary = cal.Summary
+		}
+		writeFakeJSON(w, &calendar.Calendar{Id: entry.Id, Summary: entry.Summary, TimeZone: entry.TimeZone})
+
+	case index >= 0 && r.Method == http.MethodDelete:
+		f.calendars = append(f.calendars[:index], f.calendars[index+1:]...)
+		w.WriteHeader(http.StatusNoContent)
+
+	default:
+		writeFakeError(w, http.StatusNotFound, "notFound")
+	}
+}
+
+// serveCalendarList returns the calendar list, paginated by maxResults with
+// the page token holding the next offset.
+func (f *fakeCalendar) serveCalendarList(w http.ResponseWriter, query url.Values) {
+	offset, _ := strconv.Atoi(query.Get("pageToken"))
+	pageSize, _ := strconv.Atoi(query.Get("maxResults"))
+	if pageSize <= 0 {
+		pageSize = 100
+	}
+
+	end := min(offset+pageSize, len(f.calendars))
+	page := &calendar.CalendarList{Items: f.calendars[offset:end]}
+	if end < len(f.calendars) {
+		page.NextPageToken = strconv.Itoa(end)
+	}
+	writeFakeJSON(w, page)
+}
+
+// serveList returns stored events overlapping [timeMin, timeMax) in
+// insertion order, paginated by maxResults with the page token holding the
+// next offset.
+func (f *fakeCalendar) serveList(w http.ResponseWriter, query url.Values) {
+	timeMin, _ := time.Parse(time.RFC3339, query.Get("timeMin"))
+	timeMax, _ := time.Parse(time.RFC3339, query.Get("timeMax"))
+
+	var matched []*calendar.Event
+	if token := query.Get("syncToken"); token != "" {
+		seq, err := strconv.Atoi(token)
+		if err != nil || f.expireSyncTokens {
+			writeFakeError(w, http.StatusGone, "fullSyncRequired")
+			return
+		}
+		f.servePage(w, query, f.syncChanges(seq))
+		return
+	}
+
+	for _, id := range f.order {
+		event := f.events[id]
+		start, end := fakeEventBounds(event)
+		if !timeMin.IsZero() && !end.After(timeMin) {
+			continue
+		}
+		if !timeMax.IsZero() && !start.Before(timeMax) {
+			continue
+		}
+		if q := strings.ToLower(query.Get("q")); q != "" && !fakeEventContains(event, q) {
+			continue
+		}
+		if !fakeEventHasProperties(event, query["privateExtendedProperty"]) {
+			continue
+		}
+		if event.Status == "cancelled" && query.Get("showDeleted") != "true" {
+			continue
+		}
+		matched = append(matched, event)
+	}
+	f.servePage(w, query, matched)
+}
+
+// fakeEventHasProperties reports whether the event has every "key=value"
+// private extended property in filters.
+func fakeEventHasProperties(event *calendar.Event, filters []string) bool {
+	for _, filter := range filters {
+		key, value, _ := strings.Cut(filter, "=")
+		if event.ExtendedProperties == nil {
+			return false
+		}
+		if got, ok := event.ExtendedProperties.Private[key]; !ok || got != value {
+			return false
+		}
+	}
+	return true
+}
+
+// servePage writes the page of matched selected by the query, setting a
+// sync token on the last page.
+func (f *fakeCalendar) servePage(w http.ResponseWriter, query url.Values, matched []*calendar.Event) {
+	offset, _ := strconv.Atoi(query.Get("pageToken"))
+	pageSize, _ := strconv.Atoi(query.Get("maxResults"))
+	if pageSize <= 0 {
+		pageSize = len(matched) + 1
+	}
+
+	if f.failListPage > 0 && offset/pageSize+1 == f.failListPage {
+		writeFakeError(w, http.StatusInternalServerError, "backendError")
+		return
+	}
+
+	end := offset + pageSize
+	if end > len(matched) {
+		end = len(matched)
+	}
+
+	result := &calendar.Events{Items: matched[offset:end]}
+	if end < len(matched) {
+		result.NextPageToken = strconv.Itoa(end)
+	} else {
+		result.NextSyncToken = strconv.Itoa(f.seq)
+	}
+	writeFakeJSON(w, result)
+}
+
+// fakeInstances expands the first RRULE of event into up to limit
+// instances. Only FREQ=DAILY and FREQ=WEEKLY with INTERVAL and COUNT are
+// understood, which is enough for the tests. A non-recurring event is its
+// own only instance.
+// serveInstances lists the occurrences of a recurring event within the
+// query's range, with changed occurrences as stored and cancelled ones left
+// out.
+func (f *fakeCalendar) serveInstances(w http.ResponseWriter, event *calendar.Event, query url.Values) {
+	timeMin, _ := time.Parse(time.RFC3339, query.Get("timeMin"))
+	timeMax, _ := time.Parse(time.RFC3339, query.Get("timeMax"))
+	maxResults, _ := strconv.Atoi(query.Get("maxResults"))
+
+	limit := maxResults
+	if limit <= 0 {
+		limit = 1000
+	}
+
+	var items []*calendar.Event
+	for _, instance := range fakeInstances(event, limit) {
+		if stored, ok := f.events[instance.Id]; ok {
+			instance = stored
+		}
+		if f.deleted[instance.Id] || instance.Status == "cancelled" {
+			continue
+		}
+		start, end := fakeEventBounds(instance)
+		if (!timeMin.IsZero() && !end.After(timeMin)) || (!timeMax.IsZero() && !start.Before(timeMax)) {
+			continue
+		}
+		items = append(items, instance)
+	}
+	writeFakeJSON(w, &calendar.Events{Items: items})
+}
+
+// lookup returns the stored event with the given ID. An unchanged occurrence
+// of a stored recurring event, whose ID is the series ID and its start time,
+// is stored as an exception on first access, as if it had always been.
+func (f *fakeCalendar) lookup(id string) (*calendar.Event, bool) {
+	if event, ok := f.events[id]; ok {
+		return event, true
+	}
+	if f.deleted[id] {
+		return nil, false
+	}
+
+	seriesID, _, ok := strings.Cut(id, "_")
+	series, exists := f.events[seriesID]
+	if !ok || !exists {
+		return nil, false
+	}
+	for _, instance := range fakeInstances(series, 1000) {
+		if instance.Id == id {
+			return f.store(instance), true
+		}
+	}
+	return nil, false
+}
+
+func fakeInstances(event *calendar.Event, limit int) []*calendar.Event {
+	var parts map[string]string
+	for _, line := range event.Recurrence {
+		if rule, ok := strings.CutPrefix(line, "RRULE:"); ok {
+			parts = parseRRULEParts(rule)
+			break
+		}
+	}
+	if parts == nil {
+		return []*calendar.Event{event}
+	}
+
+	step := 24 * time.Hour
+	if parts["FREQ"] == "WEEKLY" {
+		step = 7 * 24 * time.Hour
+	}
+	if interval, err := strconv.Atoi(parts["INTERVAL"]); err == nil {
+		step *= time.Duration(interval)
+	}
+	if count, err := strconv.Atoi(parts["COUNT"]); err == nil && (limit <= 0 || count < limit) {
+		limit = count
+	}
+
+	start, end := fakeEventBounds(event)
+	var instances []*calendar.Event
+	for i := 0; i < limit; i++ {
+		offset := time.Duration(i) * step
+		instanceStart := start.Add(offset)
+		instances = append(instances, &calendar.Event{
+			Id:               event.Id + "_" + instanceStart.UTC().Format("20060102T150405Z"),
+			RecurringEventId: event.Id,
+			Summary:          event.Summary,
+			Start:            &calendar.EventDateTime{DateTime: instanceStart.Format(time.RFC3339)},
+			End:              &calendar.EventDateTime{DateTime: end.Add(offset).Format(time.RFC3339)},
+		})
+	}
+	return instances
+}
+
+// fakeEventContains reports whether an event's text fields contain q, a
+// rough stand-in for the API's free-text search.
+func fakeEventContains(event *calendar.Event, q string) bool {
+	fields := []string{event.Summary, event.Description, event.Location}
+	for _, attendee := range event.Attendees {
+		fields = append(fields, attendee.Email)
+	}
+	for _, field := range fields {
+		if strings.Contains(strings.ToLower(field), q) {
+			return true
+		}
+	}
+	return false
+}
+
+// fakeEventBounds returns the start and end of a timed or all-day event.
+func fakeEventBounds(event *calendar.Event) (time.Time, time.Time) {
+	parse := func(dt *calendar.EventDateTime) time.Time {
+		if dt == nil {
+			return time.Time{}
+		}
+		if dt.DateTime != "" {
+			t, _ := time.Parse(time.RFC3339, dt.DateTime)
+			return t
+		}
+		t, _ := time.Parse("2006-01-02", dt.Date)
+		return t
+	}
+	return parse(event.Start), parse(event.End)
+}
+
+// patchFakeEvent applies the top-level fields of a PATCH body to an event,
+// returning the patched event and the decoded body.
+func patchFakeEvent(event *calendar.Event, r *http.Request) (*calendar.Event, map[string]json.RawMessage, error) {
+	var patch map[string]json.RawMessage
+	if err := json.NewDecoder(r.Body).Decode(&patch); err != nil {
+		return nil, nil, err
+	}
+
+	data, err := json.Marshal(event)
+	if err != nil {
+		return nil, nil, err
+	}
+	var merged map[string]json.RawMessage
+	if err := json.Unmarshal(data, &merged); err != nil {
+		return nil, nil, err
+	}
+	for key, value := range patch {
+		merged[key] = value
+	}
+
+	data, err = json.Marshal(merged)
+	if err != nil {
+		return nil, nil, err
+	}
+	var patched calendar.Event
+	if err := json.Unmarshal(data, &patched); err != nil {
+		return nil, nil, err
+	}
+	return &patched, patch, nil
+}
+
+func writeFakeJSON(w http.ResponseWriter, v interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(v)
+}
+
+func writeFakeError(w http.ResponseWriter, code int, reason string) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(code)
+	fmt.Fprintf(w, `{"error":{"code":%d,"message":"%s","errors":[{"reason":"%s"}]}}`, code, reason, reason)
+}
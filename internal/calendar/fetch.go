@@ -0,0 +1,107 @@
+package calendar
+
+import (
+	"context"
+	"fmt"
+	"sync"
+
+	"google.golang.org/api/calendar/v3"
+)
+
+// maxConcurrentFetches bounds the number of in-flight requests in GetEvents.
+const maxConcurrentFetches = 5
+
+// EventFetchOutcome is the per-ID outcome of GetEvents. Exactly one of Result
+// and Err is set.
+type EventFetchOutcome struct {
+	// Index is the position of the ID in the input.
+	Index int
+
+	EventID string
+	Result  *EventResult
+	Err     error
+}
+
+// GetEvent fetches a single event by ID. A missing event is reported as
+// ErrEventNotFound.
+func (c *Client) GetEvent(ctx context.Context, eventID string) (*EventResult, error) {
+	if eventID == "" {
+		return nil, fmt.Errorf("%w: event ID is required", ErrInvalidEventTime)
+	}
+
+	event, err := c.fetchEvent(ctx, eventID)
+	if err != nil {
+		return nil, err
+	}
+
+	return parseEventResult(event)
+}
+
+// fetchEvent fetches the raw API event, retrying transient failures.
+func (c *Client) fetchEvent(ctx context.Context, eventID string) (*calendar.Event, error) {
+	ctx, cancel := c.withRequestTimeout(ctx)
+	defer cancel()
+
+	var event *calendar.Event
+	err := c.retry(ctx, true, func() error {
+		var err error
+		event, err = c.service.Events.Get(c.calendarID, eventID).Context(ctx).Do()
+		return err
+	})
+	if err != nil {
+		return nil, wrapEventError(err, eventID)
+	}
+	return event, nil
+}
+
+// GetEvents fetches several events concurrently, returning one outcome per ID
+// in input order. Per-event failures, including missing events, are reported
+// in the outcomes rather than aborting the batch. The returned error is only
+// set when ctx is cancelled.
+func (c *Client) GetEvents(ctx context.Context, ids []string) ([]EventFetchOutcome, error) {
+	outcomes := make([]EventFetchOutcome, len(ids))
+	sem := make(chan struct{}, maxConcurrentFetches)
+	var wg sync.WaitGroup
+
+	for i, id := range ids {
+		select {
+		case sem <- struct{}{}:
+		case <-ctx.Done():
+			wg.Wait()
+			return outcomes, ctx.Err()
+		}
+
+		wg.Add(1)
+		go func(i int, id string) {
+			defer wg.Done()
+			defer func() { <-sem }()
+
+			result, err := c.GetEvent(ctx, id)
+			outcomes[i] = EventFetchOutcome{
+				Index:   i,
+				EventID: id,
+				Result:  result,
+				Err:     err,
+			}
+		}(i, id)
+	}
+
+	wg.Wait()
+	return outcomes, ctx.Err()
+}
+
+// CheckAccess verifies that the configured calendar can be read by fetching
+// at most one event. It doesn't modify anything.
+func (c *Client) CheckAccess(ctx context.Context) error {
+	ctx, cancel := c.withRequestTimeout(ctx)
+	defer cancel()
+
+	err := c.retry(ctx, true, func() error {
+		_, err := c.service.Events.List(c.calendarID).MaxResults(1).Context(ctx).Do()
+		return err
+	})
+	if err != nil {
+		return wrapAPIErrorAs(err, ErrEventListFailed)
+	}
+	return nil
+}
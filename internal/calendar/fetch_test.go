@@ -0,0 +1,85 @@
+package calendar
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"google.golang.org/api/calendar/v3"
+)
+
+func TestGetEvents_MixedResults(t *testing.T) {
+	client, fake := newFakeClient(t)
+
+	for _, id := range []string{"one", "three"} {
+		fake.addEvent(&calendar.Event{
+			Id:      id,
+			Summary: "Event " + id,
+			Start:   &calendar.EventDateTime{DateTime: "2024-01-15T14:00:00Z"},
+			End:     &calendar.EventDateTime{DateTime: "2024-01-15T15:00:00Z"},
+		})
+	}
+
+	outcomes, err := client.GetEvents(context.Background(), []string{"one", "two", "three"})
+	if err != nil {
+		t.Fatalf("GetEvents() error = %v", err)
+	}
+
+	if len(outcomes) != 3 {
+		t.Fatalf("Expected 3 outcomes, got %d", len(outcomes))
+	}
+
+	successes := 0
+	for i, outcome := range outcomes {
+		if outcome.Index != i {
+			t.Errorf("outcome %d has Index %d", i, outcome.Index)
+		}
+		if outcome.Err == nil {
+			successes++
+			if outcome.Result.ID != outcome.EventID {
+				t.Errorf("outcome %d Result.ID = %q, want %q", i, outcome.Result.ID, outcome.EventID)
+			}
+		}
+	}
+
+	if successes != 2 {
+		t.Errorf("Expected 2 successes, got %d", successes)
+	}
+
+	if !errors.Is(outcomes[1].Err, ErrEventNotFound) {
+		t.Errorf("outcome for missing ID error = %v, want ErrEventNotFound", outcomes[1].Err)
+	}
+}
+
+func TestGetEvent_NotFound(t *testing.T) {
+	client, _ := newFakeClient(t)
+
+	_, err := client.GetEvent(context.Background(), "missing")
+	if !errors.Is(err, ErrEventNotFound) {
+		t.Errorf("GetEvent() error = %v, want ErrEventNotFound", err)
+	}
+}
+
+func TestGetEvents_CancelledContext(t *testing.T) {
+	client, _ := newFakeClient(t)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	_, err := client.GetEvents(ctx, []string{"one"})
+	if !errors.Is(err, context.Canceled) {
+		t.Errorf("GetEvents() error = %v, want context.Canceled", err)
+	}
+}
+
+func TestCheckAccess(t *testing.T) {
+	client, fake := newFakeClient(t)
+
+	if err := client.CheckAccess(context.Background()); err != nil {
+		t.Fatalf("CheckAccess() error = %v", err)
+	}
+
+	if fake.requestCount("POST", "") != 0 || fake.requestCount("PATCH", "") != 0 {
+		t.Error("CheckAccess() should not modify anything")
+	}
+}
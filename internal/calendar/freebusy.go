@@ -0,0 +1,88 @@
+package calendar
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"google.golang.org/api/calendar/v3"
+)
+
+// ErrFreeBusyFailed is returned when busy times can't be fetched for one of
+// the requested calendars.
+var ErrFreeBusyFailed = errors.New("failed to query free/busy")
+
+// FreeBusy returns the busy periods of each calendar in [start, end), keyed
+// by calendar ID and sorted by start time. Empty calendarIDs queries the
+// client's own calendar. Unlike ListEvents, this works for calendars whose
+// events the user can't read, such as a colleague's, as long as their
+// free/busy information is shared.
+func (c *Client) FreeBusy(ctx context.Context, start, end time.Time, calendarIDs []string) (map[string][]TimeSlot, error) {
+	if !end.After(start) {
+		return nil, fmt.Errorf("%w: end must be after start", ErrInvalidEventTime)
+	}
+	if len(calendarIDs) == 0 {
+		calendarIDs = []string{c.calendarID}
+	}
+
+	ctx, cancel := c.withRequestTimeout(ctx)
+	defer cancel()
+
+	req := &calendar.FreeBusyRequest{
+		TimeMin: start.Format(time.RFC3339),
+		TimeMax: end.Format(time.RFC3339),
+	}
+	for _, id := range calendarIDs {
+		req.Items = append(req.Items, &calendar.FreeBusyRequestItem{Id: id})
+	}
+
+	var resp *calendar.FreeBusyResponse
+	err := c.retry(ctx, true, func() error {
+		var err error
+		resp, err = c.service.Freebusy.Query(req).Context(ctx).Do()
+		return err
+	})
+	if err != nil {
+		return nil, wrapAPIErrorAs(err, ErrFreeBusyFailed)
+	}
+
+	busy := make(map[string][]TimeSlot, len(calendarIDs))
+	for _, id := range calendarIDs {
+		cal, ok := resp.Calendars[id]
+		if !ok {
+			return nil, fmt.Errorf("%w: no result for calendar %s", ErrFreeBusyFailed, id)
+		}
+		if len(cal.Errors) > 0 {
+			if cal.Errors[0].Reason == "notFound" {
+				return nil, fmt.Errorf("%w: %s", ErrCalendarNotFound, id)
+			}
+			return nil, fmt.Errorf("%w: calendar %s: %s", ErrFreeBusyFailed, id, cal.Errors[0].Reason)
+		}
+
+		slots := make([]TimeSlot, 0, len(cal.Busy))
+		for _, period := range cal.Busy {
+			slot, err := parseTimePeriod(period)
+			if err != nil {
+				return nil, err
+			}
+			slots = append(slots, slot)
+		}
+		busy[id] = slots
+	}
+
+	return busy, nil
+}
+
+// parseTimePeriod converts an API time period to a TimeSlot.
+func parseTimePeriod(period *calendar.TimePeriod) (TimeSlot, error) {
+	start, err := time.Parse(time.RFC3339, period.Start)
+	if err != nil {
+		return TimeSlot{}, fmt.Errorf("failed to parse busy start: %w", err)
+	}
+	end, err := time.Parse(time.RFC3339, period.End)
+	if err != nil {
+		return TimeSlot{}, fmt.Errorf("failed to parse busy end: %w", err)
+	}
+	return TimeSlot{Start: start, End: end}, nil
+}
@@ -0,0 +1,64 @@
+package calendar
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+
+	"google.golang.org/api/calendar/v3"
+)
+
+func TestFreeBusy(t *testing.T) {
+	client, fake := newFakeClient(t)
+	day := time.Date(2024, 1, 15, 0, 0, 0, 0, time.UTC)
+
+	addTimedEvent(fake, "Standup", day.Add(9*time.Hour), day.Add(10*time.Hour), false)
+	addTimedEvent(fake, "Focus (free)", day.Add(13*time.Hour), day.Add(15*time.Hour), true)
+	addTimedEvent(fake, "Tomorrow", day.Add(33*time.Hour), day.Add(34*time.Hour), false)
+	fake.otherBusy = map[string][]*calendar.TimePeriod{
+		"alice@example.com": {{Start: "2024-01-15T11:00:00Z", End: "2024-01-15T12:00:00Z"}},
+	}
+
+	busy, err := client.FreeBusy(context.Background(), day, day.AddDate(0, 0, 1), []string{"primary", "alice@example.com"})
+	if err != nil {
+		t.Fatalf("FreeBusy() error = %v", err)
+	}
+
+	want := map[string][]TimeSlot{
+		"primary":           {{Start: day.Add(9 * time.Hour), End: day.Add(10 * time.Hour)}},
+		"alice@example.com": {{Start: day.Add(11 * time.Hour), End: day.Add(12 * time.Hour)}},
+	}
+	if !reflect.DeepEqual(busy, want) {
+		t.Errorf("FreeBusy() = %v, want %v", busy, want)
+	}
+}
+
+func TestFreeBusy_DefaultsToClientCalendar(t *testing.T) {
+	client, _ := newFakeClient(t)
+	day := time.Date(2024, 1, 15, 0, 0, 0, 0, time.UTC)
+
+	busy, err := client.FreeBusy(context.Background(), day, day.AddDate(0, 0, 1), nil)
+	if err != nil {
+		t.Fatalf("FreeBusy() error = %v", err)
+	}
+	if slots, ok := busy["primary"]; !ok || len(slots) != 0 {
+		t.Errorf("FreeBusy() = %v, want an empty entry for primary", busy)
+	}
+}
+
+func TestFreeBusy_Errors(t *testing.T) {
+	client, _ := newFakeClient(t)
+	day := time.Date(2024, 1, 15, 0, 0, 0, 0, time.UTC)
+
+	_, err := client.FreeBusy(context.Background(), day, day.AddDate(0, 0, 1), []string{"nobody@example.com"})
+	if !errors.Is(err, ErrCalendarNotFound) {
+		t.Errorf("FreeBusy() unknown calendar error = %v, want ErrCalendarNotFound", err)
+	}
+
+	_, err = client.FreeBusy(context.Background(), day, day, nil)
+	if !errors.Is(err, ErrInvalidEventTime) {
+		t.Errorf("FreeBusy() empty range error = %v, want ErrInvalidEventTime", err)
+	}
+}
@@ -0,0 +1,437 @@
+package calendar
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// ErrInvalidICS is returned for iCalendar data that can't be parsed.
+var ErrInvalidICS = errors.New("invalid iCalendar data")
+
+// ICSEvent is one VEVENT read from an iCalendar file by ParseICS.
+type ICSEvent struct {
+	// Line is the line of the input where the VEVENT begins.
+	Line int
+
+	// UID is the event's iCalendar UID, for reference. Google assigns new
+	// event IDs, so it isn't carried over.
+	UID string
+
+	// Params holds the event, ready for CreateEvent. For all-day events
+	// EndDate is the last day, as read by clients with InclusiveEndDate.
+	Params EventParams
+
+	// Unsupported lists the properties and components of the VEVENT that
+	// have no equivalent in EventParams and were dropped, e.g. "ORGANIZER"
+	// or "VALARM".
+	Unsupported []string
+
+	// Err is set when the VEVENT can't be turned into an event. Params is
+	// then incomplete.
+	Err error
+}
+
+// icsIgnored lists bookkeeping properties that are dropped without being
+// reported as unsupported.
+var icsIgnored = map[string]bool{
+	"DTSTAMP":       true,
+	"LAST-MODIFIED": true,
+	"SEQUENCE":      true,
+}
+
+// icsProperty is one unfolded content line, e.g.
+// "DTSTART;TZID=Europe/Paris:20240115T090000".
+type icsProperty struct {
+	line   int
+	name   string
+	params map[string]string
+	value  string
+}
+
+// ParseICS reads the VEVENT components of iCalendar (.ics) data. Each
+// VEVENT gets its own ICSEvent, with Err set if it can't be converted, so
+// one bad event doesn't stop the rest. An error is only returned when the
+// data as a whole can't be read.
+//
+// Floating times, which have neither a UTC suffix nor a TZID, are read in
+// timezone, resolved as in ParseTime. TZID values must be IANA names such as
+// "Europe/Paris"; VTIMEZONE definitions are not interpreted.
+func ParseICS(r io.Reader, timezone string) ([]ICSEvent, error) {
+	loc, err := getLocation(timezone)
+	if err != nil {
+		return nil, err
+	}
+
+	props, err := readICSProperties(r)
+	if err != nil {
+		return nil, err
+	}
+
+	var events []ICSEvent
+	var current []icsProperty
+	var components []string
+	for _, prop := range props {
+		switch prop.name {
+		case "BEGIN":
+			if strings.EqualFold(prop.value, "VEVENT") && !componentInEvent(components) {
+				current = nil
+			}
+			components = append(components, strings.ToUpper(prop.value))
+		case "END":
+			if len(components) == 0 || components[len(components)-1] != strings.ToUpper(prop.value) {
+				return nil, fmt.Errorf("%w: line %d: unexpected END:%s", ErrInvalidICS, prop.line, prop.value)
+			}
+			components = components[:len(components)-1]
+			if strings.EqualFold(prop.value, "VEVENT") {
+				events = append(events, parseICSEvent(current, loc))
+				current = nil
+			}
+		}
+
+		if len(components) > 0 && componentInEvent(components) {
+			current = append(current, prop)
+		}
+	}
+	if len(components) > 0 {
+		return nil, fmt.Errorf("%w: missing END:%s", ErrInvalidICS, components[len(components)-1])
+	}
+
+	return events, nil
+}
+
+// componentInEvent reports whether any of the open components is a VEVENT.
+func componentInEvent(components []string) bool {
+	for _, name := range components {
+		if name == "VEVENT" {
+			return true
+		}
+	}
+	return false
+}
+
+// readICSProperties splits the input into unfolded content lines.
+func readICSProperties(r io.Reader) ([]icsProperty, error) {
+	var props []icsProperty
+	var text strings.Builder
+	start := 0
+
+	flush := func() error {
+		if text.Len() == 0 {
+			return nil
+		}
+		prop, err := parseICSLine(text.String())
+		if err != nil {
+			return fmt.Errorf("%w: line %d: %v", ErrInvalidICS, start, err)
+		}
+		prop.line = start
+		props = append(props, prop)
+		text.Reset()
+		return nil
+	}
+
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(nil, 1024*1024)
+	for line := 1; scanner.Scan(); line++ {
+		raw := strings.TrimRight(scanner.Text(), "\r")
+		// A line starting with a space or tab continues the previous one
+		if strings.HasPrefix(raw, " ") || strings.HasPrefix(raw, "\t") {
+			text.WriteString(raw[1:])
+			continue
+		}
+		if err := flush(); err != nil {
+			return nil, err
+		}
+		text.WriteString(raw)
+		start = line
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	if err := flush(); err != nil {
+		return nil, err
+	}
+
+	return props, nil
+}
+
+// parseICSLine splits a content line into its name, parameters and value.
+func parseICSLine(line string) (icsProperty, error) {
+	colon := -1
+	inQuotes := false
+	for i, r := range line {
+		if r == '"' {
+			inQuotes = !inQuotes
+		}
+		if r == ':' && !inQuotes {
+			colon = i
+			break
+		}
+	}
+	if colon < 0 {
+		return icsProperty{}, fmt.Errorf("missing ':' in %q", line)
+	}
+
+	parts := strings.Split(line[:colon], ";")
+	prop := icsProperty{
+		name:   strings.ToUpper(parts[0]),
+		params: make(map[string]string),
+		value:  line[colon+1:],
+	}
+	for _, param := range parts[1:] {
+		key, value, _ := strings.Cut(param, "=")
+		prop.params[strings.ToUpper(key)] = strings.Trim(value, `"`)
+	}
+	return prop, nil
+}
+
+// parseICSEvent converts the properties of one VEVENT, including those of
+// any nested components, into an ICSEvent.
+func parseICSEvent(props []icsProperty, loc *time.Location) ICSEvent {
+	var event ICSEvent
+	if len(props) > 0 {
+		event.Line = props[0].line
+	}
+
+	unsupported := make(map[string]bool)
+	var start, end time.Time
+	var startIsDate bool
+	var duration time.Duration
+	nested := 0
+
+	fail := func(prop icsProperty, err error) {
+		if event.Err == nil {
+			event.Err = fmt.Errorf("%w: line %d: %s: %w", ErrInvalidICS, prop.line, prop.name, err)
+		}
+	}
+
+	for _, prop := range props {
+		switch {
+		case prop.name == "BEGIN":
+			if nested > 0 || !strings.EqualFold(prop.value, "VEVENT") {
+				unsupported[strings.ToUpper(prop.value)] = true
+				nested++
+			}
+			continue
+		case prop.name == "END":
+			if nested > 0 {
+				nested--
+			}
+			continue
+		case nested > 0:
+			// Properties of nested components such as VALARM
+			continue
+		}
+
+		switch prop.name {
+		case "UID":
+			event.UID = prop.value
+		case "SUMMARY":
+			event.Params.Title = strings.TrimSpace(unescapeICSText(prop.value))
+		case "DESCRIPTION":
+			event.Params.Description = unescapeICSText(prop.value)
+		case "LOCATION":
+			event.Params.Location = unescapeICSText(prop.value)
+		case "DTSTART":
+			t, isDate, err := parseICSTime(prop, loc)
+			if err != nil {
+				fail(prop, err)
+				continue
+			}
+			start, startIsDate = t, isDate
+		case "DTEND":
+			t, _, err := parseICSTime(prop, loc)
+			if err != nil {
+				fail(prop, err)
+				continue
+			}
+			end = t
+		case "DURATION":
+			d, err := parseICSDuration(prop.value)
+			if err != nil {
+				fail(prop, err)
+				continue
+			}
+			duration = d
+		case "RRULE", "EXRULE", "RDATE", "EXDATE":
+			event.Params.Recurrence = append(event.Params.Recurrence, formatICSRecurrence(prop))
+		case "ATTENDEE":
+			email := strings.TrimSpace(prop.value)
+			if len(email) >= len("mailto:") && strings.EqualFold(email[:len("mailto:")], "mailto:") {
+				email = email[len("mailto:"):]
+			}
+			if email != "" {
+				event.Params.Attendees = append(event.Params.Attendees, email)
+			}
+		case "TRANSP":
+			event.Params.Transparent = strings.EqualFold(prop.value, "TRANSPARENT")
+		case "CREATED":
+			t, _, err := parseICSTime(prop, loc)
+			if err != nil {
+				fail(prop, err)
+				continue
+			}
+			event.Params.OriginalCreated = t
+		case "PRIORITY":
+			p, err := strconv.Atoi(strings.TrimSpace(prop.value))
+			if err != nil || p < 0 || p > 9 {
+				fail(prop, fmt.Errorf("must be 0-9"))
+				continue
+			}
+			// iCalendar ranks 1 (highest) to 9, with 0 meaning undefined
+			if p > 0 {
+				event.Params.Priority = (p + 1) / 2
+			}
+		case "RECURRENCE-ID":
+			fail(prop, fmt.Errorf("changed occurrences of recurring events can't be imported"))
+		default:
+			if !icsIgnored[prop.name] {
+				unsupported[prop.name] = true
+			}
+		}
+	}
+
+	if event.Err != nil {
+		return event
+	}
+	if start.IsZero() {
+		event.Err = fmt.Errorf("%w: line %d: missing DTSTART", ErrInvalidICS, event.Line)
+		return event
+	}
+
+	event.Params.StartTime = start
+	if startIsDate {
+		event.Params.AllDay = true
+		// DTEND is exclusive; EndDate holds the last day
+		if end.IsZero() && duration > 0 {
+			end = start.Add(duration)
+		}
+		if last := end.AddDate(0, 0, -1); last.After(start) {
+			event.Params.EndDate = last
+		}
+	} else {
+		event.Params.Duration = duration
+		if !end.IsZero() {
+			event.Params.Duration = end.Sub(start)
+			if end.Location().String() != start.Location().String() {
+				event.Params.EndTimeZone = end.Location().String()
+			}
+		}
+	}
+
+	for name := range unsupported {
+		event.Unsupported = append(event.Unsupported, name)
+	}
+	sort.Strings(event.Unsupported)
+
+	return event
+}
+
+// parseICSTime parses a DATE or DATE-TIME value. It reports whether the
+// value is a date, which is returned as midnight UTC.
+func parseICSTime(prop icsProperty, loc *time.Location) (time.Time, bool, error) {
+	value := strings.TrimSpace(prop.value)
+
+	if strings.EqualFold(prop.params["VALUE"], "DATE") || len(value) == len("20060102") {
+		t, err := time.Parse("20060102", value)
+		if err != nil {
+			return time.Time{}, false, fmt.Errorf("invalid date %q", value)
+		}
+		return t, true, nil
+	}
+
+	if tzid := prop.params["TZID"]; tzid != "" {
+		tzLoc, err := time.LoadLocation(tzid)
+		if err != nil {
+			return time.Time{}, false, fmt.Errorf("%w: %s", ErrInvalidTimezone, tzid)
+		}
+		loc = tzLoc
+	}
+
+	layout := "20060102T150405"
+	if strings.HasSuffix(value, "Z") {
+		layout += "Z"
+		loc = time.UTC
+	}
+	t, err := time.ParseInLocation(layout, value, loc)
+	if err != nil {
+		return time.Time{}, false, fmt.Errorf("invalid date-time %q", value)
+	}
+	return t, false, nil
+}
+
+// icsDurationRegex matches RFC 5545 durations such as "PT1H30M", "P1D" or
+// "P2W".
+var icsDurationRegex = regexp.MustCompile(`^([+-])?P(?:(\d+)W)?(?:(\d+)D)?(?:T(?:(\d+)H)?(?:(\d+)M)?(?:(\d+)S)?)?$`)
+
+// parseICSDuration parses an RFC 5545 duration. Negative durations are
+// rejected since they can't describe an event's length.
+func parseICSDuration(value string) (time.Duration, error) {
+	m := icsDurationRegex.FindStringSubmatch(strings.ToUpper(strings.TrimSpace(value)))
+	if m == nil || m[1] == "-" {
+		return 0, fmt.Errorf("invalid duration %q", value)
+	}
+
+	units := []time.Duration{7 * 24 * time.Hour, 24 * time.Hour, time.Hour, time.Minute, time.Second}
+	var d time.Duration
+	for i, unit := range units {
+		if m[i+2] == "" {
+			continue
+		}
+		n, err := strconv.Atoi(m[i+2])
+		if err != nil {
+			return 0, fmt.Errorf("invalid duration %q", value)
+		}
+		d += time.Duration(n) * unit
+	}
+	return d, nil
+}
+
+// formatICSRecurrence turns a recurrence property back into the line form
+// EventParams.Recurrence uses, keeping parameters such as TZID.
+func formatICSRecurrence(prop icsProperty) string {
+	keys := make([]string, 0, len(prop.params))
+	for key := range prop.params {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	var b strings.Builder
+	b.WriteString(prop.name)
+	for _, key := range keys {
+		b.WriteString(";" + key + "=" + prop.params[key])
+	}
+	b.WriteString(":" + strings.TrimSpace(prop.value))
+	return b.String()
+}
+
+// unescapeICSText undoes the escaping of TEXT values.
+func unescapeICSText(value string) string {
+	var b strings.Builder
+	escaped := false
+	for _, r := range value {
+		if !escaped {
+			if r == '\\' {
+				escaped = true
+			} else {
+				b.WriteRune(r)
+			}
+			continue
+		}
+
+		escaped = false
+		switch r {
+		case 'n', 'N':
+			b.WriteByte('\n')
+		default:
+			b.WriteRune(r)
+		}
+	}
+	return b.String()
+}
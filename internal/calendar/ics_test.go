@@ -0,0 +1,234 @@
+package calendar
+
+import (
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+const testICS = "BEGIN:VCALENDAR\r\n" +
+	"VERSION:2.0\r\n" +
+	"PRODID:-//Example//EN\r\n" +
+	"BEGIN:VTIMEZONE\r\n" +
+	"TZID:Europe/Paris\r\n" +
+	"END:VTIMEZONE\r\n" +
+	"BEGIN:VEVENT\r\n" +
+	"UID:standup@example.com\r\n" +
+	"DTSTAMP:20240101T000000Z\r\n" +
+	"SUMMARY:Team standup\r\n" +
+	"DESCRIPTION:Daily sync\\, quick\\nBring updates\r\n" +
+	"DTSTART;TZID=Europe/Paris:20240115T090000\r\n" +
+	"DTEND;TZID=Europe/Paris:20240115T091500\r\n" +
+	"RRULE:FREQ=WEEKLY;BYDAY=MO,WE,FR\r\n" +
+	"EXDATE;TZID=Europe/Paris:20240117T090000\r\n" +
+	"ATTENDEE;CN=Alice;PARTSTAT=ACCEPTED:mailto:alice@example.com\r\n" +
+	"ATTENDEE;CN=\"Bob: PM\":MAILTO:bob@example.com\r\n" +
+	"ORGANIZER:mailto:carol@example.com\r\n" +
+	"BEGIN:VALARM\r\n" +
+	"ACTION:DISPLAY\r\n" +
+	"TRIGGER:-PT10M\r\n" +
+	"END:VALARM\r\n" +
+	"END:VEVENT\r\n" +
+	"BEGIN:VEVENT\r\n" +
+	"UID:offsite@example.com\r\n" +
+	"SUMMARY:Off\r\n" +
+	" site\r\n" +
+	"DTSTART;VALUE=DATE:20240701\r\n" +
+	"DTEND;VALUE=DATE:20240706\r\n" +
+	"TRANSP:TRANSPARENT\r\n" +
+	"END:VEVENT\r\n" +
+	"BEGIN:VEVENT\r\n" +
+	"SUMMARY:Call\r\n" +
+	"DTSTART:20240115T140000Z\r\n" +
+	"DURATION:PT1H30M\r\n" +
+	"PRIORITY:1\r\n" +
+	"END:VEVENT\r\n" +
+	"BEGIN:VEVENT\r\n" +
+	"SUMMARY:Moved standup\r\n" +
+	"RECURRENCE-ID;TZID=Europe/Paris:20240119T090000\r\n" +
+	"DTSTART;TZID=Europe/Paris:20240119T100000\r\n" +
+	"DTEND;TZID=Europe/Paris:20240119T101500\r\n" +
+	"END:VEVENT\r\n" +
+	"END:VCALENDAR\r\n"
+
+func TestParseICS(t *testing.T) {
+	events, err := ParseICS(strings.NewReader(testICS), "UTC")
+	if err != nil {
+		t.Fatalf("ParseICS() error = %v", err)
+	}
+	if len(events) != 4 {
+		t.Fatalf("got %d events, want 4", len(events))
+	}
+
+	paris, err := time.LoadLocation("Europe/Paris")
+	if err != nil {
+		t.Skipf("timezone data unavailable: %v", err)
+	}
+
+	t.Run("recurring timed event", func(t *testing.T) {
+		event := events[0]
+		if event.Err != nil {
+			t.Fatalf("Err = %v", event.Err)
+		}
+		if event.Line != 7 || event.UID != "standup@example.com" {
+			t.Errorf("Line, UID = %d, %q", event.Line, event.UID)
+		}
+
+		p := event.Params
+		if p.Title != "Team standup" || p.Description != "Daily sync, quick\nBring updates" {
+			t.Errorf("Title, Description = %q, %q", p.Title, p.Description)
+		}
+		if want := time.Date(2024, 1, 15, 9, 0, 0, 0, paris); !p.StartTime.Equal(want) || p.StartTime.Location().String() != "Europe/Paris" {
+			t.Errorf("StartTime = %v, want %v", p.StartTime, want)
+		}
+		if p.Duration != 15*time.Minute || p.AllDay || p.EndTimeZone != "" {
+			t.Errorf("Duration, AllDay, EndTimeZone = %v, %v, %q", p.Duration, p.AllDay, p.EndTimeZone)
+		}
+		wantRecurrence := []string{"RRULE:FREQ=WEEKLY;BYDAY=MO,WE,FR", "EXDATE;TZID=Europe/Paris:20240117T090000"}
+		if !reflect.DeepEqual(p.Recurrence, wantRecurrence) {
+			t.Errorf("Recurrence = %q, want %q", p.Recurrence, wantRecurrence)
+		}
+		if want := []string{"alice@example.com", "bob@example.com"}; !reflect.DeepEqual(p.Attendees, want) {
+			t.Errorf("Attendees = %q, want %q", p.Attendees, want)
+		}
+		if want := []string{"ORGANIZER", "VALARM"}; !reflect.DeepEqual(event.Unsupported, want) {
+			t.Errorf("Unsupported = %q, want %q", event.Unsupported, want)
+		}
+	})
+
+	t.Run("multi-day all-day event", func(t *testing.T) {
+		event := events[1]
+		if event.Err != nil {
+			t.Fatalf("Err = %v", event.Err)
+		}
+		p := event.Params
+		if p.Title != "Offsite" || !p.AllDay || !p.Transparent {
+			t.Errorf("Title, AllDay, Transparent = %q, %v, %v", p.Title, p.AllDay, p.Transparent)
+		}
+		if want := time.Date(2024, 7, 1, 0, 0, 0, 0, time.UTC); !p.StartTime.Equal(want) {
+			t.Errorf("StartTime = %v, want %v", p.StartTime, want)
+		}
+		if want := time.Date(2024, 7, 5, 0, 0, 0, 0, time.UTC); !p.EndDate.Equal(want) {
+			t.Errorf("EndDate = %v, want last day %v", p.EndDate, want)
+		}
+		if len(event.Unsupported) != 0 {
+			t.Errorf("Unsupported = %q, want none", event.Unsupported)
+		}
+	})
+
+	t.Run("duration and priority", func(t *testing.T) {
+		p := events[2].Params
+		if events[2].Err != nil {
+			t.Fatalf("Err = %v", events[2].Err)
+		}
+		if want := time.Date(2024, 1, 15, 14, 0, 0, 0, time.UTC); !p.StartTime.Equal(want) || p.Duration != 90*time.Minute {
+			t.Errorf("StartTime, Duration = %v, %v", p.StartTime, p.Duration)
+		}
+		if p.Priority != MaxPriority {
+			t.Errorf("Priority = %d, want %d", p.Priority, MaxPriority)
+		}
+	})
+
+	t.Run("changed occurrence is rejected", func(t *testing.T) {
+		if !errors.Is(events[3].Err, ErrInvalidICS) {
+			t.Errorf("Err = %v, want ErrInvalidICS", events[3].Err)
+		}
+	})
+}
+
+func TestParseICS_FloatingTimeUsesTimezone(t *testing.T) {
+	input := "BEGIN:VEVENT\nSUMMARY:Lunch\nDTSTART:20240115T120000\nDTEND:20240115T130000\nEND:VEVENT\n"
+
+	events, err := ParseICS(strings.NewReader(input), "America/New_York")
+	if err != nil {
+		t.Fatalf("ParseICS() error = %v", err)
+	}
+	ny, err := time.LoadLocation("America/New_York")
+	if err != nil {
+		t.Skipf("timezone data unavailable: %v", err)
+	}
+	if want := time.Date(2024, 1, 15, 12, 0, 0, 0, ny); len(events) != 1 || !events[0].Params.StartTime.Equal(want) {
+		t.Errorf("events = %+v, want one starting %v", events, want)
+	}
+}
+
+func TestParseICS_EventErrors(t *testing.T) {
+	tests := []struct {
+		name    string
+		input   string
+		wantErr error
+	}{
+		{name: "missing start", input: "BEGIN:VEVENT\nSUMMARY:x\nEND:VEVENT\n", wantErr: ErrInvalidICS},
+		{name: "bad date", input: "BEGIN:VEVENT\nDTSTART:2024-01-15\nEND:VEVENT\n", wantErr: ErrInvalidICS},
+		{name: "unknown TZID", input: "BEGIN:VEVENT\nDTSTART;TZID=Eastern Standard Time:20240115T090000\nEND:VEVENT\n", wantErr: ErrInvalidTimezone},
+		{name: "negative duration", input: "BEGIN:VEVENT\nDTSTART:20240115T090000Z\nDURATION:-PT1H\nEND:VEVENT\n", wantErr: ErrInvalidICS},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			events, err := ParseICS(strings.NewReader(tt.input), "UTC")
+			if err != nil {
+				t.Fatalf("ParseICS() error = %v", err)
+			}
+			if len(events) != 1 || !errors.Is(events[0].Err, tt.wantErr) {
+				t.Errorf("events = %+v, want one with error %v", events, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestParseICS_Malformed(t *testing.T) {
+	tests := []struct {
+		name  string
+		input string
+	}{
+		{name: "unterminated component", input: "BEGIN:VCALENDAR\nBEGIN:VEVENT\n"},
+		{name: "mismatched end", input: "BEGIN:VEVENT\nEND:VCALENDAR\n"},
+		{name: "line without colon", input: "BEGIN:VEVENT\nSUMMARY\nEND:VEVENT\n"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := ParseICS(strings.NewReader(tt.input), "UTC"); !errors.Is(err, ErrInvalidICS) {
+				t.Errorf("ParseICS() error = %v, want ErrInvalidICS", err)
+			}
+		})
+	}
+}
+
+func TestParseICSDuration(t *testing.T) {
+	tests := []struct {
+		input   string
+		want    time.Duration
+		wantErr bool
+	}{
+		{input: "PT15M", want: 15 * time.Minute},
+		{input: "PT1H30M", want: 90 * time.Minute},
+		{input: "P1D", want: 24 * time.Hour},
+		{input: "P1W", want: 7 * 24 * time.Hour},
+		{input: "P1DT2H", want: 26 * time.Hour},
+		{input: "+PT30S", want: 30 * time.Second},
+		{input: "-PT1H", wantErr: true},
+		{input: "1H", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			got, err := parseICSDuration(tt.input)
+			if tt.wantErr {
+				if err == nil {
+					t.Fatal("expected error, got nil")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("parseICSDuration(%q) = %v, want %v", tt.input, got, tt.want)
+			}
+		})
+	}
+}
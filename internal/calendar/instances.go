@@ -0,0 +1,112 @@
+package calendar
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"google.golang.org/api/calendar/v3"
+)
+
+// ExpandInstances returns the first n occurrences of the recurring event
+// eventID, in start order. A non-recurring event yields itself as its only
+// instance.
+func (c *Client) ExpandInstances(ctx context.Context, eventID string, n int) ([]*EventResult, error) {
+	if eventID == "" {
+		return nil, fmt.Errorf("%w: event ID is required", ErrInvalidEventTime)
+	}
+	if n <= 0 {
+		return nil, fmt.Errorf("%w: instance count must be positive", ErrInvalidEventTime)
+	}
+
+	ctx, cancel := c.withRequestTimeout(ctx)
+	defer cancel()
+
+	pageSize := min(n, maxPageSize)
+	var instances []*EventResult
+	pageToken := ""
+
+	for {
+		call := c.service.Events.Instances(c.calendarID, eventID).
+			MaxResults(int64(pageSize)).
+			Context(ctx)
+		if pageToken != "" {
+			call = call.PageToken(pageToken)
+		}
+
+		var page *calendar.Events
+		err := c.retry(ctx, true, func() error {
+			var err error
+			page, err = call.Do()
+			return err
+		})
+		if err != nil {
+			return nil, wrapEventError(err, eventID)
+		}
+
+		for _, item := range page.Items {
+			result, err := parseEventResult(item)
+			if err != nil {
+				return nil, err
+			}
+			instances = append(instances, result)
+			if len(instances) >= n {
+				return instances, nil
+			}
+		}
+
+		if page.NextPageToken == "" {
+			return instances, nil
+		}
+		pageToken = page.NextPageToken
+	}
+}
+
+// FindInstance returns the occurrence of the recurring event eventID that
+// starts on day, in day's location. Its ID can be passed to UpdateEvent or
+// DeleteEvent to change or cancel just that occurrence, leaving the rest of
+// the series alone. A day without an occurrence is reported as
+// ErrEventNotFound.
+func (c *Client) FindInstance(ctx context.Context, eventID string, day time.Time) (*EventResult, error) {
+	if eventID == "" {
+		return nil, fmt.Errorf("%w: event ID is required", ErrInvalidEventTime)
+	}
+
+	ctx, cancel := c.withRequestTimeout(ctx)
+	defer cancel()
+
+	dayStart := time.Date(day.Year(), day.Month(), day.Day(), 0, 0, 0, 0, day.Location())
+	dayEnd := dayStart.AddDate(0, 0, 1)
+
+	var page *calendar.Events
+	err := c.retry(ctx, true, func() error {
+		var err error
+		page, err = c.service.Events.Instances(c.calendarID, eventID).
+			TimeMin(dayStart.Format(time.RFC3339)).
+			TimeMax(dayEnd.Format(time.RFC3339)).
+			Context(ctx).
+			Do()
+		return err
+	})
+	if err != nil {
+		return nil, wrapEventError(err, eventID)
+	}
+
+	for _, item := range page.Items {
+		instance, err := parseEventResult(item)
+		if err != nil {
+			return nil, err
+		}
+
+		// The range also catches occurrences running into the day from
+		// the day before
+		start := instance.StartTime
+		if instance.AllDay {
+			start = time.Date(start.Year(), start.Month(), start.Day(), 0, 0, 0, 0, dayStart.Location())
+		}
+		if !start.Before(dayStart) {
+			return instance, nil
+		}
+	}
+	return nil, fmt.Errorf("%w: %s has no occurrence on %s", ErrEventNotFound, eventID, dayStart.Format(allDayLayout))
+}
@@ -0,0 +1,153 @@
+package calendar
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestCreateEvent_PreviewInstances(t *testing.T) {
+	client, _ := newFakeClient(t)
+	start := time.Date(2024, 1, 15, 9, 0, 0, 0, time.UTC)
+
+	result, err := client.CreateEvent(context.Background(), EventParams{
+		Title:            "Standup",
+		StartTime:        start,
+		Duration:         15 * time.Minute,
+		Recurrence:       []string{"RRULE:FREQ=DAILY;COUNT=10"},
+		PreviewInstances: 3,
+	})
+	if err != nil {
+		t.Fatalf("CreateEvent() error = %v", err)
+	}
+
+	if len(result.Instances) != 3 {
+		t.Fatalf("Instances = %d, want 3", len(result.Instances))
+	}
+	for i, instance := range result.Instances {
+		want := start.AddDate(0, 0, i)
+		if !instance.StartTime.Equal(want) {
+			t.Errorf("Instances[%d].StartTime = %v, want %v", i, instance.StartTime, want)
+		}
+		if instance.EndTime.Sub(instance.StartTime) != 15*time.Minute {
+			t.Errorf("Instances[%d] lasts %s, want 15m", i, instance.EndTime.Sub(instance.StartTime))
+		}
+	}
+}
+
+func TestCreateEvent_PreviewInstancesSkipsSingleEvents(t *testing.T) {
+	client, fake := newFakeClient(t)
+
+	result, err := client.CreateEvent(context.Background(), EventParams{
+		Title:            "One-off",
+		StartTime:        time.Date(2024, 1, 15, 9, 0, 0, 0, time.UTC),
+		Duration:         time.Hour,
+		PreviewInstances: 3,
+	})
+	if err != nil {
+		t.Fatalf("CreateEvent() error = %v", err)
+	}
+
+	if result.Instances != nil {
+		t.Errorf("Instances = %v, want nil for a non-recurring event", result.Instances)
+	}
+	if n := fake.requestCount("GET", "/calendars/primary/events/"); n != 0 {
+		t.Errorf("Made %d instance requests, want 0", n)
+	}
+}
+
+func TestExpandInstances_NotFound(t *testing.T) {
+	client, _ := newFakeClient(t)
+
+	_, err := client.ExpandInstances(context.Background(), "missing", 3)
+	if !errors.Is(err, ErrEventNotFound) {
+		t.Errorf("ExpandInstances() error = %v, want ErrEventNotFound", err)
+	}
+}
+
+func TestFindInstance(t *testing.T) {
+	client, _ := newFakeClient(t)
+	ctx := context.Background()
+
+	series, err := client.CreateEvent(ctx, EventParams{
+		Title:      "Standup",
+		StartTime:  time.Date(2024, 3, 1, 9, 0, 0, 0, time.UTC),
+		Duration:   15 * time.Minute,
+		Recurrence: []string{"RRULE:FREQ=DAILY;COUNT=10"},
+	})
+	if err != nil {
+		t.Fatalf("CreateEvent() error = %v", err)
+	}
+
+	instance, err := client.FindInstance(ctx, series.ID, time.Date(2024, 3, 4, 0, 0, 0, 0, time.UTC))
+	if err != nil {
+		t.Fatalf("FindInstance() error = %v", err)
+	}
+	if want := time.Date(2024, 3, 4, 9, 0, 0, 0, time.UTC); !instance.StartTime.Equal(want) || instance.ID == series.ID {
+		t.Errorf("FindInstance() = %s at %v, want an occurrence at %v", instance.ID, instance.StartTime, want)
+	}
+
+	_, err = client.FindInstance(ctx, series.ID, time.Date(2024, 4, 1, 0, 0, 0, 0, time.UTC))
+	if !errors.Is(err, ErrEventNotFound) {
+		t.Errorf("FindInstance() after the series error = %v, want ErrEventNotFound", err)
+	}
+}
+
+func TestFindInstance_EditAndCancel(t *testing.T) {
+	client, _ := newFakeClient(t)
+	ctx := context.Background()
+
+	series, err := client.CreateEvent(ctx, EventParams{
+		Title:      "Standup",
+		StartTime:  time.Date(2024, 3, 1, 9, 0, 0, 0, time.UTC),
+		Duration:   15 * time.Minute,
+		Recurrence: []string{"RRULE:FREQ=DAILY;COUNT=5"},
+	})
+	if err != nil {
+		t.Fatalf("CreateEvent() error = %v", err)
+	}
+
+	moved, err := client.FindInstance(ctx, series.ID, time.Date(2024, 3, 2, 0, 0, 0, 0, time.UTC))
+	if err != nil {
+		t.Fatalf("FindInstance() error = %v", err)
+	}
+	title := "Standup (moved)"
+	later := time.Date(2024, 3, 2, 11, 0, 0, 0, time.UTC)
+	if _, err := client.UpdateEvent(ctx, moved.ID, EventUpdate{Title: &title, StartTime: &later, ETag: moved.ETag}); err != nil {
+		t.Fatalf("UpdateEvent() of instance error = %v", err)
+	}
+
+	cancelled, err := client.FindInstance(ctx, series.ID, time.Date(2024, 3, 3, 0, 0, 0, 0, time.UTC))
+	if err != nil {
+		t.Fatalf("FindInstance() error = %v", err)
+	}
+	if err := client.DeleteEventIfMatch(ctx, cancelled.ID, cancelled.ETag); err != nil {
+		t.Fatalf("DeleteEventIfMatch() of instance error = %v", err)
+	}
+
+	instances, err := client.ExpandInstances(ctx, series.ID, 10)
+	if err != nil {
+		t.Fatalf("ExpandInstances() error = %v", err)
+	}
+	if len(instances) != 4 {
+		t.Fatalf("series has %d occurrences, want 4 after cancelling one", len(instances))
+	}
+	for _, instance := range instances {
+		switch {
+		case instance.ID == cancelled.ID:
+			t.Error("cancelled occurrence still listed")
+		case instance.ID == moved.ID:
+			if instance.Title != title || !instance.StartTime.Equal(later) {
+				t.Errorf("edited occurrence = %q at %v, want %q at %v", instance.Title, instance.StartTime, title, later)
+			}
+		case instance.Title != "Standup":
+			t.Errorf("occurrence %s renamed to %q, want the series unchanged", instance.ID, instance.Title)
+		}
+	}
+
+	// The cancelled day has no occurrence left
+	if _, err := client.FindInstance(ctx, series.ID, time.Date(2024, 3, 3, 0, 0, 0, 0, time.UTC)); !errors.Is(err, ErrEventNotFound) {
+		t.Errorf("FindInstance() of cancelled day error = %v, want ErrEventNotFound", err)
+	}
+}
@@ -0,0 +1,218 @@
+package calendar
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"google.golang.org/api/calendar/v3"
+)
+
+// maxPageSize is the largest page size accepted by the events.list endpoint.
+const maxPageSize = 250
+
+// ListOptions controls which events ListEvents returns.
+type ListOptions struct {
+	// TimeMin and TimeMax bound the listed events; events overlapping the
+	// range are included. Zero values leave that side unbounded.
+	TimeMin time.Time
+	TimeMax time.Time
+
+	// MaxResults caps the total number of events returned across all pages.
+	// Zero means no limit.
+	MaxResults int
+
+	// Query is free text matched server-side against event fields such as
+	// the title, description, location and attendees.
+	Query string
+
+	// Tags restricts the listing to events with all of these tags. See
+	// EventParams.Tags.
+	Tags map[string]string
+
+	// AllowPartial makes ListEvents return the events fetched so far, along
+	// with a *PartialResultError, when a later page fails.
+	AllowPartial bool
+}
+
+// PartialResultError reports a listing that failed part-way through
+// pagination. Events holds the events fetched before the failure.
+type PartialResultError struct {
+	Events []*EventResult
+	Err    error
+}
+
+func (e *PartialResultError) Error() string {
+	return fmt.Sprintf("partial results (%d events): %v", len(e.Events), e.Err)
+}
+
+func (e *PartialResultError) Unwrap() error {
+	return e.Err
+}
+
+// ListEvents returns events ordered by start time, following pagination until
+// all matching events (or MaxResults of them) have been fetched. Recurring
+// events are expanded into their individual instances.
+func (c *Client) ListEvents(ctx context.Context, opts ListOptions) ([]*EventResult, error) {
+	if !opts.TimeMin.IsZero() && !opts.TimeMax.IsZero() && !opts.TimeMax.After(opts.TimeMin) {
+		return nil, fmt.Errorf("%w: end of range must be after start", ErrInvalidEventTime)
+	}
+	if err := validateTags(opts.Tags); err != nil {
+		return nil, err
+	}
+
+	ctx, cancel := c.withRequestTimeout(ctx)
+	defer cancel()
+
+	pageSize := maxPageSize
+	if opts.MaxResults > 0 && opts.MaxResults < pageSize {
+		pageSize = opts.MaxResults
+	}
+
+	if c.cache == nil {
+		return c.listEvents(ctx, opts, pageSize)
+	}
+
+	key := listCacheKey{
+		calendarID: c.calendarID,
+		timeMin:    formatRangeBound(opts.TimeMin),
+		timeMax:    formatRangeBound(opts.TimeMax),
+		maxResults: opts.MaxResults,
+		query:      opts.Query,
+		tags:       strings.Join(tagFilters(opts.Tags), "\x00"),
+	}
+	if events, ok := c.cache.get(key); ok {
+		return events, nil
+	}
+
+	events, err := c.listEvents(ctx, opts, pageSize)
+	if err != nil {
+		// Partial results aren't cached
+		return events, err
+	}
+	c.cache.put(key, events)
+	return events, nil
+}
+
+// ListEventsIn lists events from several calendars, merged in start order,
+// with each event's CalendarID set to the calendar it came from. Empty
+// calendarIDs lists the client's own calendar. opts.MaxResults applies to
+// each calendar separately.
+func (c *Client) ListEventsIn(ctx context.Context, calendarIDs []string, opts ListOptions) ([]*EventResult, error) {
+	if len(calendarIDs) == 0 {
+		calendarIDs = []string{c.calendarID}
+	}
+
+	var merged []*EventResult
+	for _, id := range calendarIDs {
+		// A shallow copy shares the service, settings and cache
+		other := *c
+		other.calendarID = id
+
+		events, err := other.ListEvents(ctx, opts)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", id, err)
+		}
+		for _, event := range events {
+			event.CalendarID = id
+		}
+		merged = append(merged, events...)
+	}
+
+	sort.SliceStable(merged, func(i, j int) bool {
+		return merged[i].StartTime.Before(merged[j].StartTime)
+	})
+	return merged, nil
+}
+
+// UpcomingEvents returns the events starting within the next window, in
+// start order. Events already in progress aren't included.
+func (c *Client) UpcomingEvents(ctx context.Context, window time.Duration) ([]*EventResult, error) {
+	if window <= 0 {
+		return nil, fmt.Errorf("%w: window must be positive", ErrInvalidEventTime)
+	}
+
+	now := c.now()
+	events, err := c.ListEvents(ctx, ListOptions{TimeMin: now, TimeMax: now.Add(window)})
+	if err != nil {
+		return nil, err
+	}
+
+	var upcoming []*EventResult
+	for _, event := range events {
+		if !event.StartTime.Before(now) {
+			upcoming = append(upcoming, event)
+		}
+	}
+	return upcoming, nil
+}
+
+// listEvents implements ListEvents with an explicit page size.
+func (c *Client) listEvents(ctx context.Context, opts ListOptions, pageSize int) ([]*EventResult, error) {
+	var events []*EventResult
+	pageToken := ""
+
+	for {
+		call := c.service.Events.List(c.calendarID).
+			SingleEvents(true).
+			OrderBy("startTime").
+			MaxResults(int64(pageSize)).
+			Context(ctx)
+		if !opts.TimeMin.IsZero() {
+			call = call.TimeMin(formatRangeBound(opts.TimeMin))
+		}
+		if !opts.TimeMax.IsZero() {
+			call = call.TimeMax(formatRangeBound(opts.TimeMax))
+		}
+		if opts.Query != "" {
+			call = call.Q(opts.Query)
+		}
+		if len(opts.Tags) > 0 {
+			call = call.PrivateExtendedProperty(tagFilters(opts.Tags)...)
+		}
+		if pageToken != "" {
+			call = call.PageToken(pageToken)
+		}
+
+		var page *calendar.Events
+		err := c.retry(ctx, true, func() error {
+			var err error
+			page, err = call.Do()
+			return err
+		})
+		if err != nil {
+			err = wrapAPIErrorAs(err, ErrEventListFailed)
+			if opts.AllowPartial && len(events) > 0 {
+				return events, &PartialResultError{Events: events, Err: err}
+			}
+			return nil, err
+		}
+
+		for _, item := range page.Items {
+			result, err := parseEventResult(item)
+			if err != nil {
+				return nil, err
+			}
+			events = append(events, result)
+
+			if opts.MaxResults > 0 && len(events) >= opts.MaxResults {
+				return events, nil
+			}
+		}
+
+		if page.NextPageToken == "" {
+			return events, nil
+		}
+		pageToken = page.NextPageToken
+	}
+}
+
+// formatRangeBound formats a list range bound for the API, or "" when unset.
+func formatRangeBound(t time.Time) string {
+	if t.IsZero() {
+		return ""
+	}
+	return t.UTC().Format(time.RFC3339)
+}
@@ -0,0 +1,139 @@
+package calendar
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"google.golang.org/api/calendar/v3"
+)
+
+// seedHourlyEvents adds count one-hour events starting at base, one per hour.
+func seedHourlyEvents(fake *fakeCalendar, base time.Time, count int) {
+	for i := 0; i < count; i++ {
+		start := base.Add(time.Duration(i) * time.Hour)
+		fake.addEvent(&calendar.Event{
+			Id:      fmt.Sprintf("hourly-%d", i),
+			Summary: fmt.Sprintf("Event %d", i),
+			Start:   &calendar.EventDateTime{DateTime: start.Format(time.RFC3339)},
+			End:     &calendar.EventDateTime{DateTime: start.Add(time.Hour).Format(time.RFC3339)},
+		})
+	}
+}
+
+func TestListEvents_Pagination(t *testing.T) {
+	client, fake := newFakeClient(t)
+	base := time.Date(2024, 1, 15, 8, 0, 0, 0, time.UTC)
+	seedHourlyEvents(fake, base, 5)
+
+	events, err := client.ListEvents(context.Background(), ListOptions{
+		TimeMin:    base,
+		TimeMax:    base.Add(24 * time.Hour),
+		MaxResults: 4,
+	})
+	if err != nil {
+		t.Fatalf("ListEvents() error = %v", err)
+	}
+
+	if len(events) != 4 {
+		t.Fatalf("ListEvents() returned %d events, want 4", len(events))
+	}
+	if events[0].ID != "hourly-0" || events[3].ID != "hourly-3" {
+		t.Errorf("ListEvents() returned %s..%s, want hourly-0..hourly-3", events[0].ID, events[3].ID)
+	}
+}
+
+func TestListEvents_TimeRange(t *testing.T) {
+	client, fake := newFakeClient(t)
+	base := time.Date(2024, 1, 15, 8, 0, 0, 0, time.UTC)
+	seedHourlyEvents(fake, base, 5)
+
+	events, err := client.ListEvents(context.Background(), ListOptions{
+		TimeMin: base.Add(2 * time.Hour),
+		TimeMax: base.Add(4 * time.Hour),
+	})
+	if err != nil {
+		t.Fatalf("ListEvents() error = %v", err)
+	}
+
+	if len(events) != 2 {
+		t.Fatalf("ListEvents() returned %d events, want 2", len(events))
+	}
+}
+
+func TestListEvents_InvalidRange(t *testing.T) {
+	client, _ := newFakeClient(t)
+	now := time.Now()
+
+	_, err := client.ListEvents(context.Background(), ListOptions{TimeMin: now, TimeMax: now.Add(-time.Hour)})
+	if !errors.Is(err, ErrInvalidEventTime) {
+		t.Errorf("ListEvents() error = %v, want ErrInvalidEventTime", err)
+	}
+}
+
+func TestListEvents_PartialResults(t *testing.T) {
+	client, fake := newFakeClient(t)
+	base := time.Date(2024, 1, 15, 8, 0, 0, 0, time.UTC)
+	seedHourlyEvents(fake, base, 5)
+	fake.failListPage = 2
+
+	events, err := listWithPageSize(client, 2, true)
+
+	var partialErr *PartialResultError
+	if !errors.As(err, &partialErr) {
+		t.Fatalf("ListEvents() error = %v, want *PartialResultError", err)
+	}
+	if !errors.Is(err, ErrEventListFailed) {
+		t.Errorf("ListEvents() error = %v, want ErrEventListFailed", err)
+	}
+	if len(events) != 2 || len(partialErr.Events) != 2 {
+		t.Errorf("partial events = %d (error carries %d), want 2", len(events), len(partialErr.Events))
+	}
+
+	// Without AllowPartial, the already-fetched page is dropped
+	events, err = listWithPageSize(client, 2, false)
+	if err == nil || events != nil {
+		t.Errorf("ListEvents() = %v, %v; want nil events and an error", events, err)
+	}
+	if errors.As(err, &partialErr) {
+		t.Error("Expected a plain error when AllowPartial is off")
+	}
+}
+
+// listWithPageSize lists all events using a fixed page size.
+func listWithPageSize(client *Client, pageSize int, allowPartial bool) ([]*EventResult, error) {
+	return client.listEvents(context.Background(), ListOptions{AllowPartial: allowPartial}, pageSize)
+}
+
+func TestListEventsIn(t *testing.T) {
+	client, fake := newFakeClient(t)
+	base := time.Date(2024, 1, 15, 8, 0, 0, 0, time.UTC)
+	seedHourlyEvents(fake, base, 2)
+
+	// The fake serves the same events for every calendar
+	events, err := client.ListEventsIn(context.Background(), []string{"primary", "team@group.calendar.google.com"}, ListOptions{
+		TimeMin: base,
+		TimeMax: base.Add(24 * time.Hour),
+	})
+	if err != nil {
+		t.Fatalf("ListEventsIn() error = %v", err)
+	}
+
+	if len(events) != 4 {
+		t.Fatalf("ListEventsIn() returned %d events, want 4", len(events))
+	}
+	want := []string{"primary", "team@group.calendar.google.com", "primary", "team@group.calendar.google.com"}
+	for i, event := range events {
+		if event.CalendarID != want[i] {
+			t.Errorf("events[%d].CalendarID = %q, want %q", i, event.CalendarID, want[i])
+		}
+		if i > 0 && event.StartTime.Before(events[i-1].StartTime) {
+			t.Errorf("events[%d] starts before events[%d]", i, i-1)
+		}
+	}
+	if got := fake.requestCount("GET", "/calendars/team@group.calendar.google.com/events"); got != 1 {
+		t.Errorf("made %d requests to the second calendar, want 1", got)
+	}
+}
@@ -0,0 +1,93 @@
+package calendar
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+)
+
+// ErrUnknownField is returned when an output field allowlist names a field
+// EventResult doesn't have.
+var ErrUnknownField = errors.New("unknown output field")
+
+// OutputOptions controls how MarshalEventResult renders an event.
+type OutputOptions struct {
+	// Indent is the indentation for each nesting level, e.g. "  ". Empty
+	// produces compact single-line output.
+	Indent string
+
+	// Fields limits the output to the named JSON fields, in the given
+	// order, e.g. []string{"id", "link"}. Fields that are empty on the
+	// event are written as null. Nil writes every field.
+	Fields []string
+}
+
+// MarshalEventResult encodes r as JSON according to opts.
+func MarshalEventResult(r *EventResult, opts OutputOptions) ([]byte, error) {
+	data, err := json.Marshal(r)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal event: %w", err)
+	}
+
+	if opts.Fields != nil {
+		if data, err = projectFields(data, opts.Fields); err != nil {
+			return nil, err
+		}
+	}
+
+	if opts.Indent == "" {
+		return data, nil
+	}
+	var out bytes.Buffer
+	if err := json.Indent(&out, data, "", opts.Indent); err != nil {
+		return nil, fmt.Errorf("failed to indent event: %w", err)
+	}
+	return out.Bytes(), nil
+}
+
+// projectFields rewrites a JSON-encoded EventResult as an object holding only
+// fields, in order.
+func projectFields(data []byte, fields []string) ([]byte, error) {
+	var all map[string]json.RawMessage
+	if err := json.Unmarshal(data, &all); err != nil {
+		return nil, fmt.Errorf("failed to project event fields: %w", err)
+	}
+
+	known := eventResultFields()
+	var out bytes.Buffer
+	out.WriteByte('{')
+	for i, field := range fields {
+		if !known[field] {
+			return nil, fmt.Errorf("%w: %s", ErrUnknownField, field)
+		}
+		if i > 0 {
+			out.WriteByte(',')
+		}
+		key, _ := json.Marshal(field)
+		out.Write(key)
+		out.WriteByte(':')
+		if value, ok := all[field]; ok {
+			out.Write(value)
+		} else {
+			out.WriteString("null")
+		}
+	}
+	out.WriteByte('}')
+	return out.Bytes(), nil
+}
+
+// eventResultFields returns the JSON names of EventResult's fields.
+func eventResultFields() map[string]bool {
+	fields := make(map[string]bool)
+	typ := reflect.TypeFor[EventResult]()
+	for i := 0; i < typ.NumField(); i++ {
+		name, _, _ := strings.Cut(typ.Field(i).Tag.Get("json"), ",")
+		if name != "" && name != "-" {
+			fields[name] = true
+		}
+	}
+	return fields
+}
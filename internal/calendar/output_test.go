@@ -0,0 +1,72 @@
+package calendar
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"time"
+)
+
+func testOutputEvent() *EventResult {
+	return &EventResult{
+		ID:        "abc123",
+		Title:     "Design Review",
+		StartTime: time.Date(2024, 1, 15, 14, 0, 0, 0, time.UTC),
+		EndTime:   time.Date(2024, 1, 15, 15, 0, 0, 0, time.UTC),
+		Link:      "https://calendar.google.com/event?eid=abc123",
+	}
+}
+
+func TestMarshalEventResult_Compact(t *testing.T) {
+	data, err := MarshalEventResult(testOutputEvent(), OutputOptions{})
+	if err != nil {
+		t.Fatalf("MarshalEventResult() error = %v", err)
+	}
+
+	want := `{"id":"abc123","title":"Design Review","start_time":"2024-01-15T14:00:00Z","end_time":"2024-01-15T15:00:00Z","link":"https://calendar.google.com/event?eid=abc123"}`
+	if string(data) != want {
+		t.Errorf("MarshalEventResult() =\n%s\nwant\n%s", data, want)
+	}
+}
+
+func TestMarshalEventResult_Indent(t *testing.T) {
+	data, err := MarshalEventResult(testOutputEvent(), OutputOptions{Indent: "  "})
+	if err != nil {
+		t.Fatalf("MarshalEventResult() error = %v", err)
+	}
+
+	if !strings.Contains(string(data), "\n  \"id\": \"abc123\",\n") {
+		t.Errorf("MarshalEventResult() not indented:\n%s", data)
+	}
+}
+
+func TestMarshalEventResult_Fields(t *testing.T) {
+	tests := []struct {
+		name   string
+		fields []string
+		want   string
+	}{
+		{"id and link", []string{"id", "link"}, `{"id":"abc123","link":"https://calendar.google.com/event?eid=abc123"}`},
+		{"keeps requested order", []string{"link", "id"}, `{"link":"https://calendar.google.com/event?eid=abc123","id":"abc123"}`},
+		{"empty field", []string{"id", "meet_link"}, `{"id":"abc123","meet_link":null}`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			data, err := MarshalEventResult(testOutputEvent(), OutputOptions{Fields: tt.fields})
+			if err != nil {
+				t.Fatalf("MarshalEventResult() error = %v", err)
+			}
+			if string(data) != tt.want {
+				t.Errorf("MarshalEventResult() = %s, want %s", data, tt.want)
+			}
+		})
+	}
+}
+
+func TestMarshalEventResult_UnknownField(t *testing.T) {
+	_, err := MarshalEventResult(testOutputEvent(), OutputOptions{Fields: []string{"id", "nope"}})
+	if !errors.Is(err, ErrUnknownField) {
+		t.Errorf("MarshalEventResult() error = %v, want ErrUnknownField", err)
+	}
+}
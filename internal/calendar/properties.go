@@ -0,0 +1,199 @@
+package calendar
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"google.golang.org/api/calendar/v3"
+)
+
+// Private extended property keys used to store data the API has no field for.
+const (
+	propImportedCreatedAt = "importedCreatedAt"
+	propPriority          = "priority"
+)
+
+// Event priorities range from MaxPriority (most important) to MinPriority.
+// Zero means no priority.
+const (
+	MaxPriority = 1
+	MinPriority = 5
+)
+
+// ErrInvalidPriority is returned for priorities outside MaxPriority..MinPriority.
+var ErrInvalidPriority = errors.New("invalid priority")
+
+// ErrInvalidTag is returned for tags the API can't store or filter by.
+var ErrInvalidTag = errors.New("invalid tag")
+
+// Limits the API puts on extended properties.
+const (
+	maxTagKeyLength   = 44
+	maxTagValueLength = 1024
+)
+
+// reservedProperties are the private extended properties calgo uses itself,
+// which can't be set as tags.
+var reservedProperties = map[string]bool{
+	propImportedCreatedAt: true,
+	propPriority:          true,
+}
+
+// setPrivateProperty sets a private extended property on event.
+func setPrivateProperty(event *calendar.Event, key, value string) {
+	if event.ExtendedProperties == nil {
+		event.ExtendedProperties = &calendar.EventExtendedProperties{}
+	}
+	if event.ExtendedProperties.Private == nil {
+		event.ExtendedProperties.Private = make(map[string]string)
+	}
+	event.ExtendedProperties.Private[key] = value
+}
+
+// privateProperty returns a private extended property of event, or "" if unset.
+func privateProperty(event *calendar.Event, key string) string {
+	if event.ExtendedProperties == nil {
+		return ""
+	}
+	return event.ExtendedProperties.Private[key]
+}
+
+// parseTimeProperty parses an RFC 3339 extended property, returning the zero
+// time when it is unset or malformed.
+func parseTimeProperty(event *calendar.Event, key string) time.Time {
+	value := privateProperty(event, key)
+	if value == "" {
+		return time.Time{}
+	}
+	t, err := time.Parse(time.RFC3339, value)
+	if err != nil {
+		return time.Time{}
+	}
+	return t
+}
+
+// SortByOriginalCreated sorts events in place by when they were originally
+// created: OriginalCreated for imported events, Created otherwise. The sort is
+// stable, so events with equal timestamps keep their relative order.
+func SortByOriginalCreated(events []*EventResult) {
+	sort.SliceStable(events, func(i, j int) bool {
+		return originalCreated(events[i]).Before(originalCreated(events[j]))
+	})
+}
+
+// originalCreated returns the creation time to sort an event by.
+func originalCreated(event *EventResult) time.Time {
+	if !event.OriginalCreated.IsZero() {
+		return event.OriginalCreated
+	}
+	return event.Created
+}
+
+// validatePriority checks that priority is zero or within range.
+func validatePriority(priority int) error {
+	if priority != 0 && (priority < MaxPriority || priority > MinPriority) {
+		return fmt.Errorf("%w: %d (must be between %d and %d)", ErrInvalidPriority, priority, MaxPriority, MinPriority)
+	}
+	return nil
+}
+
+// parsePriorityProperty reads the priority property, returning zero when it
+// is unset or malformed.
+func parsePriorityProperty(event *calendar.Event) int {
+	priority, err := strconv.Atoi(privateProperty(event, propPriority))
+	if err != nil || validatePriority(priority) != nil {
+		return 0
+	}
+	return priority
+}
+
+// SortByPriority sorts events in place from most to least important, with
+// events that have no priority last. The sort is stable, so events of equal
+// priority keep their relative order (e.g. by start time).
+func SortByPriority(events []*EventResult) {
+	rank := func(event *EventResult) int {
+		if event.Priority == 0 {
+			return MinPriority + 1
+		}
+		return event.Priority
+	}
+
+	sort.SliceStable(events, func(i, j int) bool {
+		return rank(events[i]) < rank(events[j])
+	})
+}
+
+// ParseTags parses tags given as "key=value", e.g. "calgo:source=script",
+// as passed to --tag. A later tag with the same key wins.
+func ParseTags(inputs []string) (map[string]string, error) {
+	if len(inputs) == 0 {
+		return nil, nil
+	}
+
+	tags := make(map[string]string, len(inputs))
+	for _, input := range inputs {
+		key, value, ok := strings.Cut(input, "=")
+		if !ok {
+			return nil, fmt.Errorf("%w: %q (use key=value)", ErrInvalidTag, input)
+		}
+		tags[strings.TrimSpace(key)] = strings.TrimSpace(value)
+	}
+	if err := validateTags(tags); err != nil {
+		return nil, err
+	}
+	return tags, nil
+}
+
+// validateTags checks that tags can be stored as private extended
+// properties and used in list filters.
+func validateTags(tags map[string]string) error {
+	for key, value := range tags {
+		switch {
+		case key == "":
+			return fmt.Errorf("%w: empty key", ErrInvalidTag)
+		case strings.Contains(key, "="):
+			return fmt.Errorf("%w: key %q must not contain \"=\"", ErrInvalidTag, key)
+		case len(key) > maxTagKeyLength:
+			return fmt.Errorf("%w: key %q is longer than %d bytes", ErrInvalidTag, key, maxTagKeyLength)
+		case len(value) > maxTagValueLength:
+			return fmt.Errorf("%w: value of %q is longer than %d bytes", ErrInvalidTag, key, maxTagValueLength)
+		case reservedProperties[key]:
+			return fmt.Errorf("%w: %q is used by calgo", ErrInvalidTag, key)
+		}
+	}
+	return nil
+}
+
+// parseTags returns the event's private extended properties that aren't
+// reserved, or nil if there are none.
+func parseTags(event *calendar.Event) map[string]string {
+	if event.ExtendedProperties == nil {
+		return nil
+	}
+
+	var tags map[string]string
+	for key, value := range event.ExtendedProperties.Private {
+		if reservedProperties[key] {
+			continue
+		}
+		if tags == nil {
+			tags = make(map[string]string)
+		}
+		tags[key] = value
+	}
+	return tags
+}
+
+// tagFilters formats tags as sorted "key=value" filters for events.list.
+func tagFilters(tags map[string]string) []string {
+	filters := make([]string, 0, len(tags))
+	for key, value := range tags {
+		filters = append(filters, key+"="+value)
+	}
+	sort.Strings(filters)
+	return filters
+}
@@ -0,0 +1,243 @@
+package calendar
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestCreateEvent_OriginalCreated(t *testing.T) {
+	client, fake := newFakeClient(t)
+
+	original := time.Date(2019, 3, 4, 9, 30, 0, 0, time.UTC)
+	result, err := client.CreateEvent(context.Background(), EventParams{
+		Title:           "Imported Meeting",
+		StartTime:       time.Date(2024, 1, 15, 14, 0, 0, 0, time.UTC),
+		Duration:        time.Hour,
+		OriginalCreated: original,
+	})
+	if err != nil {
+		t.Fatalf("CreateEvent() error = %v", err)
+	}
+
+	sent := fake.inserted[0]
+	if sent.ExtendedProperties == nil || sent.ExtendedProperties.Private[propImportedCreatedAt] != "2019-03-04T09:30:00Z" {
+		t.Errorf("Expected %s private property to be set, got %+v", propImportedCreatedAt, sent.ExtendedProperties)
+	}
+
+	if !result.OriginalCreated.Equal(original) {
+		t.Errorf("CreateEvent() OriginalCreated = %v, want %v", result.OriginalCreated, original)
+	}
+
+	fetched, err := client.GetEvent(context.Background(), result.ID)
+	if err != nil {
+		t.Fatalf("GetEvent() error = %v", err)
+	}
+	if !fetched.OriginalCreated.Equal(original) {
+		t.Errorf("GetEvent() OriginalCreated = %v, want %v", fetched.OriginalCreated, original)
+	}
+}
+
+func TestBuildEvent_NoOriginalCreated(t *testing.T) {
+	event := buildEvent(EventParams{
+		Title:     "Meeting",
+		StartTime: time.Date(2024, 1, 15, 14, 0, 0, 0, time.UTC),
+		Duration:  time.Hour,
+	})
+
+	if event.ExtendedProperties != nil {
+		t.Errorf("Expected no extended properties, got %+v", event.ExtendedProperties)
+	}
+}
+
+func TestSortByOriginalCreated(t *testing.T) {
+	day := func(d int) time.Time { return time.Date(2024, 1, d, 0, 0, 0, 0, time.UTC) }
+
+	events := []*EventResult{
+		{ID: "recent", Created: day(20)},
+		{ID: "imported-old", Created: day(21), OriginalCreated: day(1)},
+		{ID: "middle", Created: day(10)},
+		{ID: "imported-mid", Created: day(21), OriginalCreated: day(5)},
+	}
+
+	SortByOriginalCreated(events)
+
+	want := []string{"imported-old", "imported-mid", "middle", "recent"}
+	for i, id := range want {
+		if events[i].ID != id {
+			t.Errorf("position %d = %s, want %s", i, events[i].ID, id)
+		}
+	}
+}
+
+func TestCreateEvent_Priority(t *testing.T) {
+	client, fake := newFakeClient(t)
+
+	result, err := client.CreateEvent(context.Background(), EventParams{
+		Title:     "Board Meeting",
+		StartTime: time.Date(2024, 1, 15, 14, 0, 0, 0, time.UTC),
+		Duration:  time.Hour,
+		Priority:  2,
+	})
+	if err != nil {
+		t.Fatalf("CreateEvent() error = %v", err)
+	}
+
+	sent := fake.inserted[0]
+	if sent.ExtendedProperties == nil || sent.ExtendedProperties.Private[propPriority] != "2" {
+		t.Errorf("Expected %s private property 2, got %+v", propPriority, sent.ExtendedProperties)
+	}
+
+	fetched, err := client.GetEvent(context.Background(), result.ID)
+	if err != nil {
+		t.Fatalf("GetEvent() error = %v", err)
+	}
+	if fetched.Priority != 2 {
+		t.Errorf("GetEvent() Priority = %d, want 2", fetched.Priority)
+	}
+}
+
+func TestValidateEventParams_Priority(t *testing.T) {
+	tests := []struct {
+		priority int
+		wantErr  bool
+	}{
+		{0, false},
+		{1, false},
+		{5, false},
+		{-1, true},
+		{6, true},
+	}
+
+	for _, tt := range tests {
+		err := validateEventParams(EventParams{
+			Title:     "Meeting",
+			StartTime: time.Date(2024, 1, 15, 14, 0, 0, 0, time.UTC),
+			Duration:  time.Hour,
+			Priority:  tt.priority,
+		})
+		if (err != nil) != tt.wantErr {
+			t.Errorf("priority %d: error = %v, wantErr %v", tt.priority, err, tt.wantErr)
+		}
+		if tt.wantErr && !errors.Is(err, ErrInvalidPriority) {
+			t.Errorf("priority %d: error = %v, want ErrInvalidPriority", tt.priority, err)
+		}
+	}
+}
+
+func TestSortByPriority(t *testing.T) {
+	events := []*EventResult{
+		{ID: "none-a"},
+		{ID: "low", Priority: 5},
+		{ID: "high", Priority: 1},
+		{ID: "none-b"},
+		{ID: "mid", Priority: 3},
+	}
+
+	SortByPriority(events)
+
+	want := []string{"high", "mid", "low", "none-a", "none-b"}
+	for i, id := range want {
+		if events[i].ID != id {
+			t.Errorf("position %d = %s, want %s", i, events[i].ID, id)
+		}
+	}
+}
+
+func TestParseTags(t *testing.T) {
+	tests := []struct {
+		name    string
+		inputs  []string
+		want    map[string]string
+		wantErr bool
+	}{
+		{name: "none"},
+		{
+			name:   "key value pairs",
+			inputs: []string{"calgo:source=script", " calgo:batch-id = xyz "},
+			want:   map[string]string{"calgo:source": "script", "calgo:batch-id": "xyz"},
+		},
+		{name: "empty value", inputs: []string{"reviewed="}, want: map[string]string{"reviewed": ""}},
+		{name: "value with equals", inputs: []string{"expr=a=b"}, want: map[string]string{"expr": "a=b"}},
+		{name: "later wins", inputs: []string{"k=1", "k=2"}, want: map[string]string{"k": "2"}},
+		{name: "missing equals", inputs: []string{"calgo:source"}, wantErr: true},
+		{name: "empty key", inputs: []string{"=x"}, wantErr: true},
+		{name: "reserved key", inputs: []string{"priority=1"}, wantErr: true},
+		{name: "long key", inputs: []string{strings.Repeat("k", maxTagKeyLength+1) + "=x"}, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParseTags(tt.inputs)
+			if tt.wantErr {
+				if !errors.Is(err, ErrInvalidTag) {
+					t.Fatalf("ParseTags() error = %v, want ErrInvalidTag", err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("ParseTags() error = %v", err)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("ParseTags() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestTags_CreateAndList(t *testing.T) {
+	client, fake := newFakeClient(t)
+	ctx := context.Background()
+	start := time.Date(2024, 1, 15, 9, 0, 0, 0, time.UTC)
+
+	tagged, err := client.CreateEvent(ctx, EventParams{
+		Title:     "Generated",
+		StartTime: start,
+		Duration:  time.Hour,
+		Priority:  2,
+		Tags:      map[string]string{"calgo:source": "script", "calgo:batch-id": "xyz"},
+	})
+	if err != nil {
+		t.Fatalf("CreateEvent() error = %v", err)
+	}
+	if want := map[string]string{"calgo:source": "script", "calgo:batch-id": "xyz"}; !reflect.DeepEqual(tagged.Tags, want) {
+		t.Errorf("Tags = %v, want %v without the priority property", tagged.Tags, want)
+	}
+	if _, err := client.CreateEvent(ctx, EventParams{Title: "Manual", StartTime: start, Duration: time.Hour}); err != nil {
+		t.Fatalf("CreateEvent() error = %v", err)
+	}
+	if _, err := client.CreateEvent(ctx, EventParams{
+		Title:     "Other batch",
+		StartTime: start,
+		Duration:  time.Hour,
+		Tags:      map[string]string{"calgo:source": "script", "calgo:batch-id": "abc"},
+	}); err != nil {
+		t.Fatalf("CreateEvent() error = %v", err)
+	}
+
+	events, err := client.ListEvents(ctx, ListOptions{Tags: map[string]string{"calgo:source": "script", "calgo:batch-id": "xyz"}})
+	if err != nil {
+		t.Fatalf("ListEvents() error = %v", err)
+	}
+	if len(events) != 1 || events[0].ID != tagged.ID {
+		t.Errorf("ListEvents() by tags = %v, want only the tagged event", events)
+	}
+
+	events, err = client.ListEvents(ctx, ListOptions{Tags: map[string]string{"calgo:source": "script"}})
+	if err != nil {
+		t.Fatalf("ListEvents() error = %v", err)
+	}
+	if len(events) != 2 {
+		t.Errorf("ListEvents() by source = %d events, want 2", len(events))
+	}
+
+	if _, err := client.CreateEvent(ctx, EventParams{Title: "Bad", StartTime: start, Duration: time.Hour, Tags: map[string]string{"a=b": "c"}}); !errors.Is(err, ErrInvalidTag) {
+		t.Errorf("CreateEvent() with bad tag error = %v, want ErrInvalidTag", err)
+	}
+	if n := len(fake.inserted); n != 3 {
+		t.Errorf("inserted %d events, want 3", n)
+	}
+}
@@ -0,0 +1,125 @@
+package calendar
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// ErrQuickAddFailed is returned when the API can't create an event from text.
+var ErrQuickAddFailed = errors.New("failed to quick add event")
+
+// QuickAdd creates an event from a sentence such as "Lunch with Sam
+// tomorrow at 1pm", letting Google work out the title and time. sendUpdates
+// is as EventParams.SendUpdates. The event is created as Google understood
+// it, so callers should show it to the user; see ParseQuickEvent for
+// parsing the text locally instead.
+func (c *Client) QuickAdd(ctx context.Context, text, sendUpdates string) (*EventResult, error) {
+	text = strings.TrimSpace(text)
+	if text == "" {
+		return nil, fmt.Errorf("%w: text is required", ErrQuickAddFailed)
+	}
+	switch sendUpdates {
+	case "", SendUpdatesAll, SendUpdatesExternalOnly, SendUpdatesNone:
+	default:
+		return nil, fmt.Errorf("%w: %q", ErrInvalidSendUpdates, sendUpdates)
+	}
+
+	ctx, cancel := c.withRequestTimeout(ctx)
+	defer cancel()
+
+	call := c.service.Events.QuickAdd(c.calendarID, text).Context(ctx)
+	if sendUpdates != "" {
+		call = call.SendUpdates(sendUpdates)
+	}
+
+	// Not retried: a lost response would otherwise create the event twice
+	created, err := call.Do()
+	if err != nil {
+		return nil, wrapAPIErrorAs(err, ErrQuickAddFailed)
+	}
+	c.InvalidateCache()
+	return parseEventResult(created)
+}
+
+// quickDurationRegex matches a duration such as "for 1 hour", "for 45 mins"
+// or "for 1h30m".
+var quickDurationRegex = regexp.MustCompile(`(?i)\bfor\s+(\d+)\s*(hours?|hrs?|minutes?|mins?)\b|\bfor\s+(\d+h(?:\d+m)?|\d+m)\b`)
+
+// quickExamples is shown when a quick event can't be parsed.
+const quickExamples = `e.g. "Team meeting tomorrow at 2pm for 1 hour" or "Standup every weekday at 9:30"`
+
+// ParseQuickEvent parses a sentence such as "Team meeting tomorrow at 2pm
+// for 1 hour" into EventParams with our own parsers, as an alternative to
+// QuickAdd. It finds a recurrence phrase (see ExtractRecurrence), a time
+// (see ExtractTime) and a duration introduced by "for"; the remaining words
+// are the title. Without a duration defaultDuration is used. A recurrence
+// with a time of day, as in "every monday at 10am", needs no separate time.
+func ParseQuickEvent(text, timezone string, defaultDuration time.Duration) (EventParams, error) {
+	loc, err := getLocation(timezone)
+	if err != nil {
+		return EventParams{}, err
+	}
+	return parseQuickEvent(text, timezone, defaultDuration, time.Now().In(loc))
+}
+
+// parseQuickEvent implements ParseQuickEvent relative to now.
+func parseQuickEvent(text, timezone string, defaultDuration time.Duration, now time.Time) (EventParams, error) {
+	params := EventParams{Duration: defaultDuration}
+
+	rest := text
+	if m := quickDurationRegex.FindStringSubmatchIndex(rest); m != nil {
+		duration, err := quickDuration(rest, m)
+		if err != nil {
+			return params, err
+		}
+		params.Duration = duration
+		rest = rest[:m[0]] + rest[m[1]:]
+	}
+
+	rest, rec, hasRecurrence := ExtractRecurrence(rest)
+	if hasRecurrence {
+		params.Recurrence = []string{rec.Rule}
+	}
+
+	rest, start, found, err := ExtractTime(rest, timezone)
+	if err != nil {
+		return params, err
+	}
+	switch {
+	case found:
+		params.StartTime = start
+	case hasRecurrence && rec.HasTime:
+		params.StartTime = rec.FirstOccurrence(now)
+	default:
+		return params, fmt.Errorf("%w: no date or time in %q; %s", ErrInvalidDateFormat, text, quickExamples)
+	}
+
+	params.Title = strings.Join(strings.Fields(rest), " ")
+	if params.Title == "" {
+		return params, fmt.Errorf("%w: no title in %q; %s", ErrInvalidEventTime, text, quickExamples)
+	}
+	return params, nil
+}
+
+// quickDuration converts a quickDurationRegex match, given as submatch
+// indexes into s, to a duration.
+func quickDuration(s string, m []int) (time.Duration, error) {
+	if m[2] < 0 {
+		// The "1h30m" form
+		return ParseDuration(s[m[6]:m[7]])
+	}
+
+	n, err := strconv.Atoi(s[m[2]:m[3]])
+	if err != nil {
+		return 0, fmt.Errorf("%w: duration %q", ErrInvalidEventTime, s[m[0]:m[1]])
+	}
+	if strings.HasPrefix(strings.ToLower(s[m[4]:m[5]]), "h") {
+		return time.Duration(n) * time.Hour, nil
+	}
+	return time.Duration(n) * time.Minute, nil
+}
@@ -0,0 +1,107 @@
+package calendar
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestQuickAdd(t *testing.T) {
+	client, fake := newFakeClient(t)
+
+	result, err := client.QuickAdd(context.Background(), " Lunch with Sam tomorrow at 1pm ", SendUpdatesNone)
+	if err != nil {
+		t.Fatalf("QuickAdd() error = %v", err)
+	}
+	if result.ID == "" || result.Title != "Lunch with Sam tomorrow at 1pm" {
+		t.Errorf("QuickAdd() = %+v", result)
+	}
+	query := fake.queries[len(fake.queries)-1]
+	if query.Get("text") != "Lunch with Sam tomorrow at 1pm" || query.Get("sendUpdates") != "none" {
+		t.Errorf("query = %v, want trimmed text and sendUpdates=none", query)
+	}
+
+	if _, err := client.QuickAdd(context.Background(), "  ", ""); !errors.Is(err, ErrQuickAddFailed) {
+		t.Errorf("QuickAdd() of empty text error = %v, want ErrQuickAddFailed", err)
+	}
+}
+
+func TestParseQuickEvent(t *testing.T) {
+	// Times are relative to the real day, recurrences to a Monday
+	now := time.Date(2024, 1, 15, 8, 0, 0, 0, time.UTC)
+	today := time.Now().UTC()
+	tomorrow := today.AddDate(0, 0, 1)
+
+	tests := []struct {
+		name       string
+		text       string
+		title      string
+		start      time.Time
+		duration   time.Duration
+		recurrence []string
+	}{
+		{
+			name:     "time and duration",
+			text:     "Team meeting tomorrow at 2pm for 1 hour",
+			title:    "Team meeting",
+			start:    time.Date(tomorrow.Year(), tomorrow.Month(), tomorrow.Day(), 14, 0, 0, 0, time.UTC),
+			duration: time.Hour,
+		},
+		{
+			name:     "default duration",
+			text:     "Call Sam at 15:00",
+			title:    "Call Sam",
+			start:    time.Date(today.Year(), today.Month(), today.Day(), 15, 0, 0, 0, time.UTC),
+			duration: 30 * time.Minute,
+		},
+		{
+			name:     "compact duration",
+			text:     "Review for 1h30m 2024-01-20 10:00",
+			title:    "Review",
+			start:    time.Date(2024, 1, 20, 10, 0, 0, 0, time.UTC),
+			duration: 90 * time.Minute,
+		},
+		{
+			name:       "recurrence with time",
+			text:       "Standup every weekday at 9:30 for 15 mins",
+			title:      "Standup",
+			start:      time.Date(2024, 1, 15, 9, 30, 0, 0, time.UTC),
+			duration:   15 * time.Minute,
+			recurrence: []string{"RRULE:FREQ=WEEKLY;BYDAY=MO,TU,WE,TH,FR"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			params, err := parseQuickEvent(tt.text, "UTC", 30*time.Minute, now)
+			if err != nil {
+				t.Fatalf("parseQuickEvent(%q) error = %v", tt.text, err)
+			}
+			if params.Title != tt.title {
+				t.Errorf("Title = %q, want %q", params.Title, tt.title)
+			}
+			if !params.StartTime.Equal(tt.start) {
+				t.Errorf("StartTime = %v, want %v", params.StartTime, tt.start)
+			}
+			if params.Duration != tt.duration {
+				t.Errorf("Duration = %v, want %v", params.Duration, tt.duration)
+			}
+			if !reflect.DeepEqual(params.Recurrence, tt.recurrence) {
+				t.Errorf("Recurrence = %v, want %v", params.Recurrence, tt.recurrence)
+			}
+		})
+	}
+}
+
+func TestParseQuickEvent_Errors(t *testing.T) {
+	now := time.Date(2024, 1, 15, 8, 0, 0, 0, time.UTC)
+
+	if _, err := parseQuickEvent("Team meeting", "UTC", 30*time.Minute, now); !errors.Is(err, ErrInvalidDateFormat) {
+		t.Errorf("no time error = %v, want ErrInvalidDateFormat", err)
+	}
+	if _, err := parseQuickEvent("tomorrow at 2pm", "UTC", 30*time.Minute, now); !errors.Is(err, ErrInvalidEventTime) {
+		t.Errorf("no title error = %v, want ErrInvalidEventTime", err)
+	}
+}
@@ -0,0 +1,517 @@
+package calendar
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Errors for recurrence parsing.
+var (
+	ErrInvalidRecurrence     = errors.New("invalid recurrence")
+	ErrOverlappingRecurrence = errors.New("recurring instances overlap")
+)
+
+// weekdayCodes maps lowercase day names and abbreviations to RRULE BYDAY codes.
+var weekdayCodes = map[string]string{
+	"monday":    "MO",
+	"mon":       "MO",
+	"tuesday":   "TU",
+	"tue":       "TU",
+	"tues":      "TU",
+	"wednesday": "WE",
+	"wed":       "WE",
+	"thursday":  "TH",
+	"thu":       "TH",
+	"thurs":     "TH",
+	"friday":    "FR",
+	"fri":       "FR",
+	"saturday":  "SA",
+	"sat":       "SA",
+	"sunday":    "SU",
+	"sun":       "SU",
+}
+
+// weekdaysByDay is the BYDAY list for Monday through Friday.
+const weekdaysByDay = "MO,TU,WE,TH,FR"
+
+// recurrenceUnits maps singular unit names to RRULE FREQ values.
+var recurrenceUnits = map[string]string{
+	"minute": "MINUTELY",
+	"hour":   "HOURLY",
+	"day":    "DAILY",
+	"week":   "WEEKLY",
+	"month":  "MONTHLY",
+	"year":   "YEARLY",
+}
+
+// everyIntervalRegex matches "every N units" phrases such as "every 2 weeks".
+var everyIntervalRegex = regexp.MustCompile(`^every\s+(\d+)\s+(minutes?|mins?|hours?|hrs?|days?|weeks?|months?|years?)$`)
+
+// ParseRecurrencePhrase converts a simple recurrence phrase into an RRULE
+// string suitable for EventParams.Recurrence.
+// Supported phrases:
+//   - "daily", "weekly", "monthly", "yearly"
+//   - "every day", "every week", "every month", "every year"
+//   - "every weekday"
+//   - "every monday", "every tue", ...
+//   - "every 2 weeks", "every 3 days", "every 30 minutes", "every 2 hours"
+func ParseRecurrencePhrase(input string) (string, error) {
+	phrase := strings.Join(strings.Fields(strings.ToLower(input)), " ")
+	if phrase == "" {
+		return "", fmt.Errorf("%w: empty input", ErrInvalidRecurrence)
+	}
+
+	switch phrase {
+	case "daily", "every day":
+		return "RRULE:FREQ=DAILY", nil
+	case "weekly", "every week":
+		return "RRULE:FREQ=WEEKLY", nil
+	case "monthly", "every month":
+		return "RRULE:FREQ=MONTHLY", nil
+	case "yearly", "annually", "every year":
+		return "RRULE:FREQ=YEARLY", nil
+	case "every weekday":
+		return "RRULE:FREQ=WEEKLY;BYDAY=" + weekdaysByDay, nil
+	}
+
+	if day, ok := strings.CutPrefix(phrase, "every "); ok {
+		if code, ok := weekdayCodes[day]; ok {
+			return "RRULE:FREQ=WEEKLY;BYDAY=" + code, nil
+		}
+	}
+
+	if matches := everyIntervalRegex.FindStringSubmatch(phrase); matches != nil {
+		interval, err := strconv.Atoi(matches[1])
+		if err != nil || interval <= 0 {
+			return "", fmt.Errorf("%w: interval must be a positive number in '%s'", ErrInvalidRecurrence, input)
+		}
+
+		freq := recurrenceUnits[normalizeRecurrenceUnit(matches[2])]
+		if interval == 1 {
+			return "RRULE:FREQ=" + freq, nil
+		}
+		return fmt.Sprintf("RRULE:FREQ=%s;INTERVAL=%d", freq, interval), nil
+	}
+
+	return "", fmt.Errorf("%w: could not parse '%s'. Try phrases like 'daily', 'every weekday', 'every monday', or 'every 2 weeks'", ErrInvalidRecurrence, input)
+}
+
+// normalizeRecurrenceUnit maps unit spellings like "mins" or "hrs" to the
+// singular keys of recurrenceUnits.
+func normalizeRecurrenceUnit(unit string) string {
+	switch {
+	case strings.HasPrefix(unit, "min"):
+		return "minute"
+	case strings.HasPrefix(unit, "h"):
+		return "hour"
+	default:
+		return strings.TrimSuffix(unit, "s")
+	}
+}
+
+// dayIndex maps RRULE BYDAY codes to their position in the week.
+var dayIndex = map[string]int{
+	"MO": 0, "TU": 1, "WE": 2, "TH": 3, "FR": 4, "SA": 5, "SU": 6,
+}
+
+// validFreqs holds the FREQ values accepted by ValidateRRULE.
+var validFreqs = map[string]bool{
+	"SECONDLY": true, "MINUTELY": true, "HOURLY": true, "DAILY": true,
+	"WEEKLY": true, "MONTHLY": true, "YEARLY": true,
+}
+
+// byDayRegex matches a BYDAY entry: a weekday code with an optional
+// ordinal such as "1MO" or "-1FR".
+var byDayRegex = regexp.MustCompile(`^[+-]?(?:\d{1,2})?(MO|TU|WE|TH|FR|SA|SU)$`)
+
+// ValidateRRULE checks an RRULE, with or without the "RRULE:" prefix, for
+// the mistakes the API would otherwise reject with an opaque error: a
+// missing or unknown FREQ, a non-positive INTERVAL or COUNT, an invalid
+// BYDAY code, and UNTIL combined with COUNT.
+func ValidateRRULE(rule string) error {
+	body := strings.TrimPrefix(rule, "RRULE:")
+	parts := parseRRULEParts(body)
+
+	freq, ok := parts["FREQ"]
+	if !ok {
+		return fmt.Errorf("%w: %s: FREQ is required", ErrInvalidRecurrence, rule)
+	}
+	if !validFreqs[freq] {
+		return fmt.Errorf("%w: %s: unknown FREQ %q", ErrInvalidRecurrence, rule, freq)
+	}
+
+	for _, key := range []string{"INTERVAL", "COUNT"} {
+		value, ok := parts[key]
+		if !ok {
+			continue
+		}
+		if n, err := strconv.Atoi(value); err != nil || n <= 0 {
+			return fmt.Errorf("%w: %s: %s must be a positive integer", ErrInvalidRecurrence, rule, key)
+		}
+	}
+
+	if byDay, ok := parts["BYDAY"]; ok {
+		for _, day := range strings.Split(byDay, ",") {
+			if !byDayRegex.MatchString(day) {
+				return fmt.Errorf("%w: %s: invalid BYDAY value %q", ErrInvalidRecurrence, rule, day)
+			}
+		}
+	}
+
+	_, hasUntil := parts["UNTIL"]
+	_, hasCount := parts["COUNT"]
+	if hasUntil && hasCount {
+		return fmt.Errorf("%w: %s: UNTIL and COUNT can't both be set", ErrInvalidRecurrence, rule)
+	}
+
+	return nil
+}
+
+// validateRecurrence runs ValidateRRULE on each RRULE line of recurrence.
+// Other lines such as EXDATE are passed through to the API unchecked.
+func validateRecurrence(recurrence []string) error {
+	for _, line := range recurrence {
+		if strings.HasPrefix(line, "RRULE:") {
+			if err := ValidateRRULE(line); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// checkRecurrenceOverlap returns ErrOverlappingRecurrence when an event of
+// the given duration would overlap its own next instance under any of the
+// RRULE lines in recurrence (e.g. a 2-hour event repeating every hour).
+func checkRecurrenceOverlap(recurrence []string, duration time.Duration) error {
+	for _, line := range recurrence {
+		rule, ok := strings.CutPrefix(line, "RRULE:")
+		if !ok {
+			continue
+		}
+
+		gap, ok := minRecurrenceGap(parseRRULEParts(rule))
+		if ok && duration > gap {
+			return fmt.Errorf("%w: event lasts %s but repeats every %s", ErrOverlappingRecurrence, duration, gap)
+		}
+	}
+	return nil
+}
+
+// parseRRULEParts splits an RRULE body like "FREQ=WEEKLY;INTERVAL=2" into
+// its uppercase key/value parts.
+func parseRRULEParts(rule string) map[string]string {
+	parts := make(map[string]string)
+	for _, part := range strings.Split(rule, ";") {
+		key, value, ok := strings.Cut(part, "=")
+		if !ok {
+			continue
+		}
+		parts[strings.ToUpper(strings.TrimSpace(key))] = strings.ToUpper(strings.TrimSpace(value))
+	}
+	return parts
+}
+
+// minRecurrenceGap returns the shortest time between consecutive instances
+// for a parsed RRULE. Monthly and yearly rules use their shortest possible
+// period. It returns false when the gap can't be determined.
+func minRecurrenceGap(parts map[string]string) (time.Duration, bool) {
+	interval := 1
+	if value, ok := parts["INTERVAL"]; ok {
+		n, err := strconv.Atoi(value)
+		if err != nil || n <= 0 {
+			return 0, false
+		}
+		interval = n
+	}
+
+	day := 24 * time.Hour
+	switch parts["FREQ"] {
+	case "MINUTELY":
+		return time.Duration(interval) * time.Minute, true
+	case "HOURLY":
+		return time.Duration(interval) * time.Hour, true
+	case "DAILY":
+		return time.Duration(interval) * day, true
+	case "WEEKLY":
+		return minWeeklyGap(parts["BYDAY"], interval), true
+	case "MONTHLY":
+		return time.Duration(interval) * 28 * day, true
+	case "YEARLY":
+		return time.Duration(interval) * 365 * day, true
+	default:
+		return 0, false
+	}
+}
+
+// minWeeklyGap returns the shortest gap between the BYDAY days of a weekly
+// rule, including the wrap-around into the next repeating week.
+func minWeeklyGap(byDay string, interval int) time.Duration {
+	day := 24 * time.Hour
+
+	var days []int
+	for _, code := range strings.Split(byDay, ",") {
+		if idx, ok := dayIndex[code]; ok {
+			days = append(days, idx)
+		}
+	}
+	if len(days) <= 1 {
+		return time.Duration(7*interval) * day
+	}
+
+	sort.Ints(days)
+	gap := 7*interval - days[len(days)-1] + days[0]
+	for i := 1; i < len(days); i++ {
+		if d := days[i] - days[i-1]; d > 0 && d < gap {
+			gap = d
+		}
+	}
+	return time.Duration(gap) * day
+}
+
+// skipWeekends applies params.SkipWeekends: daily RRULEs become weekday
+// rules and a Saturday or Sunday start moves to the next Monday at the
+// same time of day.
+func skipWeekends(params EventParams) EventParams {
+	if !params.SkipWeekends {
+		return params
+	}
+
+	if len(params.Recurrence) > 0 {
+		recurrence := make([]string, len(params.Recurrence))
+		for i, line := range params.Recurrence {
+			recurrence[i] = weekdayRecurrence(line)
+		}
+		params.Recurrence = recurrence
+	}
+
+	switch params.StartTime.Weekday() {
+	case time.Saturday:
+		params.StartTime = params.StartTime.AddDate(0, 0, 2)
+	case time.Sunday:
+		params.StartTime = params.StartTime.AddDate(0, 0, 1)
+	}
+	return params
+}
+
+// weekdayRecurrence rewrites an RRULE line repeating every day into
+// FREQ=WEEKLY;BYDAY=MO,TU,WE,TH,FR, keeping its other parts such as COUNT
+// or UNTIL. Other lines, including daily rules with an INTERVAL or BYDAY
+// that can't be expressed as weekdays, are returned unchanged.
+func weekdayRecurrence(line string) string {
+	rule, ok := strings.CutPrefix(line, "RRULE:")
+	if !ok {
+		return line
+	}
+
+	parts := parseRRULEParts(rule)
+	if parts["FREQ"] != "DAILY" {
+		return line
+	}
+	if _, ok := parts["BYDAY"]; ok {
+		return line
+	}
+	if interval, ok := parts["INTERVAL"]; ok && interval != "1" {
+		return line
+	}
+
+	kept := []string{"FREQ=WEEKLY", "BYDAY=" + weekdaysByDay}
+	for _, part := range strings.Split(rule, ";") {
+		key, _, _ := strings.Cut(part, "=")
+		switch strings.ToUpper(strings.TrimSpace(key)) {
+		case "FREQ", "INTERVAL", "":
+			continue
+		}
+		kept = append(kept, part)
+	}
+	return "RRULE:" + strings.Join(kept, ";")
+}
+
+// Recurrence is a natural-language recurrence parsed by ParseRecurrence.
+type Recurrence struct {
+	// Rule is the RRULE line, e.g. "RRULE:FREQ=WEEKLY;BYDAY=MO".
+	Rule string
+
+	// HasTime reports whether the phrase gave a time of day, as in "every
+	// weekday at 9am". Hour and Minute hold it.
+	HasTime bool
+	Hour    int
+	Minute  int
+}
+
+// ordinalWeeks maps ordinal words to RRULE BYDAY week numbers.
+var ordinalWeeks = map[string]int{
+	"first": 1, "1st": 1,
+	"second": 2, "2nd": 2,
+	"third": 3, "3rd": 3,
+	"fourth": 4, "4th": 4,
+	"last": -1,
+}
+
+// nthWeekdayRegex matches phrases like "first friday of the month" or
+// "every last mon of each month".
+var nthWeekdayRegex = regexp.MustCompile(`^(?:every |on the |the )?(\w+) (\w+) of (?:the|every|each) month$`)
+
+// recurrenceAtRegex splits a trailing "at TIME" off a recurrence phrase.
+var recurrenceAtRegex = regexp.MustCompile(`^(.+?) at (.+)$`)
+
+// ParseRecurrence converts a natural-language recurrence into an RRULE and
+// an optional time of day. On top of the ParseRecurrencePhrase phrases it
+// supports:
+//   - a trailing time: "every weekday at 9am", "every monday at 14:30"
+//   - nth weekday of the month: "first friday of the month",
+//     "last monday of every month"
+//   - "every other": "every other week", "every other tuesday"
+func ParseRecurrence(input string) (Recurrence, error) {
+	phrase := strings.Join(strings.Fields(strings.ToLower(input)), " ")
+	if phrase == "" {
+		return Recurrence{}, fmt.Errorf("%w: empty input", ErrInvalidRecurrence)
+	}
+
+	var rec Recurrence
+	if matches := recurrenceAtRegex.FindStringSubmatch(phrase); matches != nil {
+		hour, minute, _, ok := parseClock(matches[2])
+		if !ok {
+			return Recurrence{}, fmt.Errorf("%w: invalid time '%s' in '%s'", ErrInvalidRecurrence, matches[2], input)
+		}
+		phrase = matches[1]
+		rec.HasTime, rec.Hour, rec.Minute = true, hour, minute
+	}
+
+	if matches := nthWeekdayRegex.FindStringSubmatch(phrase); matches != nil {
+		week, okWeek := ordinalWeeks[matches[1]]
+		code, okDay := weekdayCodes[matches[2]]
+		if !okWeek || !okDay {
+			return Recurrence{}, fmt.Errorf("%w: could not parse '%s'", ErrInvalidRecurrence, input)
+		}
+		rec.Rule = fmt.Sprintf("RRULE:FREQ=MONTHLY;BYDAY=%d%s", week, code)
+		return rec, nil
+	}
+
+	if rest, ok := strings.CutPrefix(phrase, "every other "); ok {
+		rule, err := ParseRecurrencePhrase("every " + rest)
+		if err != nil {
+			return Recurrence{}, err
+		}
+		if strings.Contains(rule, "INTERVAL=") {
+			return Recurrence{}, fmt.Errorf("%w: 'every other' can't be combined with a count in '%s'", ErrInvalidRecurrence, input)
+		}
+		rec.Rule = rule + ";INTERVAL=2"
+		return rec, nil
+	}
+
+	rule, err := ParseRecurrencePhrase(phrase)
+	if err != nil {
+		return Recurrence{}, err
+	}
+	rec.Rule = rule
+	return rec, nil
+}
+
+// FirstOccurrence returns the first time at or after from that matches the
+// recurrence's days and time of day, for use as the event's start. Without
+// a time of day, from's clock time is kept. Rules more frequent than daily
+// start at from.
+func (r Recurrence) FirstOccurrence(from time.Time) time.Time {
+	parts := parseRRULEParts(strings.TrimPrefix(r.Rule, "RRULE:"))
+	switch parts["FREQ"] {
+	case "SECONDLY", "MINUTELY", "HOURLY":
+		return from
+	}
+
+	hour, minute, second := from.Clock()
+	if r.HasTime {
+		hour, minute, second = r.Hour, r.Minute, 0
+	}
+
+	// A monthly nth-weekday rule recurs at least once every 31 days,
+	// within a year at most
+	for day := 0; day < 366; day++ {
+		d := from.AddDate(0, 0, day)
+		candidate := time.Date(d.Year(), d.Month(), d.Day(), hour, minute, second, 0, from.Location())
+		if candidate.Before(from) {
+			continue
+		}
+		if matchesByDay(candidate, parts["BYDAY"]) {
+			return candidate
+		}
+	}
+	return from
+}
+
+// matchesByDay reports whether t falls on one of the BYDAY entries, where
+// an entry's ordinal is its week within the month (negative counts from
+// the end). An empty byDay matches every day.
+func matchesByDay(t time.Time, byDay string) bool {
+	if byDay == "" {
+		return true
+	}
+
+	for _, entry := range strings.Split(byDay, ",") {
+		code := entry[len(entry)-2:]
+		if dayIndex[code] != (int(t.Weekday())+6)%7 {
+			continue
+		}
+
+		ordinal := entry[:len(entry)-2]
+		if ordinal == "" {
+			return true
+		}
+		n, err := strconv.Atoi(ordinal)
+		if err != nil {
+			continue
+		}
+		if n > 0 && (t.Day()-1)/7+1 == n {
+			return true
+		}
+		if n < 0 {
+			daysInMonth := time.Date(t.Year(), t.Month()+1, 0, 0, 0, 0, 0, t.Location()).Day()
+			if (daysInMonth-t.Day())/7+1 == -n {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// recurrenceStartWords are the words a recurrence phrase can begin with.
+var recurrenceStartWords = map[string]bool{
+	"every": true, "daily": true, "weekly": true, "monthly": true,
+	"yearly": true, "annually": true,
+	"first": true, "second": true, "third": true, "fourth": true, "last": true,
+	"1st": true, "2nd": true, "3rd": true, "4th": true,
+}
+
+// maxRecurrenceWords is the longest run of words ExtractRecurrence tries,
+// e.g. "last friday of every month at 4:30 pm".
+const maxRecurrenceWords = 9
+
+// ExtractRecurrence finds the first recurrence phrase anywhere in input, as
+// in "standup every weekday at 9am", and returns the input with the phrase
+// removed. The longest run of words that ParseRecurrence accepts wins, so a
+// trailing "at 9am" is taken along with the phrase.
+func ExtractRecurrence(input string) (remainder string, rec Recurrence, found bool) {
+	words := strings.Fields(input)
+	for start, word := range words {
+		if !recurrenceStartWords[strings.ToLower(word)] {
+			continue
+		}
+
+		for end := min(len(words), start+maxRecurrenceWords); end > start; end-- {
+			parsed, err := ParseRecurrence(strings.Join(words[start:end], " "))
+			if err != nil {
+				continue
+			}
+
+			rest := append(append([]string{}, words[:start]...), words[end:]...)
+			return strings.Join(rest, " "), parsed, true
+		}
+	}
+
+	return input, Recurrence{}, false
+}
@@ -0,0 +1,408 @@
+package calendar
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestParseRecurrencePhrase(t *testing.T) {
+	tests := []struct {
+		name  string
+		input string
+		want  string
+	}{
+		{
+			name:  "daily",
+			input: "daily",
+			want:  "RRULE:FREQ=DAILY",
+		},
+		{
+			name:  "weekly",
+			input: "weekly",
+			want:  "RRULE:FREQ=WEEKLY",
+		},
+		{
+			name:  "every weekday",
+			input: "every weekday",
+			want:  "RRULE:FREQ=WEEKLY;BYDAY=MO,TU,WE,TH,FR",
+		},
+		{
+			name:  "every monday",
+			input: "every monday",
+			want:  "RRULE:FREQ=WEEKLY;BYDAY=MO",
+		},
+		{
+			name:  "abbreviated day with mixed case",
+			input: "Every  Fri",
+			want:  "RRULE:FREQ=WEEKLY;BYDAY=FR",
+		},
+		{
+			name:  "every 2 weeks",
+			input: "every 2 weeks",
+			want:  "RRULE:FREQ=WEEKLY;INTERVAL=2",
+		},
+		{
+			name:  "every 1 month",
+			input: "every 1 month",
+			want:  "RRULE:FREQ=MONTHLY",
+		},
+		{
+			name:  "every 30 minutes",
+			input: "every 30 minutes",
+			want:  "RRULE:FREQ=MINUTELY;INTERVAL=30",
+		},
+		{
+			name:  "every 2 hrs",
+			input: "every 2 hrs",
+			want:  "RRULE:FREQ=HOURLY;INTERVAL=2",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParseRecurrencePhrase(tt.input)
+			if err != nil {
+				t.Fatalf("ParseRecurrencePhrase(%q) error = %v", tt.input, err)
+			}
+			if got != tt.want {
+				t.Errorf("ParseRecurrencePhrase(%q) = %q, want %q", tt.input, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseRecurrencePhrase_Invalid(t *testing.T) {
+	inputs := []string{
+		"",
+		"sometimes",
+		"every blursday",
+		"every 0 days",
+		"every two weeks",
+	}
+
+	for _, input := range inputs {
+		t.Run(input, func(t *testing.T) {
+			_, err := ParseRecurrencePhrase(input)
+			if !errors.Is(err, ErrInvalidRecurrence) {
+				t.Errorf("ParseRecurrencePhrase(%q) error = %v, want ErrInvalidRecurrence", input, err)
+			}
+		})
+	}
+}
+
+func TestBuildEvent_Recurrence(t *testing.T) {
+	rule, err := ParseRecurrencePhrase("every weekday")
+	if err != nil {
+		t.Fatalf("ParseRecurrencePhrase failed: %v", err)
+	}
+
+	event := buildEvent(EventParams{
+		Title:      "Standup",
+		StartTime:  time.Date(2024, 1, 15, 9, 0, 0, 0, time.UTC),
+		Duration:   15 * time.Minute,
+		Recurrence: []string{rule},
+	})
+
+	if len(event.Recurrence) != 1 || event.Recurrence[0] != rule {
+		t.Errorf("buildEvent() Recurrence = %v, want [%s]", event.Recurrence, rule)
+	}
+}
+
+func TestCheckRecurrenceOverlap(t *testing.T) {
+	tests := []struct {
+		name       string
+		recurrence []string
+		duration   time.Duration
+		wantErr    bool
+	}{
+		{
+			name:       "two hour event every hour",
+			recurrence: []string{"RRULE:FREQ=HOURLY"},
+			duration:   2 * time.Hour,
+			wantErr:    true,
+		},
+		{
+			name:       "one hour event every hour",
+			recurrence: []string{"RRULE:FREQ=HOURLY"},
+			duration:   time.Hour,
+			wantErr:    false,
+		},
+		{
+			name:       "thirty minutes every 20 minutes",
+			recurrence: []string{"RRULE:FREQ=MINUTELY;INTERVAL=20"},
+			duration:   30 * time.Minute,
+			wantErr:    true,
+		},
+		{
+			name:       "two day event on consecutive weekdays",
+			recurrence: []string{"RRULE:FREQ=WEEKLY;BYDAY=MO,TU,WE,TH,FR"},
+			duration:   48 * time.Hour,
+			wantErr:    true,
+		},
+		{
+			name:       "two day event on monday and thursday",
+			recurrence: []string{"RRULE:FREQ=WEEKLY;BYDAY=MO,TH"},
+			duration:   48 * time.Hour,
+			wantErr:    false,
+		},
+		{
+			name:       "daily standup",
+			recurrence: []string{"RRULE:FREQ=DAILY;COUNT=10"},
+			duration:   15 * time.Minute,
+			wantErr:    false,
+		},
+		{
+			name:       "non-RRULE lines are ignored",
+			recurrence: []string{"EXDATE:20240115T090000Z"},
+			duration:   48 * time.Hour,
+			wantErr:    false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := checkRecurrenceOverlap(tt.recurrence, tt.duration)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("checkRecurrenceOverlap() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if err != nil && !errors.Is(err, ErrOverlappingRecurrence) {
+				t.Errorf("checkRecurrenceOverlap() error = %v, want ErrOverlappingRecurrence", err)
+			}
+		})
+	}
+}
+
+func TestValidateEventParams_OverlappingRecurrence(t *testing.T) {
+	err := validateEventParams(EventParams{
+		Title:      "Marathon",
+		StartTime:  time.Now(),
+		Duration:   2 * time.Hour,
+		Recurrence: []string{"RRULE:FREQ=HOURLY"},
+	})
+	if !errors.Is(err, ErrOverlappingRecurrence) {
+		t.Errorf("validateEventParams() error = %v, want ErrOverlappingRecurrence", err)
+	}
+}
+
+func TestValidateRRULE(t *testing.T) {
+	tests := []struct {
+		name    string
+		rule    string
+		wantErr bool
+	}{
+		{"valid weekly", "RRULE:FREQ=WEEKLY;INTERVAL=2;BYDAY=MO,WE;COUNT=10", false},
+		{"valid without prefix", "FREQ=MONTHLY;BYDAY=-1FR;UNTIL=20241231T000000Z", false},
+		{"missing FREQ", "RRULE:INTERVAL=2;BYDAY=MO", true},
+		{"unknown FREQ", "RRULE:FREQ=FORTNIGHTLY", true},
+		{"zero INTERVAL", "RRULE:FREQ=DAILY;INTERVAL=0", true},
+		{"non-numeric COUNT", "RRULE:FREQ=DAILY;COUNT=ten", true},
+		{"invalid BYDAY", "RRULE:FREQ=WEEKLY;BYDAY=MO,XX", true},
+		{"UNTIL and COUNT", "RRULE:FREQ=DAILY;COUNT=5;UNTIL=20241231T000000Z", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := ValidateRRULE(tt.rule)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ValidateRRULE(%q) error = %v, wantErr %v", tt.rule, err, tt.wantErr)
+			}
+			if err != nil && !errors.Is(err, ErrInvalidRecurrence) {
+				t.Errorf("ValidateRRULE(%q) error = %v, want ErrInvalidRecurrence", tt.rule, err)
+			}
+		})
+	}
+}
+
+func TestValidateEventParams_InvalidRRULE(t *testing.T) {
+	err := validateEventParams(EventParams{
+		Title:      "Standup",
+		StartTime:  time.Now(),
+		Duration:   15 * time.Minute,
+		Recurrence: []string{"RRULE:BYDAY=MO", "EXDATE:20240120T090000Z"},
+	})
+	if !errors.Is(err, ErrInvalidRecurrence) {
+		t.Errorf("validateEventParams() error = %v, want ErrInvalidRecurrence", err)
+	}
+}
+
+func TestWeekdayRecurrence(t *testing.T) {
+	tests := []struct {
+		name string
+		line string
+		want string
+	}{
+		{"daily", "RRULE:FREQ=DAILY", "RRULE:FREQ=WEEKLY;BYDAY=MO,TU,WE,TH,FR"},
+		{"daily with count", "RRULE:FREQ=DAILY;COUNT=10", "RRULE:FREQ=WEEKLY;BYDAY=MO,TU,WE,TH,FR;COUNT=10"},
+		{"daily interval 1", "RRULE:FREQ=DAILY;INTERVAL=1;UNTIL=20240301T000000Z", "RRULE:FREQ=WEEKLY;BYDAY=MO,TU,WE,TH,FR;UNTIL=20240301T000000Z"},
+		{"daily interval 2", "RRULE:FREQ=DAILY;INTERVAL=2", "RRULE:FREQ=DAILY;INTERVAL=2"},
+		{"daily with byday", "RRULE:FREQ=DAILY;BYDAY=SA,SU", "RRULE:FREQ=DAILY;BYDAY=SA,SU"},
+		{"weekly", "RRULE:FREQ=WEEKLY", "RRULE:FREQ=WEEKLY"},
+		{"exdate", "EXDATE:20240120T090000Z", "EXDATE:20240120T090000Z"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := weekdayRecurrence(tt.line); got != tt.want {
+				t.Errorf("weekdayRecurrence(%q) = %q, want %q", tt.line, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSkipWeekends_SingleEvent(t *testing.T) {
+	tests := []struct {
+		name  string
+		start time.Time
+		want  time.Time
+	}{
+		{"saturday", time.Date(2024, 1, 13, 9, 0, 0, 0, time.UTC), time.Date(2024, 1, 15, 9, 0, 0, 0, time.UTC)},
+		{"sunday", time.Date(2024, 1, 14, 9, 0, 0, 0, time.UTC), time.Date(2024, 1, 15, 9, 0, 0, 0, time.UTC)},
+		{"friday", time.Date(2024, 1, 12, 9, 0, 0, 0, time.UTC), time.Date(2024, 1, 12, 9, 0, 0, 0, time.UTC)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := skipWeekends(EventParams{StartTime: tt.start, SkipWeekends: true})
+			if !got.StartTime.Equal(tt.want) {
+				t.Errorf("StartTime = %v, want %v", got.StartTime, tt.want)
+			}
+		})
+	}
+
+	saturday := time.Date(2024, 1, 13, 9, 0, 0, 0, time.UTC)
+	if got := skipWeekends(EventParams{StartTime: saturday}); !got.StartTime.Equal(saturday) {
+		t.Errorf("StartTime moved without SkipWeekends: %v", got.StartTime)
+	}
+}
+
+func TestCreateEvent_SkipWeekends(t *testing.T) {
+	client, fake := newFakeClient(t)
+
+	result, err := client.CreateEvent(context.Background(), EventParams{
+		Title:        "Standup",
+		StartTime:    time.Date(2024, 1, 13, 9, 0, 0, 0, time.UTC),
+		Duration:     15 * time.Minute,
+		Recurrence:   []string{"RRULE:FREQ=DAILY"},
+		SkipWeekends: true,
+	})
+	if err != nil {
+		t.Fatalf("CreateEvent() error = %v", err)
+	}
+
+	if want := time.Date(2024, 1, 15, 9, 0, 0, 0, time.UTC); !result.StartTime.Equal(want) {
+		t.Errorf("StartTime = %v, want %v", result.StartTime, want)
+	}
+	stored := fake.events[result.ID]
+	if want := []string{"RRULE:FREQ=WEEKLY;BYDAY=MO,TU,WE,TH,FR"}; !reflect.DeepEqual(stored.Recurrence, want) {
+		t.Errorf("Recurrence = %v, want %v", stored.Recurrence, want)
+	}
+}
+
+func TestParseRecurrence(t *testing.T) {
+	tests := []struct {
+		input string
+		want  Recurrence
+	}{
+		{"every monday", Recurrence{Rule: "RRULE:FREQ=WEEKLY;BYDAY=MO"}},
+		{"Every Weekday at 9am", Recurrence{Rule: "RRULE:FREQ=WEEKLY;BYDAY=MO,TU,WE,TH,FR", HasTime: true, Hour: 9}},
+		{"daily at 14:30", Recurrence{Rule: "RRULE:FREQ=DAILY", HasTime: true, Hour: 14, Minute: 30}},
+		{"first friday of the month", Recurrence{Rule: "RRULE:FREQ=MONTHLY;BYDAY=1FR"}},
+		{"the 3rd wed of every month at 4pm", Recurrence{Rule: "RRULE:FREQ=MONTHLY;BYDAY=3WE", HasTime: true, Hour: 16}},
+		{"last monday of each month", Recurrence{Rule: "RRULE:FREQ=MONTHLY;BYDAY=-1MO"}},
+		{"every other week", Recurrence{Rule: "RRULE:FREQ=WEEKLY;INTERVAL=2"}},
+		{"every other tuesday at 10:00", Recurrence{Rule: "RRULE:FREQ=WEEKLY;BYDAY=TU;INTERVAL=2", HasTime: true, Hour: 10}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			got, err := ParseRecurrence(tt.input)
+			if err != nil {
+				t.Fatalf("ParseRecurrence(%q) error = %v", tt.input, err)
+			}
+			if got != tt.want {
+				t.Errorf("ParseRecurrence(%q) = %+v, want %+v", tt.input, got, tt.want)
+			}
+			if err := ValidateRRULE(got.Rule); err != nil {
+				t.Errorf("rule %q doesn't validate: %v", got.Rule, err)
+			}
+		})
+	}
+}
+
+func TestParseRecurrence_Invalid(t *testing.T) {
+	inputs := []string{
+		"",
+		"every weekday at noonish",
+		"fifth friday of the month",
+		"first funday of the month",
+		"every other 2 weeks",
+		"sometimes",
+	}
+
+	for _, input := range inputs {
+		t.Run(input, func(t *testing.T) {
+			_, err := ParseRecurrence(input)
+			if !errors.Is(err, ErrInvalidRecurrence) {
+				t.Errorf("ParseRecurrence(%q) error = %v, want ErrInvalidRecurrence", input, err)
+			}
+		})
+	}
+}
+
+func TestRecurrence_FirstOccurrence(t *testing.T) {
+	// Wednesday, March 6, 2024 at 11:15
+	from := time.Date(2024, 3, 6, 11, 15, 0, 0, time.UTC)
+
+	tests := []struct {
+		phrase string
+		want   time.Time
+	}{
+		{"every weekday at 9am", time.Date(2024, 3, 7, 9, 0, 0, 0, time.UTC)},
+		{"every weekday at 2pm", time.Date(2024, 3, 6, 14, 0, 0, 0, time.UTC)},
+		{"every monday", time.Date(2024, 3, 11, 11, 15, 0, 0, time.UTC)},
+		{"daily", from},
+		{"first friday of the month at 10:00", time.Date(2024, 4, 5, 10, 0, 0, 0, time.UTC)},
+		{"last friday of the month", time.Date(2024, 3, 29, 11, 15, 0, 0, time.UTC)},
+		{"every 2 hours", from},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.phrase, func(t *testing.T) {
+			rec, err := ParseRecurrence(tt.phrase)
+			if err != nil {
+				t.Fatalf("ParseRecurrence(%q) error = %v", tt.phrase, err)
+			}
+			if got := rec.FirstOccurrence(from); !got.Equal(tt.want) {
+				t.Errorf("FirstOccurrence() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestExtractRecurrence(t *testing.T) {
+	tests := []struct {
+		input         string
+		wantRemainder string
+		wantRule      string
+		wantFound     bool
+	}{
+		{"Standup every weekday at 9am", "Standup", "RRULE:FREQ=WEEKLY;BYDAY=MO,TU,WE,TH,FR", true},
+		{"Demo first friday of the month in Room 4", "Demo in Room 4", "RRULE:FREQ=MONTHLY;BYDAY=1FR", true},
+		{"1:1 with Sam every other thursday", "1:1 with Sam", "RRULE:FREQ=WEEKLY;BYDAY=TH;INTERVAL=2", true},
+		{"Lunch tomorrow at noon", "Lunch tomorrow at noon", "", false},
+		{"Last call", "Last call", "", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			remainder, rec, found := ExtractRecurrence(tt.input)
+			if found != tt.wantFound || remainder != tt.wantRemainder || rec.Rule != tt.wantRule {
+				t.Errorf("ExtractRecurrence(%q) = %q, %q, %v; want %q, %q, %v",
+					tt.input, remainder, rec.Rule, found, tt.wantRemainder, tt.wantRule, tt.wantFound)
+			}
+		})
+	}
+}
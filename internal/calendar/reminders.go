@@ -0,0 +1,50 @@
+package calendar
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"google.golang.org/api/calendar/v3"
+)
+
+// ErrInvalidReminder is returned for reminders the API doesn't accept.
+var ErrInvalidReminder = errors.New("invalid reminder")
+
+// Limits on EventParams.Reminders set by the API.
+const (
+	maxReminders      = 5
+	maxReminderBefore = 4 * 7 * 24 * time.Hour
+)
+
+// validateReminders checks the number and range of reminders.
+func validateReminders(reminders []time.Duration) error {
+	if len(reminders) > maxReminders {
+		return fmt.Errorf("%w: at most %d reminders are allowed", ErrInvalidReminder, maxReminders)
+	}
+	for _, before := range reminders {
+		if before < 0 || before > maxReminderBefore {
+			return fmt.Errorf("%w: %s must be between 0 and 4 weeks before the event", ErrInvalidReminder, before)
+		}
+		if before%time.Minute != 0 {
+			return fmt.Errorf("%w: %s is not a whole number of minutes", ErrInvalidReminder, before)
+		}
+	}
+	return nil
+}
+
+// setReminders replaces the calendar's default reminders for event with
+// popup reminders the given times before it starts.
+func setReminders(event *calendar.Event, reminders []time.Duration) {
+	event.Reminders = &calendar.EventReminders{
+		// UseDefault must be sent as false for the overrides to apply
+		ForceSendFields: []string{"UseDefault"},
+	}
+	for _, before := range reminders {
+		event.Reminders.Overrides = append(event.Reminders.Overrides, &calendar.EventReminder{
+			Method:          "popup",
+			Minutes:         int64(before / time.Minute),
+			ForceSendFields: []string{"Minutes"},
+		})
+	}
+}
@@ -0,0 +1,64 @@
+package calendar
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestCreateEvent_Reminders(t *testing.T) {
+	client, fake := newFakeClient(t)
+	params := EventParams{
+		Title:     "Standup",
+		StartTime: time.Date(2024, 1, 15, 9, 30, 0, 0, time.UTC),
+		Duration:  15 * time.Minute,
+		Reminders: []time.Duration{10 * time.Minute, 0},
+	}
+
+	if _, err := client.CreateEvent(context.Background(), params); err != nil {
+		t.Fatalf("CreateEvent() error = %v", err)
+	}
+
+	reminders := fake.inserted[0].Reminders
+	if reminders == nil || reminders.UseDefault || len(reminders.Overrides) != 2 {
+		t.Fatalf("Reminders = %+v, want two overrides of the defaults", reminders)
+	}
+	if r := reminders.Overrides[0]; r.Method != "popup" || r.Minutes != 10 {
+		t.Errorf("first reminder = %+v, want a popup 10 minutes before", r)
+	}
+
+	if _, err := client.CreateEvent(context.Background(), EventParams{
+		Title:     "Lunch",
+		StartTime: time.Date(2024, 1, 15, 12, 0, 0, 0, time.UTC),
+		Duration:  time.Hour,
+	}); err != nil {
+		t.Fatalf("CreateEvent() error = %v", err)
+	}
+	if fake.inserted[1].Reminders != nil {
+		t.Errorf("Reminders = %+v, want the calendar defaults", fake.inserted[1].Reminders)
+	}
+}
+
+func TestValidateReminders(t *testing.T) {
+	tests := []struct {
+		name      string
+		reminders []time.Duration
+		wantErr   bool
+	}{
+		{name: "none", reminders: nil},
+		{name: "at start and four weeks before", reminders: []time.Duration{0, maxReminderBefore}},
+		{name: "too many", reminders: make([]time.Duration, 6), wantErr: true},
+		{name: "negative", reminders: []time.Duration{-time.Minute}, wantErr: true},
+		{name: "too early", reminders: []time.Duration{maxReminderBefore + time.Minute}, wantErr: true},
+		{name: "seconds", reminders: []time.Duration{90 * time.Second}, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := validateReminders(tt.reminders)
+			if tt.wantErr != errors.Is(err, ErrInvalidReminder) {
+				t.Errorf("validateReminders() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
@@ -0,0 +1,174 @@
+package calendar
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"google.golang.org/api/calendar/v3"
+)
+
+// allDayLayout is the date format used by all-day events.
+const allDayLayout = "2006-01-02"
+
+// EventCreateOutcome is the per-item outcome of a bulk operation. Exactly one
+// of Result and Err is set.
+type EventCreateOutcome struct {
+	// Index is the position of the item in the input.
+	Index int
+
+	// EventID is the ID of the event the outcome refers to, when known.
+	EventID string
+
+	Result *EventResult
+	Err    error
+}
+
+// RescheduleEvent moves an existing event so it starts at newStart, keeping
+// its duration. For all-day events only the date of newStart is used.
+func (c *Client) RescheduleEvent(ctx context.Context, eventID string, newStart time.Time) (*EventResult, error) {
+	if eventID == "" {
+		return nil, fmt.Errorf("%w: event ID is required", ErrInvalidEventTime)
+	}
+
+	ctx, cancel := c.withRequestTimeout(ctx)
+	defer cancel()
+
+	existing, err := c.fetchEvent(ctx, eventID)
+	if err != nil {
+		return nil, err
+	}
+
+	return c.rescheduleEvent(ctx, existing, newStart)
+}
+
+// ShiftEvents moves each event by delta, e.g. to push a project's events back
+// a week. Events are processed in order, in chunks per the client's Batch
+// policy, and each gets its own outcome, so one failure doesn't stop the
+// rest. All-day events can only be shifted by whole days. The returned error
+// is only set when ctx is cancelled.
+func (c *Client) ShiftEvents(ctx context.Context, eventIDs []string, delta time.Duration) ([]EventCreateOutcome, error) {
+	return c.forEachEvent(ctx, eventIDs, func(eventID string) (*EventResult, error) {
+		return c.shiftEvent(ctx, eventID, delta)
+	})
+}
+
+// forEachEvent applies fn to each event ID in order, in chunks per the
+// client's Batch policy, collecting one outcome per ID. It stops when ctx is
+// cancelled and returns the outcomes so far with ctx's error.
+func (c *Client) forEachEvent(ctx context.Context, eventIDs []string, fn func(eventID string) (*EventResult, error)) ([]EventCreateOutcome, error) {
+	outcomes := make([]EventCreateOutcome, 0, len(eventIDs))
+
+	var ctxErr error
+	err := c.inChunks(ctx, len(eventIDs), func(start, end int) {
+		for i := start; i < end && ctxErr == nil; i++ {
+			if ctxErr = ctx.Err(); ctxErr != nil {
+				return
+			}
+
+			result, err := fn(eventIDs[i])
+			outcomes = append(outcomes, EventCreateOutcome{
+				Index:   i,
+				EventID: eventIDs[i],
+				Result:  result,
+				Err:     err,
+			})
+		}
+	})
+	if ctxErr != nil {
+		return outcomes, ctxErr
+	}
+	return outcomes, err
+}
+
+// shiftEvent moves a single event by delta.
+func (c *Client) shiftEvent(ctx context.Context, eventID string, delta time.Duration) (*EventResult, error) {
+	ctx, cancel := c.withRequestTimeout(ctx)
+	defer cancel()
+
+	existing, err := c.fetchEvent(ctx, eventID)
+	if err != nil {
+		return nil, err
+	}
+
+	if isAllDay(existing) {
+		if delta%(24*time.Hour) != 0 {
+			return nil, fmt.Errorf("%w: all-day event %s can only be shifted by whole days", ErrInvalidEventTime, eventID)
+		}
+
+		start, err := time.Parse(allDayLayout, existing.Start.Date)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse start date: %w", err)
+		}
+		return c.rescheduleEvent(ctx, existing, start.Add(delta))
+	}
+
+	start, err := time.Parse(time.RFC3339, existing.Start.DateTime)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse start time: %w", err)
+	}
+	return c.rescheduleEvent(ctx, existing, start.Add(delta))
+}
+
+// rescheduleEvent patches an already-fetched event to start at newStart.
+func (c *Client) rescheduleEvent(ctx context.Context, existing *calendar.Event, newStart time.Time) (*EventResult, error) {
+	patch := &calendar.Event{}
+
+	if isAllDay(existing) {
+		start, err := time.Parse(allDayLayout, existing.Start.Date)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse start date: %w", err)
+		}
+		end, err := time.Parse(allDayLayout, existing.End.Date)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse end date: %w", err)
+		}
+
+		newStartDate := time.Date(newStart.Year(), newStart.Month(), newStart.Day(), 0, 0, 0, 0, time.UTC)
+		patch.Start = &calendar.EventDateTime{Date: newStartDate.Format(allDayLayout)}
+		patch.End = &calendar.EventDateTime{Date: newStartDate.Add(end.Sub(start)).Format(allDayLayout)}
+	} else {
+		start, err := time.Parse(time.RFC3339, existing.Start.DateTime)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse start time: %w", err)
+		}
+		end, err := time.Parse(time.RFC3339, existing.End.DateTime)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse end time: %w", err)
+		}
+
+		newEnd := newStart.Add(end.Sub(start))
+		patch.Start = &calendar.EventDateTime{
+			DateTime: newStart.Format(time.RFC3339),
+			TimeZone: existing.Start.TimeZone,
+		}
+		patch.End = &calendar.EventDateTime{
+			DateTime: newEnd.Format(time.RFC3339),
+			TimeZone: existing.End.TimeZone,
+		}
+	}
+
+	// The new times are worked out from the fetched event, so only apply
+	// them to that version
+	var updated *calendar.Event
+	err := c.retryPatch(ctx, existing.Etag != "", func() error {
+		call := c.service.Events.Patch(c.calendarID, existing.Id, patch).Context(ctx)
+		if existing.Etag != "" {
+			call.Header().Set("If-Match", existing.Etag)
+		}
+		var err error
+		updated, err = call.Do()
+		return err
+	})
+	c.InvalidateCache()
+	if err != nil {
+		return nil, wrapEventError(err, existing.Id)
+	}
+
+	return parseEventResult(updated)
+}
+
+// isAllDay reports whether the event uses date-only start and end values.
+func isAllDay(event *calendar.Event) bool {
+	return event.Start != nil && event.Start.Date != "" && event.Start.DateTime == ""
+}
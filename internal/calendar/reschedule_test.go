@@ -0,0 +1,137 @@
+package calendar
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"google.golang.org/api/calendar/v3"
+)
+
+func TestShiftEvents_TimedEvents(t *testing.T) {
+	client, fake := newFakeClient(t)
+
+	fake.addEvent(&calendar.Event{
+		Id:      "standup",
+		Summary: "Standup",
+		Start:   &calendar.EventDateTime{DateTime: "2024-01-15T09:00:00Z"},
+		End:     &calendar.EventDateTime{DateTime: "2024-01-15T09:15:00Z"},
+	})
+	fake.addEvent(&calendar.Event{
+		Id:      "review",
+		Summary: "Review",
+		Start:   &calendar.EventDateTime{DateTime: "2024-01-16T14:00:00Z"},
+		End:     &calendar.EventDateTime{DateTime: "2024-01-16T15:00:00Z"},
+	})
+
+	outcomes, err := client.ShiftEvents(context.Background(), []string{"standup", "review"}, time.Hour)
+	if err != nil {
+		t.Fatalf("ShiftEvents() error = %v", err)
+	}
+
+	if len(outcomes) != 2 {
+		t.Fatalf("Expected 2 outcomes, got %d", len(outcomes))
+	}
+
+	tests := []struct {
+		id        string
+		wantStart string
+		wantEnd   string
+	}{
+		{id: "standup", wantStart: "2024-01-15T10:00:00Z", wantEnd: "2024-01-15T10:15:00Z"},
+		{id: "review", wantStart: "2024-01-16T15:00:00Z", wantEnd: "2024-01-16T16:00:00Z"},
+	}
+
+	for i, tt := range tests {
+		t.Run(tt.id, func(t *testing.T) {
+			outcome := outcomes[i]
+			if outcome.Err != nil {
+				t.Fatalf("outcome error = %v", outcome.Err)
+			}
+			if outcome.Index != i || outcome.EventID != tt.id {
+				t.Errorf("outcome = {%d %s}, want {%d %s}", outcome.Index, outcome.EventID, i, tt.id)
+			}
+
+			stored := fake.events[tt.id]
+			if stored.Start.DateTime != tt.wantStart {
+				t.Errorf("Start = %s, want %s", stored.Start.DateTime, tt.wantStart)
+			}
+			if stored.End.DateTime != tt.wantEnd {
+				t.Errorf("End = %s, want %s", stored.End.DateTime, tt.wantEnd)
+			}
+		})
+	}
+}
+
+func TestShiftEvents_AllDayEvents(t *testing.T) {
+	client, fake := newFakeClient(t)
+
+	fake.addEvent(&calendar.Event{
+		Id:      "offsite",
+		Summary: "Offsite",
+		Start:   &calendar.EventDateTime{Date: "2024-03-04"},
+		End:     &calendar.EventDateTime{Date: "2024-03-06"},
+	})
+
+	outcomes, err := client.ShiftEvents(context.Background(), []string{"offsite", "offsite"}, 7*24*time.Hour)
+	if err != nil {
+		t.Fatalf("ShiftEvents() error = %v", err)
+	}
+	if outcomes[0].Err != nil || outcomes[1].Err != nil {
+		t.Fatalf("unexpected outcome errors: %v, %v", outcomes[0].Err, outcomes[1].Err)
+	}
+
+	stored := fake.events["offsite"]
+	if stored.Start.Date != "2024-03-18" || stored.End.Date != "2024-03-20" {
+		t.Errorf("Dates = %s..%s, want 2024-03-18..2024-03-20", stored.Start.Date, stored.End.Date)
+	}
+}
+
+func TestShiftEvents_PerItemErrors(t *testing.T) {
+	client, fake := newFakeClient(t)
+
+	fake.addEvent(&calendar.Event{
+		Id:    "holiday",
+		Start: &calendar.EventDateTime{Date: "2024-03-04"},
+		End:   &calendar.EventDateTime{Date: "2024-03-05"},
+	})
+
+	outcomes, err := client.ShiftEvents(context.Background(), []string{"holiday", "missing"}, time.Hour)
+	if err != nil {
+		t.Fatalf("ShiftEvents() error = %v", err)
+	}
+
+	if !errors.Is(outcomes[0].Err, ErrInvalidEventTime) {
+		t.Errorf("sub-day shift of all-day event error = %v, want ErrInvalidEventTime", outcomes[0].Err)
+	}
+	if outcomes[1].Err == nil {
+		t.Error("Expected error for missing event")
+	}
+	if fake.events["holiday"].Start.Date != "2024-03-04" {
+		t.Error("All-day event should not be modified by a sub-day shift")
+	}
+}
+
+func TestRescheduleEvent_KeepsDuration(t *testing.T) {
+	client, fake := newFakeClient(t)
+
+	fake.addEvent(&calendar.Event{
+		Id:    "sync",
+		Start: &calendar.EventDateTime{DateTime: "2024-01-15T09:00:00Z", TimeZone: "UTC"},
+		End:   &calendar.EventDateTime{DateTime: "2024-01-15T09:45:00Z", TimeZone: "UTC"},
+	})
+
+	newStart := time.Date(2024, 1, 17, 13, 0, 0, 0, time.UTC)
+	result, err := client.RescheduleEvent(context.Background(), "sync", newStart)
+	if err != nil {
+		t.Fatalf("RescheduleEvent() error = %v", err)
+	}
+
+	if !result.StartTime.Equal(newStart) {
+		t.Errorf("StartTime = %v, want %v", result.StartTime, newStart)
+	}
+	if got := result.EndTime.Sub(result.StartTime); got != 45*time.Minute {
+		t.Errorf("Duration = %v, want 45m", got)
+	}
+}
@@ -0,0 +1,198 @@
+package calendar
+
+import (
+	"context"
+	"errors"
+	"math"
+	"math/rand/v2"
+	"net"
+	"net/http"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"google.golang.org/api/googleapi"
+)
+
+// RetryPolicy controls automatic retries of transient API failures.
+type RetryPolicy struct {
+	// MaxAttempts is the total number of attempts, including the first.
+	// Values below 1 mean a single attempt.
+	MaxAttempts int
+
+	// BaseDelay is the wait before the first retry; it doubles after each
+	// further attempt. Each wait is randomized to between half and all of
+	// its nominal length, so clients failing together don't retry together.
+	BaseDelay time.Duration
+
+	// MaxDelay caps each wait, including one asked for by a Retry-After
+	// header. Zero means no cap.
+	MaxDelay time.Duration
+}
+
+// DefaultRetryPolicy returns the retry policy used by new clients.
+func DefaultRetryPolicy() RetryPolicy {
+	return RetryPolicy{
+		MaxAttempts: 3,
+		BaseDelay:   500 * time.Millisecond,
+		MaxDelay:    30 * time.Second,
+	}
+}
+
+// delay returns how long to wait before retry number attempt (1-based)
+// after err. A Retry-After header on err is honored when it asks for a
+// longer wait than the backoff.
+func (p RetryPolicy) delay(attempt int, err error) time.Duration {
+	backoff := p.BaseDelay << (attempt - 1)
+	if backoff < 0 {
+		// Shifted past the range of a Duration
+		backoff = time.Duration(math.MaxInt64)
+	}
+	if p.MaxDelay > 0 && backoff > p.MaxDelay {
+		backoff = p.MaxDelay
+	}
+	if half := backoff / 2; half > 0 {
+		backoff = half + rand.N(backoff-half+1)
+	}
+
+	if after, ok := retryAfter(err); ok && after > backoff {
+		backoff = after
+		if p.MaxDelay > 0 && backoff > p.MaxDelay {
+			backoff = p.MaxDelay
+		}
+	}
+	return backoff
+}
+
+// retryAfter returns the wait asked for by the Retry-After header of an API
+// error, given either in seconds or as an HTTP date.
+func retryAfter(err error) (time.Duration, bool) {
+	var apiErr *googleapi.Error
+	if !errors.As(err, &apiErr) || apiErr.Header == nil {
+		return 0, false
+	}
+
+	value := strings.TrimSpace(apiErr.Header.Get("Retry-After"))
+	if value == "" {
+		return 0, false
+	}
+	if seconds, err := strconv.Atoi(value); err == nil && seconds >= 0 {
+		return time.Duration(seconds) * time.Second, true
+	}
+	if at, err := http.ParseTime(value); err == nil {
+		return max(time.Until(at), 0), true
+	}
+	return 0, false
+}
+
+// eventIDPattern matches the characters the API accepts in client-supplied
+// event IDs (base32hex). See validEventID for the length limits.
+var eventIDPattern = regexp.MustCompile(`^[a-v0-9]+$`)
+
+// validEventID reports whether id is acceptable as a client-supplied event ID.
+func validEventID(id string) bool {
+	return len(id) >= 5 && len(id) <= 1024 && eventIDPattern.MatchString(id)
+}
+
+// retry runs fn, retrying transient failures according to the client's
+// policy. Operations that aren't idempotent are attempted once: after an
+// ambiguous failure such as a timeout the server may already have applied
+// the change, and repeating it could, for example, create a duplicate event.
+func (c *Client) retry(ctx context.Context, idempotent bool, fn func() error) error {
+	return c.retryIf(ctx, idempotent, isTransient, fn)
+}
+
+// retryPatch is retry for a PATCH, which is idempotent unless conditional,
+// i.e. sent with If-Match. A conditional patch is only retried after a rate
+// limit, which the API returns without applying the request: after a server
+// error or timeout the first attempt may have been applied, and the retry
+// would then fail with 412 as if someone else had changed the event.
+func (c *Client) retryPatch(ctx context.Context, conditional bool, fn func() error) error {
+	if conditional {
+		return c.retryIf(ctx, true, isRateLimit, fn)
+	}
+	return c.retry(ctx, true, fn)
+}
+
+// retryIf is retry, retrying the failures for which transient is true.
+func (c *Client) retryIf(ctx context.Context, idempotent bool, transient func(error) bool, fn func() error) error {
+	attempts := c.Retry.MaxAttempts
+	if attempts < 1 || !idempotent {
+		attempts = 1
+	}
+
+	var err error
+	for attempt := 0; attempt < attempts; attempt++ {
+		if attempt > 0 {
+			select {
+			case <-time.After(c.Retry.delay(attempt, err)):
+			case <-ctx.Done():
+				return err
+			}
+		}
+
+		err = fn()
+		if err == nil || !transient(err) {
+			return err
+		}
+	}
+	return err
+}
+
+// isTransient reports whether err is a failure worth retrying: rate limits,
+// server errors, and network timeouts. Context cancellation is not, and
+// neither is an exhausted daily quota, which retrying soon can't fix.
+func isTransient(err error) bool {
+	if errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded) {
+		return false
+	}
+
+	var apiErr *googleapi.Error
+	if errors.As(err, &apiErr) {
+		switch apiErr.Code {
+		case 429, 500, 502, 503, 504:
+			return true
+		case 403:
+			return isRateLimited(apiErr)
+		}
+		return false
+	}
+
+	var netErr net.Error
+	return errors.As(err, &netErr) && netErr.Timeout()
+}
+
+// isRateLimit reports whether err is a 429 response or a 403 rate limit.
+func isRateLimit(err error) bool {
+	var apiErr *googleapi.Error
+	if !errors.As(err, &apiErr) {
+		return false
+	}
+	return apiErr.Code == 429 || apiErr.Code == 403 && isRateLimited(apiErr)
+}
+
+// isRateLimited reports whether a 403 response is a per-minute rate limit,
+// which the Calendar API reports as 403 as well as 429.
+func isRateLimited(apiErr *googleapi.Error) bool {
+	for _, e := range apiErr.Errors {
+		if e.Reason == "rateLimitExceeded" || e.Reason == "userRateLimitExceeded" {
+			return true
+		}
+	}
+	return false
+}
+
+// isGone reports whether err is a 404 or 410 response, returned for an
+// event that doesn't exist or was deleted.
+func isGone(err error) bool {
+	var apiErr *googleapi.Error
+	return errors.As(err, &apiErr) && (apiErr.Code == 404 || apiErr.Code == 410)
+}
+
+// isConflict reports whether err is a 409 response, returned when inserting
+// an event whose client-supplied ID already exists.
+func isConflict(err error) bool {
+	var apiErr *googleapi.Error
+	return errors.As(err, &apiErr) && apiErr.Code == 409
+}
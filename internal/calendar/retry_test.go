@@ -0,0 +1,283 @@
+package calendar
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"testing"
+	"time"
+
+	"google.golang.org/api/calendar/v3"
+	"google.golang.org/api/googleapi"
+)
+
+func TestCreateEvent_NotRetriedWithoutID(t *testing.T) {
+	client, fake := newFakeClient(t)
+	fake.timeoutInserts = 1
+
+	_, err := client.CreateEvent(context.Background(), EventParams{
+		Title:     "Meeting",
+		StartTime: time.Date(2024, 1, 15, 14, 0, 0, 0, time.UTC),
+		Duration:  time.Hour,
+	})
+	if err == nil {
+		t.Fatal("CreateEvent() expected error after timeout")
+	}
+
+	if got := fake.requestCount("POST", ""); got != 1 {
+		t.Errorf("Expected 1 insert attempt, got %d", got)
+	}
+	if len(fake.events) != 1 {
+		t.Errorf("Expected exactly 1 stored event, got %d", len(fake.events))
+	}
+}
+
+func TestCreateEvent_RetriedWithID(t *testing.T) {
+	client, fake := newFakeClient(t)
+	fake.timeoutInserts = 1
+
+	result, err := client.CreateEvent(context.Background(), EventParams{
+		ID:        "standup20240115",
+		Title:     "Meeting",
+		StartTime: time.Date(2024, 1, 15, 14, 0, 0, 0, time.UTC),
+		Duration:  time.Hour,
+	})
+	if err != nil {
+		t.Fatalf("CreateEvent() error = %v", err)
+	}
+
+	if result.ID != "standup20240115" {
+		t.Errorf("CreateEvent() ID = %q, want standup20240115", result.ID)
+	}
+	if got := fake.requestCount("POST", ""); got != 2 {
+		t.Errorf("Expected 2 insert attempts, got %d", got)
+	}
+	if len(fake.events) != 1 {
+		t.Errorf("Expected exactly 1 stored event, got %d", len(fake.events))
+	}
+}
+
+func TestCreateEvent_ConflictOnFirstAttempt(t *testing.T) {
+	client, fake := newFakeClient(t)
+	fake.addEvent(&calendar.Event{
+		Id:      "taken1234",
+		Summary: "Existing",
+		Start:   &calendar.EventDateTime{DateTime: "2024-01-15T14:00:00Z"},
+		End:     &calendar.EventDateTime{DateTime: "2024-01-15T15:00:00Z"},
+	})
+
+	_, err := client.CreateEvent(context.Background(), EventParams{
+		ID:        "taken1234",
+		Title:     "Meeting",
+		StartTime: time.Date(2024, 1, 15, 14, 0, 0, 0, time.UTC),
+		Duration:  time.Hour,
+	})
+	if !errors.Is(err, ErrEventCreationFailed) {
+		t.Errorf("CreateEvent() error = %v, want ErrEventCreationFailed for an ID already in use", err)
+	}
+}
+
+func TestValidateEventParams_InvalidID(t *testing.T) {
+	err := validateEventParams(EventParams{
+		ID:        "Not_Valid",
+		Title:     "Meeting",
+		StartTime: time.Date(2024, 1, 15, 14, 0, 0, 0, time.UTC),
+		Duration:  time.Hour,
+	})
+	if !errors.Is(err, ErrInvalidEventTime) {
+		t.Errorf("validateEventParams() error = %v, want ErrInvalidEventTime", err)
+	}
+}
+
+func TestRetry(t *testing.T) {
+	transient := &googleapi.Error{Code: 503}
+	permanent := &googleapi.Error{Code: 400}
+	rateLimited := &googleapi.Error{Code: 403, Errors: []googleapi.ErrorItem{{Reason: "userRateLimitExceeded"}}}
+	quotaExceeded := &googleapi.Error{Code: 403, Errors: []googleapi.ErrorItem{{Reason: "quotaExceeded"}}}
+
+	tests := []struct {
+		name         string
+		idempotent   bool
+		errs         []error
+		wantAttempts int
+		wantErr      bool
+	}{
+		{"succeeds first time", true, []error{nil}, 1, false},
+		{"retries transient read", true, []error{transient, nil}, 2, false},
+		{"gives up after max attempts", true, []error{transient, transient, transient, nil}, 3, true},
+		{"does not retry permanent error", true, []error{permanent, nil}, 1, true},
+		{"does not retry non-idempotent", false, []error{transient, nil}, 1, true},
+		{"does not retry cancellation", true, []error{fmt.Errorf("wrapped: %w", context.Canceled), nil}, 1, true},
+		{"retries rate limit reported as 403", true, []error{rateLimited, nil}, 2, false},
+		{"does not retry exhausted quota", true, []error{quotaExceeded, nil}, 1, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client := newClientWithService(nil, "primary")
+			client.Retry.BaseDelay = time.Millisecond
+
+			attempts := 0
+			err := client.retry(context.Background(), tt.idempotent, func() error {
+				err := tt.errs[attempts]
+				attempts++
+				return err
+			})
+
+			if (err != nil) != tt.wantErr {
+				t.Errorf("retry() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if attempts != tt.wantAttempts {
+				t.Errorf("retry() made %d attempts, want %d", attempts, tt.wantAttempts)
+			}
+		})
+	}
+}
+
+func TestRetryPolicy_Delay(t *testing.T) {
+	policy := RetryPolicy{BaseDelay: 100 * time.Millisecond, MaxDelay: time.Second}
+	transient := &googleapi.Error{Code: 503}
+
+	tests := []struct {
+		name     string
+		attempt  int
+		err      error
+		min, max time.Duration
+	}{
+		{"first retry", 1, transient, 50 * time.Millisecond, 100 * time.Millisecond},
+		{"doubles", 3, transient, 200 * time.Millisecond, 400 * time.Millisecond},
+		{"capped", 10, transient, 500 * time.Millisecond, time.Second},
+		{
+			name:    "honors longer Retry-After",
+			attempt: 1,
+			err:     &googleapi.Error{Code: 429, Header: http.Header{"Retry-After": {"1"}}},
+			min:     time.Second, max: time.Second,
+		},
+		{
+			name:    "caps Retry-After",
+			attempt: 1,
+			err:     &googleapi.Error{Code: 429, Header: http.Header{"Retry-After": {"120"}}},
+			min:     time.Second, max: time.Second,
+		},
+		{
+			name:    "ignores shorter Retry-After",
+			attempt: 3,
+			err:     &googleapi.Error{Code: 429, Header: http.Header{"Retry-After": {"0"}}},
+			min:     200 * time.Millisecond, max: 400 * time.Millisecond,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			for i := 0; i < 20; i++ {
+				if got := policy.delay(tt.attempt, tt.err); got < tt.min || got > tt.max {
+					t.Fatalf("delay(%d) = %v, want between %v and %v", tt.attempt, got, tt.min, tt.max)
+				}
+			}
+		})
+	}
+}
+
+func TestRetryAfter(t *testing.T) {
+	at := time.Now().Add(time.Minute).UTC().Format(http.TimeFormat)
+
+	tests := []struct {
+		name   string
+		value  string
+		min    time.Duration
+		max    time.Duration
+		wantOK bool
+	}{
+		{name: "seconds", value: "7", min: 7 * time.Second, max: 7 * time.Second, wantOK: true},
+		{name: "HTTP date", value: at, min: 58 * time.Second, max: time.Minute, wantOK: true},
+		{name: "missing"},
+		{name: "garbage", value: "soon"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := &googleapi.Error{Code: 503, Header: http.Header{}}
+			if tt.value != "" {
+				err.Header.Set("Retry-After", tt.value)
+			}
+
+			got, ok := retryAfter(err)
+			if ok != tt.wantOK {
+				t.Fatalf("retryAfter() ok = %v, want %v", ok, tt.wantOK)
+			}
+			if ok && (got < tt.min || got > tt.max) {
+				t.Errorf("retryAfter() = %v, want between %v and %v", got, tt.min, tt.max)
+			}
+		})
+	}
+}
+
+func TestRetryPatch(t *testing.T) {
+	serverError := &googleapi.Error{Code: 503}
+	rateLimited := &googleapi.Error{Code: 429}
+
+	tests := []struct {
+		name         string
+		conditional  bool
+		errs         []error
+		wantAttempts int
+	}{
+		{"retries server error", false, []error{serverError, nil}, 2},
+		{"retries rate limit", false, []error{rateLimited, nil}, 2},
+		{"conditional retries rate limit", true, []error{rateLimited, nil}, 2},
+		{"conditional doesn't retry server error", true, []error{serverError, nil}, 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client := newClientWithService(nil, "primary")
+			client.Retry.BaseDelay = time.Millisecond
+
+			attempts := 0
+			client.retryPatch(context.Background(), tt.conditional, func() error {
+				err := tt.errs[attempts]
+				attempts++
+				return err
+			})
+			if attempts != tt.wantAttempts {
+				t.Errorf("retryPatch() made %d attempts, want %d", attempts, tt.wantAttempts)
+			}
+		})
+	}
+}
+
+func TestPatches_Retried(t *testing.T) {
+	client, fake := newFakeClient(t)
+	fake.addEvent(&calendar.Event{
+		Id:      "evt1",
+		Summary: "Standup",
+		Etag:    `"1"`,
+		Start:   &calendar.EventDateTime{DateTime: "2024-01-15T09:00:00Z"},
+		End:     &calendar.EventDateTime{DateTime: "2024-01-15T09:15:00Z"},
+	})
+	ctx := context.Background()
+
+	title := "Daily standup"
+	fake.rejectPatches = []int{http.StatusServiceUnavailable}
+	if _, err := client.UpdateEvent(ctx, "evt1", EventUpdate{Title: &title}); err != nil {
+		t.Errorf("UpdateEvent() after a 503 error = %v, want it retried", err)
+	}
+
+	// Rescheduling patches with If-Match: a rate limit is safe to retry,
+	// but after a 503 the first attempt may have been applied
+	fake.rejectPatches = []int{http.StatusTooManyRequests}
+	newStart := time.Date(2024, 1, 16, 9, 0, 0, 0, time.UTC)
+	if _, err := client.RescheduleEvent(ctx, "evt1", newStart); err != nil {
+		t.Errorf("RescheduleEvent() after a 429 error = %v, want it retried", err)
+	}
+
+	before := fake.requestCount("PATCH", "")
+	fake.rejectPatches = []int{http.StatusServiceUnavailable}
+	if _, err := client.RescheduleEvent(ctx, "evt1", newStart.Add(time.Hour)); err == nil {
+		t.Error("RescheduleEvent() after a 503 succeeded, want the conditional patch not retried")
+	}
+	if n := fake.requestCount("PATCH", "") - before; n != 1 {
+		t.Errorf("conditional patch sent %d times after a 503, want once", n)
+	}
+}
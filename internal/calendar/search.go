@@ -0,0 +1,147 @@
+package calendar
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// ErrInvalidQuery is returned when a search query can't be parsed.
+var ErrInvalidQuery = errors.New("invalid search query")
+
+// EventQuery is a parsed search query. Empty fields match everything;
+// matching is case-insensitive and by substring.
+type EventQuery struct {
+	// Text is free text searched server-side via the API's q parameter.
+	Text string
+
+	// Title, Location and Attendee filter the results client-side.
+	Title    string
+	Location string
+	Attendee string
+}
+
+// queryFields maps field prefixes to the EventQuery field they set.
+var queryFields = map[string]func(*EventQuery) *string{
+	"title":    func(q *EventQuery) *string { return &q.Title },
+	"location": func(q *EventQuery) *string { return &q.Location },
+	"loc":      func(q *EventQuery) *string { return &q.Location },
+	"attendee": func(q *EventQuery) *string { return &q.Attendee },
+	"with":     func(q *EventQuery) *string { return &q.Attendee },
+}
+
+// ParseEventQuery parses a search query such as
+// `location:HQ title:standup notes`. Terms prefixed with title:, location:
+// (or loc:) and attendee: (or with:) set the matching filter, and a value
+// may be quoted to include spaces, as in title:"design review". Repeated
+// fields are joined with spaces. Everything else, including words with
+// other colons like "10:30", becomes Text.
+func ParseEventQuery(q string) (EventQuery, error) {
+	var query EventQuery
+	var text []string
+
+	terms, err := splitQueryTerms(q)
+	if err != nil {
+		return EventQuery{}, err
+	}
+	for _, term := range terms {
+		name, value, ok := strings.Cut(term, ":")
+		field, known := queryFields[strings.ToLower(name)]
+		if !ok || !known {
+			text = append(text, unquote(term))
+			continue
+		}
+
+		value = unquote(value)
+		if value == "" {
+			return EventQuery{}, fmt.Errorf("%w: %s: needs a value", ErrInvalidQuery, name)
+		}
+		target := field(&query)
+		*target = strings.TrimSpace(*target + " " + value)
+	}
+
+	query.Text = strings.Join(text, " ")
+	return query, nil
+}
+
+// splitQueryTerms splits q on whitespace outside double quotes.
+func splitQueryTerms(q string) ([]string, error) {
+	var terms []string
+	var current strings.Builder
+	quoted := false
+	for _, r := range q {
+		switch {
+		case r == '"':
+			quoted = !quoted
+			current.WriteRune(r)
+		case !quoted && (r == ' ' || r == '\t' || r == '\n'):
+			if current.Len() > 0 {
+				terms = append(terms, current.String())
+				current.Reset()
+			}
+		default:
+			current.WriteRune(r)
+		}
+	}
+	if quoted {
+		return nil, fmt.Errorf("%w: unterminated quote", ErrInvalidQuery)
+	}
+	if current.Len() > 0 {
+		terms = append(terms, current.String())
+	}
+	return terms, nil
+}
+
+// unquote removes the double quotes from a query term.
+func unquote(term string) string {
+	return strings.ReplaceAll(term, `"`, "")
+}
+
+// Matches reports whether event passes the query's client-side filters.
+// Text isn't checked, since the API applies it.
+func (q EventQuery) Matches(event *EventResult) bool {
+	if !containsFold(event.Title, q.Title) || !containsFold(event.Location, q.Location) {
+		return false
+	}
+	if q.Attendee == "" {
+		return true
+	}
+	for _, email := range event.Attendees {
+		if containsFold(email, q.Attendee) {
+			return true
+		}
+	}
+	return false
+}
+
+// containsFold reports whether s contains substr, ignoring case.
+func containsFold(s, substr string) bool {
+	return strings.Contains(strings.ToLower(s), strings.ToLower(substr))
+}
+
+// SearchEvents lists events in the range given by opts that match query.
+// Text is sent to the API; the other filters are applied to the results,
+// and opts.MaxResults caps the filtered results rather than the listing.
+func (c *Client) SearchEvents(ctx context.Context, query EventQuery, opts ListOptions) ([]*EventResult, error) {
+	limit := opts.MaxResults
+	opts.MaxResults = 0
+	opts.Query = query.Text
+
+	events, err := c.ListEvents(ctx, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	var matched []*EventResult
+	for _, event := range events {
+		if !query.Matches(event) {
+			continue
+		}
+		matched = append(matched, event)
+		if limit > 0 && len(matched) >= limit {
+			break
+		}
+	}
+	return matched, nil
+}
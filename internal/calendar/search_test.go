@@ -0,0 +1,121 @@
+package calendar
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"google.golang.org/api/calendar/v3"
+)
+
+func TestParseEventQuery(t *testing.T) {
+	tests := []struct {
+		input string
+		want  EventQuery
+	}{
+		{
+			input: "location:HQ title:standup",
+			want:  EventQuery{Title: "standup", Location: "HQ"},
+		},
+		{
+			input: `roadmap title:"design review" with:alice@example.com notes`,
+			want:  EventQuery{Text: "roadmap notes", Title: "design review", Attendee: "alice@example.com"},
+		},
+		{
+			input: "Title:sync loc:Room title:weekly",
+			want:  EventQuery{Title: "sync weekly", Location: "Room"},
+		},
+		{
+			input: "call at 10:30",
+			want:  EventQuery{Text: "call at 10:30"},
+		},
+		{
+			input: "",
+			want:  EventQuery{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			got, err := ParseEventQuery(tt.input)
+			if err != nil {
+				t.Fatalf("ParseEventQuery(%q) error = %v", tt.input, err)
+			}
+			if got != tt.want {
+				t.Errorf("ParseEventQuery(%q) = %+v, want %+v", tt.input, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseEventQuery_Invalid(t *testing.T) {
+	for _, input := range []string{"title:", `title:"unterminated`} {
+		t.Run(input, func(t *testing.T) {
+			if _, err := ParseEventQuery(input); !errors.Is(err, ErrInvalidQuery) {
+				t.Errorf("ParseEventQuery(%q) error = %v, want ErrInvalidQuery", input, err)
+			}
+		})
+	}
+}
+
+func TestSearchEvents(t *testing.T) {
+	client, fake := newFakeClient(t)
+	day := time.Date(2024, 1, 15, 0, 0, 0, 0, time.UTC)
+
+	add := func(hour int, title, location, description string, attendees ...string) {
+		event := &calendar.Event{
+			Summary:     title,
+			Location:    location,
+			Description: description,
+			Start:       &calendar.EventDateTime{DateTime: day.Add(time.Duration(hour) * time.Hour).Format(time.RFC3339)},
+			End:         &calendar.EventDateTime{DateTime: day.Add(time.Duration(hour+1) * time.Hour).Format(time.RFC3339)},
+		}
+		for _, email := range attendees {
+			event.Attendees = append(event.Attendees, &calendar.EventAttendee{Email: email})
+		}
+		fake.addEvent(event)
+	}
+	add(9, "Team Standup", "HQ Room 1", "")
+	add(10, "Standup retro", "Remote", "")
+	add(11, "1:1", "HQ Room 2", "standup follow-up", "bob@example.com")
+	add(12, "Daily standup", "HQ Room 3", "planning", "alice@example.com")
+
+	tests := []struct {
+		query string
+		want  []string
+	}{
+		{"location:HQ title:standup", []string{"Team Standup", "Daily standup"}},
+		{"standup loc:hq", []string{"Team Standup", "1:1", "Daily standup"}},
+		{"with:alice", []string{"Daily standup"}},
+		{"planning title:standup", []string{"Daily standup"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.query, func(t *testing.T) {
+			query, err := ParseEventQuery(tt.query)
+			if err != nil {
+				t.Fatalf("ParseEventQuery() error = %v", err)
+			}
+
+			events, err := client.SearchEvents(context.Background(), query, ListOptions{TimeMin: day, TimeMax: day.Add(24 * time.Hour)})
+			if err != nil {
+				t.Fatalf("SearchEvents() error = %v", err)
+			}
+
+			var titles []string
+			for _, event := range events {
+				titles = append(titles, event.Title)
+			}
+			if len(titles) != len(tt.want) {
+				t.Fatalf("SearchEvents() = %v, want %v", titles, tt.want)
+			}
+			for i := range titles {
+				if titles[i] != tt.want[i] {
+					t.Errorf("SearchEvents() = %v, want %v", titles, tt.want)
+					break
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,233 @@
+package calendar
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"time"
+)
+
+// ErrNoFreeSlot is returned when no free slot of the requested length exists.
+var ErrNoFreeSlot = errors.New("no free slot available")
+
+// TimeSlot is a span of time on the calendar.
+type TimeSlot struct {
+	Start time.Time `json:"start"`
+	End   time.Time `json:"end"`
+}
+
+// Duration returns the length of the slot.
+func (s TimeSlot) Duration() time.Duration {
+	return s.End.Sub(s.Start)
+}
+
+// NextStartAfter returns the earliest start that leaves buffer free after an
+// event ending at lastEnd.
+func NextStartAfter(lastEnd time.Time, buffer time.Duration) time.Time {
+	return lastEnd.Add(buffer)
+}
+
+// NudgeStart moves start to NextStartAfter(lastEnd, buffer) when it falls at
+// or just after lastEnd, within the buffer, so events created back to back
+// don't abut. Starts before lastEnd are left alone, as are all starts when
+// lastEnd is zero.
+func NudgeStart(start, lastEnd time.Time, buffer time.Duration) time.Time {
+	if lastEnd.IsZero() || start.Before(lastEnd) {
+		return start
+	}
+	if next := NextStartAfter(lastEnd, buffer); start.Before(next) {
+		return next
+	}
+	return start
+}
+
+// WorkingHours bounds the part of each day considered for free slots, as
+// offsets from midnight in the local time of the search range.
+type WorkingHours struct {
+	Start time.Duration
+	End   time.Duration
+}
+
+// DefaultWorkingHours returns the working hours used by new clients, 9:00 to 17:00.
+func DefaultWorkingHours() WorkingHours {
+	return WorkingHours{Start: 9 * time.Hour, End: 17 * time.Hour}
+}
+
+// SlotQuery describes a search for open slots by FindSlots.
+type SlotQuery struct {
+	// Start and End bound the search. Days are taken in Start's location.
+	Start time.Time
+	End   time.Time
+
+	// Duration is the shortest slot worth returning.
+	Duration time.Duration
+
+	// Calendars lists the calendars that must all be free, e.g. those of
+	// a meeting's attendees. Empty checks the client's calendar.
+	Calendars []string
+
+	// WorkingHours bounds the part of each day searched. The zero value
+	// uses the client's WorkingHours.
+	WorkingHours WorkingHours
+
+	// SkipWeekends leaves Saturdays and Sundays out of the search.
+	SkipWeekends bool
+
+	// Limit caps the number of slots returned. Zero means no limit.
+	Limit int
+}
+
+// FindSlots returns the gaps of at least q.Duration within working hours
+// where every calendar in q is free, in start order. Busy time comes from
+// the free/busy endpoint, so transparent events don't block time and other
+// people's calendars can be checked without reading their events.
+func (c *Client) FindSlots(ctx context.Context, q SlotQuery) ([]TimeSlot, error) {
+	if q.Duration <= 0 {
+		return nil, fmt.Errorf("%w: duration must be positive", ErrInvalidEventTime)
+	}
+	if !q.End.After(q.Start) {
+		return nil, fmt.Errorf("%w: end of range must be after start", ErrInvalidEventTime)
+	}
+	if q.Limit < 0 {
+		return nil, fmt.Errorf("%w: limit cannot be negative", ErrInvalidEventTime)
+	}
+
+	busyByCalendar, err := c.FreeBusy(ctx, q.Start, q.End, q.Calendars)
+	if err != nil {
+		return nil, err
+	}
+
+	var busy []TimeSlot
+	for _, slots := range busyByCalendar {
+		busy = append(busy, slots...)
+	}
+	sort.Slice(busy, func(i, j int) bool { return busy[i].Start.Before(busy[j].Start) })
+
+	hours := q.WorkingHours
+	if hours == (WorkingHours{}) {
+		hours = c.WorkingHours
+	}
+
+	var slots []TimeSlot
+	loc := q.Start.Location()
+	for day := time.Date(q.Start.Year(), q.Start.Month(), q.Start.Day(), 0, 0, 0, 0, loc); day.Before(q.End); day = day.AddDate(0, 0, 1) {
+		if q.SkipWeekends && isWeekend(day) {
+			continue
+		}
+
+		window := TimeSlot{
+			Start: latest(q.Start, day.Add(hours.Start)),
+			End:   earliest(q.End, day.Add(hours.End)),
+		}
+		if !window.End.After(window.Start) {
+			continue
+		}
+
+		for _, slot := range freeSlotsIn(window, busy, q.Duration) {
+			slots = append(slots, slot)
+			if q.Limit > 0 && len(slots) == q.Limit {
+				return slots, nil
+			}
+		}
+	}
+
+	return slots, nil
+}
+
+// FindFreeSlots returns the gaps of at least dur between busy events in
+// [start, end), restricted to the client's WorkingHours on each day. Days
+// are taken in start's location. Transparent events don't block time. See
+// FindSlots for more options.
+func (c *Client) FindFreeSlots(ctx context.Context, start, end time.Time, dur time.Duration) ([]TimeSlot, error) {
+	return c.FindSlots(ctx, SlotQuery{Start: start, End: end, Duration: dur})
+}
+
+// IsTimeFree reports whether [start, end) is free, e.g. to answer "can I
+// meet at 3pm tomorrow?". When it isn't, the overlapping events are
+// returned. Transparent events don't block time.
+func (c *Client) IsTimeFree(ctx context.Context, start, end time.Time) (bool, []*EventResult, error) {
+	if !end.After(start) {
+		return false, nil, fmt.Errorf("%w: end must be after start", ErrInvalidEventTime)
+	}
+
+	events, err := c.ListEvents(ctx, ListOptions{TimeMin: start, TimeMax: end})
+	if err != nil {
+		return false, nil, err
+	}
+
+	var conflicts []*EventResult
+	for _, event := range events {
+		if event.Transparent {
+			continue
+		}
+		if event.StartTime.Before(end) && event.EndTime.After(start) {
+			conflicts = append(conflicts, event)
+		}
+	}
+	return len(conflicts) == 0, conflicts, nil
+}
+
+// NextFreeSlotToday returns the first dur-long free slot between now and the
+// end of today's working hours in timezone, e.g. to "block my next free 25
+// minutes". It returns ErrNoFreeSlot when nothing fits today.
+func (c *Client) NextFreeSlotToday(ctx context.Context, dur time.Duration, timezone string) (*TimeSlot, error) {
+	loc, err := getLocation(timezone)
+	if err != nil {
+		return nil, err
+	}
+
+	now := c.now().In(loc)
+	endOfDay := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, loc).AddDate(0, 0, 1)
+
+	slots, err := c.FindFreeSlots(ctx, now, endOfDay, dur)
+	if err != nil {
+		return nil, err
+	}
+	if len(slots) == 0 {
+		return nil, fmt.Errorf("%w: nothing of %s left in today's working hours", ErrNoFreeSlot, dur)
+	}
+
+	return &TimeSlot{Start: slots[0].Start, End: slots[0].Start.Add(dur)}, nil
+}
+
+// freeSlotsIn returns the gaps of at least dur in window not covered by
+// busy, which must be sorted by start time.
+func freeSlotsIn(window TimeSlot, busy []TimeSlot, dur time.Duration) []TimeSlot {
+	var slots []TimeSlot
+	cursor := window.Start
+
+	for _, b := range busy {
+		if !b.End.After(cursor) || !b.Start.Before(window.End) {
+			continue
+		}
+		if b.Start.Sub(cursor) >= dur {
+			slots = append(slots, TimeSlot{Start: cursor, End: b.Start})
+		}
+		cursor = latest(cursor, b.End)
+	}
+
+	if window.End.Sub(cursor) >= dur {
+		slots = append(slots, TimeSlot{Start: cursor, End: window.End})
+	}
+	return slots
+}
+
+// isWeekend reports whether t falls on a Saturday or Sunday.
+func isWeekend(t time.Time) bool {
+	return t.Weekday() == time.Saturday || t.Weekday() == time.Sunday
+}
+
+func latest(a, b time.Time) time.Time {
+	if a.After(b) {
+		return a
+	}
+	return b
+}
+
+func earliest(a, b time.Time) time.Time {
+	if a.Before(b) {
+		return a
+	}
+	return b
+}
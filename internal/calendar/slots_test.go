@@ -0,0 +1,233 @@
+package calendar
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"google.golang.org/api/calendar/v3"
+)
+
+// addTimedEvent seeds the fake with an event between start and end.
+func addTimedEvent(fake *fakeCalendar, title string, start, end time.Time, transparent bool) {
+	event := &calendar.Event{
+		Summary: title,
+		Start:   &calendar.EventDateTime{DateTime: start.Format(time.RFC3339)},
+		End:     &calendar.EventDateTime{DateTime: end.Format(time.RFC3339)},
+	}
+	if transparent {
+		event.Transparency = "transparent"
+	}
+	fake.addEvent(event)
+}
+
+func TestFindFreeSlots(t *testing.T) {
+	client, fake := newFakeClient(t)
+	day := time.Date(2024, 1, 15, 0, 0, 0, 0, time.UTC)
+
+	addTimedEvent(fake, "Standup", day.Add(9*time.Hour), day.Add(10*time.Hour), false)
+	addTimedEvent(fake, "Review", day.Add(10*time.Hour+30*time.Minute), day.Add(12*time.Hour), false)
+	addTimedEvent(fake, "Focus (free)", day.Add(13*time.Hour), day.Add(15*time.Hour), true)
+
+	slots, err := client.FindFreeSlots(context.Background(), day, day.Add(24*time.Hour), 45*time.Minute)
+	if err != nil {
+		t.Fatalf("FindFreeSlots() error = %v", err)
+	}
+
+	// The 30-minute gap at 10:00 is too short; the transparent event doesn't block
+	want := []TimeSlot{{Start: day.Add(12 * time.Hour), End: day.Add(17 * time.Hour)}}
+	if len(slots) != len(want) {
+		t.Fatalf("FindFreeSlots() = %v, want %v", slots, want)
+	}
+	for i := range want {
+		if !slots[i].Start.Equal(want[i].Start) || !slots[i].End.Equal(want[i].End) {
+			t.Errorf("slot %d = %v-%v, want %v-%v", i, slots[i].Start, slots[i].End, want[i].Start, want[i].End)
+		}
+	}
+}
+
+func TestFindSlots(t *testing.T) {
+	// Friday, January 19, 2024
+	friday := time.Date(2024, 1, 19, 0, 0, 0, 0, time.UTC)
+	monday := friday.AddDate(0, 0, 3)
+
+	tests := []struct {
+		name  string
+		query SlotQuery
+		want  []TimeSlot
+	}{
+		{
+			name:  "all calendars must be free",
+			query: SlotQuery{Start: friday, End: friday.AddDate(0, 0, 1), Duration: time.Hour, Calendars: []string{"primary", "alice@example.com"}},
+			want: []TimeSlot{
+				{Start: friday.Add(10 * time.Hour), End: friday.Add(11 * time.Hour)},
+				{Start: friday.Add(15 * time.Hour), End: friday.Add(17 * time.Hour)},
+			},
+		},
+		{
+			name:  "skips weekends",
+			query: SlotQuery{Start: friday.Add(16 * time.Hour), End: monday.Add(11 * time.Hour), Duration: time.Hour, SkipWeekends: true},
+			want: []TimeSlot{
+				{Start: friday.Add(16 * time.Hour), End: friday.Add(17 * time.Hour)},
+				{Start: monday.Add(9 * time.Hour), End: monday.Add(11 * time.Hour)},
+			},
+		},
+		{
+			name:  "working hours override and limit",
+			query: SlotQuery{Start: friday, End: monday.AddDate(0, 0, 1), Duration: time.Hour, WorkingHours: WorkingHours{Start: 7 * time.Hour, End: 9 * time.Hour}, Limit: 2},
+			want: []TimeSlot{
+				{Start: friday.Add(7 * time.Hour), End: friday.Add(9 * time.Hour)},
+				{Start: friday.AddDate(0, 0, 1).Add(7 * time.Hour), End: friday.AddDate(0, 0, 1).Add(9 * time.Hour)},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client, fake := newFakeClient(t)
+			addTimedEvent(fake, "Standup", friday.Add(9*time.Hour), friday.Add(10*time.Hour), false)
+			fake.otherBusy = map[string][]*calendar.TimePeriod{
+				"alice@example.com": {{Start: "2024-01-19T11:00:00Z", End: "2024-01-19T15:00:00Z"}},
+			}
+
+			slots, err := client.FindSlots(context.Background(), tt.query)
+			if err != nil {
+				t.Fatalf("FindSlots() error = %v", err)
+			}
+			if len(slots) != len(tt.want) {
+				t.Fatalf("FindSlots() = %v, want %v", slots, tt.want)
+			}
+			for i := range tt.want {
+				if !slots[i].Start.Equal(tt.want[i].Start) || !slots[i].End.Equal(tt.want[i].End) {
+					t.Errorf("slot %d = %v-%v, want %v-%v", i, slots[i].Start, slots[i].End, tt.want[i].Start, tt.want[i].End)
+				}
+			}
+		})
+	}
+}
+
+func TestFindSlots_Invalid(t *testing.T) {
+	client, _ := newFakeClient(t)
+	day := time.Date(2024, 1, 15, 0, 0, 0, 0, time.UTC)
+
+	queries := []SlotQuery{
+		{Start: day, End: day.AddDate(0, 0, 1)},
+		{Start: day, End: day, Duration: time.Hour},
+		{Start: day, End: day.AddDate(0, 0, 1), Duration: time.Hour, Limit: -1},
+	}
+	for _, q := range queries {
+		if _, err := client.FindSlots(context.Background(), q); !errors.Is(err, ErrInvalidEventTime) {
+			t.Errorf("FindSlots(%+v) error = %v, want ErrInvalidEventTime", q, err)
+		}
+	}
+}
+
+func TestNextFreeSlotToday(t *testing.T) {
+	client, fake := newFakeClient(t)
+	day := time.Date(2024, 1, 15, 0, 0, 0, 0, time.UTC)
+	client.now = func() time.Time { return day.Add(8 * time.Hour) }
+
+	addTimedEvent(fake, "Busy morning", day.Add(9*time.Hour), day.Add(12*time.Hour), false)
+	addTimedEvent(fake, "Lunch", day.Add(12*time.Hour), day.Add(12*time.Hour+30*time.Minute), false)
+
+	slot, err := client.NextFreeSlotToday(context.Background(), 25*time.Minute, "UTC")
+	if err != nil {
+		t.Fatalf("NextFreeSlotToday() error = %v", err)
+	}
+
+	wantStart := day.Add(12*time.Hour + 30*time.Minute)
+	if !slot.Start.Equal(wantStart) {
+		t.Errorf("NextFreeSlotToday() start = %v, want %v", slot.Start, wantStart)
+	}
+	if slot.Duration() != 25*time.Minute {
+		t.Errorf("NextFreeSlotToday() duration = %v, want 25m", slot.Duration())
+	}
+}
+
+func TestNextFreeSlotToday_NoneLeft(t *testing.T) {
+	client, _ := newFakeClient(t)
+	day := time.Date(2024, 1, 15, 0, 0, 0, 0, time.UTC)
+	client.now = func() time.Time { return day.Add(16*time.Hour + 50*time.Minute) }
+
+	_, err := client.NextFreeSlotToday(context.Background(), 25*time.Minute, "UTC")
+	if !errors.Is(err, ErrNoFreeSlot) {
+		t.Errorf("NextFreeSlotToday() error = %v, want ErrNoFreeSlot", err)
+	}
+}
+
+func TestNextStartAfter(t *testing.T) {
+	lastEnd := time.Date(2024, 1, 15, 10, 0, 0, 0, time.UTC)
+
+	if got, want := NextStartAfter(lastEnd, 5*time.Minute), lastEnd.Add(5*time.Minute); !got.Equal(want) {
+		t.Errorf("NextStartAfter() = %v, want %v", got, want)
+	}
+}
+
+func TestNudgeStart(t *testing.T) {
+	lastEnd := time.Date(2024, 1, 15, 10, 0, 0, 0, time.UTC)
+	buffer := 5 * time.Minute
+
+	tests := []struct {
+		name    string
+		start   time.Time
+		lastEnd time.Time
+		want    time.Time
+	}{
+		{"abutting start is nudged", lastEnd, lastEnd, lastEnd.Add(buffer)},
+		{"start inside buffer is nudged", lastEnd.Add(2 * time.Minute), lastEnd, lastEnd.Add(buffer)},
+		{"start after buffer is kept", lastEnd.Add(10 * time.Minute), lastEnd, lastEnd.Add(10 * time.Minute)},
+		{"earlier start is kept", lastEnd.Add(-time.Hour), lastEnd, lastEnd.Add(-time.Hour)},
+		{"no previous event", lastEnd, time.Time{}, lastEnd},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := NudgeStart(tt.start, tt.lastEnd, buffer); !got.Equal(tt.want) {
+				t.Errorf("NudgeStart() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestIsTimeFree(t *testing.T) {
+	client, fake := newFakeClient(t)
+	day := time.Date(2024, 1, 16, 0, 0, 0, 0, time.UTC)
+
+	addTimedEvent(fake, "Review", day.Add(14*time.Hour), day.Add(15*time.Hour+30*time.Minute), false)
+	addTimedEvent(fake, "Focus (free)", day.Add(16*time.Hour), day.Add(17*time.Hour), true)
+
+	tests := []struct {
+		name      string
+		start     time.Time
+		end       time.Time
+		wantFree  bool
+		wantTitle string
+	}{
+		{"free morning", day.Add(10 * time.Hour), day.Add(11 * time.Hour), true, ""},
+		{"conflicting slot", day.Add(15 * time.Hour), day.Add(16 * time.Hour), false, "Review"},
+		{"abutting the end", day.Add(15*time.Hour + 30*time.Minute), day.Add(16 * time.Hour), true, ""},
+		{"over a transparent event", day.Add(16 * time.Hour), day.Add(17 * time.Hour), true, ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			free, conflicts, err := client.IsTimeFree(context.Background(), tt.start, tt.end)
+			if err != nil {
+				t.Fatalf("IsTimeFree() error = %v", err)
+			}
+			if free != tt.wantFree {
+				t.Errorf("IsTimeFree() = %v, want %v", free, tt.wantFree)
+			}
+			if tt.wantFree {
+				if conflicts != nil {
+					t.Errorf("conflicts = %v, want nil", conflicts)
+				}
+				return
+			}
+			if len(conflicts) != 1 || conflicts[0].Title != tt.wantTitle {
+				t.Errorf("conflicts = %v, want [%s]", conflicts, tt.wantTitle)
+			}
+		})
+	}
+}
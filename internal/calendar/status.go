@@ -0,0 +1,79 @@
+package calendar
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"google.golang.org/api/calendar/v3"
+)
+
+// ErrInvalidStatus is returned for an unknown event status.
+var ErrInvalidStatus = errors.New("invalid event status")
+
+// Event statuses for EventParams.Status and EventUpdate.Status.
+const (
+	StatusConfirmed = "confirmed"
+	StatusTentative = "tentative"
+
+	// StatusCancelled marks an event as cancelled. Google Calendar treats
+	// it much like a deleted event, but guests are told it was cancelled
+	// and it still appears in incremental syncs. See CancelEvent.
+	StatusCancelled = "cancelled"
+)
+
+// ParseStatus parses an event status as given to --status: "confirmed",
+// "tentative" or "cancelled" (also "canceled"), in any case.
+func ParseStatus(input string) (string, error) {
+	switch strings.ToLower(strings.TrimSpace(input)) {
+	case "confirmed":
+		return StatusConfirmed, nil
+	case "tentative":
+		return StatusTentative, nil
+	case "cancelled", "canceled":
+		return StatusCancelled, nil
+	default:
+		return "", fmt.Errorf("%w: %q (use confirmed, tentative or cancelled)", ErrInvalidStatus, input)
+	}
+}
+
+// validateStatus checks an API status value; empty is allowed.
+func validateStatus(status string) error {
+	switch status {
+	case "", StatusConfirmed, StatusTentative, StatusCancelled:
+		return nil
+	default:
+		return fmt.Errorf("%w: %q", ErrInvalidStatus, status)
+	}
+}
+
+// CancelEvent marks an event cancelled instead of deleting it, and emails
+// the cancellation to its guests. When etag is set the event is only
+// cancelled if it is still at that version, as with DeleteEventIfMatch.
+func (c *Client) CancelEvent(ctx context.Context, eventID, etag string) (*EventResult, error) {
+	if eventID == "" {
+		return nil, fmt.Errorf("%w: event ID is required", ErrInvalidEventTime)
+	}
+
+	ctx, cancel := c.withRequestTimeout(ctx)
+	defer cancel()
+
+	var cancelled *calendar.Event
+	err := c.retryPatch(ctx, etag != "", func() error {
+		call := c.service.Events.Patch(c.calendarID, eventID, &calendar.Event{Status: StatusCancelled}).
+			SendUpdates(SendUpdatesAll).
+			Context(ctx)
+		if etag != "" {
+			call.Header().Set("If-Match", etag)
+		}
+		var err error
+		cancelled, err = call.Do()
+		return err
+	})
+	c.InvalidateCache()
+	if err != nil {
+		return nil, wrapEventErrorAs(err, eventID, ErrEventDeleteFailed)
+	}
+	return parseEventResult(cancelled)
+}
@@ -0,0 +1,116 @@
+package calendar
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestParseStatus(t *testing.T) {
+	tests := []struct {
+		input   string
+		want    string
+		wantErr bool
+	}{
+		{input: "confirmed", want: StatusConfirmed},
+		{input: " Tentative ", want: StatusTentative},
+		{input: "cancelled", want: StatusCancelled},
+		{input: "canceled", want: StatusCancelled},
+		{input: "maybe", wantErr: true},
+		{input: "", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			got, err := ParseStatus(tt.input)
+			if tt.wantErr {
+				if !errors.Is(err, ErrInvalidStatus) {
+					t.Errorf("ParseStatus(%q) error = %v, want ErrInvalidStatus", tt.input, err)
+				}
+				return
+			}
+			if err != nil || got != tt.want {
+				t.Errorf("ParseStatus(%q) = %q, %v; want %q", tt.input, got, err, tt.want)
+			}
+		})
+	}
+}
+
+func TestEventStatus(t *testing.T) {
+	client, fake := newFakeClient(t)
+	ctx := context.Background()
+
+	created, err := client.CreateEvent(ctx, EventParams{
+		Title:     "Maybe lunch",
+		StartTime: time.Date(2024, 1, 15, 12, 0, 0, 0, time.UTC),
+		Duration:  time.Hour,
+		Status:    StatusTentative,
+	})
+	if err != nil {
+		t.Fatalf("CreateEvent() error = %v", err)
+	}
+	if created.Status != StatusTentative || fake.inserted[0].Status != StatusTentative {
+		t.Errorf("created status = %q, sent %q; want tentative", created.Status, fake.inserted[0].Status)
+	}
+
+	updated, err := client.UpdateEvent(ctx, created.ID, EventUpdate{Status: StatusConfirmed})
+	if err != nil {
+		t.Fatalf("UpdateEvent() error = %v", err)
+	}
+	if updated.Status != StatusConfirmed {
+		t.Errorf("updated status = %q, want confirmed", updated.Status)
+	}
+
+	_, err = client.UpdateEvent(ctx, created.ID, EventUpdate{Status: "done"})
+	if !errors.Is(err, ErrInvalidStatus) {
+		t.Errorf("UpdateEvent() with bad status error = %v, want ErrInvalidStatus", err)
+	}
+	_, err = client.CreateEvent(ctx, EventParams{
+		Title:     "Bad",
+		StartTime: time.Date(2024, 1, 15, 12, 0, 0, 0, time.UTC),
+		Duration:  time.Hour,
+		Status:    "done",
+	})
+	if !errors.Is(err, ErrInvalidStatus) {
+		t.Errorf("CreateEvent() with bad status error = %v, want ErrInvalidStatus", err)
+	}
+}
+
+func TestCancelEvent(t *testing.T) {
+	client, fake := newFakeClient(t)
+	ctx := context.Background()
+	event := fake.addEvent(testAPIEvent("evt1", "Review"))
+
+	cancelled, err := client.CancelEvent(ctx, "evt1", event.Etag)
+	if err != nil {
+		t.Fatalf("CancelEvent() error = %v", err)
+	}
+	if cancelled.Status != StatusCancelled {
+		t.Errorf("status = %q, want cancelled", cancelled.Status)
+	}
+	if got := fake.queries[len(fake.queries)-1].Get("sendUpdates"); got != SendUpdatesAll {
+		t.Errorf("sendUpdates = %q, want guests notified", got)
+	}
+
+	// The event is kept, but no longer listed
+	if _, err := client.GetEvent(ctx, "evt1"); err != nil {
+		t.Errorf("GetEvent() of cancelled event error = %v", err)
+	}
+	events, err := client.ListEvents(ctx, ListOptions{
+		TimeMin: time.Date(2024, 1, 15, 0, 0, 0, 0, time.UTC),
+		TimeMax: time.Date(2024, 1, 16, 0, 0, 0, 0, time.UTC),
+	})
+	if err != nil {
+		t.Fatalf("ListEvents() error = %v", err)
+	}
+	if len(events) != 0 {
+		t.Errorf("ListEvents() = %d events, want the cancelled one left out", len(events))
+	}
+
+	if _, err := client.CancelEvent(ctx, "evt1", event.Etag); !errors.Is(err, ErrConflict) {
+		t.Errorf("CancelEvent() with stale etag error = %v, want ErrConflict", err)
+	}
+	if _, err := client.CancelEvent(ctx, "missing", ""); !errors.Is(err, ErrEventNotFound) {
+		t.Errorf("CancelEvent() of missing event error = %v, want ErrEventNotFound", err)
+	}
+}
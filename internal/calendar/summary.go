@@ -0,0 +1,171 @@
+package calendar
+
+import (
+	"context"
+	"time"
+)
+
+// WeekSummary is a digest of one week of events.
+type WeekSummary struct {
+	// Start and End bound the week; End is exclusive.
+	Start time.Time
+	End   time.Time
+
+	// MeetingCount and MeetingTime cover the timed events that block time.
+	// All-day and transparent events are listed in Days but not counted.
+	MeetingCount int
+	MeetingTime  time.Duration
+
+	// BusiestDay is midnight of the day with the most meeting time, or zero
+	// for a week without meetings. Ties go to the earlier day.
+	BusiestDay time.Time
+
+	// Days holds the week's events grouped by start day.
+	Days []DayGroup
+}
+
+// MeetingHours returns MeetingTime in hours.
+func (s WeekSummary) MeetingHours() float64 {
+	return s.MeetingTime.Hours()
+}
+
+// StartOfWeek returns midnight of the day on or before t that falls on
+// first, in t's location.
+func StartOfWeek(t time.Time, first time.Weekday) time.Time {
+	offset := (int(t.Weekday()) - int(first) + 7) % 7
+	day := t.AddDate(0, 0, -offset)
+	return time.Date(day.Year(), day.Month(), day.Day(), 0, 0, 0, 0, t.Location())
+}
+
+// WeekAhead summarizes the seven days starting at midnight of weekStart in
+// timezone, e.g. for a Monday-morning digest. Callers pick weekStart with
+// StartOfWeek and the configured first day of the week. Only events that
+// start within the week are included.
+func (c *Client) WeekAhead(ctx context.Context, weekStart time.Time, timezone string) (WeekSummary, error) {
+	loc, err := getLocation(timezone)
+	if err != nil {
+		return WeekSummary{}, err
+	}
+
+	weekStart = weekStart.In(loc)
+	start := time.Date(weekStart.Year(), weekStart.Month(), weekStart.Day(), 0, 0, 0, 0, loc)
+	end := start.AddDate(0, 0, 7)
+
+	events, err := c.ListEvents(ctx, ListOptions{TimeMin: start, TimeMax: end})
+	if err != nil {
+		return WeekSummary{}, err
+	}
+
+	var inWeek []*EventResult
+	for _, event := range events {
+		if !event.StartTime.Before(start) {
+			inWeek = append(inWeek, event)
+		}
+	}
+
+	summary := WeekSummary{Start: start, End: end, Days: GroupByDay(inWeek, loc)}
+	var busiest time.Duration
+	for _, day := range summary.Days {
+		var dayTime time.Duration
+		for _, event := range day.Events {
+			if event.AllDay || event.Transparent {
+				continue
+			}
+			summary.MeetingCount++
+			dayTime += event.EndTime.Sub(event.StartTime)
+		}
+		summary.MeetingTime += dayTime
+		if dayTime > busiest {
+			busiest = dayTime
+			summary.BusiestDay = day.Date
+		}
+	}
+
+	return summary, nil
+}
+
+// TodayStatus is a glanceable summary of the rest of today.
+type TodayStatus struct {
+	// Now is the time the status was computed at.
+	Now time.Time
+
+	// NextEvent is the next timed event starting later today, or nil.
+	// MinutesUntilNext is the whole minutes until it starts.
+	NextEvent        *EventResult
+	MinutesUntilNext int
+
+	// BusyMinutes is the time blocked by timed, non-transparent events
+	// over the whole of today, with overlaps counted once.
+	BusyMinutes int
+
+	// FreeMinutes is the free time left in today's working hours.
+	FreeMinutes int
+}
+
+// TodaySummary reports the next event and the busy and free time for today
+// in timezone.
+func (c *Client) TodaySummary(ctx context.Context, timezone string) (TodayStatus, error) {
+	loc, err := getLocation(timezone)
+	if err != nil {
+		return TodayStatus{}, err
+	}
+
+	now := c.now().In(loc)
+	dayStart := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, loc)
+	dayEnd := dayStart.AddDate(0, 0, 1)
+	status := TodayStatus{Now: now}
+
+	upcoming, err := c.UpcomingEvents(ctx, dayEnd.Sub(now))
+	if err != nil {
+		return TodayStatus{}, err
+	}
+	for _, event := range upcoming {
+		if !event.AllDay {
+			status.NextEvent = event
+			status.MinutesUntilNext = int(event.StartTime.Sub(now) / time.Minute)
+			break
+		}
+	}
+
+	events, err := c.ListEvents(ctx, ListOptions{TimeMin: dayStart, TimeMax: dayEnd})
+	if err != nil {
+		return TodayStatus{}, err
+	}
+	var busy []TimeSlot
+	for _, event := range events {
+		if !event.AllDay && !event.Transparent {
+			busy = append(busy, TimeSlot{
+				Start: latest(event.StartTime, dayStart),
+				End:   earliest(event.EndTime, dayEnd),
+			})
+		}
+	}
+	status.BusyMinutes = int(mergedDuration(busy) / time.Minute)
+
+	free, err := c.FindFreeSlots(ctx, now, dayEnd, time.Minute)
+	if err != nil {
+		return TodayStatus{}, err
+	}
+	var freeTime time.Duration
+	for _, slot := range free {
+		freeTime += slot.Duration()
+	}
+	status.FreeMinutes = int(freeTime / time.Minute)
+
+	return status, nil
+}
+
+// mergedDuration returns the total time covered by slots, which must be
+// sorted by start time, counting overlaps once.
+func mergedDuration(slots []TimeSlot) time.Duration {
+	var total time.Duration
+	var cursor time.Time
+	for _, slot := range slots {
+		start := latest(slot.Start, cursor)
+		if slot.End.After(start) {
+			total += slot.End.Sub(start)
+			cursor = slot.End
+		}
+	}
+	return total
+}
@@ -0,0 +1,114 @@
+package calendar
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"google.golang.org/api/calendar/v3"
+)
+
+func TestStartOfWeek(t *testing.T) {
+	wednesday := time.Date(2024, 1, 17, 15, 30, 0, 0, time.UTC)
+
+	tests := []struct {
+		first time.Weekday
+		want  time.Time
+	}{
+		{time.Monday, time.Date(2024, 1, 15, 0, 0, 0, 0, time.UTC)},
+		{time.Sunday, time.Date(2024, 1, 14, 0, 0, 0, 0, time.UTC)},
+		{time.Wednesday, time.Date(2024, 1, 17, 0, 0, 0, 0, time.UTC)},
+		{time.Thursday, time.Date(2024, 1, 11, 0, 0, 0, 0, time.UTC)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.first.String(), func(t *testing.T) {
+			if got := StartOfWeek(wednesday, tt.first); !got.Equal(tt.want) {
+				t.Errorf("StartOfWeek(%s) = %v, want %v", tt.first, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestWeekAhead(t *testing.T) {
+	client, fake := newFakeClient(t)
+	monday := time.Date(2024, 1, 15, 0, 0, 0, 0, time.UTC)
+
+	addTimedEvent(fake, "Standup", monday.Add(9*time.Hour), monday.Add(9*time.Hour+30*time.Minute), false)
+	addTimedEvent(fake, "Planning", monday.Add(24*time.Hour+10*time.Hour), monday.Add(24*time.Hour+12*time.Hour), false)
+	addTimedEvent(fake, "Review", monday.Add(24*time.Hour+14*time.Hour), monday.Add(24*time.Hour+15*time.Hour), false)
+	addTimedEvent(fake, "Focus", monday.Add(48*time.Hour+9*time.Hour), monday.Add(48*time.Hour+17*time.Hour), true)
+	fake.addEvent(&calendar.Event{
+		Summary: "Conference",
+		Start:   &calendar.EventDateTime{Date: "2024-01-18"},
+		End:     &calendar.EventDateTime{Date: "2024-01-20"},
+	})
+	// Outside the week
+	addTimedEvent(fake, "Next week", monday.AddDate(0, 0, 7).Add(9*time.Hour), monday.AddDate(0, 0, 7).Add(10*time.Hour), false)
+
+	summary, err := client.WeekAhead(context.Background(), monday.Add(8*time.Hour), "UTC")
+	if err != nil {
+		t.Fatalf("WeekAhead() error = %v", err)
+	}
+
+	if summary.MeetingCount != 3 {
+		t.Errorf("MeetingCount = %d, want 3", summary.MeetingCount)
+	}
+	if summary.MeetingHours() != 3.5 {
+		t.Errorf("MeetingHours() = %v, want 3.5", summary.MeetingHours())
+	}
+	if want := monday.AddDate(0, 0, 1); !summary.BusiestDay.Equal(want) {
+		t.Errorf("BusiestDay = %v, want %v", summary.BusiestDay, want)
+	}
+	if len(summary.Days) != 4 {
+		t.Errorf("Days = %d groups, want 4", len(summary.Days))
+	}
+	if !summary.Start.Equal(monday) || !summary.End.Equal(monday.AddDate(0, 0, 7)) {
+		t.Errorf("week = %v - %v, want %v - %v", summary.Start, summary.End, monday, monday.AddDate(0, 0, 7))
+	}
+}
+
+func TestTodaySummary(t *testing.T) {
+	client, fake := newFakeClient(t)
+	day := time.Date(2024, 1, 15, 0, 0, 0, 0, time.UTC)
+	client.now = func() time.Time { return day.Add(10 * time.Hour) }
+
+	// Working hours are 9:00-17:00
+	addTimedEvent(fake, "Standup", day.Add(9*time.Hour), day.Add(9*time.Hour+30*time.Minute), false)
+	addTimedEvent(fake, "Review", day.Add(11*time.Hour+15*time.Minute), day.Add(12*time.Hour+15*time.Minute), false)
+	addTimedEvent(fake, "Overlapping", day.Add(12*time.Hour), day.Add(12*time.Hour+30*time.Minute), false)
+	addTimedEvent(fake, "Focus", day.Add(14*time.Hour), day.Add(16*time.Hour), true)
+
+	status, err := client.TodaySummary(context.Background(), "UTC")
+	if err != nil {
+		t.Fatalf("TodaySummary() error = %v", err)
+	}
+
+	if status.NextEvent == nil || status.NextEvent.Title != "Review" {
+		t.Fatalf("NextEvent = %+v, want Review", status.NextEvent)
+	}
+	if status.MinutesUntilNext != 75 {
+		t.Errorf("MinutesUntilNext = %d, want 75", status.MinutesUntilNext)
+	}
+	// 30 minutes of standup plus 11:15-12:30 counted once
+	if status.BusyMinutes != 105 {
+		t.Errorf("BusyMinutes = %d, want 105", status.BusyMinutes)
+	}
+	// 10:00-17:00 is 420 minutes, less 75 busy; the transparent block is free
+	if status.FreeMinutes != 345 {
+		t.Errorf("FreeMinutes = %d, want 345", status.FreeMinutes)
+	}
+}
+
+func TestTodaySummary_NothingUpcoming(t *testing.T) {
+	client, _ := newFakeClient(t)
+	client.now = func() time.Time { return time.Date(2024, 1, 15, 18, 0, 0, 0, time.UTC) }
+
+	status, err := client.TodaySummary(context.Background(), "UTC")
+	if err != nil {
+		t.Fatalf("TodaySummary() error = %v", err)
+	}
+	if status.NextEvent != nil || status.FreeMinutes != 0 || status.BusyMinutes != 0 {
+		t.Errorf("TodaySummary() = %+v, want an empty status", status)
+	}
+}
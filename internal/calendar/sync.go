@@ -0,0 +1,229 @@
+package calendar
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+
+	"google.golang.org/api/calendar/v3"
+	"google.golang.org/api/googleapi"
+)
+
+// Errors for incremental sync.
+var (
+	ErrSyncFailed       = errors.New("failed to sync events")
+	ErrSyncTokenExpired = errors.New("sync token expired")
+	ErrSyncStoreFailed  = errors.New("failed to access sync token store")
+)
+
+// SyncResult holds the changes to a calendar since a sync token.
+type SyncResult struct {
+	// Changed holds the events created or updated since the token, or all
+	// events when Full is set. Recurring events are not expanded.
+	Changed []*EventResult `json:"changed"`
+
+	// Deleted holds the IDs of events deleted or cancelled since the token.
+	Deleted []string `json:"deleted"`
+
+	// SyncToken is the token to pass to the next SyncChanges call.
+	SyncToken string `json:"sync_token"`
+
+	// Full reports that this was a full sync, either because no token was
+	// given or because the given one had expired. Callers keeping a local
+	// copy should replace it with Changed.
+	Full bool `json:"full"`
+}
+
+// SyncChanges returns the changes to the calendar since token, which comes
+// from an earlier SyncResult. An empty token, or one the API no longer
+// accepts, does a full sync instead. Results aren't cached.
+func (c *Client) SyncChanges(ctx context.Context, token string) (*SyncResult, error) {
+	ctx, cancel := c.withRequestTimeout(ctx)
+	defer cancel()
+
+	if token != "" {
+		result, err := c.syncChanges(ctx, token)
+		if !errors.Is(err, ErrSyncTokenExpired) {
+			return result, err
+		}
+	}
+
+	result, err := c.syncChanges(ctx, "")
+	if err != nil {
+		return nil, err
+	}
+	result.Full = true
+	return result, nil
+}
+
+// syncChanges follows the pages of one sync from token, which is empty for
+// a full sync, until the API returns the next sync token.
+func (c *Client) syncChanges(ctx context.Context, token string) (*SyncResult, error) {
+	result := &SyncResult{}
+	pageToken := ""
+
+	for {
+		// Sync tokens can't be combined with time bounds, ordering or a query
+		call := c.service.Events.List(c.calendarID).
+			MaxResults(maxPageSize).
+			Context(ctx)
+		if token != "" {
+			call = call.SyncToken(token)
+		}
+		if pageToken != "" {
+			call = call.PageToken(pageToken)
+		}
+
+		var page *calendar.Events
+		err := c.retry(ctx, true, func() error {
+			var err error
+			page, err = call.Do()
+			return err
+		})
+		if err != nil {
+			var apiErr *googleapi.Error
+			if errors.As(err, &apiErr) && apiErr.Code == 410 {
+				return nil, ErrSyncTokenExpired
+			}
+			return nil, wrapAPIErrorAs(err, ErrSyncFailed)
+		}
+
+		for _, item := range page.Items {
+			if item.Status == "cancelled" {
+				result.Deleted = append(result.Deleted, item.Id)
+				continue
+			}
+			event, err := parseEventResult(item)
+			if err != nil {
+				return nil, fmt.Errorf("%w: event %s: %w", ErrSyncFailed, item.Id, err)
+			}
+			result.Changed = append(result.Changed, event)
+		}
+
+		if page.NextPageToken == "" {
+			if page.NextSyncToken == "" {
+				return nil, fmt.Errorf("%w: no sync token in response", ErrSyncFailed)
+			}
+			result.SyncToken = page.NextSyncToken
+			return result, nil
+		}
+		pageToken = page.NextPageToken
+	}
+}
+
+// SyncTokenStore persists the latest sync token of each calendar.
+type SyncTokenStore interface {
+	// Load returns the token saved for the calendar, or "" if there is none.
+	Load(calendarID string) (string, error)
+
+	// Save replaces the token saved for the calendar. An empty token
+	// removes it.
+	Save(calendarID, token string) error
+}
+
+// Sync is SyncChanges from the token saved in store for the client's
+// calendar, saving the new token once the changes have been fetched.
+func (c *Client) Sync(ctx context.Context, store SyncTokenStore) (*SyncResult, error) {
+	token, err := store.Load(c.calendarID)
+	if err != nil {
+		return nil, err
+	}
+
+	result, err := c.SyncChanges(ctx, token)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := store.Save(c.calendarID, result.SyncToken); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// FileSyncTokenStore is a SyncTokenStore keeping the tokens of all
+// calendars in one JSON file, readable only by the user.
+type FileSyncTokenStore struct {
+	path string
+	mu   sync.Mutex
+}
+
+// NewFileSyncTokenStore returns a store backed by the file at path, which is
+// created on the first Save.
+func NewFileSyncTokenStore(path string) *FileSyncTokenStore {
+	return &FileSyncTokenStore{path: path}
+}
+
+// Load implements SyncTokenStore.
+func (s *FileSyncTokenStore) Load(calendarID string) (string, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	tokens, err := s.read()
+	if err != nil {
+		return "", err
+	}
+	return tokens[calendarID], nil
+}
+
+// Save implements SyncTokenStore.
+func (s *FileSyncTokenStore) Save(calendarID, token string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	tokens, err := s.read()
+	if err != nil {
+		return err
+	}
+	if token == "" {
+		delete(tokens, calendarID)
+	} else {
+		tokens[calendarID] = token
+	}
+
+	data, err := json.MarshalIndent(tokens, "", "  ")
+	if err != nil {
+		return fmt.Errorf("%w: %w", ErrSyncStoreFailed, err)
+	}
+
+	// Write to a temporary file and rename it so a crash can't leave a
+	// truncated file behind
+	tmp, err := os.CreateTemp(filepath.Dir(s.path), filepath.Base(s.path)+".*")
+	if err != nil {
+		return fmt.Errorf("%w: %w", ErrSyncStoreFailed, err)
+	}
+	defer os.Remove(tmp.Name())
+
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		return fmt.Errorf("%w: %w", ErrSyncStoreFailed, err)
+	}
+	if err := tmp.Close(); err != nil {
+		return fmt.Errorf("%w: %w", ErrSyncStoreFailed, err)
+	}
+	if err := os.Rename(tmp.Name(), s.path); err != nil {
+		return fmt.Errorf("%w: %w", ErrSyncStoreFailed, err)
+	}
+	return nil
+}
+
+// read returns the saved tokens, or an empty map if the file doesn't exist.
+func (s *FileSyncTokenStore) read() (map[string]string, error) {
+	tokens := make(map[string]string)
+
+	data, err := os.ReadFile(s.path)
+	if errors.Is(err, os.ErrNotExist) {
+		return tokens, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrSyncStoreFailed, err)
+	}
+
+	if err := json.Unmarshal(data, &tokens); err != nil {
+		return nil, fmt.Errorf("%w: %s: %w", ErrSyncStoreFailed, s.path, err)
+	}
+	return tokens, nil
+}
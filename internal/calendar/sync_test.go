@@ -0,0 +1,143 @@
+package calendar
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"google.golang.org/api/calendar/v3"
+)
+
+func TestSyncChanges(t *testing.T) {
+	client, fake := newFakeClient(t)
+	ctx := context.Background()
+	fake.addEvent(testAPIEvent("kept", "Standup"))
+	fake.addEvent(testAPIEvent("gone", "Lunch"))
+
+	full, err := client.SyncChanges(ctx, "")
+	if err != nil {
+		t.Fatalf("full SyncChanges() error = %v", err)
+	}
+	if !full.Full || len(full.Changed) != 2 || len(full.Deleted) != 0 || full.SyncToken == "" {
+		t.Fatalf("full sync = %+v, want 2 changed events and a token", full)
+	}
+
+	fake.mu.Lock()
+	fake.remove("gone")
+	fake.mu.Unlock()
+	fake.addEvent(testAPIEvent("new", "Review"))
+
+	delta, err := client.SyncChanges(ctx, full.SyncToken)
+	if err != nil {
+		t.Fatalf("incremental SyncChanges() error = %v", err)
+	}
+	if delta.Full {
+		t.Error("incremental sync reported as full")
+	}
+	if len(delta.Changed) != 1 || delta.Changed[0].ID != "new" {
+		t.Errorf("Changed = %v, want only the new event", delta.Changed)
+	}
+	if len(delta.Deleted) != 1 || delta.Deleted[0] != "gone" {
+		t.Errorf("Deleted = %v, want [gone]", delta.Deleted)
+	}
+
+	again, err := client.SyncChanges(ctx, delta.SyncToken)
+	if err != nil {
+		t.Fatalf("repeated SyncChanges() error = %v", err)
+	}
+	if len(again.Changed) != 0 || len(again.Deleted) != 0 {
+		t.Errorf("sync without changes = %+v, want nothing", again)
+	}
+}
+
+func TestSyncChanges_ExpiredToken(t *testing.T) {
+	client, fake := newFakeClient(t)
+	fake.addEvent(testAPIEvent("kept", "Standup"))
+	fake.expireSyncTokens = true
+
+	result, err := client.SyncChanges(context.Background(), "1")
+	if err != nil {
+		t.Fatalf("SyncChanges() error = %v", err)
+	}
+	if !result.Full || len(result.Changed) != 1 {
+		t.Errorf("result = %+v, want a full sync", result)
+	}
+}
+
+func TestSync_FileStore(t *testing.T) {
+	client, fake := newFakeClient(t)
+	ctx := context.Background()
+	fake.addEvent(testAPIEvent("kept", "Standup"))
+
+	path := filepath.Join(t.TempDir(), "sync_tokens.json")
+	store := NewFileSyncTokenStore(path)
+
+	if _, err := client.Sync(ctx, store); err != nil {
+		t.Fatalf("first Sync() error = %v", err)
+	}
+	token, err := store.Load("primary")
+	if err != nil || token == "" {
+		t.Fatalf("saved token = %q, %v; want one", token, err)
+	}
+	info, err := os.Stat(path)
+	if err != nil {
+		t.Fatalf("token file: %v", err)
+	}
+	if mode := info.Mode().Perm(); mode != 0600 {
+		t.Errorf("token file mode = %v, want 0600", mode)
+	}
+
+	fake.addEvent(testAPIEvent("new", "Review"))
+	// A fresh store reads the token back, as a later run would
+	result, err := client.Sync(ctx, NewFileSyncTokenStore(path))
+	if err != nil {
+		t.Fatalf("second Sync() error = %v", err)
+	}
+	if result.Full || len(result.Changed) != 1 {
+		t.Errorf("second sync = %+v, want only the new event", result)
+	}
+}
+
+func TestFileSyncTokenStore(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "sync_tokens.json")
+	store := NewFileSyncTokenStore(path)
+
+	if token, err := store.Load("primary"); err != nil || token != "" {
+		t.Errorf("Load() without file = %q, %v; want empty", token, err)
+	}
+	if err := store.Save("primary", "a"); err != nil {
+		t.Fatalf("Save() error = %v", err)
+	}
+	if err := store.Save("team@group.calendar.google.com", "b"); err != nil {
+		t.Fatalf("Save() error = %v", err)
+	}
+	if err := store.Save("primary", ""); err != nil {
+		t.Fatalf("Save(empty) error = %v", err)
+	}
+
+	if token, _ := store.Load("primary"); token != "" {
+		t.Errorf("removed token = %q, want empty", token)
+	}
+	if token, _ := store.Load("team@group.calendar.google.com"); token != "b" {
+		t.Errorf("token = %q, want b", token)
+	}
+
+	if err := os.WriteFile(path, []byte("not json"), 0600); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := store.Load("primary"); !errors.Is(err, ErrSyncStoreFailed) {
+		t.Errorf("Load() of corrupt file error = %v, want ErrSyncStoreFailed", err)
+	}
+}
+
+// testAPIEvent returns a one-hour API event with the given ID and title.
+func testAPIEvent(id, title string) *calendar.Event {
+	return &calendar.Event{
+		Id:      id,
+		Summary: title,
+		Start:   &calendar.EventDateTime{DateTime: "2024-01-15T09:00:00Z"},
+		End:     &calendar.EventDateTime{DateTime: "2024-01-15T10:00:00Z"},
+	}
+}
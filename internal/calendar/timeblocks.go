@@ -0,0 +1,101 @@
+package calendar
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+)
+
+// maxBlockSearches bounds how often PlanFreeBlocks widens its busy-time
+// query when busy periods push the blocks past the end of the last one.
+const maxBlockSearches = 10
+
+// BlockPlan describes a series of work blocks separated by breaks, e.g.
+// four 50-minute blocks with 10-minute breaks from 9:00.
+type BlockPlan struct {
+	Start    time.Time
+	Duration time.Duration
+	Break    time.Duration
+	Count    int
+}
+
+// validate checks the plan before any blocks are placed.
+func (p BlockPlan) validate() error {
+	switch {
+	case p.Start.IsZero():
+		return fmt.Errorf("%w: start time is required", ErrInvalidEventTime)
+	case p.Duration <= 0:
+		return fmt.Errorf("%w: block duration must be positive", ErrInvalidEventTime)
+	case p.Break < 0:
+		return fmt.Errorf("%w: break cannot be negative", ErrInvalidEventTime)
+	case p.Count <= 0:
+		return fmt.Errorf("%w: block count must be positive", ErrInvalidEventTime)
+	}
+	return nil
+}
+
+// end returns when the last block ends if no busy time is in the way.
+func (p BlockPlan) end() time.Time {
+	return p.Start.Add(time.Duration(p.Count)*p.Duration + time.Duration(p.Count-1)*p.Break)
+}
+
+// PlanBlocks places the blocks of plan back to back, each a break after the
+// one before. A block that would overlap a busy period starts when that
+// period ends instead, and the blocks after it follow on from there.
+func PlanBlocks(plan BlockPlan, busy []TimeSlot) ([]TimeSlot, error) {
+	if err := plan.validate(); err != nil {
+		return nil, err
+	}
+
+	busy = append([]TimeSlot(nil), busy...)
+	sort.Slice(busy, func(i, j int) bool { return busy[i].Start.Before(busy[j].Start) })
+
+	blocks := make([]TimeSlot, 0, plan.Count)
+	next := plan.Start
+	for len(blocks) < plan.Count {
+		start := next
+		// Busy periods are sorted, so one pass moves the block past every
+		// period it runs into
+		for _, period := range busy {
+			if period.Start.Before(start.Add(plan.Duration)) && period.End.After(start) {
+				start = period.End
+			}
+		}
+
+		blocks = append(blocks, TimeSlot{Start: start, End: start.Add(plan.Duration)})
+		next = start.Add(plan.Duration + plan.Break)
+	}
+	return blocks, nil
+}
+
+// PlanFreeBlocks is PlanBlocks around the busy periods of the client's
+// calendar. It returns ErrNoFreeSlot if the blocks keep being pushed back
+// by busy time.
+func (c *Client) PlanFreeBlocks(ctx context.Context, plan BlockPlan) ([]TimeSlot, error) {
+	if err := plan.validate(); err != nil {
+		return nil, err
+	}
+
+	end := plan.end()
+	for range maxBlockSearches {
+		busy, err := c.FreeBusy(ctx, plan.Start, end, nil)
+		if err != nil {
+			return nil, err
+		}
+
+		blocks, err := PlanBlocks(plan, busy[c.calendarID])
+		if err != nil {
+			return nil, err
+		}
+		last := blocks[len(blocks)-1].End
+		if !last.After(end) {
+			return blocks, nil
+		}
+
+		// Blocks past the queried range may overlap busy time it didn't
+		// include; query again up to where they now end
+		end = last
+	}
+	return nil, fmt.Errorf("%w: couldn't fit %d blocks around busy time", ErrNoFreeSlot, plan.Count)
+}
@@ -0,0 +1,109 @@
+package calendar
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"google.golang.org/api/calendar/v3"
+)
+
+func TestPlanBlocks(t *testing.T) {
+	at := func(hour, minute int) time.Time {
+		return time.Date(2024, 1, 15, hour, minute, 0, 0, time.UTC)
+	}
+	plan := BlockPlan{Start: at(9, 0), Duration: 50 * time.Minute, Break: 10 * time.Minute, Count: 3}
+
+	tests := []struct {
+		name   string
+		busy   []TimeSlot
+		starts []time.Time
+	}{
+		{
+			name:   "back to back",
+			starts: []time.Time{at(9, 0), at(10, 0), at(11, 0)},
+		},
+		{
+			name:   "busy period pushes later blocks",
+			busy:   []TimeSlot{{Start: at(10, 30), End: at(11, 15)}},
+			starts: []time.Time{at(9, 0), at(11, 15), at(12, 15)},
+		},
+		{
+			name: "adjacent busy periods, unsorted",
+			busy: []TimeSlot{
+				{Start: at(9, 30), End: at(10, 0)},
+				{Start: at(9, 0), End: at(9, 30)},
+			},
+			starts: []time.Time{at(10, 0), at(11, 0), at(12, 0)},
+		},
+		{
+			name:   "busy time in a break is ignored",
+			busy:   []TimeSlot{{Start: at(9, 50), End: at(10, 0)}},
+			starts: []time.Time{at(9, 0), at(10, 0), at(11, 0)},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			blocks, err := PlanBlocks(plan, tt.busy)
+			if err != nil {
+				t.Fatalf("PlanBlocks() error = %v", err)
+			}
+			if len(blocks) != len(tt.starts) {
+				t.Fatalf("PlanBlocks() returned %d blocks, want %d", len(blocks), len(tt.starts))
+			}
+			for i, block := range blocks {
+				if !block.Start.Equal(tt.starts[i]) || block.Duration() != plan.Duration {
+					t.Errorf("block %d = %v-%v, want a 50m block at %v", i, block.Start, block.End, tt.starts[i])
+				}
+			}
+		})
+	}
+
+	bad := plan
+	bad.Count = 0
+	if _, err := PlanBlocks(bad, nil); !errors.Is(err, ErrInvalidEventTime) {
+		t.Errorf("PlanBlocks() with no blocks error = %v, want ErrInvalidEventTime", err)
+	}
+}
+
+func TestPlanFreeBlocks(t *testing.T) {
+	client, fake := newFakeClient(t)
+	busy := func(id, start, end string) {
+		fake.addEvent(&calendar.Event{
+			Id:    id,
+			Start: &calendar.EventDateTime{DateTime: start},
+			End:   &calendar.EventDateTime{DateTime: end},
+		})
+	}
+	// The second meeting is past the blocks' unobstructed end, so it is only
+	// seen once the first has pushed them back
+	busy("standup", "2024-01-15T09:30:00Z", "2024-01-15T10:00:00Z")
+	busy("review", "2024-01-15T11:00:00Z", "2024-01-15T12:00:00Z")
+
+	blocks, err := client.PlanFreeBlocks(context.Background(), BlockPlan{
+		Start:    time.Date(2024, 1, 15, 9, 0, 0, 0, time.UTC),
+		Duration: 50 * time.Minute,
+		Break:    10 * time.Minute,
+		Count:    2,
+	})
+	if err != nil {
+		t.Fatalf("PlanFreeBlocks() error = %v", err)
+	}
+
+	want := []time.Time{
+		time.Date(2024, 1, 15, 10, 0, 0, 0, time.UTC),
+		time.Date(2024, 1, 15, 12, 0, 0, 0, time.UTC),
+	}
+	for i, block := range blocks {
+		if !block.Start.Equal(want[i]) {
+			t.Errorf("block %d starts at %v, want %v", i, block.Start, want[i])
+		}
+	}
+	// Each time the blocks are pushed past the queried range, it is queried
+	// again up to their new end, until they fit
+	if n := fake.requestCount("POST", "/freeBusy"); n != 3 {
+		t.Errorf("made %d free/busy queries, want 3", n)
+	}
+}
@@ -0,0 +1,95 @@
+package calendar
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+	"time"
+)
+
+// rangeSnap is the boundary "now" is rounded up to when a range starts now.
+const rangeSnap = 5 * time.Minute
+
+// restOfDayRegex matches phrases meaning "from now until the end of the workday".
+var restOfDayRegex = regexp.MustCompile(`^(?:(?:the\s+)?rest\s+of\s+(?:the|my\s+)?\s*(?:work\s*)?day|(?:until|till|til)\s+(?:eod|end\s+of\s+(?:the\s+)?day))$`)
+
+// rangeSeparatorRegex splits "START to END" style ranges.
+var rangeSeparatorRegex = regexp.MustCompile(`\s+(?:to|until|till|-)\s+`)
+
+// ParseTimeRange parses a time range into a TimeSlot.
+// Supported formats:
+//   - "rest of day", "rest of my day", "until eod", "till end of day": from
+//     now, rounded up to the next 5 minutes, to the end of hours.End today
+//   - "START to END", "START - END", "START until END": each side is parsed
+//     with ParseTime; a time-only END such as "15:00" or "3pm" falls on
+//     START's date
+//
+// The timezone is resolved as in ParseTime.
+func ParseTimeRange(input string, timezone string, hours WorkingHours) (TimeSlot, error) {
+	loc, err := getLocation(timezone)
+	if err != nil {
+		return TimeSlot{}, err
+	}
+	return parseTimeRange(input, timezone, hours, time.Now().In(loc))
+}
+
+// parseTimeRange implements ParseTimeRange relative to now.
+func parseTimeRange(input string, timezone string, hours WorkingHours, now time.Time) (TimeSlot, error) {
+	input = strings.ToLower(strings.TrimSpace(input))
+	if input == "" {
+		return TimeSlot{}, fmt.Errorf("%w: empty input", ErrInvalidDateFormat)
+	}
+
+	if restOfDayRegex.MatchString(input) {
+		return restOfDay(now, hours)
+	}
+
+	startInput, endInput, ok := splitRange(input)
+	if !ok {
+		return TimeSlot{}, fmt.Errorf("%w: expected a range like \"14:00 to 15:00\": %s", ErrInvalidDateFormat, input)
+	}
+
+	start, err := ParseTime(startInput, timezone)
+	if err != nil {
+		return TimeSlot{}, err
+	}
+
+	var end time.Time
+	if hour, minute, second, ok := parseClock(endInput); ok {
+		end = time.Date(start.Year(), start.Month(), start.Day(), hour, minute, second, 0, start.Location())
+	} else if end, err = ParseTime(endInput, timezone); err != nil {
+		return TimeSlot{}, err
+	}
+
+	if !end.After(start) {
+		return TimeSlot{}, fmt.Errorf("%w: end of range must be after start", ErrInvalidEventTime)
+	}
+	return TimeSlot{Start: start, End: end}, nil
+}
+
+// restOfDay returns the span from now, rounded up to rangeSnap, to the end
+// of today's working hours.
+func restOfDay(now time.Time, hours WorkingHours) (TimeSlot, error) {
+	start := now.Truncate(rangeSnap)
+	if start.Before(now) {
+		start = start.Add(rangeSnap)
+	}
+
+	midnight := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, now.Location())
+	end := midnight.Add(hours.End)
+	if !end.After(start) {
+		return TimeSlot{}, fmt.Errorf("%w: the workday ended at %s", ErrInvalidEventTime, end.Format("15:04"))
+	}
+	return TimeSlot{Start: start, End: end}, nil
+}
+
+// splitRange splits input at its first range separator.
+func splitRange(input string) (start, end string, ok bool) {
+	loc := rangeSeparatorRegex.FindStringIndex(input)
+	if loc == nil {
+		return "", "", false
+	}
+	start = strings.TrimSpace(input[:loc[0]])
+	end = strings.TrimSpace(input[loc[1]:])
+	return start, end, start != "" && end != ""
+}
@@ -0,0 +1,103 @@
+package calendar
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestParseTimeRange_RestOfDay(t *testing.T) {
+	hours := WorkingHours{Start: 9 * time.Hour, End: 18 * time.Hour}
+	now := time.Date(2024, 1, 15, 14, 7, 30, 0, time.UTC)
+
+	phrases := []string{"rest of day", "rest of the day", "Rest of my day", "until eod", "till EOD", "until end of day"}
+	for _, phrase := range phrases {
+		t.Run(phrase, func(t *testing.T) {
+			got, err := parseTimeRange(phrase, "UTC", hours, now)
+			if err != nil {
+				t.Fatalf("parseTimeRange(%q) error = %v", phrase, err)
+			}
+			if want := time.Date(2024, 1, 15, 14, 10, 0, 0, time.UTC); !got.Start.Equal(want) {
+				t.Errorf("Start = %v, want %v", got.Start, want)
+			}
+			if want := time.Date(2024, 1, 15, 18, 0, 0, 0, time.UTC); !got.End.Equal(want) {
+				t.Errorf("End = %v, want %v", got.End, want)
+			}
+		})
+	}
+}
+
+func TestParseTimeRange_RestOfDayAfterWorkday(t *testing.T) {
+	now := time.Date(2024, 1, 15, 19, 0, 0, 0, time.UTC)
+
+	_, err := parseTimeRange("rest of day", "UTC", DefaultWorkingHours(), now)
+	if !errors.Is(err, ErrInvalidEventTime) {
+		t.Errorf("parseTimeRange() error = %v, want ErrInvalidEventTime", err)
+	}
+}
+
+func TestParseTimeRange_RestOfDayNearNow(t *testing.T) {
+	// End the workday at midnight so the test doesn't depend on the hour it runs
+	hours := WorkingHours{End: 24 * time.Hour}
+	now := time.Now().In(time.UTC)
+	if now.Hour() == 23 && now.Minute() >= 50 {
+		t.Skip("too close to midnight")
+	}
+
+	got, err := ParseTimeRange("until eod", "UTC", hours)
+	if err != nil {
+		t.Fatalf("ParseTimeRange() error = %v", err)
+	}
+	if diff := got.Start.Sub(now); diff < 0 || diff > rangeSnap+time.Second {
+		t.Errorf("Start = %v, want within %s after now (%v)", got.Start, rangeSnap, now)
+	}
+	wantEnd := time.Date(now.Year(), now.Month(), now.Day()+1, 0, 0, 0, 0, time.UTC)
+	if !got.End.Equal(wantEnd) {
+		t.Errorf("End = %v, want %v", got.End, wantEnd)
+	}
+}
+
+func TestParseTimeRange_ExplicitRange(t *testing.T) {
+	tests := []struct {
+		input     string
+		wantStart time.Time
+		wantEnd   time.Time
+	}{
+		{"2024-01-15 14:00 to 15:30", time.Date(2024, 1, 15, 14, 0, 0, 0, time.UTC), time.Date(2024, 1, 15, 15, 30, 0, 0, time.UTC)},
+		{"2024-01-15 14:00 - 3pm", time.Date(2024, 1, 15, 14, 0, 0, 0, time.UTC), time.Date(2024, 1, 15, 15, 0, 0, 0, time.UTC)},
+		{"2024-01-15 14:00 until 2024-01-16 09:00", time.Date(2024, 1, 15, 14, 0, 0, 0, time.UTC), time.Date(2024, 1, 16, 9, 0, 0, 0, time.UTC)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			got, err := parseTimeRange(tt.input, "UTC", DefaultWorkingHours(), time.Now())
+			if err != nil {
+				t.Fatalf("parseTimeRange(%q) error = %v", tt.input, err)
+			}
+			if !got.Start.Equal(tt.wantStart) || !got.End.Equal(tt.wantEnd) {
+				t.Errorf("parseTimeRange(%q) = %v - %v, want %v - %v", tt.input, got.Start, got.End, tt.wantStart, tt.wantEnd)
+			}
+		})
+	}
+}
+
+func TestParseTimeRange_Invalid(t *testing.T) {
+	tests := []struct {
+		input   string
+		wantErr error
+	}{
+		{"", ErrInvalidDateFormat},
+		{"2024-01-15 14:00", ErrInvalidDateFormat},
+		{"2024-01-15 14:00 to 13:00", ErrInvalidEventTime},
+		{"2024-01-15 14:00 to nonsense", ErrInvalidDateFormat},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			_, err := parseTimeRange(tt.input, "UTC", DefaultWorkingHours(), time.Now())
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("parseTimeRange(%q) error = %v, want %v", tt.input, err, tt.wantErr)
+			}
+		})
+	}
+}
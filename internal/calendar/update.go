@@ -0,0 +1,176 @@
+package calendar
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"google.golang.org/api/calendar/v3"
+)
+
+// EventUpdate lists the changes UpdateEvent applies to an event. Nil fields
+// are left unchanged; a non-nil empty Description or Location clears it.
+type EventUpdate struct {
+	Title       *string
+	Description *string
+	Location    *string
+
+	// StartTime moves the event. Unless EndTime or Duration is also set,
+	// the event keeps its current duration.
+	StartTime *time.Time
+
+	// EndTime sets a new end time. It can't be combined with Duration.
+	EndTime *time.Time
+
+	// Duration sets a new length, measured from the new or current start.
+	Duration time.Duration
+
+	// Status sets a new status, e.g. StatusTentative. Empty leaves it
+	// unchanged; see CancelEvent to also notify guests of a cancellation.
+	Status string
+
+	// Timezone is the IANA timezone for new start and end times. Empty
+	// keeps the event's current timezone.
+	Timezone string
+
+	// ETag is the version of the event the update is based on, e.g.
+	// EventResult.ETag from an earlier read. The update fails with
+	// ErrConflict if the event has changed since. When it is empty and the
+	// update needs the current event to work out new times, the fetched
+	// version is checked instead.
+	ETag string
+
+	// Force skips the version check, overwriting any concurrent change.
+	Force bool
+}
+
+// changesTime reports whether the update moves or resizes the event.
+func (u EventUpdate) changesTime() bool {
+	return u.StartTime != nil || u.EndTime != nil || u.Duration != 0
+}
+
+// UpdateEvent patches an existing event, sending only the fields set in
+// update. Changing the time of an all-day event turns it into a timed one.
+func (c *Client) UpdateEvent(ctx context.Context, eventID string, update EventUpdate) (*EventResult, error) {
+	if eventID == "" {
+		return nil, fmt.Errorf("%w: event ID is required", ErrInvalidEventTime)
+	}
+	if err := validateEventUpdate(update); err != nil {
+		return nil, err
+	}
+
+	ctx, cancel := c.withRequestTimeout(ctx)
+	defer cancel()
+
+	patch := &calendar.Event{}
+	if update.Title != nil {
+		patch.Summary = strings.TrimSpace(*update.Title)
+	}
+	if update.Description != nil {
+		patch.Description = *update.Description
+		if patch.Description == "" {
+			patch.ForceSendFields = append(patch.ForceSendFields, "Description")
+		}
+	}
+	if update.Location != nil {
+		patch.Location = *update.Location
+		if patch.Location == "" {
+			patch.ForceSendFields = append(patch.ForceSendFields, "Location")
+		}
+	}
+
+	patch.Status = update.Status
+
+	etag := update.ETag
+	if update.changesTime() {
+		existing, err := c.fetchEvent(ctx, eventID)
+		if err != nil {
+			return nil, err
+		}
+		if err := applyTimeUpdate(patch, existing, update); err != nil {
+			return nil, err
+		}
+		if etag == "" {
+			etag = existing.Etag
+		}
+	}
+
+	conditional := etag != "" && !update.Force
+	var updated *calendar.Event
+	err := c.retryPatch(ctx, conditional, func() error {
+		call := c.service.Events.Patch(c.calendarID, eventID, patch).Context(ctx)
+		if conditional {
+			call.Header().Set("If-Match", etag)
+		}
+		var err error
+		updated, err = call.Do()
+		return err
+	})
+	c.InvalidateCache()
+	if err != nil {
+		return nil, wrapEventError(err, eventID)
+	}
+	return parseEventResult(updated)
+}
+
+// validateEventUpdate checks an update before any request is made.
+func validateEventUpdate(update EventUpdate) error {
+	if update.Title == nil && update.Description == nil && update.Location == nil && update.Status == "" && !update.changesTime() {
+		return fmt.Errorf("%w: no changes given", ErrInvalidEventTime)
+	}
+	if err := validateStatus(update.Status); err != nil {
+		return err
+	}
+	if update.Title != nil && strings.TrimSpace(*update.Title) == "" {
+		return fmt.Errorf("%w: title cannot be empty", ErrInvalidEventTime)
+	}
+	if update.EndTime != nil && update.Duration != 0 {
+		return fmt.Errorf("%w: end time and duration can't both be set", ErrInvalidEventTime)
+	}
+	if update.Duration < 0 {
+		return fmt.Errorf("%w: duration must be positive", ErrInvalidEventTime)
+	}
+	return nil
+}
+
+// applyTimeUpdate sets the patch's start and end from the update, filling in
+// whatever the update leaves unset from the existing event.
+func applyTimeUpdate(patch, existing *calendar.Event, update EventUpdate) error {
+	current, err := parseEventResult(existing)
+	if err != nil {
+		return err
+	}
+
+	start := current.StartTime
+	if update.StartTime != nil {
+		start = *update.StartTime
+	}
+
+	var end time.Time
+	switch {
+	case update.EndTime != nil:
+		end = *update.EndTime
+	case update.Duration != 0:
+		end = start.Add(update.Duration)
+	default:
+		end = start.Add(current.EndTime.Sub(current.StartTime))
+	}
+
+	if !end.After(start) {
+		return fmt.Errorf("%w: end time must be after start time", ErrInvalidEventTime)
+	}
+
+	timezone := update.Timezone
+	if timezone == "" && existing.Start != nil {
+		timezone = existing.Start.TimeZone
+	}
+
+	patch.Start = &calendar.EventDateTime{DateTime: start.Format(time.RFC3339), TimeZone: timezone}
+	patch.End = &calendar.EventDateTime{DateTime: end.Format(time.RFC3339), TimeZone: timezone}
+	if isAllDay(existing) {
+		patch.Start.NullFields = []string{"Date"}
+		patch.End.NullFields = []string{"Date"}
+	}
+	return nil
+}
@@ -0,0 +1,199 @@
+package calendar
+
+import (
+	"context"
+	"errors"
+	"sort"
+	"testing"
+	"time"
+
+	"google.golang.org/api/calendar/v3"
+)
+
+func TestUpdateEvent_SendsOnlyChangedFields(t *testing.T) {
+	client, fake := newFakeClient(t)
+	fake.addEvent(&calendar.Event{
+		Id:          "evt",
+		Summary:     "Planning",
+		Description: "Agenda TBD",
+		Location:    "Room 1",
+		Start:       &calendar.EventDateTime{DateTime: "2024-01-15T10:00:00Z"},
+		End:         &calendar.EventDateTime{DateTime: "2024-01-15T11:00:00Z"},
+	})
+
+	title := "Q1 Planning"
+	empty := ""
+	result, err := client.UpdateEvent(context.Background(), "evt", EventUpdate{Title: &title, Location: &empty})
+	if err != nil {
+		t.Fatalf("UpdateEvent() error = %v", err)
+	}
+
+	if len(fake.patches) != 1 {
+		t.Fatalf("got %d PATCH requests, want 1", len(fake.patches))
+	}
+	var keys []string
+	for key := range fake.patches[0] {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	if len(keys) != 2 || keys[0] != "location" || keys[1] != "summary" {
+		t.Errorf("PATCH fields = %v, want [location summary]", keys)
+	}
+
+	if n := fake.requestCount("GET", "/calendars/primary/events/evt"); n != 0 {
+		t.Errorf("made %d GET requests for a text-only update, want 0", n)
+	}
+
+	if result.Title != "Q1 Planning" || result.Location != "" || result.Description != "Agenda TBD" {
+		t.Errorf("result = %+v", result)
+	}
+}
+
+func TestUpdateEvent_Times(t *testing.T) {
+	newStart := time.Date(2024, 1, 15, 14, 0, 0, 0, time.UTC)
+	newEnd := time.Date(2024, 1, 15, 16, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name      string
+		update    EventUpdate
+		wantStart time.Time
+		wantEnd   time.Time
+	}{
+		{
+			name:      "start keeps duration",
+			update:    EventUpdate{StartTime: &newStart},
+			wantStart: newStart,
+			wantEnd:   newStart.Add(time.Hour),
+		},
+		{
+			name:      "end keeps start",
+			update:    EventUpdate{EndTime: &newEnd},
+			wantStart: time.Date(2024, 1, 15, 10, 0, 0, 0, time.UTC),
+			wantEnd:   newEnd,
+		},
+		{
+			name:      "duration from current start",
+			update:    EventUpdate{Duration: 30 * time.Minute},
+			wantStart: time.Date(2024, 1, 15, 10, 0, 0, 0, time.UTC),
+			wantEnd:   time.Date(2024, 1, 15, 10, 30, 0, 0, time.UTC),
+		},
+		{
+			name:      "start and duration",
+			update:    EventUpdate{StartTime: &newStart, Duration: 2 * time.Hour},
+			wantStart: newStart,
+			wantEnd:   newEnd,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client, fake := newFakeClient(t)
+			fake.addEvent(&calendar.Event{
+				Id:      "evt",
+				Summary: "Planning",
+				Start:   &calendar.EventDateTime{DateTime: "2024-01-15T10:00:00Z", TimeZone: "UTC"},
+				End:     &calendar.EventDateTime{DateTime: "2024-01-15T11:00:00Z", TimeZone: "UTC"},
+			})
+
+			result, err := client.UpdateEvent(context.Background(), "evt", tt.update)
+			if err != nil {
+				t.Fatalf("UpdateEvent() error = %v", err)
+			}
+			if !result.StartTime.Equal(tt.wantStart) || !result.EndTime.Equal(tt.wantEnd) {
+				t.Errorf("times = %v - %v, want %v - %v", result.StartTime, result.EndTime, tt.wantStart, tt.wantEnd)
+			}
+			if result.Title != "Planning" {
+				t.Errorf("Title = %q, want unchanged", result.Title)
+			}
+		})
+	}
+}
+
+func TestUpdateEvent_Invalid(t *testing.T) {
+	start := time.Date(2024, 1, 15, 12, 0, 0, 0, time.UTC)
+	before := start.Add(-time.Hour)
+	blank := "  "
+
+	tests := []struct {
+		name   string
+		id     string
+		update EventUpdate
+	}{
+		{name: "empty ID", id: "", update: EventUpdate{Title: &blank}},
+		{name: "no changes", id: "evt", update: EventUpdate{}},
+		{name: "blank title", id: "evt", update: EventUpdate{Title: &blank}},
+		{name: "end and duration", id: "evt", update: EventUpdate{EndTime: &start, Duration: time.Hour}},
+		{name: "end before start", id: "evt", update: EventUpdate{StartTime: &start, EndTime: &before}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client, fake := newFakeClient(t)
+			fake.addEvent(&calendar.Event{
+				Id:    "evt",
+				Start: &calendar.EventDateTime{DateTime: "2024-01-15T10:00:00Z"},
+				End:   &calendar.EventDateTime{DateTime: "2024-01-15T11:00:00Z"},
+			})
+
+			_, err := client.UpdateEvent(context.Background(), tt.id, tt.update)
+			if !errors.Is(err, ErrInvalidEventTime) {
+				t.Errorf("UpdateEvent() error = %v, want ErrInvalidEventTime", err)
+			}
+			if len(fake.patches) != 0 {
+				t.Errorf("sent %d PATCH requests, want 0", len(fake.patches))
+			}
+		})
+	}
+}
+
+func TestUpdateEvent_NotFound(t *testing.T) {
+	client, _ := newFakeClient(t)
+	title := "New"
+
+	_, err := client.UpdateEvent(context.Background(), "missing", EventUpdate{Title: &title})
+	if !errors.Is(err, ErrEventNotFound) {
+		t.Errorf("UpdateEvent() error = %v, want ErrEventNotFound", err)
+	}
+}
+
+func TestUpdateEvent_Conflict(t *testing.T) {
+	client, fake := newFakeClient(t)
+	ctx := context.Background()
+	fake.addEvent(&calendar.Event{
+		Id:      "evt",
+		Summary: "Planning",
+		Start:   &calendar.EventDateTime{DateTime: "2024-01-15T10:00:00Z"},
+		End:     &calendar.EventDateTime{DateTime: "2024-01-15T11:00:00Z"},
+	})
+
+	read, err := client.GetEvent(ctx, "evt")
+	if err != nil {
+		t.Fatalf("GetEvent() error = %v", err)
+	}
+	if read.ETag == "" {
+		t.Fatal("GetEvent() returned no ETag")
+	}
+
+	// Someone else renames the event after it was read
+	theirs := "Their planning"
+	if _, err := client.UpdateEvent(ctx, "evt", EventUpdate{Title: &theirs}); err != nil {
+		t.Fatalf("concurrent UpdateEvent() error = %v", err)
+	}
+
+	mine := "My planning"
+	_, err = client.UpdateEvent(ctx, "evt", EventUpdate{Title: &mine, ETag: read.ETag})
+	if !errors.Is(err, ErrConflict) {
+		t.Fatalf("UpdateEvent() with stale ETag error = %v, want ErrConflict", err)
+	}
+	if got := fake.events["evt"].Summary; got != theirs {
+		t.Errorf("title = %q after conflict, want %q kept", got, theirs)
+	}
+
+	result, err := client.UpdateEvent(ctx, "evt", EventUpdate{Title: &mine, ETag: read.ETag, Force: true})
+	if err != nil {
+		t.Fatalf("forced UpdateEvent() error = %v", err)
+	}
+	if result.Title != mine || result.ETag == read.ETag {
+		t.Errorf("forced update = %+v, want the new title and a new ETag", result)
+	}
+}
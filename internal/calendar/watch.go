@@ -0,0 +1,337 @@
+package calendar
+
+import (
+	"context"
+	"crypto/subtle"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+
+	"google.golang.org/api/calendar/v3"
+)
+
+// Errors for push notification channels.
+var (
+	ErrWatchFailed         = errors.New("failed to watch calendar")
+	ErrInvalidNotification = errors.New("invalid push notification")
+)
+
+// Resource states reported by push notifications.
+const (
+	// ResourceStateSync is sent once when a channel is created, before any
+	// change.
+	ResourceStateSync = "sync"
+
+	// ResourceStateExists reports that events in the calendar changed.
+	ResourceStateExists = "exists"
+
+	// ResourceStateNotExists reports that the watched resource was deleted.
+	ResourceStateNotExists = "not_exists"
+)
+
+// Defaults for Watcher.
+const (
+	DefaultWatchTTL         = 7 * 24 * time.Hour
+	DefaultWatchRenewBefore = 10 * time.Minute
+)
+
+// WatchChannel is a push notification channel for a calendar's events.
+type WatchChannel struct {
+	// ID identifies the channel; it is generated by Watch.
+	ID string `json:"id"`
+
+	// ResourceID identifies the watched calendar to the API. Stopping the
+	// channel requires it.
+	ResourceID string `json:"resource_id"`
+
+	// Token is a secret sent back with every notification, so the receiver
+	// can tell genuine notifications from forged ones.
+	Token string `json:"-"`
+
+	CalendarID string    `json:"calendar_id"`
+	Address    string    `json:"address"`
+	Expiration time.Time `json:"expiration"`
+}
+
+// Watch asks Google to send a notification to address, an HTTPS URL, whenever
+// events in the calendar change. The channel expires after ttl, or when
+// Google's own limit is reached if that is sooner; zero leaves it to Google.
+func (c *Client) Watch(ctx context.Context, address string, ttl time.Duration) (*WatchChannel, error) {
+	if address == "" {
+		return nil, fmt.Errorf("%w: a notification address is required", ErrWatchFailed)
+	}
+	if ttl < 0 {
+		return nil, fmt.Errorf("%w: ttl must not be negative", ErrWatchFailed)
+	}
+
+	id, err := newRequestID()
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrWatchFailed, err)
+	}
+	token, err := newRequestID()
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrWatchFailed, err)
+	}
+
+	channel := &calendar.Channel{
+		Id:      id,
+		Type:    "web_hook",
+		Address: address,
+		Token:   token,
+	}
+	if ttl > 0 {
+		channel.Params = map[string]string{"ttl": strconv.FormatInt(int64(ttl/time.Second), 10)}
+	}
+
+	ctx, cancel := c.withRequestTimeout(ctx)
+	defer cancel()
+
+	// A repeated watch with the same channel ID is rejected rather than
+	// duplicated, so retrying is safe
+	var created *calendar.Channel
+	err = c.retry(ctx, true, func() error {
+		var err error
+		created, err = c.service.Events.Watch(c.calendarID, channel).Context(ctx).Do()
+		return err
+	})
+	if err != nil {
+		return nil, wrapAPIErrorAs(err, ErrWatchFailed)
+	}
+
+	return &WatchChannel{
+		ID:         created.Id,
+		ResourceID: created.ResourceId,
+		Token:      token,
+		CalendarID: c.calendarID,
+		Address:    address,
+		Expiration: time.UnixMilli(created.Expiration),
+	}, nil
+}
+
+// StopWatch stops notifications on a channel created by Watch.
+func (c *Client) StopWatch(ctx context.Context, channel *WatchChannel) error {
+	ctx, cancel := c.withRequestTimeout(ctx)
+	defer cancel()
+
+	err := c.retry(ctx, true, func() error {
+		return c.service.Channels.Stop(&calendar.Channel{
+			Id:         channel.ID,
+			ResourceId: channel.ResourceID,
+		}).Context(ctx).Do()
+	})
+	if err != nil {
+		return wrapAPIErrorAs(err, ErrWatchFailed)
+	}
+	return nil
+}
+
+// Notification is a push notification received on a watch channel.
+type Notification struct {
+	ChannelID     string
+	ResourceID    string
+	ResourceState string
+	Token         string
+
+	// MessageNumber increases with each notification on a channel; the
+	// first, sync message is number 1.
+	MessageNumber int64
+
+	// Expiration is when the channel expires, if Google reported it.
+	Expiration time.Time
+}
+
+// ParseNotification reads a push notification from the headers of Google's
+// request. Notifications have no body.
+func ParseNotification(header http.Header) (*Notification, error) {
+	n := &Notification{
+		ChannelID:     header.Get("X-Goog-Channel-ID"),
+		ResourceID:    header.Get("X-Goog-Resource-ID"),
+		ResourceState: header.Get("X-Goog-Resource-State"),
+		Token:         header.Get("X-Goog-Channel-Token"),
+	}
+	if n.ChannelID == "" || n.ResourceState == "" {
+		return nil, fmt.Errorf("%w: missing channel ID or resource state", ErrInvalidNotification)
+	}
+
+	if number := header.Get("X-Goog-Message-Number"); number != "" {
+		var err error
+		n.MessageNumber, err = strconv.ParseInt(number, 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("%w: message number %q", ErrInvalidNotification, number)
+		}
+	}
+	if expiration := header.Get("X-Goog-Channel-Expiration"); expiration != "" {
+		var err error
+		n.Expiration, err = time.Parse(time.RFC1123, expiration)
+		if err != nil {
+			return nil, fmt.Errorf("%w: expiration %q", ErrInvalidNotification, expiration)
+		}
+	}
+	return n, nil
+}
+
+// Watcher keeps a watch channel open on a calendar for as long as Run runs,
+// renewing it before it expires, and serves the notifications sent to it.
+// Mount it as the handler for the channel's address.
+type Watcher struct {
+	client  *Client
+	address string
+
+	// TTL is the lifetime requested for each channel.
+	TTL time.Duration
+
+	// RenewBefore is how long before a channel expires a replacement is
+	// created. Short-lived channels are renewed halfway through their life
+	// instead.
+	RenewBefore time.Duration
+
+	// OnChange is called from Run after notifications report changes.
+	// Notifications arriving while it runs are coalesced into one further
+	// call. A typical OnChange calls Client.Sync to fetch the changes.
+	OnChange func(ctx context.Context)
+
+	mu sync.Mutex
+	// current is the open channel; previous is the one it replaced, whose
+	// notifications are still accepted until it has been stopped.
+	current  *WatchChannel
+	previous *WatchChannel
+
+	changed chan struct{}
+}
+
+// NewWatcher returns a Watcher for the client's calendar that asks Google to
+// send notifications to address.
+func NewWatcher(client *Client, address string, onChange func(ctx context.Context)) *Watcher {
+	return &Watcher{
+		client:      client,
+		address:     address,
+		TTL:         DefaultWatchTTL,
+		RenewBefore: DefaultWatchRenewBefore,
+		OnChange:    onChange,
+		changed:     make(chan struct{}, 1),
+	}
+}
+
+// Channel returns the open channel, or nil before Run has opened one.
+func (w *Watcher) Channel() *WatchChannel {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	return w.current
+}
+
+// Run opens a channel and keeps it open, calling OnChange as notifications
+// arrive, until ctx is done or renewing the channel fails. The open channel
+// is stopped before Run returns. It returns ctx's error after a normal
+// shutdown.
+func (w *Watcher) Run(ctx context.Context) error {
+	channel, err := w.client.Watch(ctx, w.address, w.TTL)
+	if err != nil {
+		return err
+	}
+	w.mu.Lock()
+	w.current = channel
+	w.mu.Unlock()
+
+	defer func() {
+		// Stop the channel even though ctx is done
+		stopCtx, cancel := context.WithTimeout(context.WithoutCancel(ctx), time.Minute)
+		defer cancel()
+		_ = w.client.StopWatch(stopCtx, w.Channel())
+	}()
+
+	for {
+		renew := time.NewTimer(w.renewDelay(channel))
+
+		select {
+		case <-ctx.Done():
+			renew.Stop()
+			return ctx.Err()
+
+		case <-w.changed:
+			renew.Stop()
+			if w.OnChange != nil {
+				w.OnChange(ctx)
+			}
+
+		case <-renew.C:
+			if channel, err = w.renew(ctx); err != nil {
+				return err
+			}
+		}
+	}
+}
+
+// renewDelay returns how long to wait before renewing channel.
+func (w *Watcher) renewDelay(channel *WatchChannel) time.Duration {
+	remaining := channel.Expiration.Sub(w.client.now())
+	delay := remaining - w.RenewBefore
+	if delay < remaining/2 {
+		delay = remaining / 2
+	}
+	return delay
+}
+
+// renew replaces the open channel with a new one, then stops the old one.
+// Both are accepted by ServeHTTP in between, so no change is missed.
+func (w *Watcher) renew(ctx context.Context) (*WatchChannel, error) {
+	channel, err := w.client.Watch(ctx, w.address, w.TTL)
+	if err != nil {
+		return nil, err
+	}
+
+	w.mu.Lock()
+	old := w.current
+	w.previous, w.current = old, channel
+	w.mu.Unlock()
+
+	// A channel that can't be stopped still expires on its own
+	_ = w.client.StopWatch(ctx, old)
+
+	w.mu.Lock()
+	w.previous = nil
+	w.mu.Unlock()
+
+	return channel, nil
+}
+
+// ServeHTTP acknowledges a push notification. Notifications for channels
+// other than the watcher's, or with the wrong token, are rejected.
+func (w *Watcher) ServeHTTP(rw http.ResponseWriter, r *http.Request) {
+	n, err := ParseNotification(r.Header)
+	if err != nil {
+		http.Error(rw, err.Error(), http.StatusBadRequest)
+		return
+	}
+	if !w.accepts(n) {
+		http.Error(rw, "unknown channel", http.StatusForbidden)
+		return
+	}
+
+	if n.ResourceState != ResourceStateSync {
+		select {
+		case w.changed <- struct{}{}:
+		default:
+			// A change is already pending
+		}
+	}
+	rw.WriteHeader(http.StatusOK)
+}
+
+// accepts reports whether n comes from the open channel, or the one being
+// replaced.
+func (w *Watcher) accepts(n *Notification) bool {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	for _, channel := range []*WatchChannel{w.current, w.previous} {
+		if channel != nil && channel.ID == n.ChannelID &&
+			subtle.ConstantTimeCompare([]byte(channel.Token), []byte(n.Token)) == 1 {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,156 @@
+package calendar
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestWatch(t *testing.T) {
+	client, fake := newFakeClient(t)
+	ctx := context.Background()
+
+	channel, err := client.Watch(ctx, "https://example.com/notify", time.Hour)
+	if err != nil {
+		t.Fatalf("Watch() error = %v", err)
+	}
+	if channel.ID == "" || channel.Token == "" || channel.ResourceID == "" || channel.CalendarID != "primary" {
+		t.Errorf("Watch() = %+v", channel)
+	}
+	if until := time.Until(channel.Expiration); until < 59*time.Minute || until > time.Hour {
+		t.Errorf("channel expires in %v, want about an hour", until)
+	}
+
+	open := fake.openChannels()
+	if len(open) != 1 || open[0].Type != "web_hook" || open[0].Params["ttl"] != "3600" {
+		t.Fatalf("open channels = %+v, want one web hook with a ttl", open)
+	}
+
+	if err := client.StopWatch(ctx, channel); err != nil {
+		t.Fatalf("StopWatch() error = %v", err)
+	}
+	if open := fake.openChannels(); len(open) != 0 {
+		t.Errorf("%d channels still open after StopWatch", len(open))
+	}
+	if _, err := client.Watch(ctx, "", 0); !errors.Is(err, ErrWatchFailed) {
+		t.Errorf("Watch() without address error = %v, want ErrWatchFailed", err)
+	}
+}
+
+func TestParseNotification(t *testing.T) {
+	header := http.Header{}
+	header.Set("X-Goog-Channel-ID", "chan-1")
+	header.Set("X-Goog-Channel-Token", "secret")
+	header.Set("X-Goog-Resource-ID", "res-1")
+	header.Set("X-Goog-Resource-State", "exists")
+	header.Set("X-Goog-Message-Number", "4")
+	header.Set("X-Goog-Channel-Expiration", "Tue, 16 Jan 2024 09:00:00 GMT")
+
+	n, err := ParseNotification(header)
+	if err != nil {
+		t.Fatalf("ParseNotification() error = %v", err)
+	}
+	want := Notification{
+		ChannelID:     "chan-1",
+		ResourceID:    "res-1",
+		ResourceState: ResourceStateExists,
+		Token:         "secret",
+		MessageNumber: 4,
+	}
+	if !n.Expiration.Equal(time.Date(2024, 1, 16, 9, 0, 0, 0, time.UTC)) {
+		t.Errorf("Expiration = %v", n.Expiration)
+	}
+	n.Expiration = time.Time{}
+	if *n != want {
+		t.Errorf("ParseNotification() = %+v, want %+v", *n, want)
+	}
+
+	header.Set("X-Goog-Message-Number", "four")
+	if _, err := ParseNotification(header); !errors.Is(err, ErrInvalidNotification) {
+		t.Errorf("bad message number error = %v, want ErrInvalidNotification", err)
+	}
+	if _, err := ParseNotification(http.Header{}); !errors.Is(err, ErrInvalidNotification) {
+		t.Errorf("empty headers error = %v, want ErrInvalidNotification", err)
+	}
+}
+
+func TestWatcher(t *testing.T) {
+	client, fake := newFakeClient(t)
+	fake.channelTTL = 200 * time.Millisecond
+
+	changes := make(chan struct{}, 10)
+	watcher := NewWatcher(client, "https://example.com/notify", func(ctx context.Context) {
+		changes <- struct{}{}
+	})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan error, 1)
+	go func() { done <- watcher.Run(ctx) }()
+
+	first := waitForChannel(t, watcher, nil)
+
+	notify := func(channel *WatchChannel, token, state string) int {
+		req := httptest.NewRequest(http.MethodPost, "/notify", nil)
+		req.Header.Set("X-Goog-Channel-ID", channel.ID)
+		req.Header.Set("X-Goog-Channel-Token", token)
+		req.Header.Set("X-Goog-Resource-ID", channel.ResourceID)
+		req.Header.Set("X-Goog-Resource-State", state)
+		rec := httptest.NewRecorder()
+		watcher.ServeHTTP(rec, req)
+		return rec.Code
+	}
+
+	if code := notify(first, first.Token, ResourceStateSync); code != http.StatusOK {
+		t.Errorf("sync notification got %d, want 200", code)
+	}
+	if code := notify(first, "forged", ResourceStateExists); code != http.StatusForbidden {
+		t.Errorf("forged notification got %d, want 403", code)
+	}
+	if code := notify(first, first.Token, ResourceStateExists); code != http.StatusOK {
+		t.Errorf("change notification got %d, want 200", code)
+	}
+
+	select {
+	case <-changes:
+	case <-time.After(time.Second):
+		t.Fatal("OnChange not called after a change notification")
+	}
+	select {
+	case <-changes:
+		t.Error("OnChange called for the sync or forged notification")
+	default:
+	}
+
+	// The channel is renewed halfway through its short life
+	second := waitForChannel(t, watcher, first)
+	if code := notify(second, second.Token, ResourceStateExists); code != http.StatusOK {
+		t.Errorf("notification on renewed channel got %d, want 200", code)
+	}
+
+	cancel()
+	if err := <-done; !errors.Is(err, context.Canceled) {
+		t.Errorf("Run() error = %v, want context.Canceled", err)
+	}
+	if open := fake.openChannels(); len(open) != 0 {
+		t.Errorf("%d channels left open after Run returned", len(open))
+	}
+}
+
+// waitForChannel waits until the watcher has opened a channel other than
+// previous and returns it.
+func waitForChannel(t *testing.T, watcher *Watcher, previous *WatchChannel) *WatchChannel {
+	t.Helper()
+
+	deadline := time.Now().Add(2 * time.Second)
+	for time.Now().Before(deadline) {
+		if channel := watcher.Channel(); channel != nil && channel != previous {
+			return channel
+		}
+		time.Sleep(5 * time.Millisecond)
+	}
+	t.Fatal("watcher didn't open a channel in time")
+	return nil
+}
@@ -0,0 +1,33 @@
+package calendar
+
+import (
+	"encoding/base64"
+	"net/url"
+)
+
+// eventURLBase is the Google Calendar web address for a single event.
+const eventURLBase = "https://calendar.google.com/calendar/event"
+
+// WebLink returns a link to the event that names its calendar, so it opens
+// correctly when shared across accounts. Google identifies the event by an
+// "eid" of the event ID and calendar ID joined by a space, base64 encoded.
+// calendarID must be the calendar's address; for "primary" or an empty ID
+// the link Google returned is used instead. See Client.WebLink.
+func (r *EventResult) WebLink(calendarID string) string {
+	if calendarID == "" || calendarID == "primary" || r.ID == "" {
+		return r.Link
+	}
+
+	eid := base64.RawURLEncoding.EncodeToString([]byte(r.ID + " " + calendarID))
+	return eventURLBase + "?" + url.Values{"eid": {eid}}.Encode()
+}
+
+// WebLink returns r.WebLink for the client's calendar, resolving "primary"
+// to the user's address when it is known from SelfEmail.
+func (c *Client) WebLink(r *EventResult) string {
+	calendarID := c.calendarID
+	if calendarID == "primary" {
+		calendarID = c.selfEmail()
+	}
+	return r.WebLink(calendarID)
+}
@@ -0,0 +1,45 @@
+package calendar
+
+import (
+	"encoding/base64"
+	"net/url"
+	"testing"
+)
+
+func TestEventResult_WebLink(t *testing.T) {
+	event := &EventResult{ID: "abc123def", Link: "https://www.google.com/calendar/event?eid=original"}
+
+	link := event.WebLink("team@example.com")
+
+	parsed, err := url.Parse(link)
+	if err != nil {
+		t.Fatalf("WebLink() = %q is not a URL: %v", link, err)
+	}
+	eid, err := base64.RawURLEncoding.DecodeString(parsed.Query().Get("eid"))
+	if err != nil {
+		t.Fatalf("eid is not base64: %v", err)
+	}
+	if want := "abc123def team@example.com"; string(eid) != want {
+		t.Errorf("eid decodes to %q, want %q", eid, want)
+	}
+
+	for _, calendarID := range []string{"", "primary"} {
+		if got := event.WebLink(calendarID); got != event.Link {
+			t.Errorf("WebLink(%q) = %q, want the HtmlLink fallback", calendarID, got)
+		}
+	}
+}
+
+func TestClient_WebLink_ResolvesPrimary(t *testing.T) {
+	client, _ := newFakeClient(t)
+	event := &EventResult{ID: "abc123def", Link: "https://www.google.com/calendar/event?eid=original"}
+
+	if got := client.WebLink(event); got != event.Link {
+		t.Errorf("WebLink() without SelfEmail = %q, want the HtmlLink fallback", got)
+	}
+
+	client.SelfEmail = "me@example.com"
+	if got, want := client.WebLink(event), event.WebLink("me@example.com"); got != want {
+		t.Errorf("WebLink() = %q, want %q", got, want)
+	}
+}
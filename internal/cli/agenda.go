@@ -0,0 +1,227 @@
+package cli
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/ezer/calgo/internal/calendar"
+	"github.com/ezer/calgo/internal/config"
+)
+
+// agendaColors are the ANSI colors given to the calendars after the first,
+// which keeps the terminal's default color.
+var agendaColors = []string{"36", "35", "33", "32", "34", "31"}
+
+// agendaOptions holds the flags shared by the agenda commands.
+type agendaOptions struct {
+	with    []string
+	noColor bool
+}
+
+// agendaCalendar is a calendar shown in an agenda.
+type agendaCalendar struct {
+	id    string
+	label string
+
+	// color is an ANSI color code, or empty for the default color.
+	color string
+}
+
+// agendaSpan returns the first day and the number of days an agenda covers.
+type agendaSpan func(now time.Time, cfg *config.Config) (time.Time, int, error)
+
+func newTodayCommand(global *globalOptions) *cobra.Command {
+	return newAgendaCommand(global, "today", "Show today's agenda",
+		func(now time.Time, cfg *config.Config) (time.Time, int, error) {
+			return startOfDay(now), 1, nil
+		})
+}
+
+func newWeekCommand(global *globalOptions) *cobra.Command {
+	return newAgendaCommand(global, "week", "Show this week's agenda",
+		func(now time.Time, cfg *config.Config) (time.Time, int, error) {
+			first, err := cfg.WeekStartDay()
+			if err != nil {
+				return time.Time{}, 0, err
+			}
+			return calendar.StartOfWeek(now, first), 7, nil
+		})
+}
+
+func newAgendaCommand(global *globalOptions, use, short string, span agendaSpan) *cobra.Command {
+	opts := &agendaOptions{}
+
+	cmd := &cobra.Command{
+		Use:   use,
+		Short: short,
+		Long: short + `, grouped by day, in the display timezone.
+
+Events in progress are marked with ">". Use --with to add other calendars,
+by name or ID; their events are labelled with the calendar and, on a
+terminal, colored by calendar. Set NO_COLOR or use --no-color to turn colors
+off.`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cfg, err := global.loadConfig()
+			if err != nil {
+				return err
+			}
+
+			loc, err := cfg.DisplayLocation()
+			if err != nil {
+				return err
+			}
+			now := time.Now().In(loc)
+			start, days, err := span(now, cfg)
+			if err != nil {
+				return err
+			}
+
+			ctx, cancel := cfg.CommandContext(cmd.Context())
+			defer cancel()
+
+			client, err := newCalendarClient(ctx, cfg)
+			if err != nil {
+				return err
+			}
+
+			calendars := []agendaCalendar{{id: cfg.CalendarID, label: cfg.CalendarID}}
+			for i, nameOrID := range opts.with {
+				id, err := client.ResolveCalendarID(ctx, nameOrID)
+				if err != nil {
+					return err
+				}
+				calendars = append(calendars, agendaCalendar{
+					id:    id,
+					label: nameOrID,
+					color: agendaColors[i%len(agendaColors)],
+				})
+			}
+			if opts.noColor || !colorEnabled(cmd.OutOrStdout()) {
+				for i := range calendars {
+					calendars[i].color = ""
+				}
+			}
+
+			ids := make([]string, len(calendars))
+			for i, cal := range calendars {
+				ids[i] = cal.id
+			}
+			events, err := client.ListEventsIn(ctx, ids, calendar.ListOptions{
+				TimeMin: start,
+				TimeMax: start.AddDate(0, 0, days),
+			})
+			if err != nil {
+				return err
+			}
+
+			if global.json {
+				return writeEventsJSON(cmd.OutOrStdout(), events)
+			}
+			return writeAgenda(cmd.OutOrStdout(), events, start, days, now, calendars)
+		},
+	}
+
+	flags := cmd.Flags()
+	flags.StringSliceVar(&opts.with, "with", nil, "other calendars to include, by name or ID")
+	flags.BoolVar(&opts.noColor, "no-color", false, "don't color events by calendar")
+
+	return cmd
+}
+
+// colorEnabled reports whether w is a terminal that should get ANSI colors.
+func colorEnabled(w io.Writer) bool {
+	if os.Getenv("NO_COLOR") != "" {
+		return false
+	}
+	f, ok := w.(*os.File)
+	if !ok {
+		return false
+	}
+	info, err := f.Stat()
+	return err == nil && info.Mode()&os.ModeCharDevice != 0
+}
+
+// startOfDay returns midnight at the start of t's day in its location.
+func startOfDay(t time.Time) time.Time {
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, t.Location())
+}
+
+// writeAgenda writes the events of each of days days from start, which is
+// midnight in the display location. Events spanning several days are shown
+// on each. When there are several calendars, events are labelled with their
+// calendar.
+func writeAgenda(w io.Writer, events []*calendar.EventResult, start time.Time, days int, now time.Time, calendars []agendaCalendar) error {
+	loc := start.Location()
+	byID := make(map[string]agendaCalendar, len(calendars))
+	for _, cal := range calendars {
+		byID[cal.id] = cal
+	}
+
+	var lines []string
+	for i := 0; i < days; i++ {
+		dayStart := start.AddDate(0, 0, i)
+		dayEnd := dayStart.AddDate(0, 0, 1)
+
+		heading := dayStart.Format("Mon, Jan 2")
+		if dayStart.Equal(startOfDay(now)) {
+			heading += " (today)"
+		}
+		if i > 0 {
+			lines = append(lines, "")
+		}
+		lines = append(lines, heading)
+
+		count := 0
+		for _, event := range events {
+			if !eventOnDay(event, dayStart, dayEnd) {
+				continue
+			}
+			count++
+
+			marker := "  "
+			if !event.AllDay && !now.Before(event.StartTime) && now.Before(event.EndTime) {
+				marker = "> "
+			}
+
+			when := "all day    "
+			if !event.AllDay {
+				when = event.StartTime.In(loc).Format("15:04") + "-" + event.EndTime.In(loc).Format("15:04")
+			}
+			line := when + "  " + event.Title
+			if event.Location != "" {
+				line += " @ " + event.Location
+			}
+
+			cal := byID[event.CalendarID]
+			if len(calendars) > 1 {
+				line += "  [" + cal.label + "]"
+			}
+			if cal.color != "" {
+				line = "\x1b[" + cal.color + "m" + line + "\x1b[0m"
+			}
+			lines = append(lines, "  "+marker+line)
+		}
+		if count == 0 {
+			lines = append(lines, "    No events")
+		}
+	}
+
+	_, err := fmt.Fprintln(w, strings.Join(lines, "\n"))
+	return err
+}
+
+// eventOnDay reports whether event overlaps the day [dayStart, dayEnd).
+// All-day events are compared by date, since their times are midnight UTC.
+func eventOnDay(event *calendar.EventResult, dayStart, dayEnd time.Time) bool {
+	if event.AllDay {
+		date := time.Date(dayStart.Year(), dayStart.Month(), dayStart.Day(), 0, 0, 0, 0, time.UTC)
+		return !date.Before(event.StartTime) && date.Before(event.EndTime)
+	}
+	return event.StartTime.Before(dayEnd) && event.EndTime.After(dayStart)
+}
@@ -0,0 +1,90 @@
+package cli
+
+import (
+	"bytes"
+	"testing"
+	"time"
+
+	"github.com/ezer/calgo/internal/calendar"
+)
+
+func TestWriteAgenda(t *testing.T) {
+	loc := time.FixedZone("UTC+2", 2*60*60)
+	day := time.Date(2024, 1, 15, 0, 0, 0, 0, loc)
+	now := day.Add(9*time.Hour + 30*time.Minute)
+
+	events := []*calendar.EventResult{
+		{
+			Title:      "Offsite",
+			AllDay:     true,
+			StartTime:  time.Date(2024, 1, 14, 0, 0, 0, 0, time.UTC),
+			EndTime:    time.Date(2024, 1, 17, 0, 0, 0, 0, time.UTC),
+			CalendarID: "primary",
+		},
+		{
+			Title:      "Standup",
+			StartTime:  day.Add(9 * time.Hour),
+			EndTime:    day.Add(10 * time.Hour),
+			CalendarID: "primary",
+		},
+		{
+			Title:      "Review",
+			Location:   "Room 1",
+			StartTime:  time.Date(2024, 1, 15, 12, 0, 0, 0, time.UTC),
+			EndTime:    time.Date(2024, 1, 15, 13, 0, 0, 0, time.UTC),
+			CalendarID: "team@group.calendar.google.com",
+		},
+	}
+
+	t.Run("single calendar", func(t *testing.T) {
+		calendars := []agendaCalendar{{id: "primary", label: "primary"}}
+
+		var buf bytes.Buffer
+		if err := writeAgenda(&buf, events[:2], day, 2, now, calendars); err != nil {
+			t.Fatalf("writeAgenda() error = %v", err)
+		}
+		want := "Mon, Jan 15 (today)\n" +
+			"    all day      Offsite\n" +
+			"  > 09:00-10:00  Standup\n" +
+			"\n" +
+			"Tue, Jan 16\n" +
+			"    all day      Offsite\n"
+		if got := buf.String(); got != want {
+			t.Errorf("writeAgenda() =\n%s\nwant\n%s", got, want)
+		}
+	})
+
+	t.Run("several calendars with color", func(t *testing.T) {
+		calendars := []agendaCalendar{
+			{id: "primary", label: "primary"},
+			{id: "team@group.calendar.google.com", label: "Team", color: "36"},
+		}
+
+		var buf bytes.Buffer
+		if err := writeAgenda(&buf, events[1:], day, 1, now, calendars); err != nil {
+			t.Fatalf("writeAgenda() error = %v", err)
+		}
+		want := "Mon, Jan 15 (today)\n" +
+			"  > 09:00-10:00  Standup  [primary]\n" +
+			"    \x1b[36m14:00-15:00  Review @ Room 1  [Team]\x1b[0m\n"
+		if got := buf.String(); got != want {
+			t.Errorf("writeAgenda() =\n%q\nwant\n%q", got, want)
+		}
+	})
+
+	t.Run("empty day", func(t *testing.T) {
+		var buf bytes.Buffer
+		if err := writeAgenda(&buf, nil, day.AddDate(0, 0, 1), 1, now, nil); err != nil {
+			t.Fatalf("writeAgenda() error = %v", err)
+		}
+		if got, want := buf.String(), "Tue, Jan 16\n    No events\n"; got != want {
+			t.Errorf("writeAgenda() = %q, want %q", got, want)
+		}
+	})
+}
+
+func TestColorEnabled_NotATerminal(t *testing.T) {
+	if colorEnabled(&bytes.Buffer{}) {
+		t.Error("colorEnabled(buffer) = true, want false")
+	}
+}
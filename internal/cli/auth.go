@@ -0,0 +1,83 @@
+package cli
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+
+	"github.com/ezer/calgo/internal/auth"
+	"github.com/ezer/calgo/internal/config"
+)
+
+func newAuthCommand(global *globalOptions) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "auth",
+		Short: "Manage authorization with Google",
+	}
+
+	cmd.AddCommand(newAuthLoginCommand(global))
+
+	return cmd
+}
+
+func newAuthLoginCommand(global *globalOptions) *cobra.Command {
+	var device, noBrowser, manageCalendars bool
+
+	cmd := &cobra.Command{
+		Use:   "login",
+		Short: "Authorize calgo and save a new token",
+		Long: `Authorize calgo with your Google account and save the token, replacing
+any saved one. Other commands do this on first use; login is for choosing
+how, or switching accounts. With --profile the token is saved to that
+profile's token file, e.g. calgo auth login --profile work.
+
+By default a browser is opened and the authorization is received on a local
+callback server. On a machine without a browser, e.g. over SSH, use
+--no-browser: the authorization URL is printed to open in a browser
+anywhere. After you approve, the browser is sent to a localhost page that
+may fail to load; paste its address, or just the code in it, back into the
+terminal.
+
+--device runs the OAuth device flow, but Google doesn't support calendar
+access with it: it fails with an invalid_scope error, and --no-browser is
+the way to authorize without a browser.
+
+calgo asks to manage your events and read your calendars. Add
+--manage-calendars to also grant full access to your calendars, which
+calendars create, rename and delete need.`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cfg, err := global.loadConfig()
+			if err != nil {
+				return err
+			}
+			if cfg.AuthMode == config.AuthModeADC {
+				return fmt.Errorf("%w: auth_mode is adc; log in with gcloud auth application-default login instead", config.ErrInvalidAuthMode)
+			}
+
+			authenticator, err := newAuthenticator(cfg)
+			if err != nil {
+				return err
+			}
+			authenticator.ManageCalendars = manageCalendars
+			switch {
+			case device:
+				authenticator.Flow = auth.FlowDevice
+			case noBrowser:
+				authenticator.Flow = auth.FlowManual
+			}
+
+			// Not bounded by the command timeout: the user may take a while
+			// to approve in another browser or on another device
+			_, err = authenticator.Login(cmd.Context())
+			return err
+		},
+	}
+
+	cmd.Flags().BoolVar(&device, "device", false, "use the OAuth device flow (unsupported by Google for calendar access; use --no-browser)")
+	cmd.Flags().BoolVar(&noBrowser, "no-browser", false, "print the authorization URL and read back the code you paste")
+	cmd.Flags().BoolVar(&manageCalendars, "manage-calendars", false, "also grant full calendar access, needed by calendars create, rename and delete")
+	cmd.MarkFlagsMutuallyExclusive("device", "no-browser")
+
+	return cmd
+}
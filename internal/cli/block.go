@@ -0,0 +1,298 @@
+package cli
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/ezer/calgo/internal/calendar"
+	"github.com/ezer/calgo/internal/config"
+)
+
+// blockOptions holds the flags of the block command.
+type blockOptions struct {
+	title          string
+	start          string
+	duration       string
+	breakLength    string
+	count          int
+	avoidConflicts bool
+}
+
+// focusOptions holds the flags of the block focus command.
+type focusOptions struct {
+	title   string
+	start   string
+	decline string
+	message string
+}
+
+func newBlockCommand(global *globalOptions) *cobra.Command {
+	opts := &blockOptions{}
+
+	cmd := &cobra.Command{
+		Use:   "block",
+		Short: "Block time on the calendar",
+		Long: `Create a series of work blocks with breaks in between, e.g.
+"calgo block --start 9am --duration 50m --break 10m --count 4" for four
+pomodoro-style blocks from 9:00 to 12:50.
+
+With --avoid-conflicts a block that would overlap a busy event starts after
+it instead, and the remaining blocks follow on from there. Blocks that
+can't be created are reported, and the others are kept.
+
+Use "calgo block focus" to block a single stretch of focus time.`,
+		Annotations: supportsDryRun,
+		Args:        cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cfg, err := global.loadConfig()
+			if err != nil {
+				return err
+			}
+
+			plan, err := buildBlockPlan(opts, cfg.Timezone)
+			if err != nil {
+				return err
+			}
+
+			ctx, cancel := cfg.CommandContext(cmd.Context())
+			defer cancel()
+
+			client, err := newCalendarClient(ctx, cfg)
+			if err != nil {
+				return err
+			}
+
+			var blocks []calendar.TimeSlot
+			if opts.avoidConflicts {
+				blocks, err = client.PlanFreeBlocks(ctx, plan)
+			} else {
+				blocks, err = calendar.PlanBlocks(plan, nil)
+			}
+			if err != nil {
+				return err
+			}
+
+			params := blockParams(strings.TrimSpace(opts.title), blocks)
+			for _, p := range params {
+				if err := calendar.ValidateEventParams(p); err != nil {
+					return err
+				}
+			}
+
+			out := cmd.OutOrStdout()
+			if global.dryRun {
+				return writeEventPayloads(out, client, params)
+			}
+
+			outcomes, err := client.CreateEvents(ctx, params)
+			if err != nil {
+				return err
+			}
+
+			if global.json {
+				var created []*calendar.EventResult
+				for _, outcome := range outcomes {
+					if outcome.Err == nil {
+						created = append(created, outcome.Result)
+					}
+				}
+				if err := writeEventsJSON(out, created); err != nil {
+					return err
+				}
+			} else {
+				loc, err := cfg.DisplayLocation()
+				if err != nil {
+					return err
+				}
+				if err := writeBlocks(out, params, outcomes, loc); err != nil {
+					return err
+				}
+			}
+			return blocksError(outcomes)
+		},
+	}
+
+	flags := cmd.Flags()
+	flags.StringVar(&opts.title, "title", "Work block", "title of each block")
+	flags.StringVar(&opts.start, "start", "", "start of the first block (required)")
+	flags.StringVar(&opts.duration, "duration", "50m", "length of each block")
+	flags.StringVar(&opts.breakLength, "break", "10m", "break between blocks")
+	flags.IntVar(&opts.count, "count", 4, "number of blocks")
+	flags.BoolVar(&opts.avoidConflicts, "avoid-conflicts", false, "move blocks past busy events")
+	cmd.MarkFlagRequired("start")
+
+	cmd.AddCommand(newBlockFocusCommand(global))
+
+	return cmd
+}
+
+// buildBlockPlan turns the block flags into a BlockPlan.
+func buildBlockPlan(opts *blockOptions, timezone string) (calendar.BlockPlan, error) {
+	plan := calendar.BlockPlan{Count: opts.count}
+
+	var err error
+	if plan.Start, err = calendar.ParseTime(opts.start, timezone); err != nil {
+		return plan, fmt.Errorf("invalid start time: %w", err)
+	}
+	if plan.Duration, err = calendar.ParseDuration(opts.duration); err != nil {
+		return plan, fmt.Errorf("invalid duration: %w", err)
+	}
+	if plan.Break, err = calendar.ParseDuration(opts.breakLength); err != nil {
+		return plan, fmt.Errorf("invalid break: %w", err)
+	}
+	return plan, nil
+}
+
+// blockParams returns the events for blocks, numbered in their titles.
+func blockParams(title string, blocks []calendar.TimeSlot) []calendar.EventParams {
+	params := make([]calendar.EventParams, 0, len(blocks))
+	for i, block := range blocks {
+		params = append(params, calendar.EventParams{
+			Title:     fmt.Sprintf("%s (%d/%d)", title, i+1, len(blocks)),
+			StartTime: block.Start,
+			Duration:  block.Duration(),
+		})
+	}
+	return params
+}
+
+// writeBlocks writes a table with the outcome of each block.
+func writeBlocks(w io.Writer, params []calendar.EventParams, outcomes []calendar.EventCreateOutcome, loc *time.Location) error {
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(tw, "STATUS	EVENT	DETAILS")
+	for _, outcome := range outcomes {
+		if outcome.Err != nil {
+			event := &calendar.EventResult{
+				Title:     params[outcome.Index].Title,
+				StartTime: params[outcome.Index].StartTime,
+				EndTime:   params[outcome.Index].StartTime.Add(params[outcome.Index].Duration),
+			}
+			fmt.Fprintf(tw, "failed\t%s\t%s\n", formatEventLine(event, loc), outcome.Err)
+			continue
+		}
+		fmt.Fprintf(tw, "created\t%s\t%s\n", formatEventLine(outcome.Result, loc), outcome.Result.ID)
+	}
+	return tw.Flush()
+}
+
+// blocksError returns an error counting the blocks that weren't created,
+// or nil if all were.
+func blocksError(outcomes []calendar.EventCreateOutcome) error {
+	var errs []error
+	for _, outcome := range outcomes {
+		if outcome.Err != nil {
+			errs = append(errs, outcome.Err)
+		}
+	}
+	if len(errs) == 0 {
+		return nil
+	}
+	return fmt.Errorf("%d of %d blocks not created: %w", len(errs), len(outcomes), errors.Join(errs...))
+}
+
+func newBlockFocusCommand(global *globalOptions) *cobra.Command {
+	opts := &focusOptions{}
+
+	cmd := &cobra.Command{
+		Use:   "focus <duration>",
+		Short: "Block focus time",
+		Long: `Create a focus time event, e.g. "calgo block focus 2h", starting now or at
+--start. Google Calendar mutes chat notifications during focus time and,
+with --decline, declines meetings that conflict with it.
+
+Focus time needs a Google Workspace account.`,
+		Annotations: supportsDryRun,
+		Args:        cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cfg, err := global.loadConfig()
+			if err != nil {
+				return err
+			}
+
+			params, err := buildFocusParams(opts, args[0], cfg.Timezone, time.Now())
+			if err != nil {
+				return err
+			}
+			return createAndReport(cmd, global, cfg, params)
+		},
+	}
+
+	flags := cmd.Flags()
+	flags.StringVar(&opts.title, "title", "Focus time", "event title")
+	flags.StringVar(&opts.start, "start", "", "start time (default now)")
+	flags.StringVar(&opts.decline, "decline", "new", "conflicting invitations to decline: all, new or none")
+	flags.StringVar(&opts.message, "message", "", "message sent with declined invitations")
+
+	return cmd
+}
+
+// buildFocusParams turns the block focus flags and duration into
+// EventParams. Without --start the block starts at now, to the minute.
+func buildFocusParams(opts *focusOptions, duration, timezone string, now time.Time) (calendar.EventParams, error) {
+	params := calendar.EventParams{
+		Title:          strings.TrimSpace(opts.title),
+		EventType:      calendar.EventTypeFocusTime,
+		DeclineMessage: opts.message,
+	}
+
+	var err error
+	if params.Duration, err = calendar.ParseDuration(duration); err != nil {
+		return params, fmt.Errorf("invalid duration: %w", err)
+	}
+
+	if opts.start != "" {
+		if params.StartTime, err = calendar.ParseTime(opts.start, timezone); err != nil {
+			return params, fmt.Errorf("invalid start time: %w", err)
+		}
+	} else {
+		today, err := calendar.ParseDate("today", timezone)
+		if err != nil {
+			return params, err
+		}
+		params.StartTime = now.In(today.Location()).Truncate(time.Minute)
+	}
+
+	if params.AutoDecline, err = calendar.ParseAutoDecline(opts.decline); err != nil {
+		return params, err
+	}
+
+	return params, calendar.ValidateEventParams(params)
+}
+
+// createAndReport creates the event and reports it like the create command,
+// or with --dry-run prints the request that would create it.
+func createAndReport(cmd *cobra.Command, global *globalOptions, cfg *config.Config, params calendar.EventParams) error {
+	ctx, cancel := cfg.CommandContext(cmd.Context())
+	defer cancel()
+
+	client, err := newCalendarClient(ctx, cfg)
+	if err != nil {
+		return err
+	}
+
+	if global.dryRun {
+		return writeEventPayload(cmd.OutOrStdout(), client, params)
+	}
+
+	result, err := client.CreateEvent(ctx, params)
+	if err != nil {
+		return err
+	}
+	writeCreateWarnings(cmd.ErrOrStderr(), result)
+
+	if global.json {
+		return writeJSON(cmd.OutOrStdout(), result)
+	}
+
+	loc, err := cfg.DisplayLocation()
+	if err != nil {
+		return err
+	}
+	return writeCreatedEvent(cmd.OutOrStdout(), result, loc)
+}
@@ -0,0 +1,88 @@
+package cli
+
+import (
+	"bytes"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/ezer/calgo/internal/calendar"
+)
+
+func TestBuildFocusParams(t *testing.T) {
+	now := time.Date(2024, 1, 15, 9, 12, 30, 0, time.UTC)
+
+	params, err := buildFocusParams(&focusOptions{title: "Focus time", decline: "new"}, "2h", "UTC", now)
+	if err != nil {
+		t.Fatalf("buildFocusParams() error = %v", err)
+	}
+	if want := time.Date(2024, 1, 15, 9, 12, 0, 0, time.UTC); !params.StartTime.Equal(want) {
+		t.Errorf("StartTime = %v, want now to the minute", params.StartTime)
+	}
+	if params.Duration != 2*time.Hour || params.EventType != calendar.EventTypeFocusTime || params.AutoDecline != calendar.AutoDeclineNew {
+		t.Errorf("params = %+v", params)
+	}
+
+	params, err = buildFocusParams(&focusOptions{title: "Deep work", start: "2024-01-16 14:00", decline: "none"}, "90m", "UTC", now)
+	if err != nil {
+		t.Fatalf("buildFocusParams() with --start error = %v", err)
+	}
+	if want := time.Date(2024, 1, 16, 14, 0, 0, 0, time.UTC); !params.StartTime.Equal(want) || params.AutoDecline != calendar.AutoDeclineNone {
+		t.Errorf("params = %+v, want a 14:00 start without declining", params)
+	}
+
+	if _, err := buildFocusParams(&focusOptions{title: "Focus time", decline: "new"}, "ages", "UTC", now); err == nil {
+		t.Error("expected an error for a bad duration")
+	}
+	if _, err := buildFocusParams(&focusOptions{title: "Focus time", decline: "some"}, "1h", "UTC", now); !errors.Is(err, calendar.ErrInvalidEventType) {
+		t.Errorf("bad --decline error = %v, want ErrInvalidEventType", err)
+	}
+}
+
+func TestBuildBlockPlan(t *testing.T) {
+	plan, err := buildBlockPlan(&blockOptions{start: "2024-01-15 09:00", duration: "50m", breakLength: "0", count: 4}, "UTC")
+	if err != nil {
+		t.Fatalf("buildBlockPlan() error = %v", err)
+	}
+	want := calendar.BlockPlan{
+		Start:    time.Date(2024, 1, 15, 9, 0, 0, 0, time.UTC),
+		Duration: 50 * time.Minute,
+		Count:    4,
+	}
+	if plan != want {
+		t.Errorf("buildBlockPlan() = %+v, want %+v", plan, want)
+	}
+
+	if _, err := buildBlockPlan(&blockOptions{start: "2024-01-15 09:00", duration: "50m", breakLength: "a while", count: 4}, "UTC"); err == nil {
+		t.Error("expected an error for a bad break")
+	}
+}
+
+func TestWriteBlocks(t *testing.T) {
+	start := time.Date(2024, 1, 15, 9, 0, 0, 0, time.UTC)
+	blocks := []calendar.TimeSlot{
+		{Start: start, End: start.Add(50 * time.Minute)},
+		{Start: start.Add(time.Hour), End: start.Add(110 * time.Minute)},
+	}
+	params := blockParams("Work block", blocks)
+	outcomes := []calendar.EventCreateOutcome{
+		{Index: 0, EventID: "b1", Result: &calendar.EventResult{ID: "b1", Title: params[0].Title, StartTime: blocks[0].Start, EndTime: blocks[0].End}},
+		{Index: 1, Err: errors.New("quota exceeded")},
+	}
+
+	var buf bytes.Buffer
+	if err := writeBlocks(&buf, params, outcomes, time.UTC); err != nil {
+		t.Fatalf("writeBlocks() error = %v", err)
+	}
+	want := "STATUS   EVENT                                      DETAILS\n" +
+		"created  Mon Jan 15  09:00-09:50  Work block (1/2)  b1\n" +
+		"failed   Mon Jan 15  10:00-10:50  Work block (2/2)  quota exceeded\n"
+	if got := buf.String(); got != want {
+		t.Errorf("writeBlocks() =\n%q\nwant\n%q", got, want)
+	}
+
+	if err := blocksError(outcomes); err == nil || !strings.Contains(err.Error(), "1 of 2 blocks") {
+		t.Errorf("blocksError() = %v, want 1 of 2 blocks not created", err)
+	}
+}
@@ -0,0 +1,102 @@
+package cli
+
+import (
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/ezer/calgo/internal/calendar"
+)
+
+func newBusyCommand(global *globalOptions) *cobra.Command {
+	var calendars []string
+
+	cmd := &cobra.Command{
+		Use:   "busy [day]",
+		Short: "Show busy times for a day",
+		Long: `Show the busy blocks of a day, to check availability before scheduling.
+
+The day defaults to today and can be "tomorrow", a weekday such as "friday",
+an offset such as "+2d", or a date. Use --with to also check other calendars
+whose free/busy information is shared with you, such as a colleague's.`,
+		Args: cobra.MaximumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cfg, err := global.loadConfig()
+			if err != nil {
+				return err
+			}
+
+			dayInput := "today"
+			if len(args) == 1 {
+				dayInput = args[0]
+			}
+			day, err := calendar.ParseDate(dayInput, cfg.Timezone)
+			if err != nil {
+				return err
+			}
+
+			ctx, cancel := cfg.CommandContext(cmd.Context())
+			defer cancel()
+
+			client, err := newCalendarClient(ctx, cfg)
+			if err != nil {
+				return err
+			}
+
+			ids := append([]string{cfg.CalendarID}, calendars...)
+			busy, err := client.FreeBusy(ctx, day, day.AddDate(0, 0, 1), ids)
+			if err != nil {
+				return err
+			}
+
+			if global.json {
+				return writeJSON(cmd.OutOrStdout(), busy)
+			}
+
+			loc, err := cfg.DisplayLocation()
+			if err != nil {
+				return err
+			}
+			return writeBusy(cmd.OutOrStdout(), day, ids, busy, loc)
+		},
+	}
+
+	cmd.Flags().StringSliceVar(&calendars, "with", nil, "other calendar IDs or emails to check, comma-separated")
+
+	return cmd
+}
+
+// writeBusy writes the busy blocks of each calendar in ids order, with a
+// heading per calendar when there is more than one.
+func writeBusy(w io.Writer, day time.Time, ids []string, busy map[string][]calendar.TimeSlot, loc *time.Location) error {
+	var b strings.Builder
+	fmt.Fprintf(&b, "Busy on %s:\n", day.Format("Mon, Jan 2"))
+
+	for _, id := range ids {
+		indent := "  "
+		if len(ids) > 1 {
+			fmt.Fprintf(&b, "  %s\n", id)
+			indent = "    "
+		}
+
+		slots := busy[id]
+		if len(slots) == 0 {
+			fmt.Fprintf(&b, "%sfree all day\n", indent)
+			continue
+		}
+		for _, slot := range slots {
+			fmt.Fprintf(&b, "%s%s\n", indent, formatSlot(slot, loc))
+		}
+	}
+
+	_, err := io.WriteString(w, b.String())
+	return err
+}
+
+// formatSlot formats a slot as "15:04-16:00" in loc.
+func formatSlot(slot calendar.TimeSlot, loc *time.Location) string {
+	return slot.Start.In(loc).Format("15:04") + "-" + slot.End.In(loc).Format("15:04")
+}
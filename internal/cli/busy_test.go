@@ -0,0 +1,49 @@
+package cli
+
+import (
+	"bytes"
+	"testing"
+	"time"
+
+	"github.com/ezer/calgo/internal/calendar"
+)
+
+func TestWriteBusy(t *testing.T) {
+	day := time.Date(2024, 1, 15, 0, 0, 0, 0, time.UTC)
+	busy := map[string][]calendar.TimeSlot{
+		"primary": {
+			{Start: day.Add(9 * time.Hour), End: day.Add(10 * time.Hour)},
+			{Start: day.Add(14 * time.Hour), End: day.Add(15*time.Hour + 30*time.Minute)},
+		},
+		"alice@example.com": nil,
+	}
+
+	tests := []struct {
+		name string
+		ids  []string
+		want string
+	}{
+		{
+			name: "single calendar",
+			ids:  []string{"primary"},
+			want: "Busy on Mon, Jan 15:\n  09:00-10:00\n  14:00-15:30\n",
+		},
+		{
+			name: "several calendars",
+			ids:  []string{"primary", "alice@example.com"},
+			want: "Busy on Mon, Jan 15:\n  primary\n    09:00-10:00\n    14:00-15:30\n  alice@example.com\n    free all day\n",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var buf bytes.Buffer
+			if err := writeBusy(&buf, day, tt.ids, busy, time.UTC); err != nil {
+				t.Fatalf("writeBusy() error = %v", err)
+			}
+			if got := buf.String(); got != tt.want {
+				t.Errorf("writeBusy() =\n%s\nwant\n%s", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,244 @@
+package cli
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"text/tabwriter"
+
+	"github.com/spf13/cobra"
+
+	"github.com/ezer/calgo/internal/calendar"
+)
+
+func newCalendarsCommand(global *globalOptions) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "calendars",
+		Short: "List your calendars",
+		Long: `List the calendars in your calendar list with their IDs.
+
+The calendar in use is marked with "*". Any name or ID shown can be given to
+--calendar or set as calendar_id in the config file. Use the create, rename
+and delete subcommands to manage your own secondary calendars; they need
+broader access, granted once with calgo auth login --manage-calendars.`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cfg, err := global.loadConfig()
+			if err != nil {
+				return err
+			}
+
+			ctx, cancel := cfg.CommandContext(cmd.Context())
+			defer cancel()
+
+			client, err := newCalendarClient(ctx, cfg)
+			if err != nil {
+				return err
+			}
+
+			calendars, err := client.ListCalendars(ctx)
+			if err != nil {
+				return err
+			}
+
+			if global.json {
+				if calendars == nil {
+					calendars = []calendar.CalendarInfo{}
+				}
+				return writeJSON(cmd.OutOrStdout(), calendars)
+			}
+			return writeCalendars(cmd.OutOrStdout(), calendars, cfg.CalendarID)
+		},
+	}
+
+	cmd.AddCommand(
+		newCalendarsCreateCommand(global),
+		newCalendarsRenameCommand(global),
+		newCalendarsDeleteCommand(global),
+	)
+
+	return cmd
+}
+
+func newCalendarsCreateCommand(global *globalOptions) *cobra.Command {
+	var timezone string
+
+	cmd := &cobra.Command{
+		Use:   "create <name>",
+		Short: "Create a secondary calendar",
+		Long: `Create a secondary calendar, e.g. a dedicated "Automation" calendar for
+scripted events. Its timezone defaults to the configured one.`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cfg, err := global.loadConfig()
+			if err != nil {
+				return err
+			}
+			if !cmd.Flags().Changed("timezone") {
+				timezone = cfg.Timezone
+			}
+
+			ctx, cancel := cfg.CommandContext(cmd.Context())
+			defer cancel()
+
+			client, err := newCalendarManagerClient(ctx, cfg)
+			if err != nil {
+				return err
+			}
+
+			created, err := client.CreateCalendar(ctx, args[0], timezone)
+			if err != nil {
+				return err
+			}
+
+			if global.json {
+				return writeJSON(cmd.OutOrStdout(), created)
+			}
+			_, err = fmt.Fprintf(cmd.OutOrStdout(), "Calendar created: %s\n  ID: %s\n", created.Name, created.ID)
+			return err
+		},
+	}
+
+	cmd.Flags().StringVar(&timezone, "timezone", "", "IANA timezone of the calendar (default from config)")
+
+	return cmd
+}
+
+func newCalendarsRenameCommand(global *globalOptions) *cobra.Command {
+	return &cobra.Command{
+		Use:   "rename <name-or-id> <new-name>",
+		Short: "Rename a calendar you own",
+		Args:  cobra.ExactArgs(2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cfg, err := global.loadConfig()
+			if err != nil {
+				return err
+			}
+
+			ctx, cancel := cfg.CommandContext(cmd.Context())
+			defer cancel()
+
+			client, err := newCalendarManagerClient(ctx, cfg)
+			if err != nil {
+				return err
+			}
+
+			calendarID, err := client.ResolveCalendarID(ctx, args[0])
+			if err != nil {
+				return err
+			}
+			renamed, err := client.RenameCalendar(ctx, calendarID, args[1])
+			if err != nil {
+				return err
+			}
+
+			if global.json {
+				return writeJSON(cmd.OutOrStdout(), renamed)
+			}
+			_, err = fmt.Fprintf(cmd.OutOrStdout(), "Renamed calendar %s to %s\n", renamed.ID, renamed.Name)
+			return err
+		},
+	}
+}
+
+func newCalendarsDeleteCommand(global *globalOptions) *cobra.Command {
+	var yes bool
+
+	cmd := &cobra.Command{
+		Use:   "delete <name-or-id>",
+		Short: "Delete a secondary calendar and its events",
+		Long: `Permanently delete a secondary calendar you own, with all its events.
+
+You're asked to confirm first; use --yes to skip the prompt. The primary
+calendar can't be deleted.`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cfg, err := global.loadConfig()
+			if err != nil {
+				return err
+			}
+
+			ctx, cancel := cfg.CommandContext(cmd.Context())
+			defer cancel()
+
+			client, err := newCalendarManagerClient(ctx, cfg)
+			if err != nil {
+				return err
+			}
+
+			cal, err := lookupCalendar(ctx, client, args[0])
+			if err != nil {
+				return err
+			}
+			if cal.Primary {
+				return fmt.Errorf("%s is your primary calendar, which can't be deleted", cal.Name)
+			}
+
+			if !yes {
+				question := fmt.Sprintf("Delete calendar %q (%s) and all its events?", cal.Name, cal.ID)
+				ok, err := confirm(cmd.InOrStdin(), cmd.ErrOrStderr(), question)
+				if err != nil {
+					return err
+				}
+				if !ok {
+					fmt.Fprintln(cmd.ErrOrStderr(), "Aborted.")
+					return nil
+				}
+			}
+
+			if err := client.DeleteCalendar(ctx, cal.ID); err != nil {
+				return err
+			}
+
+			out := cmd.OutOrStdout()
+			if global.json {
+				return writeJSON(out, map[string]any{"id": cal.ID, "deleted": true})
+			}
+			_, err = fmt.Fprintf(out, "Deleted calendar %s (%s)\n", cal.Name, cal.ID)
+			return err
+		},
+	}
+
+	cmd.Flags().BoolVarP(&yes, "yes", "y", false, "delete without asking for confirmation")
+
+	return cmd
+}
+
+// lookupCalendar finds a calendar in the user's calendar list by name or ID.
+func lookupCalendar(ctx context.Context, client *calendar.Client, nameOrID string) (calendar.CalendarInfo, error) {
+	calendars, err := client.ListCalendars(ctx)
+	if err != nil {
+		return calendar.CalendarInfo{}, err
+	}
+
+	calendarID, err := calendar.ResolveCalendar(calendars, nameOrID)
+	if err != nil {
+		return calendar.CalendarInfo{}, err
+	}
+	for _, cal := range calendars {
+		if cal.ID == calendarID {
+			return cal, nil
+		}
+	}
+	return calendar.CalendarInfo{}, fmt.Errorf("%w: %s", calendar.ErrCalendarNotFound, nameOrID)
+}
+
+// writeCalendars writes a table of calendars, marking the one with ID
+// current, or the primary calendar when current is "primary".
+func writeCalendars(w io.Writer, calendars []calendar.CalendarInfo, current string) error {
+	if len(calendars) == 0 {
+		_, err := fmt.Fprintln(w, "No calendars found.")
+		return err
+	}
+
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(tw, "\tNAME\tACCESS\tID")
+	for _, cal := range calendars {
+		mark := ""
+		if cal.ID == current || (current == "primary" && cal.Primary) {
+			mark = "*"
+		}
+		fmt.Fprintf(tw, "%s\t%s\t%s\t%s\n", mark, cal.Name, cal.AccessRole, cal.ID)
+	}
+	return tw.Flush()
+}
@@ -0,0 +1,48 @@
+package cli
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/ezer/calgo/internal/calendar"
+)
+
+func TestWriteCalendars(t *testing.T) {
+	calendars := []calendar.CalendarInfo{
+		{ID: "me@example.com", Name: "Personal", AccessRole: "owner", Primary: true},
+		{ID: "team@group.calendar.google.com", Name: "Team", AccessRole: "writer"},
+	}
+
+	tests := []struct {
+		name    string
+		current string
+		want    string
+	}{
+		{
+			name:    "primary",
+			current: "primary",
+			want: "   NAME      ACCESS  ID\n" +
+				"*  Personal  owner   me@example.com\n" +
+				"   Team      writer  team@group.calendar.google.com\n",
+		},
+		{
+			name:    "by ID",
+			current: "team@group.calendar.google.com",
+			want: "   NAME      ACCESS  ID\n" +
+				"   Personal  owner   me@example.com\n" +
+				"*  Team      writer  team@group.calendar.google.com\n",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var buf bytes.Buffer
+			if err := writeCalendars(&buf, calendars, tt.current); err != nil {
+				t.Fatalf("writeCalendars() error = %v", err)
+			}
+			if got := buf.String(); got != tt.want {
+				t.Errorf("writeCalendars() =\n%q\nwant\n%q", got, tt.want)
+			}
+		})
+	}
+}
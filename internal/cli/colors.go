@@ -0,0 +1,63 @@
+package cli
+
+import (
+	"fmt"
+	"io"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/spf13/cobra"
+
+	"github.com/ezer/calgo/internal/calendar"
+)
+
+func newColorsCommand(global *globalOptions) *cobra.Command {
+	return &cobra.Command{
+		Use:   "colors",
+		Short: "List the event colors",
+		Long: `List the colors events can be given with create --color.
+
+Any name or ID shown can be used, e.g. --color tomato, --color red or
+--color 11. Giving scripted events their own color, such as blue for focus
+time, makes them easy to tell apart in Google Calendar.`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cfg, err := global.loadConfig()
+			if err != nil {
+				return err
+			}
+
+			ctx, cancel := cfg.CommandContext(cmd.Context())
+			defer cancel()
+
+			client, err := newCalendarClient(ctx, cfg)
+			if err != nil {
+				return err
+			}
+
+			colors, err := client.ListColors(ctx)
+			if err != nil {
+				return err
+			}
+
+			if global.json {
+				return writeJSON(cmd.OutOrStdout(), colors)
+			}
+			return writeColors(cmd.OutOrStdout(), colors)
+		},
+	}
+}
+
+// writeColors writes a table of event colors and their names.
+func writeColors(w io.Writer, colors []calendar.EventColor) error {
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(tw, "ID\tNAMES\tCOLOR")
+	for _, color := range colors {
+		names := strings.Join(color.Names, ", ")
+		if names == "" {
+			names = "-"
+		}
+		fmt.Fprintf(tw, "%s\t%s\t%s\n", color.ID, names, color.Background)
+	}
+	return tw.Flush()
+}
@@ -0,0 +1,27 @@
+package cli
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/ezer/calgo/internal/calendar"
+)
+
+func TestWriteColors(t *testing.T) {
+	colors := []calendar.EventColor{
+		{ID: "9", Names: []string{"blue", "blueberry"}, Background: "#5484ed"},
+		{ID: "12", Background: "#ffffff"},
+	}
+
+	var buf bytes.Buffer
+	if err := writeColors(&buf, colors); err != nil {
+		t.Fatalf("writeColors() error = %v", err)
+	}
+
+	want := "ID  NAMES            COLOR\n" +
+		"9   blue, blueberry  #5484ed\n" +
+		"12  -                #ffffff\n"
+	if got := buf.String(); got != want {
+		t.Errorf("writeColors() =\n%q\nwant\n%q", got, want)
+	}
+}
@@ -0,0 +1,314 @@
+package cli
+
+import (
+	"fmt"
+	"io"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/ezer/calgo/internal/calendar"
+	"github.com/ezer/calgo/internal/config"
+)
+
+// createOptions holds the flags of the create command.
+type createOptions struct {
+	title       string
+	start       string
+	duration    string
+	description string
+	location    string
+	attendees   []string
+	notify      string
+	tags        []string
+	status      string
+	color       string
+	template    string
+	quiet       bool
+
+	// end is when the event ends, or with allDay the last day of a
+	// date-only event.
+	allDay bool
+	end    string
+
+	checkConflicts bool
+}
+
+func newCreateCommand(global *globalOptions) *cobra.Command {
+	opts := &createOptions{}
+
+	cmd := &cobra.Command{
+		Use:   "create [title]",
+		Short: "Create a new calendar event",
+		Long: `Create a new calendar event.
+
+The title can be given as an argument or with --title. Guests added with
+--attendee only get an email invitation when --notify is "all", or
+"external" for guests outside your organization.
+
+With --check-conflicts the event isn't created if it overlaps existing busy
+events; set warn_on_conflict in the config file to be warned without
+aborting.
+
+With --template the event starts from a template defined in the config
+file, e.g. --template standup; other flags override the template, and
+--attendee adds to its guests.
+
+Give events a color with --color, e.g. --color red; run "calgo colors" for
+the names available.
+
+Tag events created by scripts with --tag, e.g. --tag calgo:source=backup,
+to find them later with list --tag. Tags aren't shown to guests.
+
+Give either --duration or --end, e.g. --start 14:00 --end 16:30; a time of
+day alone for --end is on the start date. With --all-day only the dates of
+--start and --end are used, and --end is the last day of the event: --start
+2024-07-01 --end 2024-07-05 blocks five days.
+
+With --dry-run the request that would create the event is printed as JSON
+instead, showing the resolved times and timezone.`,
+		Annotations: supportsDryRun,
+		Args:        cobra.MaximumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if len(args) == 1 {
+				if opts.title != "" {
+					return fmt.Errorf("give the title either as an argument or with --title, not both")
+				}
+				opts.title = args[0]
+			}
+
+			cfg, err := global.loadConfig()
+			if err != nil {
+				return err
+			}
+
+			params, err := buildEventParams(opts, cfg)
+			if err != nil {
+				return err
+			}
+
+			ctx, cancel := cfg.CommandContext(cmd.Context())
+			defer cancel()
+
+			client, err := newCalendarClient(ctx, cfg)
+			if err != nil {
+				return err
+			}
+
+			loc, err := cfg.DisplayLocation()
+			if err != nil {
+				return err
+			}
+
+			if global.dryRun {
+				return writeEventPayload(cmd.OutOrStdout(), client, params)
+			}
+
+			if !params.RejectConflicts && cfg.WarnOnConflict {
+				conflicts, err := client.Conflicts(ctx, params)
+				if err != nil {
+					return err
+				}
+				if err := writeConflictWarning(cmd.ErrOrStderr(), conflicts, loc); err != nil {
+					return err
+				}
+			}
+
+			result, err := client.CreateEvent(ctx, params)
+			if err != nil {
+				return err
+			}
+			writeCreateWarnings(cmd.ErrOrStderr(), result)
+
+			out := cmd.OutOrStdout()
+			switch {
+			case opts.quiet:
+				_, err = fmt.Fprintln(out, result.ID)
+				return err
+			case global.json:
+				return writeJSON(out, result)
+			}
+			return writeCreatedEvent(out, result, loc)
+		},
+	}
+
+	flags := cmd.Flags()
+	flags.StringVar(&opts.title, "title", "", "event title")
+	flags.StringVar(&opts.template, "template", "", "start from this template in the config file")
+	flags.StringVar(&opts.start, "start", "", "start time, or start date with --all-day (required)")
+	flags.BoolVar(&opts.allDay, "all-day", false, "create a date-only event")
+	flags.StringVar(&opts.end, "end", "", "end time, or last day of an --all-day event (default the start date)")
+	flags.StringVar(&opts.duration, "duration", "", "duration, e.g. 45m or 1h30m (default from config)")
+	flags.StringVar(&opts.description, "description", "", "event description")
+	flags.StringVar(&opts.location, "location", "", "event location")
+	flags.StringArrayVar(&opts.attendees, "attendee", nil, "guest email address; repeat or comma-separate for several")
+	flags.StringVar(&opts.notify, "notify", "", "who gets invitation emails: all, external or none")
+	flags.StringArrayVar(&opts.tags, "tag", nil, "machine-readable key=value tag; repeat for several")
+	flags.StringVar(&opts.color, "color", "", "event color name or ID, see the colors command")
+	flags.StringVar(&opts.status, "status", "", "event status: confirmed, tentative or cancelled")
+	flags.BoolVar(&opts.checkConflicts, "check-conflicts", false, "abort if the event overlaps existing busy events")
+	flags.BoolVarP(&opts.quiet, "quiet", "q", false, "only print the event ID")
+	cmd.MarkFlagRequired("start")
+	cmd.MarkFlagsMutuallyExclusive("all-day", "duration")
+	cmd.MarkFlagsMutuallyExclusive("end", "duration")
+
+	return cmd
+}
+
+// buildEventParams turns the create flags, or an imported row, into
+// EventParams, filling in defaults from cfg.
+func buildEventParams(opts *createOptions, cfg *config.Config) (calendar.EventParams, error) {
+	params := calendar.EventParams{}
+	if opts.template != "" {
+		var err error
+		if params, err = cfg.ResolveTemplate(opts.template); err != nil {
+			return params, err
+		}
+	}
+
+	if title := strings.TrimSpace(opts.title); title != "" {
+		params.Title = title
+	}
+	if opts.description != "" {
+		params.Description = opts.description
+	}
+	if opts.location != "" {
+		params.Location = opts.location
+	}
+	params.AllDay = opts.allDay
+	params.RejectConflicts = opts.checkConflicts
+
+	if params.Title == "" {
+		return params, fmt.Errorf("a title is required")
+	}
+
+	start, err := calendar.ParseTime(opts.start, cfg.Timezone)
+	if err != nil {
+		return params, fmt.Errorf("invalid start time: %w", err)
+	}
+	params.StartTime = start
+
+	switch {
+	case opts.end != "" && opts.allDay:
+		end, err := calendar.ParseTime(opts.end, cfg.Timezone)
+		if err != nil {
+			return params, fmt.Errorf("invalid end date: %w", err)
+		}
+		params.EndDate = end
+	case opts.end != "":
+		end, err := calendar.ParseEndTime(opts.end, start, cfg.Timezone)
+		if err != nil {
+			return params, fmt.Errorf("invalid end time: %w", err)
+		}
+		if !end.After(start) {
+			return params, fmt.Errorf("%w: end time %s is not after the start", calendar.ErrInvalidEventTime, opts.end)
+		}
+		// The end replaces any duration from the template
+		params.EndTime = end
+		params.Duration = 0
+	case !opts.allDay && params.Duration == 0:
+		params.Duration = time.Duration(cfg.DefaultDuration) * time.Minute
+	}
+	if opts.duration != "" {
+		duration, err := calendar.ParseDuration(opts.duration)
+		if err != nil {
+			return params, fmt.Errorf("invalid duration: %w", err)
+		}
+		params.Duration = duration
+	}
+
+	attendees, err := calendar.ParseAttendees(strings.Join(slices.Concat(params.Attendees, opts.attendees), ","))
+	if err != nil {
+		return params, err
+	}
+	params.Attendees = attendees
+
+	if opts.notify != "" {
+		sendUpdates, err := calendar.ParseSendUpdates(opts.notify)
+		if err != nil {
+			return params, err
+		}
+		params.SendUpdates = sendUpdates
+	}
+
+	if params.Tags, err = calendar.ParseTags(opts.tags); err != nil {
+		return params, err
+	}
+
+	if opts.color != "" {
+		if _, err := calendar.ResolveColorID(opts.color); err != nil {
+			return params, err
+		}
+		params.Color = opts.color
+	}
+
+	if opts.status != "" {
+		if params.Status, err = calendar.ParseStatus(opts.status); err != nil {
+			return params, err
+		}
+	}
+
+	return params, nil
+}
+
+// writeCreatedEvent reports a created event in human-readable form.
+func writeCreatedEvent(w io.Writer, event *calendar.EventResult, loc *time.Location) error {
+	lines := []string{
+		"Event created: " + event.Title,
+		"  When: " + formatEventWhen(event, loc),
+		"  ID:   " + event.ID,
+	}
+	if len(event.Attendees) > 0 {
+		lines = append(lines, "  Guests: "+strings.Join(event.Attendees, ", "))
+	}
+	if event.MeetLink != "" {
+		lines = append(lines, "  Meet: "+event.MeetLink)
+	}
+	if event.Link != "" {
+		lines = append(lines, "  Link: "+event.Link)
+	}
+
+	_, err := fmt.Fprintln(w, strings.Join(lines, "\n"))
+	return err
+}
+
+// writeCreateWarnings reports what failed after an event was created, e.g.
+// one of its buffers.
+func writeCreateWarnings(w io.Writer, event *calendar.EventResult) {
+	for _, warning := range event.Warnings {
+		fmt.Fprintf(w, "Warning: %s\n", warning)
+	}
+}
+
+// writeConflictWarning warns about the events a new event overlaps. It
+// writes nothing when there are none.
+func writeConflictWarning(w io.Writer, conflicts []*calendar.EventResult, loc *time.Location) error {
+	if len(conflicts) == 0 {
+		return nil
+	}
+
+	lines := []string{fmt.Sprintf("Warning: overlaps %d existing event(s):", len(conflicts))}
+	for _, event := range conflicts {
+		lines = append(lines, "  "+formatEventLine(event, loc))
+	}
+	_, err := fmt.Fprintln(w, strings.Join(lines, "\n"))
+	return err
+}
+
+// formatEventWhen describes when an event takes place. All-day events show
+// their dates, timed events their start time in loc.
+func formatEventWhen(event *calendar.EventResult, loc *time.Location) string {
+	if !event.AllDay {
+		return calendar.FormatTimeIn(event.StartTime, loc)
+	}
+
+	const layout = "Mon, Jan 2, 2006"
+	// EndTime is the exclusive end date
+	last := event.EndTime.AddDate(0, 0, -1)
+	if !last.After(event.StartTime) {
+		return event.StartTime.Format(layout) + " (all day)"
+	}
+	return event.StartTime.Format(layout) + " - " + last.Format(layout) + " (all day)"
+}
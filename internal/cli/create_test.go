@@ -0,0 +1,229 @@
+package cli
+
+import (
+	"bytes"
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/ezer/calgo/internal/calendar"
+	"github.com/ezer/calgo/internal/config"
+)
+
+func TestBuildEventParams(t *testing.T) {
+	cfg := config.DefaultConfig()
+	cfg.Timezone = "UTC"
+
+	params, err := buildEventParams(&createOptions{
+		title:     "  Review  ",
+		start:     "2024-01-15 14:00",
+		attendees: []string{"alice@example.com, bob@example.com", "ALICE@example.com"},
+		notify:    "external",
+		tags:      []string{"calgo:source=script"},
+	}, cfg)
+	if err != nil {
+		t.Fatalf("buildEventParams() error = %v", err)
+	}
+
+	if params.Title != "Review" {
+		t.Errorf("Title = %q, want Review", params.Title)
+	}
+	if want := time.Date(2024, 1, 15, 14, 0, 0, 0, time.UTC); !params.StartTime.Equal(want) {
+		t.Errorf("StartTime = %v, want %v", params.StartTime, want)
+	}
+	if params.Duration != 30*time.Minute {
+		t.Errorf("Duration = %v, want config default of 30m", params.Duration)
+	}
+	if want := []string{"alice@example.com", "bob@example.com"}; !reflect.DeepEqual(params.Attendees, want) {
+		t.Errorf("Attendees = %v, want %v", params.Attendees, want)
+	}
+	if params.SendUpdates != calendar.SendUpdatesExternalOnly {
+		t.Errorf("SendUpdates = %q, want %q", params.SendUpdates, calendar.SendUpdatesExternalOnly)
+	}
+	if want := map[string]string{"calgo:source": "script"}; !reflect.DeepEqual(params.Tags, want) {
+		t.Errorf("Tags = %v, want %v", params.Tags, want)
+	}
+}
+
+func TestBuildEventParams_Template(t *testing.T) {
+	cfg := config.DefaultConfig()
+	cfg.Timezone = "UTC"
+	cfg.Templates = map[string]config.EventTemplate{
+		"standup": {
+			Title:     "Standup",
+			Duration:  15,
+			Location:  "Room 1",
+			Attendees: []string{"team@example.com"},
+			Reminders: []int{5},
+			Color:     "sage",
+		},
+	}
+
+	params, err := buildEventParams(&createOptions{
+		template:  "standup",
+		start:     "2024-01-15 09:30",
+		location:  "Room 2",
+		attendees: []string{"guest@example.com"},
+	}, cfg)
+	if err != nil {
+		t.Fatalf("buildEventParams() error = %v", err)
+	}
+
+	if params.Title != "Standup" || params.Duration != 15*time.Minute || params.Color != "sage" {
+		t.Errorf("params = %+v, want the template's title, duration and color", params)
+	}
+	if params.Location != "Room 2" {
+		t.Errorf("Location = %q, want the flag to override the template", params.Location)
+	}
+	if want := []string{"team@example.com", "guest@example.com"}; !reflect.DeepEqual(params.Attendees, want) {
+		t.Errorf("Attendees = %v, want %v", params.Attendees, want)
+	}
+	if want := []time.Duration{5 * time.Minute}; !reflect.DeepEqual(params.Reminders, want) {
+		t.Errorf("Reminders = %v, want %v", params.Reminders, want)
+	}
+
+	_, err = buildEventParams(&createOptions{template: "retro", start: "14:00"}, cfg)
+	if !errors.Is(err, config.ErrUnknownTemplate) {
+		t.Errorf("unknown template error = %v, want ErrUnknownTemplate", err)
+	}
+}
+
+func TestBuildEventParams_Errors(t *testing.T) {
+	cfg := config.DefaultConfig()
+	cfg.Timezone = "UTC"
+
+	tests := []struct {
+		name    string
+		opts    createOptions
+		wantErr error
+	}{
+		{name: "bad start", opts: createOptions{title: "x", start: "whenever"}, wantErr: calendar.ErrInvalidDateFormat},
+		{name: "bad duration", opts: createOptions{title: "x", start: "14:00", duration: "long"}},
+		{name: "bad attendee", opts: createOptions{title: "x", start: "14:00", attendees: []string{"nope"}}, wantErr: calendar.ErrInvalidAttendee},
+		{name: "bad notify", opts: createOptions{title: "x", start: "14:00", notify: "everyone"}, wantErr: calendar.ErrInvalidSendUpdates},
+		{name: "bad tag", opts: createOptions{title: "x", start: "14:00", tags: []string{"source"}}, wantErr: calendar.ErrInvalidTag},
+		{name: "bad color", opts: createOptions{title: "x", start: "14:00", color: "chartreuse"}, wantErr: calendar.ErrUnknownColor},
+		{name: "bad status", opts: createOptions{title: "x", start: "14:00", status: "maybe"}, wantErr: calendar.ErrInvalidStatus},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := buildEventParams(&tt.opts, cfg)
+			if err == nil {
+				t.Fatal("expected error, got nil")
+			}
+			if tt.wantErr != nil && !errors.Is(err, tt.wantErr) {
+				t.Errorf("error = %v, want %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestBuildEventParams_AllDay(t *testing.T) {
+	cfg := config.DefaultConfig()
+	cfg.Timezone = "UTC"
+
+	params, err := buildEventParams(&createOptions{
+		title:  "Vacation",
+		start:  "2024-07-01",
+		end:    "2024-07-05",
+		allDay: true,
+	}, cfg)
+	if err != nil {
+		t.Fatalf("buildEventParams() error = %v", err)
+	}
+
+	if !params.AllDay {
+		t.Error("AllDay = false, want true")
+	}
+	if params.Duration != 0 {
+		t.Errorf("Duration = %v, want 0 for all-day events", params.Duration)
+	}
+	if want := time.Date(2024, 7, 5, 0, 0, 0, 0, time.UTC); !params.EndDate.Equal(want) {
+		t.Errorf("EndDate = %v, want %v", params.EndDate, want)
+	}
+
+	if _, err := buildEventParams(&createOptions{start: "2024-07-01", allDay: true}, cfg); err == nil {
+		t.Error("expected a missing title to fail")
+	}
+}
+
+func TestBuildEventParams_EndTime(t *testing.T) {
+	cfg := config.DefaultConfig()
+	cfg.Timezone = "UTC"
+
+	params, err := buildEventParams(&createOptions{title: "Review", start: "2024-07-01 14:00", end: "16:30"}, cfg)
+	if err != nil {
+		t.Fatalf("buildEventParams() error = %v", err)
+	}
+	if want := time.Date(2024, 7, 1, 16, 30, 0, 0, time.UTC); !params.EndTime.Equal(want) {
+		t.Errorf("EndTime = %v, want %v on the start date", params.EndTime, want)
+	}
+	if params.Duration != 0 {
+		t.Errorf("Duration = %v, want 0 with an end time", params.Duration)
+	}
+
+	if _, err := buildEventParams(&createOptions{title: "x", start: "2024-07-01 14:00", end: "13:00"}, cfg); !errors.Is(err, calendar.ErrInvalidEventTime) {
+		t.Errorf("end before start error = %v, want ErrInvalidEventTime", err)
+	}
+}
+
+func TestFormatEventWhen_AllDay(t *testing.T) {
+	day := func(d int) time.Time { return time.Date(2024, 7, d, 0, 0, 0, 0, time.UTC) }
+
+	tests := []struct {
+		name  string
+		event *calendar.EventResult
+		want  string
+	}{
+		{
+			name:  "single day",
+			event: &calendar.EventResult{AllDay: true, StartTime: day(1), EndTime: day(2)},
+			want:  "Mon, Jul 1, 2024 (all day)",
+		},
+		{
+			name:  "multi day shows the inclusive last day",
+			event: &calendar.EventResult{AllDay: true, StartTime: day(1), EndTime: day(6)},
+			want:  "Mon, Jul 1, 2024 - Fri, Jul 5, 2024 (all day)",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := formatEventWhen(tt.event, time.UTC); got != tt.want {
+				t.Errorf("formatEventWhen() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestWriteConflictWarning(t *testing.T) {
+	day := time.Date(2024, 1, 15, 0, 0, 0, 0, time.UTC)
+
+	var buf bytes.Buffer
+	if err := writeConflictWarning(&buf, nil, time.UTC); err != nil || buf.Len() != 0 {
+		t.Fatalf("writeConflictWarning(nil) wrote %q, err %v; want nothing", buf.String(), err)
+	}
+
+	conflicts := []*calendar.EventResult{
+		{Title: "Standup", StartTime: day.Add(9 * time.Hour), EndTime: day.Add(10 * time.Hour)},
+	}
+	if err := writeConflictWarning(&buf, conflicts, time.UTC); err != nil {
+		t.Fatalf("writeConflictWarning() error = %v", err)
+	}
+	want := "Warning: overlaps 1 existing event(s):\n  Mon Jan 15  09:00-10:00  Standup\n"
+	if got := buf.String(); got != want {
+		t.Errorf("writeConflictWarning() = %q, want %q", got, want)
+	}
+}
+
+func TestWriteCreateWarnings(t *testing.T) {
+	var buf bytes.Buffer
+	writeCreateWarnings(&buf, &calendar.EventResult{
+		Warnings: []string{"failed to create buffer: backend error"},
+	})
+	if got, want := buf.String(), "Warning: failed to create buffer: backend error\n"; got != want {
+		t.Errorf("writeCreateWarnings() = %q, want %q", got, want)
+	}
+}
@@ -0,0 +1,132 @@
+package cli
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+
+	"github.com/ezer/calgo/internal/calendar"
+)
+
+func newDeleteCommand(global *globalOptions) *cobra.Command {
+	var (
+		yes           bool
+		ifMatch       string
+		force         bool
+		instance      string
+		markCancelled bool
+	)
+
+	cmd := &cobra.Command{
+		Use:   "delete <event-id>",
+		Short: "Delete an event",
+		Long: `Delete an event by ID.
+
+The event is shown and you're asked to confirm before it's deleted. Use --yes
+to skip the prompt, e.g. in scripts.
+
+If the event changes after it was shown, the delete fails rather than
+removing something you haven't seen; use --force to delete it anyway. With
+--yes, pass the etag from the event's --json output with --if-match to get
+the same check.
+
+With --cancel the event is marked cancelled and its guests are emailed a
+cancellation, instead of it being deleted outright.
+
+For a recurring event, --instance cancels only the occurrence on the given
+date, e.g. --instance 2024-03-04; the rest of the series is kept.`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			eventID := args[0]
+
+			cfg, err := global.loadConfig()
+			if err != nil {
+				return err
+			}
+
+			ctx, cancel := cfg.CommandContext(cmd.Context())
+			defer cancel()
+
+			client, err := newCalendarClient(ctx, cfg)
+			if err != nil {
+				return err
+			}
+
+			var event *calendar.EventResult
+			if instance != "" {
+				day, err := calendar.ParseDate(instance, cfg.Timezone)
+				if err != nil {
+					return fmt.Errorf("invalid --instance: %w", err)
+				}
+				event, err = client.FindInstance(ctx, eventID, day)
+				if err != nil {
+					return err
+				}
+				eventID = event.ID
+			}
+
+			etag := ifMatch
+			if !yes {
+				if event == nil {
+					event, err = client.GetEvent(ctx, eventID)
+					if err != nil {
+						return err
+					}
+				}
+
+				loc, err := cfg.DisplayLocation()
+				if err != nil {
+					return err
+				}
+
+				question := fmt.Sprintf("Delete %q?", formatEventLine(event, loc))
+				if markCancelled {
+					question = fmt.Sprintf("Cancel %q and notify its guests?", formatEventLine(event, loc))
+				}
+				ok, err := confirm(cmd.InOrStdin(), cmd.ErrOrStderr(), question)
+				if err != nil {
+					return err
+				}
+				if !ok {
+					fmt.Fprintln(cmd.ErrOrStderr(), "Aborted.")
+					return nil
+				}
+				if etag == "" && !force {
+					etag = event.ETag
+				}
+			}
+
+			out := cmd.OutOrStdout()
+			if markCancelled {
+				if _, err := client.CancelEvent(ctx, eventID, etag); err != nil {
+					return conflictHint(err)
+				}
+				if global.json {
+					return writeJSON(out, map[string]any{"id": eventID, "cancelled": true})
+				}
+				_, err = fmt.Fprintf(out, "Cancelled event %s\n", eventID)
+				return err
+			}
+
+			if err := client.DeleteEventIfMatch(ctx, eventID, etag); err != nil {
+				return conflictHint(err)
+			}
+
+			if global.json {
+				return writeJSON(out, map[string]any{"id": eventID, "deleted": true})
+			}
+			_, err = fmt.Fprintf(out, "Deleted event %s\n", eventID)
+			return err
+		},
+	}
+
+	flags := cmd.Flags()
+	flags.BoolVarP(&yes, "yes", "y", false, "delete without asking for confirmation")
+	flags.StringVar(&ifMatch, "if-match", "", "only delete the event if it still has this etag")
+	flags.BoolVar(&force, "force", false, "delete even if the event changed after it was shown")
+	flags.BoolVar(&markCancelled, "cancel", false, "mark the event cancelled and notify guests instead of deleting it")
+	flags.StringVar(&instance, "instance", "", "only cancel the occurrence of a recurring event on this date")
+	cmd.MarkFlagsMutuallyExclusive("if-match", "force")
+
+	return cmd
+}
@@ -0,0 +1,49 @@
+package cli
+
+import (
+	"fmt"
+	"io"
+
+	"github.com/spf13/cobra"
+
+	"github.com/ezer/calgo/internal/calendar"
+)
+
+// dryRunAnnotation marks the commands that honor --dry-run.
+const dryRunAnnotation = "calgo.dry-run"
+
+// supportsDryRun is the annotation for commands that honor --dry-run.
+var supportsDryRun = map[string]string{dryRunAnnotation: "true"}
+
+// checkDryRun rejects --dry-run for commands that don't honor it, rather
+// than letting them change the calendar anyway.
+func checkDryRun(cmd *cobra.Command, global *globalOptions) error {
+	if global.dryRun && cmd.Annotations[dryRunAnnotation] == "" {
+		return fmt.Errorf("%s doesn't support --dry-run", cmd.CommandPath())
+	}
+	return nil
+}
+
+// writeEventPayload writes the API request body CreateEvent would send for
+// params, as --dry-run does instead of creating the event.
+func writeEventPayload(w io.Writer, client *calendar.Client, params calendar.EventParams) error {
+	event, err := client.EventPayload(params)
+	if err != nil {
+		return err
+	}
+	return writeJSON(w, event)
+}
+
+// writeEventPayloads is writeEventPayload for several events, written as a
+// JSON array.
+func writeEventPayloads(w io.Writer, client *calendar.Client, params []calendar.EventParams) error {
+	events := make([]any, 0, len(params))
+	for _, p := range params {
+		event, err := client.EventPayload(p)
+		if err != nil {
+			return err
+		}
+		events = append(events, event)
+	}
+	return writeJSON(w, events)
+}
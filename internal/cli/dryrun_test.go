@@ -0,0 +1,78 @@
+package cli
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/ezer/calgo/internal/calendar"
+)
+
+func TestDryRun_Unsupported(t *testing.T) {
+	root := NewRootCommand("test")
+	root.SetArgs([]string{"delete", "abc123", "--dry-run"})
+	root.SetOut(io.Discard)
+
+	err := root.Execute()
+	if err == nil || !strings.Contains(err.Error(), "calgo delete doesn't support --dry-run") {
+		t.Errorf("Execute() error = %v, want --dry-run rejected", err)
+	}
+}
+
+func TestWriteEventPayloads(t *testing.T) {
+	// Building payloads makes no requests
+	client, err := calendar.NewClient(context.Background(), http.DefaultClient, "primary")
+	if err != nil {
+		t.Fatalf("NewClient() error = %v", err)
+	}
+
+	berlin, _ := time.LoadLocation("Europe/Berlin")
+	params := calendar.EventParams{
+		Title:      "Standup",
+		StartTime:  time.Date(2024, 3, 4, 9, 30, 0, 0, berlin),
+		Duration:   15 * time.Minute,
+		Recurrence: []string{"RRULE:FREQ=WEEKLY;BYDAY=MO"},
+	}
+
+	var buf bytes.Buffer
+	if err := writeEventPayload(&buf, client, params); err != nil {
+		t.Fatalf("writeEventPayload() error = %v", err)
+	}
+
+	var event struct {
+		Summary string `json:"summary"`
+		Start   struct {
+			DateTime string `json:"dateTime"`
+			TimeZone string `json:"timeZone"`
+		} `json:"start"`
+		Recurrence []string `json:"recurrence"`
+	}
+	if err := json.Unmarshal(buf.Bytes(), &event); err != nil {
+		t.Fatalf("payload isn't a JSON object: %v\n%s", err, buf.String())
+	}
+	if event.Summary != "Standup" || event.Start.DateTime != "2024-03-04T09:30:00+01:00" || event.Start.TimeZone != "Europe/Berlin" {
+		t.Errorf("payload = %+v", event)
+	}
+	if len(event.Recurrence) != 1 || event.Recurrence[0] != "RRULE:FREQ=WEEKLY;BYDAY=MO" {
+		t.Errorf("Recurrence = %v", event.Recurrence)
+	}
+
+	buf.Reset()
+	if err := writeEventPayloads(&buf, client, []calendar.EventParams{params, params}); err != nil {
+		t.Fatalf("writeEventPayloads() error = %v", err)
+	}
+	var events []json.RawMessage
+	if err := json.Unmarshal(buf.Bytes(), &events); err != nil || len(events) != 2 {
+		t.Errorf("payloads = %s, want an array of two events", buf.String())
+	}
+
+	params.Title = ""
+	if err := writeEventPayload(&buf, client, params); err == nil {
+		t.Error("expected invalid params to fail")
+	}
+}
@@ -0,0 +1,225 @@
+package cli
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+
+	"github.com/ezer/calgo/internal/calendar"
+)
+
+// editOptions holds the flags of the edit command.
+type editOptions struct {
+	title       string
+	start       string
+	end         string
+	duration    string
+	description string
+	location    string
+	status      string
+	ifMatch     string
+	force       bool
+	instance    string
+}
+
+func newEditCommand(global *globalOptions) *cobra.Command {
+	opts := &editOptions{}
+
+	cmd := &cobra.Command{
+		Use:   "edit <event-id>",
+		Short: "Change an existing event",
+		Long: `Change the title, time, location, description or status of an existing event.
+
+Only the flags you pass are changed. Moving an event with --start keeps its
+duration unless --end or --duration is also given. A time of day alone for
+--end, e.g. --end 16:30, is on the start date: the new one, or the event's
+current one. Pass an empty value, e.g. --location "", to clear a field.
+
+If the event changes while it is being edited, e.g. someone else moves it,
+the edit fails instead of overwriting their change. Pass the etag from the
+event's --json output with --if-match to also catch changes made since you
+read it, or use --force to overwrite regardless.
+
+For a recurring event, --instance changes only the occurrence on the given
+date, e.g. --instance 2024-03-04; the rest of the series is left alone.`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cfg, err := global.loadConfig()
+			if err != nil {
+				return err
+			}
+
+			var day time.Time
+			if opts.instance != "" {
+				day, err = calendar.ParseDate(opts.instance, cfg.Timezone)
+				if err != nil {
+					return fmt.Errorf("invalid --instance: %w", err)
+				}
+			}
+
+			ctx, cancel := cfg.CommandContext(cmd.Context())
+			defer cancel()
+
+			client, err := newCalendarClient(ctx, cfg)
+			if err != nil {
+				return err
+			}
+
+			// current is the event as read to work out the update, if it was
+			var current *calendar.EventResult
+			eventID := args[0]
+			if opts.instance != "" {
+				current, err = client.FindInstance(ctx, eventID, day)
+				if err != nil {
+					return err
+				}
+				eventID = current.ID
+			}
+
+			loc, err := cfg.DisplayLocation()
+			if err != nil {
+				return err
+			}
+			currentStart := func() (time.Time, error) {
+				if current == nil {
+					event, err := client.GetEvent(ctx, eventID)
+					if err != nil {
+						return time.Time{}, err
+					}
+					current = event
+				}
+				return current.StartTime.In(loc), nil
+			}
+
+			update, err := buildEventUpdate(cmd.Flags(), opts, cfg.Timezone, currentStart)
+			if err != nil {
+				return err
+			}
+			// Only apply the update to the version it was worked out from
+			if current != nil && update.ETag == "" && !update.Force {
+				update.ETag = current.ETag
+			}
+
+			result, err := client.UpdateEvent(ctx, eventID, update)
+			if err != nil {
+				return conflictHint(err)
+			}
+
+			if global.json {
+				return writeJSON(cmd.OutOrStdout(), result)
+			}
+			return writeUpdatedEvent(cmd.OutOrStdout(), result, loc)
+		},
+	}
+
+	opts.addFlags(cmd.Flags())
+	cmd.MarkFlagsMutuallyExclusive("end", "duration")
+	cmd.MarkFlagsMutuallyExclusive("if-match", "force")
+
+	return cmd
+}
+
+// addFlags registers the edit flags on flags.
+func (o *editOptions) addFlags(flags *pflag.FlagSet) {
+	flags.StringVar(&o.title, "title", "", "new event title")
+	flags.StringVar(&o.start, "start", "", "new start time")
+	flags.StringVar(&o.end, "end", "", "new end time")
+	flags.StringVar(&o.duration, "duration", "", "new duration, e.g. 45m or 1h30m")
+	flags.StringVar(&o.description, "description", "", "new description")
+	flags.StringVar(&o.location, "location", "", "new location")
+	flags.StringVar(&o.status, "status", "", "new status: confirmed, tentative or cancelled")
+	flags.StringVar(&o.ifMatch, "if-match", "", "only edit the event if it still has this etag")
+	flags.BoolVar(&o.force, "force", false, "overwrite changes made by someone else")
+	flags.StringVar(&o.instance, "instance", "", "only change the occurrence of a recurring event on this date")
+}
+
+// buildEventUpdate turns the flags that were set into an EventUpdate.
+// currentStart returns the event's current start; it is only called for an
+// --end without --start, which is anchored to it.
+func buildEventUpdate(flags *pflag.FlagSet, opts *editOptions, timezone string, currentStart func() (time.Time, error)) (calendar.EventUpdate, error) {
+	update := calendar.EventUpdate{ETag: opts.ifMatch, Force: opts.force}
+
+	if flags.Changed("title") {
+		update.Title = &opts.title
+	}
+	if flags.Changed("description") {
+		update.Description = &opts.description
+	}
+	if flags.Changed("location") {
+		update.Location = &opts.location
+	}
+
+	if flags.Changed("status") {
+		status, err := calendar.ParseStatus(opts.status)
+		if err != nil {
+			return update, err
+		}
+		update.Status = status
+	}
+
+	if flags.Changed("start") {
+		start, err := calendar.ParseTime(opts.start, timezone)
+		if err != nil {
+			return update, fmt.Errorf("invalid --start: %w", err)
+		}
+		update.StartTime = &start
+	}
+	if flags.Changed("end") {
+		var start time.Time
+		if update.StartTime != nil {
+			start = *update.StartTime
+		} else {
+			var err error
+			if start, err = currentStart(); err != nil {
+				return update, err
+			}
+		}
+
+		end, err := calendar.ParseEndTime(opts.end, start, timezone)
+		if err != nil {
+			return update, fmt.Errorf("invalid --end: %w", err)
+		}
+		if !end.After(start) {
+			return update, fmt.Errorf("%w: --end %s is not after the start", calendar.ErrInvalidEventTime, opts.end)
+		}
+		update.EndTime = &end
+	}
+	if flags.Changed("duration") {
+		duration, err := calendar.ParseDuration(opts.duration)
+		if err != nil {
+			return update, fmt.Errorf("invalid --duration: %w", err)
+		}
+		update.Duration = duration
+	}
+
+	if update.StartTime != nil || update.EndTime != nil {
+		update.Timezone = timezone
+	}
+
+	return update, nil
+}
+
+// conflictHint adds advice to ErrConflict errors from edits and deletes.
+func conflictHint(err error) error {
+	if errors.Is(err, calendar.ErrConflict) {
+		return fmt.Errorf("%w; check the event and retry, or use --force", err)
+	}
+	return err
+}
+
+// writeUpdatedEvent reports an updated event in human-readable form.
+func writeUpdatedEvent(w io.Writer, event *calendar.EventResult, loc *time.Location) error {
+	if _, err := fmt.Fprintf(w, "Updated event %s\n  %s\n", event.ID, formatEventLine(event, loc)); err != nil {
+		return err
+	}
+	if event.Link != "" {
+		if _, err := fmt.Fprintf(w, "  %s\n", event.Link); err != nil {
+			return err
+		}
+	}
+	return nil
+}
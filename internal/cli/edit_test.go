@@ -0,0 +1,131 @@
+package cli
+
+import (
+	"testing"
+	"time"
+
+	"github.com/spf13/pflag"
+
+	"github.com/ezer/calgo/internal/calendar"
+)
+
+func TestBuildEventUpdate(t *testing.T) {
+	tests := []struct {
+		name    string
+		args    []string
+		check   func(t *testing.T, got calendar.EventUpdate)
+		wantErr bool
+	}{
+		{
+			name: "only set flags are included",
+			args: []string{"--title", "Renamed"},
+			check: func(t *testing.T, got calendar.EventUpdate) {
+				if got.Title == nil || *got.Title != "Renamed" {
+					t.Errorf("Title = %v, want Renamed", got.Title)
+				}
+				if got.Description != nil || got.Location != nil || got.StartTime != nil || got.Timezone != "" {
+					t.Errorf("expected unset fields to stay empty, got %+v", got)
+				}
+			},
+		},
+		{
+			name: "empty value clears",
+			args: []string{"--location", ""},
+			check: func(t *testing.T, got calendar.EventUpdate) {
+				if got.Location == nil || *got.Location != "" {
+					t.Errorf("Location = %v, want empty string", got.Location)
+				}
+			},
+		},
+		{
+			name: "start and duration",
+			args: []string{"--start", "2024-01-15 14:00", "--duration", "45m"},
+			check: func(t *testing.T, got calendar.EventUpdate) {
+				want := time.Date(2024, 1, 15, 14, 0, 0, 0, time.UTC)
+				if got.StartTime == nil || !got.StartTime.Equal(want) {
+					t.Errorf("StartTime = %v, want %v", got.StartTime, want)
+				}
+				if got.Duration != 45*time.Minute {
+					t.Errorf("Duration = %v, want 45m", got.Duration)
+				}
+				if got.Timezone != "UTC" {
+					t.Errorf("Timezone = %q, want UTC", got.Timezone)
+				}
+			},
+		},
+		{
+			name: "version check flags",
+			args: []string{"--title", "Renamed", "--if-match", `"42"`},
+			check: func(t *testing.T, got calendar.EventUpdate) {
+				if got.ETag != `"42"` || got.Force {
+					t.Errorf("ETag, Force = %q, %v; want \"42\", false", got.ETag, got.Force)
+				}
+			},
+		},
+		{
+			name: "status",
+			args: []string{"--status", "Tentative"},
+			check: func(t *testing.T, got calendar.EventUpdate) {
+				if got.Status != calendar.StatusTentative {
+					t.Errorf("Status = %q, want tentative", got.Status)
+				}
+			},
+		},
+		{
+			name: "clock-only end is on the event's day",
+			args: []string{"--end", "16:30"},
+			check: func(t *testing.T, got calendar.EventUpdate) {
+				want := time.Date(2024, 3, 4, 16, 30, 0, 0, time.UTC)
+				if got.EndTime == nil || !got.EndTime.Equal(want) {
+					t.Errorf("EndTime = %v, want %v", got.EndTime, want)
+				}
+				if got.StartTime != nil {
+					t.Errorf("StartTime = %v, want it left unchanged", got.StartTime)
+				}
+			},
+		},
+		{
+			name: "clock-only end is on the new start's day",
+			args: []string{"--start", "2024-01-15 14:00", "--end", "16:30"},
+			check: func(t *testing.T, got calendar.EventUpdate) {
+				want := time.Date(2024, 1, 15, 16, 30, 0, 0, time.UTC)
+				if got.EndTime == nil || !got.EndTime.Equal(want) {
+					t.Errorf("EndTime = %v, want %v", got.EndTime, want)
+				}
+			},
+		},
+		{name: "end before the event's start", args: []string{"--end", "13:00"}, wantErr: true},
+		{name: "end before the new start", args: []string{"--start", "2024-01-15 14:00", "--end", "2024-01-15 13:00"}, wantErr: true},
+		{name: "bad start", args: []string{"--start", "whenever"}, wantErr: true},
+		{name: "bad end", args: []string{"--end", "whenever"}, wantErr: true},
+		{name: "bad duration", args: []string{"--duration", "soon"}, wantErr: true},
+		{name: "bad status", args: []string{"--status", "done"}, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			opts := &editOptions{}
+			flags := pflag.NewFlagSet("edit", pflag.ContinueOnError)
+			opts.addFlags(flags)
+			if err := flags.Parse(tt.args); err != nil {
+				t.Fatalf("Parse() error = %v", err)
+			}
+
+			// The event being edited starts at 14:00 on March 4
+			currentStart := func() (time.Time, error) {
+				return time.Date(2024, 3, 4, 14, 0, 0, 0, time.UTC), nil
+			}
+			update, err := buildEventUpdate(flags, opts, "UTC", currentStart)
+			if tt.wantErr {
+				if err == nil {
+					t.Fatal("expected error, got nil")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			tt.check(t, update)
+		})
+	}
+}
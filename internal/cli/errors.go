@@ -0,0 +1,120 @@
+package cli
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+
+	"github.com/ezer/calgo/internal/auth"
+	"github.com/ezer/calgo/internal/calendar"
+	"github.com/ezer/calgo/internal/config"
+)
+
+// Error codes reported with --output json, so scripts can tell failures
+// apart without matching messages.
+const (
+	codeError            = "error"
+	codeInvalidInput     = "invalid_input"
+	codeInvalidConfig    = "invalid_config"
+	codeAuthFailed       = "auth_failed"
+	codeNotFound         = "not_found"
+	codeConflict         = "conflict"
+	codePermissionDenied = "permission_denied"
+	codeQuotaExceeded    = "quota_exceeded"
+	codeNoFreeSlot       = "no_free_slot"
+	codeTimeout          = "timeout"
+)
+
+// errorCodes maps the errors commands commonly fail with to their codes.
+// The first match wins.
+var errorCodes = []struct {
+	code string
+	errs []error
+}{
+	{codeNotFound, []error{calendar.ErrEventNotFound, calendar.ErrCalendarNotFound, config.ErrUnknownTemplate, config.ErrUnknownProfile}},
+	{codeConflict, []error{calendar.ErrConflict, calendar.ErrScheduleConflict}},
+	{codePermissionDenied, []error{calendar.ErrPermissionDenied}},
+	{codeQuotaExceeded, []error{calendar.ErrQuotaExceeded}},
+	{codeNoFreeSlot, []error{calendar.ErrNoFreeSlot}},
+	{codeTimeout, []error{context.DeadlineExceeded}},
+	{codeAuthFailed, []error{
+		auth.ErrInvalidCredentials, auth.ErrAuthenticationFailed, auth.ErrTokenRefreshFailed,
+		auth.ErrInsufficientScope, auth.ErrAuthCancelled, auth.ErrCredentialsNotFound,
+		auth.ErrReauthRequired, auth.ErrScopeMismatch, auth.ErrMissingCredentialsPath,
+		auth.ErrMissingTokenPath, auth.ErrPassphraseRequired, auth.ErrTokenDecryptFailed,
+		auth.ErrInvalidFlow, auth.ErrNoDefaultCredentials,
+	}},
+	{codeInvalidConfig, []error{
+		config.ErrMissingCredentialsPath, config.ErrMissingTokenPath, config.ErrCredentialsNotFound,
+		config.ErrInvalidTimezone, config.ErrInvalidWeekStart, config.ErrInvalidDomain,
+		config.ErrInvalidWorkingHours, config.ErrInvalidRetry, config.ErrInvalidTokenEncryption,
+		config.ErrInvalidAuthMode, auth.ErrInvalidEncryption,
+	}},
+	{codeInvalidInput, []error{
+		calendar.ErrInvalidEventTime, calendar.ErrInvalidDateFormat, calendar.ErrInvalidTimezone,
+		calendar.ErrInvalidAttendee, calendar.ErrInvalidSendUpdates, calendar.ErrInvalidPriority,
+		calendar.ErrInvalidTag, calendar.ErrInvalidRecurrence, calendar.ErrOverlappingRecurrence,
+		calendar.ErrInvalidStatus, calendar.ErrUnknownColor, calendar.ErrInvalidReminder,
+		calendar.ErrInvalidEventType, calendar.ErrInvalidQuery, calendar.ErrInvalidICS,
+		calendar.ErrInvalidResponse, calendar.ErrNotAttendee, calendar.ErrAmbiguousCalendar,
+		calendar.ErrUnknownField,
+	}},
+}
+
+// errorCode returns the code reported for err, or "error" when it isn't
+// one of the known failures.
+func errorCode(err error) string {
+	for _, group := range errorCodes {
+		for _, target := range group.errs {
+			if errors.Is(err, target) {
+				return group.code
+			}
+		}
+	}
+	return codeError
+}
+
+// errorOutput is how a failed command reports its error with --output json.
+type errorOutput struct {
+	Error struct {
+		Code    string `json:"code"`
+		Message string `json:"message"`
+
+		// API describes the failed request when the Calendar API refused it.
+		API *apiErrorOutput `json:"api,omitempty"`
+
+		// Conflicts lists the events a new event would have overlapped.
+		Conflicts []*calendar.EventResult `json:"conflicts,omitempty"`
+	} `json:"error"`
+}
+
+// apiErrorOutput is the JSON form of a calendar.APIError.
+type apiErrorOutput struct {
+	Status    int    `json:"status"`
+	Reason    string `json:"reason,omitempty"`
+	Retryable bool   `json:"retryable"`
+}
+
+// writeError reports a command's error, as JSON when asJSON is set.
+func writeError(w io.Writer, err error, asJSON bool) {
+	if !asJSON {
+		fmt.Fprintf(w, "Error: %v\n", err)
+		return
+	}
+
+	var out errorOutput
+	out.Error.Code = errorCode(err)
+	out.Error.Message = err.Error()
+	var apiErr *calendar.APIError
+	if errors.As(err, &apiErr) {
+		out.Error.API = &apiErrorOutput{Status: apiErr.Code, Reason: apiErr.Reason, Retryable: apiErr.Retryable}
+	}
+	var conflictErr *calendar.ConflictError
+	if errors.As(err, &conflictErr) {
+		out.Error.Conflicts = conflictErr.Conflicts
+	}
+	if writeJSON(w, out) != nil {
+		fmt.Fprintf(w, "Error: %v\n", err)
+	}
+}
@@ -0,0 +1,120 @@
+package cli
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/ezer/calgo/internal/auth"
+	"github.com/ezer/calgo/internal/calendar"
+	"github.com/ezer/calgo/internal/config"
+)
+
+func TestErrorCode(t *testing.T) {
+	tests := []struct {
+		err  error
+		want string
+	}{
+		{fmt.Errorf("%w: abc123", calendar.ErrEventNotFound), "not_found"},
+		{fmt.Errorf("%w: %w", calendar.ErrEventDeleteFailed, calendar.ErrConflict), "conflict"},
+		{&calendar.ConflictError{}, "conflict"},
+		{fmt.Errorf("invalid start time: %w", calendar.ErrInvalidDateFormat), "invalid_input"},
+		{config.ErrMissingCredentialsPath, "invalid_config"},
+		{fmt.Errorf("%w: token expired", auth.ErrReauthRequired), "auth_failed"},
+		{fmt.Errorf("listing events: %w", context.DeadlineExceeded), "timeout"},
+		{errors.New("something else"), "error"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.err.Error(), func(t *testing.T) {
+			if got := errorCode(tt.err); got != tt.want {
+				t.Errorf("errorCode() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestWriteError(t *testing.T) {
+	var buf bytes.Buffer
+	writeError(&buf, fmt.Errorf("%w: abc123", calendar.ErrEventNotFound), false)
+	if got, want := buf.String(), "Error: event not found: abc123\n"; got != want {
+		t.Errorf("text error = %q, want %q", got, want)
+	}
+
+	buf.Reset()
+	conflict := &calendar.ConflictError{Conflicts: []*calendar.EventResult{
+		{ID: "evt1", Title: "Standup", StartTime: time.Date(2024, 1, 15, 9, 0, 0, 0, time.UTC)},
+	}}
+	writeError(&buf, conflict, true)
+
+	var out struct {
+		Error struct {
+			Code      string `json:"code"`
+			Message   string `json:"message"`
+			Conflicts []struct {
+				ID string `json:"id"`
+			} `json:"conflicts"`
+		} `json:"error"`
+	}
+	if err := json.Unmarshal(buf.Bytes(), &out); err != nil {
+		t.Fatalf("JSON error isn't valid JSON: %v\n%s", err, buf.String())
+	}
+	if out.Error.Code != "conflict" || !strings.HasPrefix(out.Error.Message, "event conflicts") {
+		t.Errorf("JSON error = %+v", out.Error)
+	}
+	if len(out.Error.Conflicts) != 1 || out.Error.Conflicts[0].ID != "evt1" {
+		t.Errorf("conflicts = %+v, want evt1", out.Error.Conflicts)
+	}
+
+	buf.Reset()
+	writeError(&buf, fmt.Errorf("listing events: %w", &calendar.APIError{Code: 503, Reason: "backendError", Retryable: true}), true)
+	if got := buf.String(); !strings.Contains(got, `"api": {
+      "status": 503,
+      "reason": "backendError",
+      "retryable": true
+    }`) {
+		t.Errorf("JSON error = %s, want the API status, reason and retryable", got)
+	}
+}
+
+func TestOutputFlag(t *testing.T) {
+	tests := []struct {
+		args     []string
+		wantJSON bool
+		wantErr  bool
+	}{
+		{args: nil, wantJSON: false},
+		{args: []string{"--output", "json"}, wantJSON: true},
+		{args: []string{"--output", "JSON"}, wantJSON: true},
+		{args: []string{"--json"}, wantJSON: true},
+		{args: []string{"--json", "--output", "text"}, wantJSON: false},
+		{args: []string{"--output", "yaml"}, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(strings.Join(tt.args, " "), func(t *testing.T) {
+			root, opts := newRootCommand("test")
+			// delete rejects --dry-run before doing anything else
+			root.SetArgs(append([]string{"delete", "abc123", "--dry-run"}, tt.args...))
+			root.SetOut(io.Discard)
+			root.SetErr(io.Discard)
+
+			err := root.Execute()
+			if tt.wantErr {
+				if err == nil || !strings.Contains(err.Error(), "unknown output format") {
+					t.Errorf("Execute() error = %v, want an unknown output format", err)
+				}
+				return
+			}
+			if opts.json != tt.wantJSON {
+				t.Errorf("json = %v, want %v", opts.json, tt.wantJSON)
+			}
+		})
+	}
+}
@@ -0,0 +1,143 @@
+package cli
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/ezer/calgo/internal/calendar"
+)
+
+// Export formats.
+const (
+	exportFormatICS      = "ics"
+	exportFormatMarkdown = "markdown"
+)
+
+// exportOptions holds the flags of the export command.
+type exportOptions struct {
+	from   string
+	to     string
+	format string
+	file   string
+}
+
+func newExportCommand(global *globalOptions) *cobra.Command {
+	opts := &exportOptions{}
+
+	cmd := &cobra.Command{
+		Use:   "export",
+		Short: "Export events to an iCalendar or markdown file",
+		Long: `Export the events between the start of --from and the start of --to.
+
+The ics format writes an iCalendar file other calendar applications can
+import; recurring events are written as their individual occurrences. The
+markdown format writes a table per day for pasting into notes. Both dates
+accept "today", a weekday such as "friday", an offset such as "+30d", or a
+date.`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			format, err := exportFormat(opts.format)
+			if err != nil {
+				return err
+			}
+
+			cfg, err := global.loadConfig()
+			if err != nil {
+				return err
+			}
+
+			timeMin, timeMax, err := exportRange(opts, cfg.Timezone)
+			if err != nil {
+				return err
+			}
+
+			ctx, cancel := cfg.CommandContext(cmd.Context())
+			defer cancel()
+
+			client, err := newCalendarClient(ctx, cfg)
+			if err != nil {
+				return err
+			}
+
+			events, err := client.ListEvents(ctx, calendar.ListOptions{TimeMin: timeMin, TimeMax: timeMax})
+			if err != nil {
+				return err
+			}
+
+			loc, err := cfg.DisplayLocation()
+			if err != nil {
+				return err
+			}
+
+			if opts.file == "" || opts.file == "-" {
+				return writeExport(cmd.OutOrStdout(), events, format, global.json, loc)
+			}
+
+			f, err := os.Create(opts.file)
+			if err != nil {
+				return err
+			}
+			if err := writeExport(f, events, format, global.json, loc); err != nil {
+				f.Close()
+				return err
+			}
+			if err := f.Close(); err != nil {
+				return err
+			}
+			_, err = fmt.Fprintf(cmd.ErrOrStderr(), "Exported %d events to %s\n", len(events), opts.file)
+			return err
+		},
+	}
+
+	flags := cmd.Flags()
+	flags.StringVar(&opts.from, "from", "today", "first day to export")
+	flags.StringVar(&opts.to, "to", "+30d", "day to stop at, not included")
+	flags.StringVar(&opts.format, "format", exportFormatICS, "output format: ics or markdown")
+	// Not --output, which is the global output format
+	flags.StringVarP(&opts.file, "out-file", "o", "", "file to write (default stdout)")
+
+	return cmd
+}
+
+// exportFormat checks the --format flag, accepting "md" for markdown.
+func exportFormat(format string) (string, error) {
+	switch strings.ToLower(format) {
+	case exportFormatICS, "ical":
+		return exportFormatICS, nil
+	case exportFormatMarkdown, "md":
+		return exportFormatMarkdown, nil
+	}
+	return "", fmt.Errorf("unknown export format %q (use ics or markdown)", format)
+}
+
+// exportRange resolves --from and --to to the start of their days.
+func exportRange(opts *exportOptions, timezone string) (time.Time, time.Time, error) {
+	from, err := calendar.ParseDate(opts.from, timezone)
+	if err != nil {
+		return time.Time{}, time.Time{}, fmt.Errorf("invalid --from: %w", err)
+	}
+	to, err := calendar.ParseDate(opts.to, timezone)
+	if err != nil {
+		return time.Time{}, time.Time{}, fmt.Errorf("invalid --to: %w", err)
+	}
+	if !to.After(from) {
+		return time.Time{}, time.Time{}, fmt.Errorf("--to must be after --from")
+	}
+	return from, to, nil
+}
+
+// writeExport writes events in format, or as JSON when asJSON is set.
+func writeExport(w io.Writer, events []*calendar.EventResult, format string, asJSON bool, loc *time.Location) error {
+	switch {
+	case asJSON:
+		return writeEventsJSON(w, events)
+	case format == exportFormatMarkdown:
+		return calendar.ExportMarkdown(w, events, loc)
+	}
+	return calendar.ExportICS(w, events)
+}
@@ -0,0 +1,87 @@
+package cli
+
+import (
+	"io"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestExportFormat(t *testing.T) {
+	tests := []struct {
+		input   string
+		want    string
+		wantErr bool
+	}{
+		{input: "ics", want: exportFormatICS},
+		{input: "ICS", want: exportFormatICS},
+		{input: "markdown", want: exportFormatMarkdown},
+		{input: "md", want: exportFormatMarkdown},
+		{input: "csv", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			got, err := exportFormat(tt.input)
+			if tt.wantErr {
+				if err == nil {
+					t.Fatal("expected error, got nil")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("exportFormat(%q) = %q, want %q", tt.input, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestExportRange(t *testing.T) {
+	from, to, err := exportRange(&exportOptions{from: "2024-01-15", to: "2024-02-01"}, "UTC")
+	if err != nil {
+		t.Fatalf("exportRange() error = %v", err)
+	}
+	wantFrom := time.Date(2024, 1, 15, 0, 0, 0, 0, time.UTC)
+	wantTo := time.Date(2024, 2, 1, 0, 0, 0, 0, time.UTC)
+	if !from.Equal(wantFrom) || !to.Equal(wantTo) {
+		t.Errorf("exportRange() = %v, %v; want %v, %v", from, to, wantFrom, wantTo)
+	}
+
+	if _, _, err := exportRange(&exportOptions{from: "2024-01-15", to: "2024-01-15"}, "UTC"); err == nil {
+		t.Error("expected error for empty range, got nil")
+	}
+	if _, _, err := exportRange(&exportOptions{from: "whenever", to: "+30d"}, "UTC"); err == nil {
+		t.Error("expected error for invalid --from, got nil")
+	}
+}
+
+func TestExportCommand_OutputFlag(t *testing.T) {
+	root, opts := newRootCommand("test")
+	// An unknown --format fails before any file is written or API called
+	root.SetArgs([]string{"export", "--output", "json", "--format", "csv", "--out-file", filepath.Join(t.TempDir(), "out.ics")})
+	root.SetOut(io.Discard)
+	root.SetErr(io.Discard)
+
+	err := root.Execute()
+	if err == nil || !strings.Contains(err.Error(), "unknown export format") {
+		t.Fatalf("Execute() error = %v, want an unknown export format", err)
+	}
+	if !opts.json {
+		t.Error("export --output json didn't set the global output format")
+	}
+
+	export, _, err := root.Find([]string{"export"})
+	if err != nil {
+		t.Fatalf("Find(export) error = %v", err)
+	}
+	if export.LocalFlags().Lookup("output") != nil {
+		t.Error("export defines its own --output, shadowing the global flag")
+	}
+	if flag := export.Flags().ShorthandLookup("o"); flag == nil || flag.Name != "out-file" {
+		t.Errorf("-o = %v, want --out-file", flag)
+	}
+}
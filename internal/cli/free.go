@@ -0,0 +1,159 @@
+package cli
+
+import (
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/ezer/calgo/internal/calendar"
+)
+
+// freeOptions holds the flags of the free command.
+type freeOptions struct {
+	duration string
+	after    string
+	before   string
+	with     []string
+	limit    int
+	weekends bool
+}
+
+func newFreeCommand(global *globalOptions) *cobra.Command {
+	opts := &freeOptions{}
+
+	cmd := &cobra.Command{
+		Use:   "free",
+		Short: "Find open slots for a meeting",
+		Long: `Find open slots of at least --duration within your working hours.
+
+The search runs from now (or the start of --after) until the start of
+--before, which defaults to a week from today. Both accept "tomorrow", a
+weekday such as "friday", an offset such as "+3d", or a date. Use --with to
+find times when other calendars are free too.`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cfg, err := global.loadConfig()
+			if err != nil {
+				return err
+			}
+
+			// ParseDate resolves the configured timezone, so "today" gives
+			// the location to take now in
+			today, err := calendar.ParseDate("today", cfg.Timezone)
+			if err != nil {
+				return err
+			}
+			query, err := buildSlotQuery(opts, cfg.Timezone, time.Now().In(today.Location()))
+			if err != nil {
+				return err
+			}
+
+			ctx, cancel := cfg.CommandContext(cmd.Context())
+			defer cancel()
+
+			client, err := newCalendarClient(ctx, cfg)
+			if err != nil {
+				return err
+			}
+
+			if len(opts.with) > 0 {
+				query.Calendars = append([]string{cfg.CalendarID}, opts.with...)
+			}
+			slots, err := client.FindSlots(ctx, query)
+			if err != nil {
+				return err
+			}
+
+			if global.json {
+				if slots == nil {
+					slots = []calendar.TimeSlot{}
+				}
+				return writeJSON(cmd.OutOrStdout(), slots)
+			}
+
+			loc, err := cfg.DisplayLocation()
+			if err != nil {
+				return err
+			}
+			return writeFreeSlots(cmd.OutOrStdout(), slots, loc)
+		},
+	}
+
+	flags := cmd.Flags()
+	flags.StringVar(&opts.duration, "duration", "", "minimum slot length, e.g. 45m or 1h (required)")
+	flags.StringVar(&opts.after, "after", "", "day to start searching from (default now)")
+	flags.StringVar(&opts.before, "before", "+7d", "day to stop searching at, exclusive")
+	flags.StringSliceVar(&opts.with, "with", nil, "other calendar IDs or emails that must also be free")
+	flags.IntVar(&opts.limit, "limit", 10, "maximum number of slots to show (0 = no limit)")
+	flags.BoolVar(&opts.weekends, "weekends", false, "include Saturdays and Sundays")
+	cmd.MarkFlagRequired("duration")
+
+	return cmd
+}
+
+// buildSlotQuery resolves the free command's flags against now.
+func buildSlotQuery(opts *freeOptions, timezone string, now time.Time) (calendar.SlotQuery, error) {
+	duration, err := calendar.ParseDuration(opts.duration)
+	if err != nil {
+		return calendar.SlotQuery{}, fmt.Errorf("invalid --duration: %w", err)
+	}
+
+	start := now
+	if opts.after != "" {
+		after, err := calendar.ParseDate(opts.after, timezone)
+		if err != nil {
+			return calendar.SlotQuery{}, fmt.Errorf("invalid --after: %w", err)
+		}
+		if after.After(start) {
+			start = after
+		}
+	}
+
+	end, err := calendar.ParseDate(opts.before, timezone)
+	if err != nil {
+		return calendar.SlotQuery{}, fmt.Errorf("invalid --before: %w", err)
+	}
+	if !end.After(start) {
+		return calendar.SlotQuery{}, fmt.Errorf("--before must be later than the start of the search")
+	}
+
+	return calendar.SlotQuery{
+		Start:        start,
+		End:          end,
+		Duration:     duration,
+		SkipWeekends: !opts.weekends,
+		Limit:        opts.limit,
+	}, nil
+}
+
+// writeFreeSlots writes one line per slot with its length.
+func writeFreeSlots(w io.Writer, slots []calendar.TimeSlot, loc *time.Location) error {
+	if len(slots) == 0 {
+		_, err := fmt.Fprintln(w, "No free slots found.")
+		return err
+	}
+
+	for _, slot := range slots {
+		_, err := fmt.Fprintf(w, "%s  %s  (%s)\n", slot.Start.In(loc).Format("Mon Jan _2"), formatSlot(slot, loc), formatLength(slot.Duration()))
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// formatLength formats a duration compactly, e.g. "45m", "2h" or "1h30m".
+func formatLength(d time.Duration) string {
+	d = d.Round(time.Minute)
+	hours, minutes := int(d.Hours()), int(d.Minutes())%60
+	switch {
+	case hours == 0:
+		return fmt.Sprintf("%dm", minutes)
+	case minutes == 0:
+		return fmt.Sprintf("%dh", hours)
+	default:
+		return fmt.Sprintf("%dh%dm", hours, minutes)
+	}
+}
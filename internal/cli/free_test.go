@@ -0,0 +1,81 @@
+package cli
+
+import (
+	"bytes"
+	"testing"
+	"time"
+
+	"github.com/ezer/calgo/internal/calendar"
+)
+
+func TestBuildSlotQuery(t *testing.T) {
+	now := time.Date(2024, 1, 15, 10, 20, 0, 0, time.UTC)
+
+	tests := []struct {
+		name      string
+		opts      freeOptions
+		wantStart time.Time
+		wantEnd   time.Time
+		wantErr   bool
+	}{
+		{
+			name:      "from now",
+			opts:      freeOptions{duration: "45m", before: "2024-01-19"},
+			wantStart: now,
+			wantEnd:   time.Date(2024, 1, 19, 0, 0, 0, 0, time.UTC),
+		},
+		{
+			name:      "after a later day",
+			opts:      freeOptions{duration: "1h", after: "2024-01-17", before: "2024-01-19"},
+			wantStart: time.Date(2024, 1, 17, 0, 0, 0, 0, time.UTC),
+			wantEnd:   time.Date(2024, 1, 19, 0, 0, 0, 0, time.UTC),
+		},
+		{
+			name:      "after a past day starts now",
+			opts:      freeOptions{duration: "1h", after: "2024-01-10", before: "2024-01-19"},
+			wantStart: now,
+			wantEnd:   time.Date(2024, 1, 19, 0, 0, 0, 0, time.UTC),
+		},
+		{name: "bad duration", opts: freeOptions{duration: "a while", before: "2024-01-19"}, wantErr: true},
+		{name: "bad before", opts: freeOptions{duration: "1h", before: "someday"}, wantErr: true},
+		{name: "empty range", opts: freeOptions{duration: "1h", before: "2024-01-15"}, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			q, err := buildSlotQuery(&tt.opts, "UTC", now)
+			if tt.wantErr {
+				if err == nil {
+					t.Fatal("expected error, got nil")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if !q.Start.Equal(tt.wantStart) || !q.End.Equal(tt.wantEnd) {
+				t.Errorf("range = %v - %v, want %v - %v", q.Start, q.End, tt.wantStart, tt.wantEnd)
+			}
+			if !q.SkipWeekends {
+				t.Error("SkipWeekends = false, want true by default")
+			}
+		})
+	}
+}
+
+func TestWriteFreeSlots(t *testing.T) {
+	day := time.Date(2024, 1, 15, 0, 0, 0, 0, time.UTC)
+	slots := []calendar.TimeSlot{
+		{Start: day.Add(9 * time.Hour), End: day.Add(9*time.Hour + 45*time.Minute)},
+		{Start: day.Add(13 * time.Hour), End: day.Add(14*time.Hour + 30*time.Minute)},
+	}
+
+	var buf bytes.Buffer
+	if err := writeFreeSlots(&buf, slots, time.UTC); err != nil {
+		t.Fatalf("writeFreeSlots() error = %v", err)
+	}
+	want := "Mon Jan 15  09:00-09:45  (45m)\nMon Jan 15  13:00-14:30  (1h30m)\n"
+	if got := buf.String(); got != want {
+		t.Errorf("writeFreeSlots() =\n%s\nwant\n%s", got, want)
+	}
+}
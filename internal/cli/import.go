@@ -0,0 +1,479 @@
+package cli
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/spf13/cobra"
+
+	"github.com/ezer/calgo/internal/calendar"
+	"github.com/ezer/calgo/internal/config"
+)
+
+// Import file formats.
+const (
+	importFormatCSV   = "csv"
+	importFormatJSONL = "jsonl"
+	importFormatICS   = "ics"
+)
+
+// importOptions holds the flags of the import command.
+type importOptions struct {
+	file   string
+	format string
+	notify string
+}
+
+// importRow is one event to import. JSON lines use these field names, CSV
+// files the same names as column headers.
+type importRow struct {
+	Title       string   `json:"title"`
+	Start       string   `json:"start"`
+	End         string   `json:"end"`
+	Duration    string   `json:"duration"`
+	Description string   `json:"description"`
+	Location    string   `json:"location"`
+	Attendees   []string `json:"attendees"`
+	AllDay      bool     `json:"all_day"`
+}
+
+// importRecord tracks one row of the input through validation and creation.
+// Rows from CSV and JSON lines are kept in row until prepareImport builds
+// their params; ICS events have params from the start and a nil row.
+type importRecord struct {
+	line   int
+	row    *importRow
+	params calendar.EventParams
+	result *calendar.EventResult
+	err    error
+
+	// unsupported lists the ICS fields that couldn't be imported.
+	unsupported []string
+}
+
+// importResult reports the outcome of one imported row.
+type importResult struct {
+	Line  int    `json:"line"`
+	Title string `json:"title"`
+	ID    string `json:"id,omitempty"`
+	Error string `json:"error,omitempty"`
+
+	// Unsupported lists the ICS fields that were dropped.
+	Unsupported []string `json:"unsupported,omitempty"`
+}
+
+func newImportCommand(global *globalOptions) *cobra.Command {
+	opts := &importOptions{}
+
+	cmd := &cobra.Command{
+		Use:   "import",
+		Short: "Create events from a CSV, JSON lines or iCalendar file",
+		Long: `Create one event per row of a CSV file or JSON lines input, or per
+VEVENT of an iCalendar (.ics) file.
+
+Rows have the fields title, start, end, duration, description, location,
+attendees and all_day, which take the same values as the create flags. CSV
+files name them in a header row and separate several attendees with commas;
+in JSON lines attendees is an array.
+
+iCalendar events keep their recurrence rules, attendees and all-day dates.
+Fields with no equivalent, such as alarms or the organizer, are dropped and
+listed after the summary. TZID values must be IANA names like Europe/Paris.
+
+Without --file, or with --file -, JSON lines are read from stdin. The format
+is otherwise taken from the file extension unless --format is given.
+
+Every row is reported on its own, so one bad row doesn't stop the rest. Use
+--dry-run to check the input without creating anything.`,
+		Annotations: supportsDryRun,
+		Args:        cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			format, err := importFormat(opts.format, opts.file)
+			if err != nil {
+				return err
+			}
+
+			cfg, err := global.loadConfig()
+			if err != nil {
+				return err
+			}
+
+			in := cmd.InOrStdin()
+			if opts.file != "" && opts.file != "-" {
+				f, err := os.Open(opts.file)
+				if err != nil {
+					return err
+				}
+				defer f.Close()
+				in = f
+			}
+
+			records, err := readImportRows(in, format, cfg.Timezone)
+			if err != nil {
+				return err
+			}
+			prepareImport(records, opts.notify, cfg)
+
+			if !global.dryRun {
+				ctx, cancel := cfg.CommandContext(cmd.Context())
+				defer cancel()
+
+				client, err := newCalendarClient(ctx, cfg)
+				if err != nil {
+					return err
+				}
+				createImported(ctx, client, records)
+			}
+
+			results := importResults(records)
+			if global.json {
+				if err := writeJSON(cmd.OutOrStdout(), results); err != nil {
+					return err
+				}
+			} else if err := writeImportSummary(cmd.OutOrStdout(), results, global.dryRun); err != nil {
+				return err
+			}
+
+			if failed := countFailed(results); failed > 0 {
+				return fmt.Errorf("%d of %d rows failed", failed, len(results))
+			}
+			return nil
+		},
+	}
+
+	flags := cmd.Flags()
+	flags.StringVarP(&opts.file, "file", "f", "", "file to import (default stdin)")
+	flags.StringVar(&opts.format, "format", "", "input format: csv, jsonl or ics (default from the file extension)")
+	flags.StringVar(&opts.notify, "notify", "", "who gets invitation emails: all, external or none")
+
+	return cmd
+}
+
+// importFormat resolves the input format from the --format flag or the
+// file's extension. Stdin defaults to JSON lines.
+func importFormat(format, path string) (string, error) {
+	switch strings.ToLower(format) {
+	case importFormatCSV:
+		return importFormatCSV, nil
+	case importFormatJSONL, "json", "ndjson":
+		return importFormatJSONL, nil
+	case importFormatICS, "ical":
+		return importFormatICS, nil
+	case "":
+	default:
+		return "", fmt.Errorf("unknown import format %q (use csv, jsonl or ics)", format)
+	}
+
+	if path == "" || path == "-" {
+		return importFormatJSONL, nil
+	}
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".csv":
+		return importFormatCSV, nil
+	case ".json", ".jsonl", ".ndjson":
+		return importFormatJSONL, nil
+	case ".ics", ".ical":
+		return importFormatICS, nil
+	}
+	return "", fmt.Errorf("can't tell the format of %s; use --format csv, jsonl or ics", path)
+}
+
+// readImportRows parses the input into one record per row. Rows that can't
+// be decoded get their own error; an error is only returned when the input
+// as a whole can't be read. ICS times without a zone are read in timezone.
+func readImportRows(r io.Reader, format, timezone string) ([]*importRecord, error) {
+	switch format {
+	case importFormatCSV:
+		return readCSVRows(r)
+	case importFormatICS:
+		return readICSRows(r, timezone)
+	}
+	return readJSONLRows(r)
+}
+
+// readJSONLRows reads one JSON object per line, skipping blank lines.
+func readJSONLRows(r io.Reader) ([]*importRecord, error) {
+	var records []*importRecord
+
+	scanner := bufio.NewScanner(r)
+	for line := 1; scanner.Scan(); line++ {
+		text := bytes.TrimSpace(scanner.Bytes())
+		if len(text) == 0 {
+			continue
+		}
+
+		record := &importRecord{line: line, row: &importRow{}}
+		decoder := json.NewDecoder(bytes.NewReader(text))
+		decoder.DisallowUnknownFields()
+		if err := decoder.Decode(record.row); err != nil {
+			record.err = fmt.Errorf("invalid JSON: %w", err)
+		}
+		records = append(records, record)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	return records, nil
+}
+
+// readCSVRows reads a CSV file whose first row names the columns. Missing
+// trailing cells are treated as empty.
+func readCSVRows(r io.Reader) ([]*importRecord, error) {
+	reader := csv.NewReader(r)
+	reader.FieldsPerRecord = -1
+	reader.TrimLeadingSpace = true
+
+	header, err := reader.Read()
+	if err == io.EOF {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("invalid CSV: %w", err)
+	}
+
+	columns := make([]string, len(header))
+	for i, name := range header {
+		name = strings.ToLower(strings.TrimSpace(name))
+		if !knownImportColumn(name) {
+			return nil, fmt.Errorf("invalid CSV: unknown column %q", name)
+		}
+		columns[i] = name
+	}
+
+	var records []*importRecord
+	for {
+		fields, err := reader.Read()
+		if err == io.EOF {
+			return records, nil
+		}
+		if err != nil {
+			return nil, fmt.Errorf("invalid CSV: %w", err)
+		}
+
+		line, _ := reader.FieldPos(0)
+		record := &importRecord{line: line, row: &importRow{}}
+		for i, value := range fields {
+			if i >= len(columns) {
+				record.err = fmt.Errorf("row has %d cells but the header only %d", len(fields), len(columns))
+				break
+			}
+			if err := record.row.set(columns[i], value); err != nil {
+				record.err = err
+				break
+			}
+		}
+		records = append(records, record)
+	}
+}
+
+// readICSRows reads the VEVENTs of an iCalendar file.
+func readICSRows(r io.Reader, timezone string) ([]*importRecord, error) {
+	events, err := calendar.ParseICS(r, timezone)
+	if err != nil {
+		return nil, err
+	}
+
+	records := make([]*importRecord, 0, len(events))
+	for _, event := range events {
+		records = append(records, &importRecord{
+			line:        event.Line,
+			params:      event.Params,
+			err:         event.Err,
+			unsupported: event.Unsupported,
+		})
+	}
+	return records, nil
+}
+
+// knownImportColumn reports whether name is a CSV column importRow reads.
+func knownImportColumn(name string) bool {
+	switch name {
+	case "title", "start", "end", "duration", "description", "location", "attendees", "all_day":
+		return true
+	}
+	return false
+}
+
+// set stores a CSV cell in the row's field for column.
+func (r *importRow) set(column, value string) error {
+	switch column {
+	case "title":
+		r.Title = value
+	case "start":
+		r.Start = value
+	case "end":
+		r.End = value
+	case "duration":
+		r.Duration = value
+	case "description":
+		r.Description = value
+	case "location":
+		r.Location = value
+	case "attendees":
+		if value != "" {
+			r.Attendees = []string{value}
+		}
+	case "all_day":
+		if strings.TrimSpace(value) == "" {
+			return nil
+		}
+		allDay, err := strconv.ParseBool(strings.TrimSpace(value))
+		if err != nil {
+			return fmt.Errorf("invalid all_day %q: use true or false", value)
+		}
+		r.AllDay = allDay
+	}
+	return nil
+}
+
+// prepareImport builds and validates the EventParams of every record that
+// decoded cleanly, recording any error on the record. notify applies to
+// every record.
+func prepareImport(records []*importRecord, notify string, cfg *config.Config) {
+	for _, record := range records {
+		if record.err != nil {
+			continue
+		}
+
+		params := record.params
+		var err error
+		if record.row != nil {
+			params, err = buildEventParams(&createOptions{
+				title:       record.row.Title,
+				start:       record.row.Start,
+				end:         record.row.End,
+				duration:    record.row.Duration,
+				description: record.row.Description,
+				location:    record.row.Location,
+				attendees:   record.row.Attendees,
+				notify:      notify,
+				allDay:      record.row.AllDay,
+			}, cfg)
+		} else if notify != "" {
+			params.SendUpdates, err = calendar.ParseSendUpdates(notify)
+		}
+		if err == nil {
+			err = calendar.ValidateEventParams(params)
+		}
+		record.params, record.err = params, err
+	}
+}
+
+// createImported creates the events of all valid records, storing each
+// result or error on its record. If ctx ends first, the records that weren't
+// tried get its error.
+func createImported(ctx context.Context, client *calendar.Client, records []*importRecord) {
+	var valid []*importRecord
+	var params []calendar.EventParams
+	for _, record := range records {
+		if record.err == nil {
+			valid = append(valid, record)
+			params = append(params, record.params)
+		}
+	}
+	if len(params) == 0 {
+		return
+	}
+
+	outcomes, err := client.CreateEvents(ctx, params)
+	for _, outcome := range outcomes {
+		valid[outcome.Index].result = outcome.Result
+		valid[outcome.Index].err = outcome.Err
+	}
+	for _, record := range valid[len(outcomes):] {
+		record.err = fmt.Errorf("not created: %w", err)
+	}
+}
+
+// importResults converts records into the results that are reported.
+func importResults(records []*importRecord) []importResult {
+	results := make([]importResult, 0, len(records))
+	for _, record := range records {
+		result := importResult{
+			Line:        record.line,
+			Title:       record.params.Title,
+			Unsupported: record.unsupported,
+		}
+		if result.Title == "" && record.row != nil {
+			result.Title = strings.TrimSpace(record.row.Title)
+		}
+		if record.result != nil {
+			result.ID = record.result.ID
+		}
+		if record.err != nil {
+			result.Error = record.err.Error()
+		}
+		results = append(results, result)
+	}
+	return results
+}
+
+// countFailed returns the number of results with an error.
+func countFailed(results []importResult) int {
+	failed := 0
+	for _, result := range results {
+		if result.Error != "" {
+			failed++
+		}
+	}
+	return failed
+}
+
+// writeImportSummary writes a table with one row per imported line, a
+// closing count and the fields each row had to drop. In a dry run valid rows
+// are reported as "ok".
+func writeImportSummary(w io.Writer, results []importResult, dryRun bool) error {
+	if len(results) == 0 {
+		_, err := fmt.Fprintln(w, "No rows to import.")
+		return err
+	}
+
+	okStatus := "created"
+	if dryRun {
+		okStatus = "ok"
+	}
+
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(tw, "LINE\tSTATUS\tTITLE\tDETAILS")
+	for _, result := range results {
+		status, details := okStatus, result.ID
+		if result.Error != "" {
+			status, details = "failed", result.Error
+		}
+		fmt.Fprintf(tw, "%d\t%s\t%s\t%s\n", result.Line, status, result.Title, details)
+	}
+	if err := tw.Flush(); err != nil {
+		return err
+	}
+
+	failed := countFailed(results)
+	summary := fmt.Sprintf("%d %s, %d failed", len(results)-failed, okStatus, failed)
+	if dryRun {
+		summary += " (dry run, nothing was created)"
+	}
+	if _, err := fmt.Fprintln(w, summary); err != nil {
+		return err
+	}
+
+	var dropped []string
+	for _, result := range results {
+		if len(result.Unsupported) > 0 {
+			dropped = append(dropped, fmt.Sprintf("  line %d: %s", result.Line, strings.Join(result.Unsupported, ", ")))
+		}
+	}
+	if len(dropped) == 0 {
+		return nil
+	}
+	_, err := fmt.Fprintln(w, "Fields not imported:\n"+strings.Join(dropped, "\n"))
+	return err
+}
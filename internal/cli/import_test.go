@@ -0,0 +1,215 @@
+package cli
+
+import (
+	"bytes"
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/ezer/calgo/internal/calendar"
+	"github.com/ezer/calgo/internal/config"
+)
+
+func TestImportFormat(t *testing.T) {
+	tests := []struct {
+		name    string
+		format  string
+		path    string
+		want    string
+		wantErr bool
+	}{
+		{name: "stdin defaults to jsonl", want: importFormatJSONL},
+		{name: "dash is stdin", path: "-", want: importFormatJSONL},
+		{name: "csv extension", path: "events.CSV", want: importFormatCSV},
+		{name: "jsonl extension", path: "events.jsonl", want: importFormatJSONL},
+		{name: "json extension", path: "events.json", want: importFormatJSONL},
+		{name: "ics extension", path: "calendar.ics", want: importFormatICS},
+		{name: "flag overrides extension", format: "csv", path: "events.txt", want: importFormatCSV},
+		{name: "unknown extension", path: "events.txt", wantErr: true},
+		{name: "unknown format", format: "xml", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := importFormat(tt.format, tt.path)
+			if tt.wantErr {
+				if err == nil {
+					t.Fatal("expected error, got nil")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("importFormat() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestReadImportRows_CSV(t *testing.T) {
+	input := `title,start,duration,attendees,all_day
+Standup,2024-01-15 09:00,15m,"alice@example.com, bob@example.com",
+Offsite,2024-01-20,,,true
+Broken,2024-01-21,,,maybe
+Short,2024-01-22 10:00
+`
+	records, err := readImportRows(strings.NewReader(input), importFormatCSV, "UTC")
+	if err != nil {
+		t.Fatalf("readImportRows() error = %v", err)
+	}
+	if len(records) != 4 {
+		t.Fatalf("got %d records, want 4", len(records))
+	}
+
+	want := importRow{
+		Title:     "Standup",
+		Start:     "2024-01-15 09:00",
+		Duration:  "15m",
+		Attendees: []string{"alice@example.com, bob@example.com"},
+	}
+	if records[0].line != 2 || !reflect.DeepEqual(*records[0].row, want) {
+		t.Errorf("record 0 = line %d %+v, want line 2 %+v", records[0].line, *records[0].row, want)
+	}
+	if !records[1].row.AllDay {
+		t.Error("record 1 AllDay = false, want true")
+	}
+	if records[2].err == nil {
+		t.Error("record 2 with all_day=maybe has no error")
+	}
+	if records[3].err != nil || records[3].row.Start != "2024-01-22 10:00" {
+		t.Errorf("short record = %+v, %v; want trailing cells empty", records[3].row, records[3].err)
+	}
+}
+
+func TestReadImportRows_CSVUnknownColumn(t *testing.T) {
+	_, err := readImportRows(strings.NewReader("title,when\nx,y\n"), importFormatCSV, "UTC")
+	if err == nil {
+		t.Fatal("expected error for unknown column, got nil")
+	}
+}
+
+func TestReadImportRows_JSONL(t *testing.T) {
+	input := `{"title": "Standup", "start": "2024-01-15 09:00", "attendees": ["alice@example.com"]}
+
+{"title": "Typo", "strat": "2024-01-15 09:00"}
+not json
+`
+	records, err := readImportRows(strings.NewReader(input), importFormatJSONL, "UTC")
+	if err != nil {
+		t.Fatalf("readImportRows() error = %v", err)
+	}
+	if len(records) != 3 {
+		t.Fatalf("got %d records, want 3 (blank lines skipped)", len(records))
+	}
+
+	if records[0].err != nil || records[0].row.Title != "Standup" || len(records[0].row.Attendees) != 1 {
+		t.Errorf("record 0 = %+v, %v", records[0].row, records[0].err)
+	}
+	for i, wantLine := range []int{1, 3, 4} {
+		if records[i].line != wantLine {
+			t.Errorf("record %d line = %d, want %d", i, records[i].line, wantLine)
+		}
+	}
+	if records[1].err == nil {
+		t.Error("record with unknown field has no error")
+	}
+	if records[2].err == nil {
+		t.Error("invalid JSON record has no error")
+	}
+}
+
+func TestPrepareImport(t *testing.T) {
+	cfg := config.DefaultConfig()
+	cfg.Timezone = "UTC"
+
+	decodeErr := errors.New("invalid JSON")
+	records := []*importRecord{
+		{line: 1, row: &importRow{Title: "Standup", Start: "2024-01-15 09:00", Duration: "15m"}},
+		{line: 2, row: &importRow{Title: "No start"}},
+		{line: 3, err: decodeErr},
+		{line: 4, params: calendar.EventParams{Title: "From ICS", StartTime: time.Date(2024, 1, 16, 9, 0, 0, 0, time.UTC), Duration: time.Hour}},
+	}
+	prepareImport(records, "all", cfg)
+
+	if records[0].err != nil {
+		t.Fatalf("valid record error = %v", records[0].err)
+	}
+	params := records[0].params
+	if want := time.Date(2024, 1, 15, 9, 0, 0, 0, time.UTC); !params.StartTime.Equal(want) || params.Duration != 15*time.Minute {
+		t.Errorf("params = %v for %v, want %v for 15m", params.StartTime, params.Duration, want)
+	}
+	if params.SendUpdates != calendar.SendUpdatesAll {
+		t.Errorf("SendUpdates = %q, want %q", params.SendUpdates, calendar.SendUpdatesAll)
+	}
+
+	if records[1].err == nil {
+		t.Error("record without start has no error")
+	}
+	if records[2].err != decodeErr {
+		t.Errorf("decode error replaced with %v", records[2].err)
+	}
+	if records[3].err != nil || records[3].params.Title != "From ICS" || records[3].params.SendUpdates != calendar.SendUpdatesAll {
+		t.Errorf("ICS record = %+v, %v; want params kept with notify applied", records[3].params, records[3].err)
+	}
+}
+
+func TestReadImportRows_ICS(t *testing.T) {
+	input := "BEGIN:VCALENDAR\nBEGIN:VEVENT\nSUMMARY:Standup\nDTSTART:20240115T090000Z\nDURATION:PT15M\n" +
+		"ORGANIZER:mailto:carol@example.com\nEND:VEVENT\nEND:VCALENDAR\n"
+
+	records, err := readImportRows(strings.NewReader(input), importFormatICS, "UTC")
+	if err != nil {
+		t.Fatalf("readImportRows() error = %v", err)
+	}
+	if len(records) != 1 {
+		t.Fatalf("got %d records, want 1", len(records))
+	}
+
+	record := records[0]
+	if record.line != 2 || record.row != nil || record.params.Title != "Standup" || record.params.Duration != 15*time.Minute {
+		t.Errorf("record = line %d, row %v, params %+v", record.line, record.row, record.params)
+	}
+	if want := []string{"ORGANIZER"}; !reflect.DeepEqual(record.unsupported, want) {
+		t.Errorf("unsupported = %q, want %q", record.unsupported, want)
+	}
+}
+
+func TestWriteImportSummary(t *testing.T) {
+	results := []importResult{
+		{Line: 2, Title: "Standup", ID: "abc123"},
+		{Line: 3, Title: "Lunch", Error: "invalid start time"},
+	}
+
+	var buf bytes.Buffer
+	if err := writeImportSummary(&buf, results, false); err != nil {
+		t.Fatalf("writeImportSummary() error = %v", err)
+	}
+	want := "LINE  STATUS   TITLE    DETAILS\n" +
+		"2     created  Standup  abc123\n" +
+		"3     failed   Lunch    invalid start time\n" +
+		"1 created, 1 failed\n"
+	if got := buf.String(); got != want {
+		t.Errorf("summary =\n%s\nwant\n%s", got, want)
+	}
+
+	buf.Reset()
+	dropped := []importResult{{Line: 2, Title: "Standup", ID: "abc123", Unsupported: []string{"ORGANIZER", "VALARM"}}}
+	if err := writeImportSummary(&buf, dropped, false); err != nil {
+		t.Fatalf("writeImportSummary() error = %v", err)
+	}
+	if got := buf.String(); !strings.HasSuffix(got, "Fields not imported:\n  line 2: ORGANIZER, VALARM\n") {
+		t.Errorf("summary with dropped fields = %q", got)
+	}
+
+	buf.Reset()
+	if err := writeImportSummary(&buf, results[:1], true); err != nil {
+		t.Fatalf("writeImportSummary() error = %v", err)
+	}
+	if got := buf.String(); !strings.Contains(got, "2     ok") || !strings.HasSuffix(got, "1 ok, 0 failed (dry run, nothing was created)\n") {
+		t.Errorf("dry run summary = %q", got)
+	}
+}
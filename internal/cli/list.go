@@ -0,0 +1,163 @@
+package cli
+
+import (
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/ezer/calgo/internal/calendar"
+)
+
+// listOptions holds the flags of the list command.
+type listOptions struct {
+	from       string
+	to         string
+	days       int
+	maxResults int
+	tags       []string
+}
+
+func newListCommand(global *globalOptions) *cobra.Command {
+	opts := &listOptions{}
+
+	cmd := &cobra.Command{
+		Use:   "list",
+		Short: "List upcoming events",
+		Long: `List events in a time range, ordered by start time.
+
+By default the next 7 days are shown, starting now. --from and --to accept
+the same formats as event start times, e.g. "tomorrow 09:00" or "2024-01-15".
+
+With --tag only events carrying all the given tags are listed, e.g. the ones
+a script created with create --tag calgo:batch-id=xyz.`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cfg, err := global.loadConfig()
+			if err != nil {
+				return err
+			}
+
+			timeMin, timeMax, err := listRange(opts, cfg.Timezone, time.Now())
+			if err != nil {
+				return err
+			}
+			tags, err := calendar.ParseTags(opts.tags)
+			if err != nil {
+				return err
+			}
+
+			ctx, cancel := cfg.CommandContext(cmd.Context())
+			defer cancel()
+
+			client, err := newCalendarClient(ctx, cfg)
+			if err != nil {
+				return err
+			}
+
+			events, err := client.ListEvents(ctx, calendar.ListOptions{
+				TimeMin:    timeMin,
+				TimeMax:    timeMax,
+				MaxResults: opts.maxResults,
+				Tags:       tags,
+			})
+			if err != nil {
+				return err
+			}
+
+			if global.json {
+				return writeEventsJSON(cmd.OutOrStdout(), events)
+			}
+
+			loc, err := cfg.DisplayLocation()
+			if err != nil {
+				return err
+			}
+			return writeEventList(cmd.OutOrStdout(), events, loc)
+		},
+	}
+
+	flags := cmd.Flags()
+	flags.StringVar(&opts.from, "from", "", "start of the range (default now)")
+	flags.StringVar(&opts.to, "to", "", "end of the range (overrides --days)")
+	flags.IntVar(&opts.days, "days", 7, "number of days to list from the start")
+	flags.IntVar(&opts.maxResults, "max", 25, "maximum number of events to show (0 = no limit)")
+	flags.StringArrayVar(&opts.tags, "tag", nil, "only list events with this key=value tag; repeat for several")
+
+	return cmd
+}
+
+// listRange resolves the list command's range flags against now.
+func listRange(opts *listOptions, timezone string, now time.Time) (time.Time, time.Time, error) {
+	if opts.maxResults < 0 {
+		return time.Time{}, time.Time{}, fmt.Errorf("--max must not be negative")
+	}
+
+	start := now
+	if opts.from != "" {
+		t, err := calendar.ParseTime(opts.from, timezone)
+		if err != nil {
+			return time.Time{}, time.Time{}, fmt.Errorf("invalid --from: %w", err)
+		}
+		start = t
+	}
+
+	if opts.to != "" {
+		end, err := calendar.ParseTime(opts.to, timezone)
+		if err != nil {
+			return time.Time{}, time.Time{}, fmt.Errorf("invalid --to: %w", err)
+		}
+		return start, end, nil
+	}
+
+	if opts.days <= 0 {
+		return time.Time{}, time.Time{}, fmt.Errorf("--days must be positive")
+	}
+	return start, start.AddDate(0, 0, opts.days), nil
+}
+
+// writeEventList writes one line per event, with times shown in loc.
+func writeEventList(w io.Writer, events []*calendar.EventResult, loc *time.Location) error {
+	if len(events) == 0 {
+		_, err := fmt.Fprintln(w, "No events found.")
+		return err
+	}
+
+	for _, event := range events {
+		if _, err := fmt.Fprintln(w, formatEventLine(event, loc)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// formatEventLine formats an event as "Mon Jan 2  15:04-16:00  Title", with
+// the location appended when set.
+func formatEventLine(event *calendar.EventResult, loc *time.Location) string {
+	start, end := event.StartTime, event.EndTime
+	if loc != nil && !event.AllDay {
+		start, end = start.In(loc), end.In(loc)
+	}
+
+	when := "all day    "
+	if !event.AllDay {
+		when = start.Format("15:04") + "-" + end.Format("15:04")
+	}
+
+	line := fmt.Sprintf("%s  %s  %s", start.Format("Mon Jan _2"), when, event.Title)
+	if event.Location != "" {
+		line += " @ " + event.Location
+	}
+	return line
+}
+
+// writeEventsJSON writes events as an indented JSON array. An empty result
+// is written as [] rather than null.
+func writeEventsJSON(w io.Writer, events []*calendar.EventResult) error {
+	if events == nil {
+		events = []*calendar.EventResult{}
+	}
+
+	return writeJSON(w, events)
+}
@@ -0,0 +1,131 @@
+package cli
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/ezer/calgo/internal/calendar"
+)
+
+func TestListRange(t *testing.T) {
+	now := time.Date(2024, 1, 15, 10, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name      string
+		opts      listOptions
+		wantStart time.Time
+		wantEnd   time.Time
+		wantErr   bool
+	}{
+		{
+			name:      "defaults to days from now",
+			opts:      listOptions{days: 7},
+			wantStart: now,
+			wantEnd:   now.AddDate(0, 0, 7),
+		},
+		{
+			name:      "from with days",
+			opts:      listOptions{from: "2024-01-20 09:00", days: 1},
+			wantStart: time.Date(2024, 1, 20, 9, 0, 0, 0, time.UTC),
+			wantEnd:   time.Date(2024, 1, 21, 9, 0, 0, 0, time.UTC),
+		},
+		{
+			name:      "to overrides days",
+			opts:      listOptions{from: "2024-01-20 09:00", to: "2024-01-20 17:00", days: 7},
+			wantStart: time.Date(2024, 1, 20, 9, 0, 0, 0, time.UTC),
+			wantEnd:   time.Date(2024, 1, 20, 17, 0, 0, 0, time.UTC),
+		},
+		{name: "invalid from", opts: listOptions{from: "not a date", days: 7}, wantErr: true},
+		{name: "invalid to", opts: listOptions{to: "not a date"}, wantErr: true},
+		{name: "zero days", opts: listOptions{days: 0}, wantErr: true},
+		{name: "negative max", opts: listOptions{days: 7, maxResults: -1}, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			start, end, err := listRange(&tt.opts, "UTC", now)
+			if tt.wantErr {
+				if err == nil {
+					t.Fatal("expected error, got nil")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if !start.Equal(tt.wantStart) || !end.Equal(tt.wantEnd) {
+				t.Errorf("listRange() = %v, %v; want %v, %v", start, end, tt.wantStart, tt.wantEnd)
+			}
+		})
+	}
+}
+
+func TestFormatEventLine(t *testing.T) {
+	loc := time.FixedZone("UTC+2", 2*60*60)
+
+	tests := []struct {
+		name  string
+		event *calendar.EventResult
+		want  string
+	}{
+		{
+			name: "timed event in display zone",
+			event: &calendar.EventResult{
+				Title:     "Standup",
+				StartTime: time.Date(2024, 1, 15, 9, 0, 0, 0, time.UTC),
+				EndTime:   time.Date(2024, 1, 15, 9, 15, 0, 0, time.UTC),
+			},
+			want: "Mon Jan 15  11:00-11:15  Standup",
+		},
+		{
+			name: "with location",
+			event: &calendar.EventResult{
+				Title:     "Lunch",
+				Location:  "Cafe",
+				StartTime: time.Date(2024, 1, 15, 10, 0, 0, 0, time.UTC),
+				EndTime:   time.Date(2024, 1, 15, 11, 0, 0, 0, time.UTC),
+			},
+			want: "Mon Jan 15  12:00-13:00  Lunch @ Cafe",
+		},
+		{
+			name: "all day keeps its date",
+			event: &calendar.EventResult{
+				Title:     "Offsite",
+				AllDay:    true,
+				StartTime: time.Date(2024, 1, 15, 0, 0, 0, 0, time.UTC),
+				EndTime:   time.Date(2024, 1, 16, 0, 0, 0, 0, time.UTC),
+			},
+			want: "Mon Jan 15  all day      Offsite",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := formatEventLine(tt.event, loc); got != tt.want {
+				t.Errorf("formatEventLine() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestWriteEventList_Empty(t *testing.T) {
+	var buf bytes.Buffer
+	if err := writeEventList(&buf, nil, time.UTC); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got := buf.String(); got != "No events found.\n" {
+		t.Errorf("output = %q", got)
+	}
+}
+
+func TestWriteEventsJSON_EmptyIsArray(t *testing.T) {
+	var buf bytes.Buffer
+	if err := writeEventsJSON(&buf, nil); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got := strings.TrimSpace(buf.String()); got != "[]" {
+		t.Errorf("output = %q, want []", got)
+	}
+}
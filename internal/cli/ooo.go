@@ -0,0 +1,89 @@
+package cli
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/ezer/calgo/internal/calendar"
+)
+
+// oooOptions holds the flags of the ooo command.
+type oooOptions struct {
+	title   string
+	until   string
+	decline string
+	message string
+}
+
+func newOOOCommand(global *globalOptions) *cobra.Command {
+	opts := &oooOptions{}
+
+	cmd := &cobra.Command{
+		Use:   "ooo <date>",
+		Short: "Mark yourself out of office",
+		Long: `Create an out-of-office event covering whole days, e.g. "calgo ooo tomorrow"
+or "calgo ooo 2024-07-01 --until 2024-07-05". --until is the last day away.
+
+Meetings you're invited to while away are declined with --message; by
+default only new invitations are, use --decline all to also decline the
+ones already accepted. Out of office needs a Google Workspace account.`,
+		Annotations: supportsDryRun,
+		Args:        cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cfg, err := global.loadConfig()
+			if err != nil {
+				return err
+			}
+
+			params, err := buildOOOParams(opts, args[0], cfg.Timezone)
+			if err != nil {
+				return err
+			}
+			return createAndReport(cmd, global, cfg, params)
+		},
+	}
+
+	flags := cmd.Flags()
+	flags.StringVar(&opts.title, "title", "Out of office", "event title")
+	flags.StringVar(&opts.until, "until", "", "last day out of office (default the start date)")
+	flags.StringVar(&opts.decline, "decline", "new", "conflicting invitations to decline: all, new or none")
+	flags.StringVar(&opts.message, "message", "", "message sent with declined invitations")
+
+	return cmd
+}
+
+// buildOOOParams turns the ooo flags and start date into EventParams. Out
+// of office can't be an all-day event, so it runs from midnight on the
+// first day to midnight after the last.
+func buildOOOParams(opts *oooOptions, date, timezone string) (calendar.EventParams, error) {
+	params := calendar.EventParams{
+		Title:          strings.TrimSpace(opts.title),
+		EventType:      calendar.EventTypeOutOfOffice,
+		DeclineMessage: opts.message,
+	}
+
+	first, err := calendar.ParseDate(date, timezone)
+	if err != nil {
+		return params, fmt.Errorf("invalid date: %w", err)
+	}
+	last := first
+	if opts.until != "" {
+		if last, err = calendar.ParseDate(opts.until, timezone); err != nil {
+			return params, fmt.Errorf("invalid --until: %w", err)
+		}
+		if last.Before(first) {
+			return params, fmt.Errorf("--until must not be before the start date")
+		}
+	}
+
+	params.StartTime = first
+	params.Duration = last.AddDate(0, 0, 1).Sub(first)
+
+	if params.AutoDecline, err = calendar.ParseAutoDecline(opts.decline); err != nil {
+		return params, err
+	}
+
+	return params, calendar.ValidateEventParams(params)
+}
@@ -0,0 +1,39 @@
+package cli
+
+import (
+	"testing"
+	"time"
+
+	"github.com/ezer/calgo/internal/calendar"
+)
+
+func TestBuildOOOParams(t *testing.T) {
+	params, err := buildOOOParams(&oooOptions{title: "Out of office", until: "2024-07-05", decline: "all", message: "Back on the 8th"}, "2024-07-01", "Europe/Berlin")
+	if err != nil {
+		t.Fatalf("buildOOOParams() error = %v", err)
+	}
+
+	berlin, _ := time.LoadLocation("Europe/Berlin")
+	if want := time.Date(2024, 7, 1, 0, 0, 0, 0, berlin); !params.StartTime.Equal(want) {
+		t.Errorf("StartTime = %v, want %v", params.StartTime, want)
+	}
+	if params.Duration != 5*24*time.Hour {
+		t.Errorf("Duration = %v, want five days", params.Duration)
+	}
+	if params.EventType != calendar.EventTypeOutOfOffice || params.AutoDecline != calendar.AutoDeclineAll || params.DeclineMessage != "Back on the 8th" {
+		t.Errorf("params = %+v", params)
+	}
+
+	// A single day spanning a DST change is 23 hours long
+	params, err = buildOOOParams(&oooOptions{title: "Out of office", decline: "new"}, "2024-03-31", "Europe/Berlin")
+	if err != nil {
+		t.Fatalf("buildOOOParams() error = %v", err)
+	}
+	if params.Duration != 23*time.Hour {
+		t.Errorf("Duration = %v, want 23h", params.Duration)
+	}
+
+	if _, err := buildOOOParams(&oooOptions{title: "Out of office", until: "2024-06-30", decline: "new"}, "2024-07-01", "UTC"); err == nil {
+		t.Error("expected an error for --until before the start")
+	}
+}
@@ -0,0 +1,181 @@
+package cli
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/ezer/calgo/internal/calendar"
+)
+
+func newQuickCommand(global *globalOptions) *cobra.Command {
+	var (
+		yes        bool
+		localParse bool
+		notify     string
+	)
+
+	cmd := &cobra.Command{
+		Use:   "quick <text>",
+		Short: "Create an event from a sentence",
+		Long: `Create an event from a sentence, e.g.
+calgo quick "Team meeting tomorrow at 2pm for 1 hour".
+
+Google Calendar works out the title and time. The event it created is shown
+and you're asked whether to keep it; if not, it's deleted. With --local-parse
+calgo then parses the sentence itself, the way create reads --start and
+--duration, and offers to create that event instead. Use --yes to keep
+Google's event without asking.
+
+With default_buffer set in the config file, an event that would start less
+than that many minutes after the end of the one before it is moved later to
+leave the gap, so events quick-added back to back don't abut.`,
+		Args: cobra.MinimumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			text := strings.Join(args, " ")
+
+			cfg, err := global.loadConfig()
+			if err != nil {
+				return err
+			}
+
+			sendUpdates := ""
+			if notify != "" {
+				if sendUpdates, err = calendar.ParseSendUpdates(notify); err != nil {
+					return err
+				}
+			}
+
+			ctx, cancel := cfg.CommandContext(cmd.Context())
+			defer cancel()
+
+			client, err := newCalendarClient(ctx, cfg)
+			if err != nil {
+				return err
+			}
+
+			loc, err := cfg.DisplayLocation()
+			if err != nil {
+				return err
+			}
+
+			result, err := client.QuickAdd(ctx, text, sendUpdates)
+			if err != nil {
+				return err
+			}
+			buffer := cfg.DefaultBufferDuration()
+			if !result.AllDay {
+				start, err := bufferedQuickStart(ctx, client, result.StartTime, buffer, result.ID)
+				if err != nil {
+					return err
+				}
+				if !start.Equal(result.StartTime) {
+					if result, err = client.RescheduleEvent(ctx, result.ID, start); err != nil {
+						return err
+					}
+				}
+			}
+
+			out, errOut := cmd.OutOrStdout(), cmd.ErrOrStderr()
+			// Both questions read from the same buffer
+			in := bufio.NewReader(cmd.InOrStdin())
+
+			if !yes {
+				question := fmt.Sprintf("Google created %q. Keep it?", formatEventLine(result, loc))
+				keep, err := confirm(in, errOut, question)
+				if err != nil {
+					return err
+				}
+				if !keep {
+					if err := client.DeleteEvent(ctx, result.ID); err != nil {
+						return err
+					}
+					if !localParse {
+						fmt.Fprintln(errOut, "Deleted. Use create, or --local-parse, to set the time yourself.")
+						return nil
+					}
+
+					params, err := calendar.ParseQuickEvent(text, cfg.Timezone, time.Duration(cfg.DefaultDuration)*time.Minute)
+					if err != nil {
+						return err
+					}
+					params.SendUpdates = sendUpdates
+					if !params.AllDay {
+						if params.StartTime, err = bufferedQuickStart(ctx, client, params.StartTime, buffer, ""); err != nil {
+							return err
+						}
+					}
+
+					ok, err := confirm(in, errOut, localQuickQuestion(params, loc))
+					if err != nil {
+						return err
+					}
+					if !ok {
+						fmt.Fprintln(errOut, "Aborted.")
+						return nil
+					}
+
+					if result, err = client.CreateEvent(ctx, params); err != nil {
+						return err
+					}
+					writeCreateWarnings(errOut, result)
+				}
+			}
+
+			if global.json {
+				return writeJSON(out, result)
+			}
+			return writeCreatedEvent(out, result, loc)
+		},
+	}
+
+	flags := cmd.Flags()
+	flags.BoolVarP(&yes, "yes", "y", false, "keep Google's event without asking")
+	flags.BoolVar(&localParse, "local-parse", false, "if Google's event is rejected, parse the text locally instead")
+	flags.StringVar(&notify, "notify", "", "who gets invitation emails: all, external or none")
+	cmd.MarkFlagsMutuallyExclusive("yes", "local-parse")
+
+	return cmd
+}
+
+// bufferedQuickStart returns start moved to buffer after the end of the
+// event just before it, when it would otherwise start less than buffer
+// after that end. The event with ID excludeID, the one being placed, is
+// ignored; a zero buffer returns start without looking.
+func bufferedQuickStart(ctx context.Context, client *calendar.Client, start time.Time, buffer time.Duration, excludeID string) (time.Time, error) {
+	if buffer <= 0 {
+		return start, nil
+	}
+
+	events, err := client.ListEvents(ctx, calendar.ListOptions{TimeMin: start.Add(-buffer), TimeMax: start})
+	if err != nil {
+		return start, err
+	}
+	var lastEnd time.Time
+	for _, event := range events {
+		if event.ID == excludeID || event.AllDay || event.EndTime.After(start) {
+			continue
+		}
+		if event.EndTime.After(lastEnd) {
+			lastEnd = event.EndTime
+		}
+	}
+	return calendar.NudgeStart(start, lastEnd, buffer), nil
+}
+
+// localQuickQuestion asks whether to create the locally parsed event params.
+func localQuickQuestion(params calendar.EventParams, loc *time.Location) string {
+	preview := &calendar.EventResult{
+		Title:     params.Title,
+		StartTime: params.StartTime,
+		EndTime:   params.StartTime.Add(params.Duration),
+	}
+	if len(params.Recurrence) > 0 {
+		return fmt.Sprintf("Create %q, repeating %s, instead?", formatEventLine(preview, loc), params.Recurrence[0])
+	}
+	return fmt.Sprintf("Create %q instead?", formatEventLine(preview, loc))
+}
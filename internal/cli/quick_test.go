@@ -0,0 +1,107 @@
+package cli
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/ezer/calgo/internal/calendar"
+)
+
+func TestLocalQuickQuestion(t *testing.T) {
+	start := time.Date(2024, 1, 15, 14, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name   string
+		params calendar.EventParams
+		want   string
+	}{
+		{
+			name:   "single event",
+			params: calendar.EventParams{Title: "Team meeting", StartTime: start, Duration: time.Hour},
+			want:   `Create "Mon Jan 15  14:00-15:00  Team meeting" instead?`,
+		},
+		{
+			name: "recurring event",
+			params: calendar.EventParams{
+				Title:      "Standup",
+				StartTime:  start,
+				Duration:   15 * time.Minute,
+				Recurrence: []string{"RRULE:FREQ=WEEKLY;BYDAY=MO,TU,WE,TH,FR"},
+			},
+			want: `Create "Mon Jan 15  14:00-14:15  Standup", repeating RRULE:FREQ=WEEKLY;BYDAY=MO,TU,WE,TH,FR, instead?`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := localQuickQuestion(tt.params, time.UTC); got != tt.want {
+				t.Errorf("localQuickQuestion() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+// redirectTransport sends every request to the server at target instead.
+type redirectTransport struct {
+	target *url.URL
+}
+
+func (t redirectTransport) RoundTrip(r *http.Request) (*http.Response, error) {
+	r = r.Clone(r.Context())
+	r.URL.Scheme, r.URL.Host = t.target.Scheme, t.target.Host
+	return http.DefaultTransport.RoundTrip(r)
+}
+
+func TestBufferedQuickStart(t *testing.T) {
+	// A meeting ends at 14:00; the quick-added event is "new"
+	var lists int
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		lists++
+		w.Header().Set("Content-Type", "application/json")
+		fmt.Fprint(w, `{"items":[
+			{"id":"meeting","summary":"Meeting","start":{"dateTime":"2024-01-15T13:00:00Z"},"end":{"dateTime":"2024-01-15T14:00:00Z"}},
+			{"id":"new","summary":"New","start":{"dateTime":"2024-01-15T14:00:00Z"},"end":{"dateTime":"2024-01-15T14:30:00Z"}}
+		]}`)
+	}))
+	t.Cleanup(server.Close)
+	target, _ := url.Parse(server.URL)
+	client, err := calendar.NewClient(context.Background(), &http.Client{Transport: redirectTransport{target}}, "primary")
+	if err != nil {
+		t.Fatalf("NewClient() error = %v", err)
+	}
+
+	at := func(hour, minute int) time.Time {
+		return time.Date(2024, 1, 15, hour, minute, 0, 0, time.UTC)
+	}
+	tests := []struct {
+		name   string
+		start  time.Time
+		buffer time.Duration
+		want   time.Time
+	}{
+		{"back to back is nudged", at(14, 0), 5 * time.Minute, at(14, 5)},
+		{"within the buffer is nudged", at(14, 2), 5 * time.Minute, at(14, 5)},
+		{"past the buffer is kept", at(14, 10), 5 * time.Minute, at(14, 10)},
+		{"no buffer", at(14, 0), 0, at(14, 0)},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client.InvalidateCache()
+			got, err := bufferedQuickStart(context.Background(), client, tt.start, tt.buffer, "new")
+			if err != nil {
+				t.Fatalf("bufferedQuickStart() error = %v", err)
+			}
+			if !got.Equal(tt.want) {
+				t.Errorf("bufferedQuickStart() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+	if lists != 3 {
+		t.Errorf("listed events %d times, want 3 (none without a buffer)", lists)
+	}
+}
@@ -0,0 +1,217 @@
+// Package cli defines the calgo command tree.
+package cli
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"os"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/ezer/calgo/internal/auth"
+	"github.com/ezer/calgo/internal/calendar"
+	"github.com/ezer/calgo/internal/config"
+)
+
+// globalOptions holds the flags shared by every command.
+type globalOptions struct {
+	configPath string
+	calendarID string
+	profile    string
+	json       bool
+	dryRun     bool
+}
+
+// NewRootCommand returns the calgo root command with all subcommands
+// attached.
+func NewRootCommand(version string) *cobra.Command {
+	root, _ := newRootCommand(version)
+	return root
+}
+
+// newRootCommand is NewRootCommand, also returning the global options the
+// flags are parsed into.
+func newRootCommand(version string) (*cobra.Command, *globalOptions) {
+	opts := &globalOptions{}
+
+	root := &cobra.Command{
+		Use:           "calgo",
+		Short:         "calgo - Google Calendar CLI tool",
+		Version:       version,
+		SilenceUsage:  true,
+		SilenceErrors: true,
+		PersistentPreRunE: func(cmd *cobra.Command, args []string) error {
+			return checkDryRun(cmd, opts)
+		},
+	}
+	root.SetVersionTemplate("calgo version {{.Version}}\n")
+	root.Flags().BoolP("version", "v", false, "show version")
+
+	flags := root.PersistentFlags()
+	flags.StringVar(&opts.configPath, "config", "", "path to config file (default ~/.config/calgo/config.yaml)")
+	flags.StringVar(&opts.calendarID, "calendar", "", "calendar name or ID to use (overrides config)")
+	flags.StringVar(&opts.profile, "profile", "", "account profile from the config file to use (or CALGO_PROFILE)")
+	flags.Var(outputFormat{json: &opts.json}, "output", "output format: text or json")
+	flags.BoolVar(&opts.json, "json", false, "output JSON for scripting, the same as --output json")
+	flags.BoolVar(&opts.dryRun, "dry-run", false, "print the API request for new events instead of creating them")
+
+	root.AddCommand(
+		newCreateCommand(opts),
+		newQuickCommand(opts),
+		newBlockCommand(opts),
+		newOOOCommand(opts),
+		newImportCommand(opts),
+		newExportCommand(opts),
+		newCalendarsCommand(opts),
+		newColorsCommand(opts),
+		newListCommand(opts),
+		newTodayCommand(opts),
+		newWeekCommand(opts),
+		newSearchCommand(opts),
+		newSyncCommand(opts),
+		newBusyCommand(opts),
+		newFreeCommand(opts),
+		newDeleteCommand(opts),
+		newEditCommand(opts),
+		newRSVPCommand(opts),
+		newAuthCommand(opts),
+	)
+
+	return root, opts
+}
+
+// Execute runs the root command and returns the process exit code. With
+// --output json errors are written as JSON too, with a code from
+// errorCode.
+func Execute(version string) int {
+	root, opts := newRootCommand(version)
+	if err := root.Execute(); err != nil {
+		writeError(os.Stderr, err, opts.json)
+		return 1
+	}
+	return 0
+}
+
+// outputFormat is the --output flag. It sets the same option as --json.
+type outputFormat struct {
+	json *bool
+}
+
+func (f outputFormat) String() string {
+	if f.json != nil && *f.json {
+		return "json"
+	}
+	return "text"
+}
+
+func (f outputFormat) Set(value string) error {
+	switch strings.ToLower(value) {
+	case "text":
+		*f.json = false
+	case "json":
+		*f.json = true
+	default:
+		return fmt.Errorf("unknown output format %q (use text or json)", value)
+	}
+	return nil
+}
+
+func (f outputFormat) Type() string {
+	return "format"
+}
+
+// loadConfig loads and validates the configuration, applying flag overrides.
+func (o *globalOptions) loadConfig() (*config.Config, error) {
+	cfg, err := config.Load(o.configPath, map[string]interface{}{
+		"calendar_id": o.calendarID,
+		"profile":     o.profile,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if err := cfg.Validate(); err != nil {
+		return nil, err
+	}
+	return cfg, nil
+}
+
+// newCalendarClient authenticates and returns a client for the configured
+// calendar. The browser flow runs if no usable token is saved, unless
+// Application Default Credentials are configured. A calendar given by
+// display name is looked up, and cfg.CalendarID replaced with its ID.
+func newCalendarClient(ctx context.Context, cfg *config.Config) (*calendar.Client, error) {
+	return openCalendarClient(ctx, cfg, false)
+}
+
+// newCalendarManagerClient is newCalendarClient for creating, renaming and
+// deleting calendars, which needs the full calendar scope. A saved token
+// without it fails with auth.ErrInsufficientScope.
+func newCalendarManagerClient(ctx context.Context, cfg *config.Config) (*calendar.Client, error) {
+	return openCalendarClient(ctx, cfg, true)
+}
+
+// openCalendarClient implements newCalendarClient and
+// newCalendarManagerClient.
+func openCalendarClient(ctx context.Context, cfg *config.Config, manageCalendars bool) (*calendar.Client, error) {
+	httpClient, err := newHTTPClient(ctx, cfg, manageCalendars)
+	if err != nil {
+		return nil, err
+	}
+
+	client, err := calendar.NewClient(ctx, httpClient, cfg.CalendarID)
+	if err != nil {
+		return nil, err
+	}
+
+	calendarID, err := client.ResolveCalendarID(ctx, cfg.CalendarID)
+	if err != nil {
+		return nil, err
+	}
+	if calendarID != cfg.CalendarID {
+		client.UseCalendar(calendarID)
+		cfg.CalendarID = calendarID
+	}
+
+	client.DefaultAddConference = cfg.DefaultAddConference
+	client.DefaultDescriptionPrefix = cfg.DefaultDescriptionPrefix
+	client.DefaultDescriptionSuffix = cfg.DefaultDescriptionSuffix
+	client.RequestTimeout = cfg.CommandTimeout()
+	client.Retry = cfg.RetryPolicy()
+	if client.WorkingHours, err = cfg.WorkingHours(); err != nil {
+		return nil, err
+	}
+	return client, nil
+}
+
+// newHTTPClient returns an HTTP client authorized as cfg.AuthMode says,
+// with the full calendar scope when manageCalendars is set.
+func newHTTPClient(ctx context.Context, cfg *config.Config, manageCalendars bool) (*http.Client, error) {
+	if cfg.AuthMode == config.AuthModeADC {
+		if manageCalendars {
+			return auth.DefaultClient(ctx, auth.ManageCalendarsScope)
+		}
+		return auth.DefaultClient(ctx)
+	}
+
+	authenticator, err := newAuthenticator(cfg)
+	if err != nil {
+		return nil, err
+	}
+	authenticator.ManageCalendars = manageCalendars
+	return authenticator.GetClient(ctx)
+}
+
+// newAuthenticator returns an authenticator for the configured credentials
+// and token file, with the credentials loaded.
+func newAuthenticator(cfg *config.Config) (*auth.Authenticator, error) {
+	authenticator := auth.NewAuthenticator(cfg.CredentialsPath, cfg.TokenPath)
+	authenticator.TokenEncryption = cfg.TokenEncryption
+	authenticator.TokenPassphrase = cfg.TokenPassphrase
+	if err := authenticator.LoadCredentials(); err != nil {
+		return nil, err
+	}
+	return authenticator, nil
+}
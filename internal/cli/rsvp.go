@@ -0,0 +1,79 @@
+package cli
+
+import (
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/ezer/calgo/internal/calendar"
+)
+
+func newRSVPCommand(global *globalOptions) *cobra.Command {
+	var comment string
+
+	cmd := &cobra.Command{
+		Use:   "rsvp <event-id> accept|decline|tentative",
+		Short: "Respond to an invitation",
+		Long: `Accept, decline or tentatively accept an event you're invited to.
+
+Use --comment to add a note to your response, such as why you can't make it;
+the organizer sees it next to your reply.`,
+		Args: cobra.ExactArgs(2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			response, err := calendar.ParseResponse(args[1])
+			if err != nil {
+				return err
+			}
+
+			cfg, err := global.loadConfig()
+			if err != nil {
+				return err
+			}
+
+			ctx, cancel := cfg.CommandContext(cmd.Context())
+			defer cancel()
+
+			client, err := newCalendarClient(ctx, cfg)
+			if err != nil {
+				return err
+			}
+
+			event, err := client.RespondToEvent(ctx, args[0], response, comment)
+			if err != nil {
+				return err
+			}
+
+			if global.json {
+				return writeJSON(cmd.OutOrStdout(), map[string]any{
+					"id":       event.ID,
+					"response": response,
+					"comment":  comment,
+				})
+			}
+
+			loc, err := cfg.DisplayLocation()
+			if err != nil {
+				return err
+			}
+			return writeRSVP(cmd.OutOrStdout(), event, response, loc)
+		},
+	}
+
+	cmd.Flags().StringVarP(&comment, "comment", "m", "", "note to the organizer")
+
+	return cmd
+}
+
+// writeRSVP reports a response to an event.
+func writeRSVP(w io.Writer, event *calendar.EventResult, response string, loc *time.Location) error {
+	verb := map[string]string{
+		calendar.ResponseAccepted:  "Accepted",
+		calendar.ResponseDeclined:  "Declined",
+		calendar.ResponseTentative: "Tentatively accepted",
+	}[response]
+
+	_, err := fmt.Fprintf(w, "%s %s\n", verb, formatEventLine(event, loc))
+	return err
+}
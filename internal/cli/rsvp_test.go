@@ -0,0 +1,39 @@
+package cli
+
+import (
+	"bytes"
+	"testing"
+	"time"
+
+	"github.com/ezer/calgo/internal/calendar"
+)
+
+func TestWriteRSVP(t *testing.T) {
+	event := &calendar.EventResult{
+		ID:        "abc123",
+		Title:     "Standup",
+		StartTime: time.Date(2024, 1, 15, 9, 0, 0, 0, time.UTC),
+		EndTime:   time.Date(2024, 1, 15, 9, 15, 0, 0, time.UTC),
+	}
+
+	tests := []struct {
+		response string
+		want     string
+	}{
+		{calendar.ResponseAccepted, "Accepted Mon Jan 15  09:00-09:15  Standup\n"},
+		{calendar.ResponseDeclined, "Declined Mon Jan 15  09:00-09:15  Standup\n"},
+		{calendar.ResponseTentative, "Tentatively accepted Mon Jan 15  09:00-09:15  Standup\n"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.response, func(t *testing.T) {
+			var buf bytes.Buffer
+			if err := writeRSVP(&buf, event, tt.response, time.UTC); err != nil {
+				t.Fatalf("writeRSVP() error = %v", err)
+			}
+			if got := buf.String(); got != tt.want {
+				t.Errorf("writeRSVP() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
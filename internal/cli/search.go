@@ -0,0 +1,113 @@
+package cli
+
+import (
+	"fmt"
+	"io"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/ezer/calgo/internal/calendar"
+)
+
+// searchOptions holds the flags of the search command.
+type searchOptions struct {
+	from       string
+	to         string
+	maxResults int
+}
+
+func newSearchCommand(global *globalOptions) *cobra.Command {
+	opts := &searchOptions{}
+
+	cmd := &cobra.Command{
+		Use:   "search <query>",
+		Short: "Search events by text",
+		Long: `Search events between the start of --from and the start of --to.
+
+Plain words are matched by Google against titles, descriptions, locations
+and guests. Narrow the results with title:, location: (or loc:) and
+attendee: (or with:) terms, quoting values with spaces:
+
+  calgo search dentist
+  calgo search 'title:"design review" with:alice'
+
+Results include the event ID, for use with edit and delete.`,
+		Args: cobra.MinimumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			query, err := calendar.ParseEventQuery(strings.Join(args, " "))
+			if err != nil {
+				return err
+			}
+
+			cfg, err := global.loadConfig()
+			if err != nil {
+				return err
+			}
+
+			timeMin, timeMax, err := searchRange(opts, cfg.Timezone)
+			if err != nil {
+				return err
+			}
+
+			ctx, cancel := cfg.CommandContext(cmd.Context())
+			defer cancel()
+
+			client, err := newCalendarClient(ctx, cfg)
+			if err != nil {
+				return err
+			}
+
+			events, err := client.SearchEvents(ctx, query, calendar.ListOptions{
+				TimeMin:    timeMin,
+				TimeMax:    timeMax,
+				MaxResults: opts.maxResults,
+			})
+			if err != nil {
+				return err
+			}
+
+			if global.json {
+				return writeEventsJSON(cmd.OutOrStdout(), events)
+			}
+
+			loc, err := cfg.DisplayLocation()
+			if err != nil {
+				return err
+			}
+			return writeSearchResults(cmd.OutOrStdout(), events, loc)
+		},
+	}
+
+	flags := cmd.Flags()
+	flags.StringVar(&opts.from, "from", "today", "first day to search")
+	flags.StringVar(&opts.to, "to", "+90d", "day to stop at, not included")
+	flags.IntVar(&opts.maxResults, "max", 25, "maximum number of results (0 = no limit)")
+
+	return cmd
+}
+
+// searchRange resolves --from and --to to the start of their days.
+func searchRange(opts *searchOptions, timezone string) (time.Time, time.Time, error) {
+	if opts.maxResults < 0 {
+		return time.Time{}, time.Time{}, fmt.Errorf("--max must not be negative")
+	}
+	return exportRange(&exportOptions{from: opts.from, to: opts.to}, timezone)
+}
+
+// writeSearchResults writes a table of matching events with their IDs.
+func writeSearchResults(w io.Writer, events []*calendar.EventResult, loc *time.Location) error {
+	if len(events) == 0 {
+		_, err := fmt.Fprintln(w, "No matching events.")
+		return err
+	}
+
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(tw, "ID\tEVENT")
+	for _, event := range events {
+		fmt.Fprintf(tw, "%s\t%s\n", event.ID, formatEventLine(event, loc))
+	}
+	return tw.Flush()
+}
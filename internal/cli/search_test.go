@@ -0,0 +1,63 @@
+package cli
+
+import (
+	"bytes"
+	"testing"
+	"time"
+
+	"github.com/ezer/calgo/internal/calendar"
+)
+
+func TestSearchRange(t *testing.T) {
+	from, to, err := searchRange(&searchOptions{from: "2024-01-01", to: "2024-04-01", maxResults: 10}, "UTC")
+	if err != nil {
+		t.Fatalf("searchRange() error = %v", err)
+	}
+	if want := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC); !from.Equal(want) {
+		t.Errorf("from = %v, want %v", from, want)
+	}
+	if want := time.Date(2024, 4, 1, 0, 0, 0, 0, time.UTC); !to.Equal(want) {
+		t.Errorf("to = %v, want %v", to, want)
+	}
+
+	if _, _, err := searchRange(&searchOptions{from: "today", to: "+90d", maxResults: -1}, "UTC"); err == nil {
+		t.Error("expected error for negative --max, got nil")
+	}
+}
+
+func TestWriteSearchResults(t *testing.T) {
+	events := []*calendar.EventResult{
+		{
+			ID:        "abc123",
+			Title:     "Dentist",
+			Location:  "Clinic",
+			StartTime: time.Date(2024, 1, 15, 9, 0, 0, 0, time.UTC),
+			EndTime:   time.Date(2024, 1, 15, 10, 0, 0, 0, time.UTC),
+		},
+		{
+			ID:        "def456789",
+			Title:     "Dentist follow-up",
+			StartTime: time.Date(2024, 2, 1, 14, 0, 0, 0, time.UTC),
+			EndTime:   time.Date(2024, 2, 1, 14, 30, 0, 0, time.UTC),
+		},
+	}
+
+	var buf bytes.Buffer
+	if err := writeSearchResults(&buf, events, time.UTC); err != nil {
+		t.Fatalf("writeSearchResults() error = %v", err)
+	}
+	want := "ID         EVENT\n" +
+		"abc123     Mon Jan 15  09:00-10:00  Dentist @ Clinic\n" +
+		"def456789  Thu Feb  1  14:00-14:30  Dentist follow-up\n"
+	if got := buf.String(); got != want {
+		t.Errorf("writeSearchResults() =\n%q\nwant\n%q", got, want)
+	}
+
+	buf.Reset()
+	if err := writeSearchResults(&buf, nil, time.UTC); err != nil {
+		t.Fatalf("writeSearchResults() error = %v", err)
+	}
+	if got := buf.String(); got != "No matching events.\n" {
+		t.Errorf("empty output = %q", got)
+	}
+}
@@ -0,0 +1,121 @@
+package cli
+
+import (
+	"fmt"
+	"io"
+	"path/filepath"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/ezer/calgo/internal/calendar"
+	"github.com/ezer/calgo/internal/config"
+)
+
+// syncTokensFile is the name of the file in the config directory holding
+// each calendar's latest sync token.
+const syncTokensFile = "sync_tokens.json"
+
+// syncTokensPath returns the sync token file for profile in dir. Each
+// profile gets its own file, since two accounts can both have a calendar
+// called "primary".
+func syncTokensPath(dir, profile string) string {
+	if profile == "" {
+		return filepath.Join(dir, syncTokensFile)
+	}
+	// Profile names are case-insensitive, like the config keys they select
+	return filepath.Join(dir, "sync_tokens-"+strings.ToLower(profile)+".json")
+}
+
+func newSyncCommand(global *globalOptions) *cobra.Command {
+	var reset bool
+
+	cmd := &cobra.Command{
+		Use:   "sync",
+		Short: "Show what changed in the calendar since the last sync",
+		Long: `Show the events created, updated or deleted since the last sync.
+
+The first sync, or one after --reset, lists every event. Each calendar's sync
+position is kept in ` + syncTokensFile + ` in the config directory
+(sync_tokens-<profile>.json with --profile), so later syncs only fetch what
+changed. Recurring events are shown once, not as their
+individual occurrences.`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cfg, err := global.loadConfig()
+			if err != nil {
+				return err
+			}
+
+			dir, err := config.EnsureConfigDir()
+			if err != nil {
+				return err
+			}
+			store := calendar.NewFileSyncTokenStore(syncTokensPath(dir, cfg.Profile))
+
+			ctx, cancel := cfg.CommandContext(cmd.Context())
+			defer cancel()
+
+			client, err := newCalendarClient(ctx, cfg)
+			if err != nil {
+				return err
+			}
+
+			if reset {
+				if err := store.Save(cfg.CalendarID, ""); err != nil {
+					return err
+				}
+			}
+
+			result, err := client.Sync(ctx, store)
+			if err != nil {
+				return err
+			}
+
+			if global.json {
+				if result.Changed == nil {
+					result.Changed = []*calendar.EventResult{}
+				}
+				if result.Deleted == nil {
+					result.Deleted = []string{}
+				}
+				return writeJSON(cmd.OutOrStdout(), result)
+			}
+
+			loc, err := cfg.DisplayLocation()
+			if err != nil {
+				return err
+			}
+			return writeSyncResult(cmd.OutOrStdout(), result, loc)
+		},
+	}
+
+	cmd.Flags().BoolVar(&reset, "reset", false, "forget the last sync and list every event")
+
+	return cmd
+}
+
+// writeSyncResult writes a count of the changes and a table of the changed
+// events and the IDs of deleted ones.
+func writeSyncResult(w io.Writer, result *calendar.SyncResult, loc *time.Location) error {
+	if result.Full {
+		fmt.Fprintf(w, "Full sync: %d events\n", len(result.Changed))
+	} else {
+		fmt.Fprintf(w, "%d changed, %d deleted\n", len(result.Changed), len(result.Deleted))
+	}
+	if len(result.Changed) == 0 && len(result.Deleted) == 0 {
+		return nil
+	}
+
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(tw, "ID\tEVENT")
+	for _, event := range result.Changed {
+		fmt.Fprintf(tw, "%s\t%s\n", event.ID, formatEventLine(event, loc))
+	}
+	for _, id := range result.Deleted {
+		fmt.Fprintf(tw, "%s\t(deleted)\n", id)
+	}
+	return tw.Flush()
+}
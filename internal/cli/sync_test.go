@@ -0,0 +1,60 @@
+package cli
+
+import (
+	"bytes"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/ezer/calgo/internal/calendar"
+)
+
+func TestWriteSyncResult(t *testing.T) {
+	result := &calendar.SyncResult{
+		Changed: []*calendar.EventResult{{
+			ID:        "abc123",
+			Title:     "Standup",
+			StartTime: time.Date(2024, 1, 15, 9, 0, 0, 0, time.UTC),
+			EndTime:   time.Date(2024, 1, 15, 9, 15, 0, 0, time.UTC),
+		}},
+		Deleted: []string{"gone"},
+	}
+
+	var buf bytes.Buffer
+	if err := writeSyncResult(&buf, result, time.UTC); err != nil {
+		t.Fatalf("writeSyncResult() error = %v", err)
+	}
+	want := "1 changed, 1 deleted\n" +
+		"ID      EVENT\n" +
+		"abc123  Mon Jan 15  09:00-09:15  Standup\n" +
+		"gone    (deleted)\n"
+	if got := buf.String(); got != want {
+		t.Errorf("output =\n%s\nwant\n%s", got, want)
+	}
+
+	buf.Reset()
+	if err := writeSyncResult(&buf, &calendar.SyncResult{Full: true}, time.UTC); err != nil {
+		t.Fatalf("writeSyncResult() error = %v", err)
+	}
+	if got := buf.String(); got != "Full sync: 0 events\n" {
+		t.Errorf("empty full sync output = %q", got)
+	}
+}
+
+func TestSyncTokensPath(t *testing.T) {
+	dir := filepath.Join("home", ".config", "calgo")
+	tests := []struct {
+		profile string
+		want    string
+	}{
+		{"", filepath.Join(dir, "sync_tokens.json")},
+		{"work", filepath.Join(dir, "sync_tokens-work.json")},
+		{"Work", filepath.Join(dir, "sync_tokens-work.json")},
+		{"personal", filepath.Join(dir, "sync_tokens-personal.json")},
+	}
+	for _, tt := range tests {
+		if got := syncTokensPath(dir, tt.profile); got != tt.want {
+			t.Errorf("syncTokensPath(%q) = %q, want %q", tt.profile, got, tt.want)
+		}
+	}
+}
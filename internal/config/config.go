@@ -1,32 +1,120 @@
 package config
 
 import (
+	"context"
+	"encoding/json"
 	"errors"
 	"fmt"
 	"os"
 	"path/filepath"
+	"strings"
+	"time"
 
 	"github.com/spf13/viper"
+
+	"github.com/ezer/calgo/internal/auth"
+	"github.com/ezer/calgo/internal/calendar"
 )
 
 // Config holds all configuration values for the application.
 type Config struct {
 	// CredentialsPath is the path to the OAuth2 credentials JSON file.
-	CredentialsPath string `mapstructure:"credentials_path"`
+	CredentialsPath string `mapstructure:"credentials_path" json:"credentials_path"`
 
 	// TokenPath is the path where the OAuth2 token will be stored.
-	TokenPath string `mapstructure:"token_path"`
+	TokenPath string `mapstructure:"token_path" json:"token_path"`
+
+	// AuthMode selects how calgo authenticates: "oauth" (the default when
+	// empty) uses CredentialsPath and a token saved at TokenPath; "adc"
+	// uses Application Default Credentials, e.g. from gcloud, and needs
+	// neither.
+	AuthMode string `mapstructure:"auth_mode" json:"auth_mode"`
+
+	// TokenEncryption encrypts the token file: "passphrase" keys it with
+	// TokenPassphrase, "machine" with a secret derived from this machine
+	// and user. Empty stores it as plain JSON. A token saved in another
+	// mode is converted the next time it is loaded.
+	TokenEncryption string `mapstructure:"token_encryption" json:"token_encryption"`
+
+	// TokenPassphrase is the passphrase for TokenEncryption "passphrase",
+	// usually given as CALGO_TOKEN_PASSPHRASE. It is never printed.
+	TokenPassphrase string `mapstructure:"token_passphrase" json:"-"`
 
 	// CalendarID is the target calendar ID (defaults to "primary").
-	CalendarID string `mapstructure:"calendar_id"`
+	CalendarID string `mapstructure:"calendar_id" json:"calendar_id"`
 
 	// DefaultDuration is the default event duration in minutes.
-	DefaultDuration int `mapstructure:"default_duration"`
+	DefaultDuration int `mapstructure:"default_duration" json:"default_duration"`
 
 	// Timezone is the default timezone for events.
-	Timezone string `mapstructure:"timezone"`
+	Timezone string `mapstructure:"timezone" json:"timezone"`
+
+	// DisplayTimezone is the timezone used when showing times, e.g. to view
+	// an agenda in a colleague's zone. Falls back to Timezone.
+	DisplayTimezone string `mapstructure:"display_timezone" json:"display_timezone"`
+
+	// DefaultAddConference requests a Google Meet link for every new event
+	// unless the event explicitly opts out.
+	DefaultAddConference bool `mapstructure:"default_add_conference" json:"default_add_conference"`
+
+	// DefaultDescriptionPrefix and DefaultDescriptionSuffix are added as a
+	// header and footer to every new event's description, e.g. a
+	// "Created via calgo" compliance note.
+	DefaultDescriptionPrefix string `mapstructure:"default_description_prefix" json:"default_description_prefix"`
+	DefaultDescriptionSuffix string `mapstructure:"default_description_suffix" json:"default_description_suffix"`
+
+	// CommandTimeoutSeconds bounds how long each command's operations may
+	// take. Zero means no timeout.
+	CommandTimeoutSeconds int `mapstructure:"command_timeout_seconds" json:"command_timeout_seconds"`
+
+	// RetryMaxAttempts is how many times an API call that hits a rate limit
+	// or server error is attempted in total; 1 turns retries off.
+	// RetryBaseDelayMS is the wait in milliseconds before the first retry,
+	// doubling after each. Zero keeps the defaults of 3 and 500.
+	RetryMaxAttempts int `mapstructure:"retry_max_attempts" json:"retry_max_attempts"`
+	RetryBaseDelayMS int `mapstructure:"retry_base_delay_ms" json:"retry_base_delay_ms"`
+
+	// WeekStart is the first day of the week for weekly summaries, e.g.
+	// "monday" or "sunday". Empty means Monday.
+	WeekStart string `mapstructure:"week_start" json:"week_start"`
+
+	// InternalDomains lists the email domains of the user's organization,
+	// e.g. to tell internal from external meetings. Subdomains count as
+	// internal too. Domains are lowercased when loaded.
+	InternalDomains []string `mapstructure:"internal_domains" json:"internal_domains"`
+
+	// DefaultBuffer is the gap in minutes kept between back-to-back events
+	// created in a row by the quick flow. Zero lets them abut.
+	DefaultBuffer int `mapstructure:"default_buffer" json:"default_buffer"`
+
+	// WarnOnConflict makes create warn when a new event overlaps existing
+	// busy events. The event is still created; use --check-conflicts to
+	// abort instead.
+	WarnOnConflict bool `mapstructure:"warn_on_conflict" json:"warn_on_conflict"`
+
+	// WorkingHoursStart and WorkingHoursEnd bound the part of each day
+	// searched for free slots, as "HH:MM". Empty means 09:00 and 17:00.
+	WorkingHoursStart string `mapstructure:"working_hours_start" json:"working_hours_start"`
+	WorkingHoursEnd   string `mapstructure:"working_hours_end" json:"working_hours_end"`
+
+	// Templates holds reusable event templates keyed by name.
+	Templates map[string]EventTemplate `mapstructure:"templates" json:"templates"`
+
+	// Profile is the name of the profile in use, if any. Its settings have
+	// already replaced the top-level ones.
+	Profile string `mapstructure:"profile" json:"profile"`
+
+	// Profiles holds per-account settings keyed by profile name, e.g.
+	// "work" and "personal".
+	Profiles map[string]Profile `mapstructure:"profiles" json:"profiles"`
 }
 
+// Authentication modes for Config.AuthMode.
+const (
+	AuthModeOAuth = "oauth"
+	AuthModeADC   = "adc"
+)
+
 // DefaultConfig returns a Config with default values.
 func DefaultConfig() *Config {
 	return &Config{
@@ -40,13 +128,21 @@ var (
 	ErrMissingCredentialsPath = errors.New("missing required configuration: credentials path (set GOOGLE_CALENDAR_CREDENTIALS or credentials_path in config)")
 	ErrMissingTokenPath       = errors.New("missing required configuration: token path (set GOOGLE_CALENDAR_TOKEN or token_path in config)")
 	ErrCredentialsNotFound    = errors.New("credentials file not found")
+	ErrInvalidTimezone        = errors.New("invalid timezone")
+	ErrInvalidWeekStart       = errors.New("invalid week start")
+	ErrInvalidDomain          = errors.New("invalid internal domain")
+	ErrInvalidWorkingHours    = errors.New("invalid working hours")
+	ErrInvalidRetry           = errors.New("invalid retry setting")
+	ErrInvalidTokenEncryption = errors.New("invalid token encryption")
+	ErrInvalidAuthMode        = errors.New("invalid auth mode")
 )
 
 // Load loads configuration from all sources with the following priority:
 // 1. CLI flags (passed via flagOverrides)
-// 2. Environment variables
-// 3. Configuration file (~/.config/calgo/config.yaml)
-// 4. Default values
+// 2. The selected profile (the "profile" flag override or CALGO_PROFILE)
+// 3. Environment variables
+// 4. Configuration file (~/.config/calgo/config.yaml)
+// 5. Default values
 func Load(configPath string, flagOverrides map[string]interface{}) (*Config, error) {
 	v := viper.New()
 
@@ -80,6 +176,38 @@ func Load(configPath string, flagOverrides map[string]interface{}) (*Config, err
 		}
 	}
 
+	return finishLoad(v, flagOverrides)
+}
+
+// LoadMerged loads configuration from several config files, applied in order
+// so that later files override values set by earlier ones (e.g. a shared org
+// config followed by a personal override). Environment variables and flag
+// overrides are applied on top with the same priority as Load. Missing files
+// are skipped.
+func LoadMerged(paths []string, flagOverrides map[string]interface{}) (*Config, error) {
+	v := viper.New()
+
+	// Set defaults
+	v.SetDefault("calendar_id", "primary")
+	v.SetDefault("default_duration", 30)
+
+	for _, path := range paths {
+		if _, err := os.Stat(path); os.IsNotExist(err) {
+			continue
+		}
+
+		v.SetConfigFile(path)
+		if err := v.MergeInConfig(); err != nil {
+			return nil, fmt.Errorf("failed to merge config file %s: %w", path, err)
+		}
+	}
+
+	return finishLoad(v, flagOverrides)
+}
+
+// finishLoad binds environment variables, applies flag overrides, and
+// unmarshals the result into a Config.
+func finishLoad(v *viper.Viper, flagOverrides map[string]interface{}) (*Config, error) {
 	// Bind environment variables
 	v.SetEnvPrefix("")
 	v.AutomaticEnv()
@@ -89,6 +217,17 @@ func Load(configPath string, flagOverrides map[string]interface{}) (*Config, err
 	v.BindEnv("token_path", "GOOGLE_CALENDAR_TOKEN")
 	v.BindEnv("calendar_id", "GOOGLE_CALENDAR_ID")
 	v.BindEnv("timezone", "TZ")
+	v.BindEnv("token_passphrase", "CALGO_TOKEN_PASSPHRASE")
+	v.BindEnv("profile", "CALGO_PROFILE")
+
+	// A profile's settings take the place of the top-level ones
+	profile := v.GetString("profile")
+	if name, ok := flagOverrides["profile"].(string); ok && name != "" {
+		profile = name
+	}
+	if err := applyProfile(v, profile); err != nil {
+		return nil, err
+	}
 
 	// Apply flag overrides (highest priority)
 	for key, value := range flagOverrides {
@@ -103,22 +242,232 @@ func Load(configPath string, flagOverrides map[string]interface{}) (*Config, err
 		return nil, fmt.Errorf("failed to unmarshal config: %w", err)
 	}
 
+	for i, domain := range cfg.InternalDomains {
+		cfg.InternalDomains[i] = normalizeDomain(domain)
+	}
+
 	return cfg, nil
 }
 
 // Validate checks that all required configuration values are present.
 func (c *Config) Validate() error {
-	if c.CredentialsPath == "" {
-		return ErrMissingCredentialsPath
+	switch c.AuthMode {
+	case "", AuthModeOAuth:
+		if c.CredentialsPath == "" {
+			return ErrMissingCredentialsPath
+		}
+
+		if c.TokenPath == "" {
+			return ErrMissingTokenPath
+		}
+	case AuthModeADC:
+		// Application Default Credentials need no files of ours
+	default:
+		return fmt.Errorf("%w: %q (use oauth or adc)", ErrInvalidAuthMode, c.AuthMode)
 	}
 
-	if c.TokenPath == "" {
-		return ErrMissingTokenPath
+	for _, tz := range []string{c.Timezone, c.DisplayTimezone} {
+		if tz == "" {
+			continue
+		}
+		if _, err := time.LoadLocation(tz); err != nil {
+			return fmt.Errorf("%w: %s", ErrInvalidTimezone, tz)
+		}
+	}
+
+	if _, err := c.WeekStartDay(); err != nil {
+		return err
+	}
+
+	for _, domain := range c.InternalDomains {
+		if normalizeDomain(domain) == "" {
+			return fmt.Errorf("%w: internal_domains entries must not be empty", ErrInvalidDomain)
+		}
+	}
+
+	if _, err := c.WorkingHours(); err != nil {
+		return err
+	}
+
+	if c.RetryMaxAttempts < 0 || c.RetryBaseDelayMS < 0 {
+		return fmt.Errorf("%w: retry_max_attempts and retry_base_delay_ms must not be negative", ErrInvalidRetry)
+	}
+
+	switch c.TokenEncryption {
+	case "", auth.EncryptionMachine:
+	case auth.EncryptionPassphrase:
+		if c.TokenPassphrase == "" {
+			return fmt.Errorf("%w: passphrase encryption needs CALGO_TOKEN_PASSPHRASE", ErrInvalidTokenEncryption)
+		}
+	default:
+		return fmt.Errorf("%w: %q (use passphrase or machine)", ErrInvalidTokenEncryption, c.TokenEncryption)
 	}
 
 	return nil
 }
 
+// IsInternal reports whether email belongs to one of InternalDomains or a
+// subdomain of one. Comparison ignores case.
+func (c *Config) IsInternal(email string) bool {
+	at := strings.LastIndex(email, "@")
+	if at < 0 {
+		return false
+	}
+	domain := normalizeDomain(email[at+1:])
+	if domain == "" {
+		return false
+	}
+
+	for _, internal := range c.InternalDomains {
+		internal = normalizeDomain(internal)
+		if internal == "" {
+			continue
+		}
+		if domain == internal || strings.HasSuffix(domain, "."+internal) {
+			return true
+		}
+	}
+	return false
+}
+
+// normalizeDomain lowercases a domain and strips surrounding space and a
+// leading "@", so "@Example.com " and "example.com" are equal.
+func normalizeDomain(domain string) string {
+	return strings.ToLower(strings.TrimPrefix(strings.TrimSpace(domain), "@"))
+}
+
+// WeekStartDay returns WeekStart as a weekday, defaulting to Monday.
+func (c *Config) WeekStartDay() (time.Weekday, error) {
+	if c.WeekStart == "" {
+		return time.Monday, nil
+	}
+	for day := time.Sunday; day <= time.Saturday; day++ {
+		if strings.EqualFold(c.WeekStart, day.String()) {
+			return day, nil
+		}
+	}
+	return 0, fmt.Errorf("%w: %s (use a day name such as monday or sunday)", ErrInvalidWeekStart, c.WeekStart)
+}
+
+// WorkingHours returns WorkingHoursStart and WorkingHoursEnd as offsets from
+// midnight, defaulting each unset bound to calendar.DefaultWorkingHours.
+func (c *Config) WorkingHours() (calendar.WorkingHours, error) {
+	hours := calendar.DefaultWorkingHours()
+
+	for _, bound := range []struct {
+		key   string
+		value string
+		dst   *time.Duration
+	}{
+		{"working_hours_start", c.WorkingHoursStart, &hours.Start},
+		{"working_hours_end", c.WorkingHoursEnd, &hours.End},
+	} {
+		if bound.value == "" {
+			continue
+		}
+		t, err := time.Parse("15:04", strings.TrimSpace(bound.value))
+		if err != nil {
+			return calendar.WorkingHours{}, fmt.Errorf("%w: %s %q (use HH:MM, e.g. 09:00)", ErrInvalidWorkingHours, bound.key, bound.value)
+		}
+		*bound.dst = time.Duration(t.Hour())*time.Hour + time.Duration(t.Minute())*time.Minute
+	}
+
+	if hours.End <= hours.Start {
+		return calendar.WorkingHours{}, fmt.Errorf("%w: working_hours_end must be after working_hours_start", ErrInvalidWorkingHours)
+	}
+	return hours, nil
+}
+
+// RedactedJSON returns the configuration as indented JSON that is safe to
+// share in bug reports. CredentialsPath and TokenPath, also those of
+// profiles, are reduced to their base names, or "<unset>" when empty, so
+// home directories and usernames don't leak; other fields are kept as is.
+func (c *Config) RedactedJSON() ([]byte, error) {
+	redacted := *c
+	redacted.CredentialsPath = redactPath(c.CredentialsPath)
+	redacted.TokenPath = redactPath(c.TokenPath)
+	if c.Profiles != nil {
+		redacted.Profiles = make(map[string]Profile, len(c.Profiles))
+		for name, profile := range c.Profiles {
+			profile.CredentialsPath = redactPath(profile.CredentialsPath)
+			profile.TokenPath = redactPath(profile.TokenPath)
+			redacted.Profiles[name] = profile
+		}
+	}
+
+	data, err := json.MarshalIndent(redacted, "", "  ")
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal config: %w", err)
+	}
+	return data, nil
+}
+
+// redactPath returns the base name of path, or "<unset>" when it is empty.
+func redactPath(path string) string {
+	if path == "" {
+		return "<unset>"
+	}
+	return filepath.Base(path)
+}
+
+// CommandTimeout returns CommandTimeoutSeconds as a duration, or zero for
+// no timeout.
+func (c *Config) CommandTimeout() time.Duration {
+	if c.CommandTimeoutSeconds <= 0 {
+		return 0
+	}
+	return time.Duration(c.CommandTimeoutSeconds) * time.Second
+}
+
+// RetryPolicy returns the API retry policy, filling in the defaults for
+// unset settings.
+func (c *Config) RetryPolicy() calendar.RetryPolicy {
+	policy := calendar.DefaultRetryPolicy()
+	if c.RetryMaxAttempts > 0 {
+		policy.MaxAttempts = c.RetryMaxAttempts
+	}
+	if c.RetryBaseDelayMS > 0 {
+		policy.BaseDelay = time.Duration(c.RetryBaseDelayMS) * time.Millisecond
+	}
+	return policy
+}
+
+// DefaultBufferDuration returns DefaultBuffer as a duration, or zero when
+// it is unset or negative.
+func (c *Config) DefaultBufferDuration() time.Duration {
+	if c.DefaultBuffer <= 0 {
+		return 0
+	}
+	return time.Duration(c.DefaultBuffer) * time.Minute
+}
+
+// CommandContext returns a context bounded by CommandTimeout, or a plain
+// cancelable context when no timeout is configured.
+func (c *Config) CommandContext(parent context.Context) (context.Context, context.CancelFunc) {
+	if timeout := c.CommandTimeout(); timeout > 0 {
+		return context.WithTimeout(parent, timeout)
+	}
+	return context.WithCancel(parent)
+}
+
+// DisplayLocation returns the location to show times in: DisplayTimezone if
+// set, then Timezone, then the system local timezone.
+func (c *Config) DisplayLocation() (*time.Location, error) {
+	tz := c.DisplayTimezone
+	if tz == "" {
+		tz = c.Timezone
+	}
+	if tz == "" {
+		return time.Local, nil
+	}
+
+	loc, err := time.LoadLocation(tz)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s", ErrInvalidTimezone, tz)
+	}
+	return loc, nil
+}
+
 // ValidateCredentialsExist checks if the credentials file exists.
 func (c *Config) ValidateCredentialsExist() error {
 	if _, err := os.Stat(c.CredentialsPath); os.IsNotExist(err) {
@@ -1,9 +1,16 @@
 package config
 
 import (
+	"context"
+	"encoding/json"
+	"errors"
 	"os"
 	"path/filepath"
+	"strings"
 	"testing"
+	"time"
+
+	"github.com/ezer/calgo/internal/calendar"
 )
 
 func TestDefaultConfig(t *testing.T) {
@@ -190,6 +197,100 @@ calendar_id: config-calendar-id
 	}
 }
 
+func TestLoadDefaultAddConference(t *testing.T) {
+	tmpDir := t.TempDir()
+	configPath := filepath.Join(tmpDir, "config.yaml")
+
+	if err := os.WriteFile(configPath, []byte("default_add_conference: true\n"), 0644); err != nil {
+		t.Fatalf("Failed to write config file: %v", err)
+	}
+
+	cfg, err := Load(configPath, nil)
+	if err != nil {
+		t.Fatalf("Load failed: %v", err)
+	}
+
+	if !cfg.DefaultAddConference {
+		t.Error("Expected DefaultAddConference to be true")
+	}
+
+	if DefaultConfig().DefaultAddConference {
+		t.Error("Expected DefaultAddConference to be false by default")
+	}
+}
+
+func TestLoadMerged_LaterFilesOverride(t *testing.T) {
+	tmpDir := t.TempDir()
+	basePath := filepath.Join(tmpDir, "base.yaml")
+	overridePath := filepath.Join(tmpDir, "override.yaml")
+
+	baseContent := `
+credentials_path: /org/credentials.json
+token_path: /org/token.json
+calendar_id: org-calendar-id
+default_duration: 45
+`
+	if err := os.WriteFile(basePath, []byte(baseContent), 0644); err != nil {
+		t.Fatalf("Failed to write base config file: %v", err)
+	}
+
+	overrideContent := `
+calendar_id: personal-calendar-id
+`
+	if err := os.WriteFile(overridePath, []byte(overrideContent), 0644); err != nil {
+		t.Fatalf("Failed to write override config file: %v", err)
+	}
+
+	// Clear environment variables to ensure config files are used
+	os.Unsetenv("GOOGLE_CALENDAR_CREDENTIALS")
+	os.Unsetenv("GOOGLE_CALENDAR_TOKEN")
+	os.Unsetenv("GOOGLE_CALENDAR_ID")
+
+	cfg, err := LoadMerged([]string{basePath, overridePath}, nil)
+	if err != nil {
+		t.Fatalf("LoadMerged failed: %v", err)
+	}
+
+	// Override file should win for calendar_id
+	if cfg.CalendarID != "personal-calendar-id" {
+		t.Errorf("Expected CalendarID to be 'personal-calendar-id', got '%s'", cfg.CalendarID)
+	}
+
+	// Base file values should be kept where not overridden
+	if cfg.CredentialsPath != "/org/credentials.json" {
+		t.Errorf("Expected CredentialsPath to be '/org/credentials.json', got '%s'", cfg.CredentialsPath)
+	}
+
+	if cfg.DefaultDuration != 45 {
+		t.Errorf("Expected DefaultDuration to be 45, got %d", cfg.DefaultDuration)
+	}
+}
+
+func TestLoadMerged_FlagsOverrideFiles(t *testing.T) {
+	tmpDir := t.TempDir()
+	basePath := filepath.Join(tmpDir, "base.yaml")
+
+	if err := os.WriteFile(basePath, []byte("calendar_id: org-calendar-id\n"), 0644); err != nil {
+		t.Fatalf("Failed to write base config file: %v", err)
+	}
+
+	os.Unsetenv("GOOGLE_CALENDAR_ID")
+
+	flagOverrides := map[string]interface{}{
+		"calendar_id": "flag-calendar-id",
+	}
+
+	// A missing file in the list should be skipped
+	cfg, err := LoadMerged([]string{basePath, filepath.Join(tmpDir, "missing.yaml")}, flagOverrides)
+	if err != nil {
+		t.Fatalf("LoadMerged failed: %v", err)
+	}
+
+	if cfg.CalendarID != "flag-calendar-id" {
+		t.Errorf("Expected CalendarID to be 'flag-calendar-id' (from flag), got '%s'", cfg.CalendarID)
+	}
+}
+
 func TestValidate_MissingCredentialsPath(t *testing.T) {
 	cfg := &Config{
 		TokenPath:  "/path/to/token.json",
@@ -233,6 +334,67 @@ func TestValidate_Success(t *testing.T) {
 	}
 }
 
+func TestValidate_InvalidDisplayTimezone(t *testing.T) {
+	cfg := &Config{
+		CredentialsPath: "/path/to/credentials.json",
+		TokenPath:       "/path/to/token.json",
+		Timezone:        "America/New_York",
+		DisplayTimezone: "Not/AZone",
+	}
+
+	err := cfg.Validate()
+	if !errors.Is(err, ErrInvalidTimezone) {
+		t.Errorf("Expected ErrInvalidTimezone, got: %v", err)
+	}
+}
+
+func TestDisplayLocation(t *testing.T) {
+	tests := []struct {
+		name            string
+		timezone        string
+		displayTimezone string
+		want            string
+	}{
+		{"display timezone wins", "America/New_York", "Asia/Tokyo", "Asia/Tokyo"},
+		{"falls back to timezone", "America/New_York", "", "America/New_York"},
+		{"falls back to local", "", "", time.Local.String()},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := &Config{Timezone: tt.timezone, DisplayTimezone: tt.displayTimezone}
+
+			loc, err := cfg.DisplayLocation()
+			if err != nil {
+				t.Fatalf("DisplayLocation failed: %v", err)
+			}
+			if loc.String() != tt.want {
+				t.Errorf("Expected location %s, got %s", tt.want, loc)
+			}
+		})
+	}
+}
+
+func TestLoadDisplayTimezone(t *testing.T) {
+	configPath := filepath.Join(t.TempDir(), "config.yaml")
+	configContent := `
+timezone: America/New_York
+display_timezone: Europe/Berlin
+`
+	if err := os.WriteFile(configPath, []byte(configContent), 0644); err != nil {
+		t.Fatalf("Failed to write config file: %v", err)
+	}
+
+	cfg, err := Load(configPath, nil)
+	if err != nil {
+		t.Fatalf("Load failed: %v", err)
+	}
+
+	if cfg.DisplayTimezone != "Europe/Berlin" {
+		t.Errorf("Expected DisplayTimezone 'Europe/Berlin', got '%s'", cfg.DisplayTimezone)
+	}
+}
+
 func TestValidateCredentialsExist_FileNotFound(t *testing.T) {
 	cfg := &Config{
 		CredentialsPath: "/nonexistent/path/credentials.json",
@@ -294,3 +456,345 @@ func TestEnsureConfigDir(t *testing.T) {
 		t.Error("Config path is not a directory")
 	}
 }
+
+func TestLoadCommandTimeout(t *testing.T) {
+	configPath := filepath.Join(t.TempDir(), "config.yaml")
+	if err := os.WriteFile(configPath, []byte("command_timeout_seconds: 15\n"), 0644); err != nil {
+		t.Fatalf("Failed to write config file: %v", err)
+	}
+
+	cfg, err := Load(configPath, nil)
+	if err != nil {
+		t.Fatalf("Load failed: %v", err)
+	}
+
+	if cfg.CommandTimeout() != 15*time.Second {
+		t.Errorf("Expected CommandTimeout 15s, got %v", cfg.CommandTimeout())
+	}
+
+	ctx, cancel := cfg.CommandContext(context.Background())
+	defer cancel()
+	if _, ok := ctx.Deadline(); !ok {
+		t.Error("Expected CommandContext to have a deadline")
+	}
+}
+
+func TestCommandContext_NoTimeout(t *testing.T) {
+	cfg := DefaultConfig()
+
+	ctx, cancel := cfg.CommandContext(context.Background())
+	defer cancel()
+	if _, ok := ctx.Deadline(); ok {
+		t.Error("Expected no deadline when CommandTimeoutSeconds is 0")
+	}
+}
+
+func TestLoadDefaultBuffer(t *testing.T) {
+	configPath := filepath.Join(t.TempDir(), "config.yaml")
+	if err := os.WriteFile(configPath, []byte("default_buffer: 5\n"), 0644); err != nil {
+		t.Fatalf("Failed to write config file: %v", err)
+	}
+
+	cfg, err := Load(configPath, nil)
+	if err != nil {
+		t.Fatalf("Load failed: %v", err)
+	}
+
+	if cfg.DefaultBufferDuration() != 5*time.Minute {
+		t.Errorf("Expected DefaultBufferDuration 5m, got %v", cfg.DefaultBufferDuration())
+	}
+	if DefaultConfig().DefaultBufferDuration() != 0 {
+		t.Error("Expected no buffer by default")
+	}
+}
+
+func TestLoadRetryPolicy(t *testing.T) {
+	configPath := filepath.Join(t.TempDir(), "config.yaml")
+	if err := os.WriteFile(configPath, []byte("retry_max_attempts: 5\nretry_base_delay_ms: 250\n"), 0644); err != nil {
+		t.Fatalf("Failed to write config file: %v", err)
+	}
+
+	cfg, err := Load(configPath, nil)
+	if err != nil {
+		t.Fatalf("Load failed: %v", err)
+	}
+
+	policy := cfg.RetryPolicy()
+	if policy.MaxAttempts != 5 || policy.BaseDelay != 250*time.Millisecond {
+		t.Errorf("RetryPolicy() = %+v, want 5 attempts from 250ms", policy)
+	}
+	if policy.MaxDelay != calendar.DefaultRetryPolicy().MaxDelay {
+		t.Errorf("MaxDelay = %v, want the default", policy.MaxDelay)
+	}
+	if got := DefaultConfig().RetryPolicy(); got != calendar.DefaultRetryPolicy() {
+		t.Errorf("default RetryPolicy() = %+v, want %+v", got, calendar.DefaultRetryPolicy())
+	}
+
+	cfg = &Config{CredentialsPath: "/c", TokenPath: "/t", RetryMaxAttempts: -1}
+	if err := cfg.Validate(); !errors.Is(err, ErrInvalidRetry) {
+		t.Errorf("Validate() error = %v, want ErrInvalidRetry", err)
+	}
+}
+
+func TestLoadDefaultDescription(t *testing.T) {
+	configPath := filepath.Join(t.TempDir(), "config.yaml")
+	configContent := `
+default_description_prefix: "[calgo]"
+default_description_suffix: Created via calgo
+`
+	if err := os.WriteFile(configPath, []byte(configContent), 0644); err != nil {
+		t.Fatalf("Failed to write config file: %v", err)
+	}
+
+	cfg, err := Load(configPath, nil)
+	if err != nil {
+		t.Fatalf("Load failed: %v", err)
+	}
+
+	if cfg.DefaultDescriptionPrefix != "[calgo]" {
+		t.Errorf("Expected DefaultDescriptionPrefix '[calgo]', got '%s'", cfg.DefaultDescriptionPrefix)
+	}
+	if cfg.DefaultDescriptionSuffix != "Created via calgo" {
+		t.Errorf("Expected DefaultDescriptionSuffix 'Created via calgo', got '%s'", cfg.DefaultDescriptionSuffix)
+	}
+}
+
+func TestRedactedJSON(t *testing.T) {
+	cfg := &Config{
+		CredentialsPath: "/home/alice/.config/calgo/credentials.json",
+		CalendarID:      "team@example.com",
+		DefaultDuration: 45,
+		Timezone:        "Europe/Berlin",
+	}
+
+	data, err := cfg.RedactedJSON()
+	if err != nil {
+		t.Fatalf("RedactedJSON failed: %v", err)
+	}
+
+	if strings.Contains(string(data), "/home/alice") {
+		t.Errorf("RedactedJSON leaked a path:\n%s", data)
+	}
+
+	var got map[string]interface{}
+	if err := json.Unmarshal(data, &got); err != nil {
+		t.Fatalf("RedactedJSON output is not valid JSON: %v", err)
+	}
+
+	want := map[string]interface{}{
+		"credentials_path": "credentials.json",
+		"token_path":       "<unset>",
+		"calendar_id":      "team@example.com",
+		"timezone":         "Europe/Berlin",
+		"default_duration": float64(45),
+	}
+	for key, value := range want {
+		if got[key] != value {
+			t.Errorf("%s = %v, want %v", key, got[key], value)
+		}
+	}
+
+	if cfg.CredentialsPath != "/home/alice/.config/calgo/credentials.json" {
+		t.Error("RedactedJSON must not modify the config")
+	}
+}
+
+func TestWeekStartDay(t *testing.T) {
+	tests := []struct {
+		weekStart string
+		want      time.Weekday
+		wantErr   bool
+	}{
+		{"", time.Monday, false},
+		{"sunday", time.Sunday, false},
+		{"Saturday", time.Saturday, false},
+		{"someday", 0, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.weekStart, func(t *testing.T) {
+			cfg := &Config{CredentialsPath: "/c", TokenPath: "/t", WeekStart: tt.weekStart}
+
+			got, err := cfg.WeekStartDay()
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("WeekStartDay() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("WeekStartDay() = %v, want %v", got, tt.want)
+			}
+			if err := cfg.Validate(); tt.wantErr && !errors.Is(err, ErrInvalidWeekStart) {
+				t.Errorf("Validate() error = %v, want ErrInvalidWeekStart", err)
+			}
+		})
+	}
+}
+
+func TestLoadInternalDomains(t *testing.T) {
+	configPath := filepath.Join(t.TempDir(), "config.yaml")
+	configContent := `
+internal_domains:
+  - Example.COM
+  - "@corp.example.org"
+`
+	if err := os.WriteFile(configPath, []byte(configContent), 0644); err != nil {
+		t.Fatalf("Failed to write config file: %v", err)
+	}
+
+	cfg, err := Load(configPath, nil)
+	if err != nil {
+		t.Fatalf("Load failed: %v", err)
+	}
+
+	want := []string{"example.com", "corp.example.org"}
+	if len(cfg.InternalDomains) != len(want) {
+		t.Fatalf("Expected InternalDomains %v, got %v", want, cfg.InternalDomains)
+	}
+	for i := range want {
+		if cfg.InternalDomains[i] != want[i] {
+			t.Errorf("Expected InternalDomains %v, got %v", want, cfg.InternalDomains)
+			break
+		}
+	}
+}
+
+func TestIsInternal(t *testing.T) {
+	cfg := &Config{InternalDomains: []string{"example.com", "Corp.Example.org"}}
+
+	tests := []struct {
+		email string
+		want  bool
+	}{
+		{"alice@example.com", true},
+		{"Bob@EXAMPLE.COM", true},
+		{"carol@eng.example.com", true},
+		{"dave@corp.example.org", true},
+		{"erin@example.org", false},
+		{"frank@notexample.com", false},
+		{"grace@gmail.com", false},
+		{"not-an-email", false},
+		{"trailing@", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.email, func(t *testing.T) {
+			if got := cfg.IsInternal(tt.email); got != tt.want {
+				t.Errorf("IsInternal(%q) = %v, want %v", tt.email, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestValidate_EmptyInternalDomain(t *testing.T) {
+	cfg := &Config{CredentialsPath: "/c", TokenPath: "/t", InternalDomains: []string{"example.com", " "}}
+
+	if err := cfg.Validate(); !errors.Is(err, ErrInvalidDomain) {
+		t.Errorf("Validate() error = %v, want ErrInvalidDomain", err)
+	}
+}
+
+func TestWorkingHours(t *testing.T) {
+	tests := []struct {
+		name      string
+		start     string
+		end       string
+		wantStart time.Duration
+		wantEnd   time.Duration
+		wantErr   bool
+	}{
+		{name: "defaults", wantStart: 9 * time.Hour, wantEnd: 17 * time.Hour},
+		{name: "both set", start: "08:30", end: "16:00", wantStart: 8*time.Hour + 30*time.Minute, wantEnd: 16 * time.Hour},
+		{name: "only end", end: "18:00", wantStart: 9 * time.Hour, wantEnd: 18 * time.Hour},
+		{name: "not a time", start: "9am", wantErr: true},
+		{name: "end before start", start: "17:00", end: "09:00", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := &Config{CredentialsPath: "/c", TokenPath: "/t", WorkingHoursStart: tt.start, WorkingHoursEnd: tt.end}
+
+			got, err := cfg.WorkingHours()
+			if tt.wantErr {
+				if !errors.Is(err, ErrInvalidWorkingHours) {
+					t.Errorf("WorkingHours() error = %v, want ErrInvalidWorkingHours", err)
+				}
+				if err := cfg.Validate(); !errors.Is(err, ErrInvalidWorkingHours) {
+					t.Errorf("Validate() error = %v, want ErrInvalidWorkingHours", err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("WorkingHours() error = %v", err)
+			}
+			if got.Start != tt.wantStart || got.End != tt.wantEnd {
+				t.Errorf("WorkingHours() = %v-%v, want %v-%v", got.Start, got.End, tt.wantStart, tt.wantEnd)
+			}
+		})
+	}
+}
+
+func TestTokenEncryption(t *testing.T) {
+	t.Setenv("CALGO_TOKEN_PASSPHRASE", "correct horse")
+	configPath := filepath.Join(t.TempDir(), "config.yaml")
+	if err := os.WriteFile(configPath, []byte("token_encryption: passphrase\n"), 0644); err != nil {
+		t.Fatalf("Failed to write config file: %v", err)
+	}
+
+	cfg, err := Load(configPath, nil)
+	if err != nil {
+		t.Fatalf("Load failed: %v", err)
+	}
+	if cfg.TokenEncryption != "passphrase" || cfg.TokenPassphrase != "correct horse" {
+		t.Errorf("TokenEncryption = %q, TokenPassphrase = %q", cfg.TokenEncryption, cfg.TokenPassphrase)
+	}
+
+	tests := []struct {
+		name       string
+		encryption string
+		passphrase string
+		wantErr    bool
+	}{
+		{"plaintext", "", "", false},
+		{"machine", "machine", "", false},
+		{"passphrase", "passphrase", "secret", false},
+		{"passphrase missing", "passphrase", "", true},
+		{"unknown mode", "rot13", "", true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := &Config{CredentialsPath: "/c", TokenPath: "/t", TokenEncryption: tt.encryption, TokenPassphrase: tt.passphrase}
+			err := cfg.Validate()
+			if tt.wantErr != errors.Is(err, ErrInvalidTokenEncryption) {
+				t.Errorf("Validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+
+	data, err := (&Config{TokenPassphrase: "secret"}).RedactedJSON()
+	if err != nil {
+		t.Fatalf("RedactedJSON failed: %v", err)
+	}
+	if strings.Contains(string(data), "secret") {
+		t.Errorf("RedactedJSON leaked the passphrase:\n%s", data)
+	}
+}
+
+func TestValidate_AuthMode(t *testing.T) {
+	tests := []struct {
+		name    string
+		cfg     Config
+		wantErr error
+	}{
+		{"oauth", Config{AuthMode: AuthModeOAuth, CredentialsPath: "/c", TokenPath: "/t"}, nil},
+		{"oauth needs credentials", Config{AuthMode: AuthModeOAuth, TokenPath: "/t"}, ErrMissingCredentialsPath},
+		{"adc needs no files", Config{AuthMode: AuthModeADC}, nil},
+		{"unknown", Config{AuthMode: "kerberos", CredentialsPath: "/c", TokenPath: "/t"}, ErrInvalidAuthMode},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.cfg.Validate()
+			if tt.wantErr == nil && err != nil || tt.wantErr != nil && !errors.Is(err, tt.wantErr) {
+				t.Errorf("Validate() error = %v, want %v", err, tt.wantErr)
+			}
+		})
+	}
+}
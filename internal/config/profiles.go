@@ -0,0 +1,84 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/spf13/viper"
+)
+
+// ErrUnknownProfile is returned when the selected profile isn't defined in
+// config.
+var ErrUnknownProfile = errors.New("unknown profile")
+
+// Profile holds the settings of one Google account, defined under the
+// profiles key in the config file and selected with --profile or
+// CALGO_PROFILE. Set fields replace the top-level settings of the same
+// name; empty ones keep them.
+type Profile struct {
+	// CredentialsPath is the path to the account's OAuth2 credentials.
+	CredentialsPath string `mapstructure:"credentials_path" json:"credentials_path"`
+
+	// TokenPath is where the account's token is stored. Profiles never
+	// share the top-level token: empty means token-<profile>.json in the
+	// config directory.
+	TokenPath string `mapstructure:"token_path" json:"token_path"`
+
+	// CalendarID is the calendar to use with the account.
+	CalendarID string `mapstructure:"calendar_id" json:"calendar_id"`
+
+	// Timezone is the default timezone for the account's events.
+	Timezone string `mapstructure:"timezone" json:"timezone"`
+}
+
+// profileKeys are the config keys a Profile can set.
+var profileKeys = []string{"credentials_path", "token_path", "calendar_id", "timezone"}
+
+// applyProfile sets the keys the named profile defines on v, above the
+// config file and environment but below flags, which are set afterwards.
+// An empty name selects no profile.
+func applyProfile(v *viper.Viper, name string) error {
+	if name == "" {
+		return nil
+	}
+
+	// Viper lowercases keys, so profile names are case-insensitive
+	prefix := "profiles." + strings.ToLower(name)
+	if !v.IsSet(prefix) {
+		return fmt.Errorf("%w: %q (defined: %s)", ErrUnknownProfile, name, profileNames(v))
+	}
+
+	for _, key := range profileKeys {
+		if value := v.GetString(prefix + "." + key); value != "" {
+			v.Set(key, value)
+		}
+	}
+
+	// Inheriting the top-level token would sign the profile in as the
+	// wrong account, and logging in would overwrite that account's token
+	if v.GetString(prefix+".token_path") == "" {
+		dir, err := GetConfigDir()
+		if err != nil {
+			return err
+		}
+		v.Set("token_path", filepath.Join(dir, "token-"+strings.ToLower(name)+".json"))
+	}
+	return nil
+}
+
+// profileNames lists the profiles defined in v, for error messages.
+func profileNames(v *viper.Viper) string {
+	profiles := v.GetStringMap("profiles")
+	names := make([]string, 0, len(profiles))
+	for name := range profiles {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	if len(names) == 0 {
+		return "none"
+	}
+	return strings.Join(names, ", ")
+}
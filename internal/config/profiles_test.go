@@ -0,0 +1,141 @@
+package config
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+const profilesConfig = `
+credentials_path: /home/me/credentials.json
+token_path: /home/me/token.json
+calendar_id: primary
+timezone: America/New_York
+profiles:
+  work:
+    credentials_path: /home/me/work-credentials.json
+    token_path: /home/me/work-token.json
+    calendar_id: me@work.example.com
+  personal:
+    token_path: /home/me/personal-token.json
+    timezone: Europe/Lisbon
+`
+
+func TestLoadProfile(t *testing.T) {
+	configPath := filepath.Join(t.TempDir(), "config.yaml")
+	if err := os.WriteFile(configPath, []byte(profilesConfig), 0644); err != nil {
+		t.Fatalf("Failed to write config file: %v", err)
+	}
+
+	tests := []struct {
+		name      string
+		env       string
+		overrides map[string]interface{}
+		want      Profile
+	}{
+		{
+			name: "no profile",
+			want: Profile{"/home/me/credentials.json", "/home/me/token.json", "primary", "America/New_York"},
+		},
+		{
+			name:      "flag",
+			overrides: map[string]interface{}{"profile": "work"},
+			want:      Profile{"/home/me/work-credentials.json", "/home/me/work-token.json", "me@work.example.com", "America/New_York"},
+		},
+		{
+			name: "environment",
+			env:  "personal",
+			want: Profile{"/home/me/credentials.json", "/home/me/personal-token.json", "primary", "Europe/Lisbon"},
+		},
+		{
+			name:      "flag beats environment",
+			env:       "personal",
+			overrides: map[string]interface{}{"profile": "Work"},
+			want:      Profile{"/home/me/work-credentials.json", "/home/me/work-token.json", "me@work.example.com", "America/New_York"},
+		},
+		{
+			name:      "calendar flag beats profile",
+			overrides: map[string]interface{}{"profile": "work", "calendar_id": "team"},
+			want:      Profile{"/home/me/work-credentials.json", "/home/me/work-token.json", "team", "America/New_York"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Setenv("CALGO_PROFILE", tt.env)
+			t.Setenv("GOOGLE_CALENDAR_CREDENTIALS", "")
+			t.Setenv("GOOGLE_CALENDAR_TOKEN", "")
+			t.Setenv("GOOGLE_CALENDAR_ID", "")
+			t.Setenv("TZ", "")
+
+			cfg, err := Load(configPath, tt.overrides)
+			if err != nil {
+				t.Fatalf("Load failed: %v", err)
+			}
+			got := Profile{cfg.CredentialsPath, cfg.TokenPath, cfg.CalendarID, cfg.Timezone}
+			if got != tt.want {
+				t.Errorf("loaded %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+
+	_, err := Load(configPath, map[string]interface{}{"profile": "school"})
+	if !errors.Is(err, ErrUnknownProfile) || !strings.Contains(err.Error(), "personal, work") {
+		t.Errorf("Load() with an unknown profile error = %v, want ErrUnknownProfile listing the profiles", err)
+	}
+}
+
+func TestLoadProfile_DefaultTokenPath(t *testing.T) {
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+	t.Setenv("GOOGLE_CALENDAR_TOKEN", "")
+
+	configPath := filepath.Join(t.TempDir(), "config.yaml")
+	config := `
+token_path: /home/me/token.json
+profiles:
+  School:
+    calendar_id: me@school.example.com
+`
+	if err := os.WriteFile(configPath, []byte(config), 0644); err != nil {
+		t.Fatalf("Failed to write config file: %v", err)
+	}
+
+	cfg, err := Load(configPath, map[string]interface{}{"profile": "School"})
+	if err != nil {
+		t.Fatalf("Load failed: %v", err)
+	}
+	want := filepath.Join(home, ".config", "calgo", "token-school.json")
+	if cfg.TokenPath != want {
+		t.Errorf("TokenPath = %q, want %q", cfg.TokenPath, want)
+	}
+
+	cfg, err = Load(configPath, nil)
+	if err != nil {
+		t.Fatalf("Load failed: %v", err)
+	}
+	if cfg.TokenPath != "/home/me/token.json" {
+		t.Errorf("TokenPath without a profile = %q, want the top-level token", cfg.TokenPath)
+	}
+}
+
+func TestRedactedJSON_Profiles(t *testing.T) {
+	cfg := &Config{Profiles: map[string]Profile{
+		"work": {CredentialsPath: "/home/alice/work-credentials.json", TokenPath: "/home/alice/work-token.json"},
+	}}
+
+	data, err := cfg.RedactedJSON()
+	if err != nil {
+		t.Fatalf("RedactedJSON failed: %v", err)
+	}
+	if strings.Contains(string(data), "/home/alice") {
+		t.Errorf("RedactedJSON leaked a profile path:\n%s", data)
+	}
+	if !strings.Contains(string(data), "work-token.json") {
+		t.Errorf("RedactedJSON dropped the profile's token file name:\n%s", data)
+	}
+	if cfg.Profiles["work"].TokenPath != "/home/alice/work-token.json" {
+		t.Error("RedactedJSON must not modify the config's profiles")
+	}
+}
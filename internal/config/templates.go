@@ -0,0 +1,113 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/ezer/calgo/internal/calendar"
+)
+
+// ErrUnknownTemplate is returned when a template name isn't defined in config.
+var ErrUnknownTemplate = errors.New("unknown template")
+
+// EventTemplate is a reusable set of event defaults defined under the
+// templates key in the config file.
+type EventTemplate struct {
+	// Title is the event title.
+	Title string `mapstructure:"title" json:"title"`
+
+	// Duration is the event duration in minutes. Zero uses DefaultDuration.
+	Duration int `mapstructure:"duration" json:"duration"`
+
+	// Recurrence is either a phrase such as "every weekday" or a raw RRULE.
+	Recurrence string `mapstructure:"recurrence" json:"recurrence"`
+
+	// Description is the event description.
+	Description string `mapstructure:"description" json:"description"`
+
+	// Location is the event location.
+	Location string `mapstructure:"location" json:"location"`
+
+	// Attendees holds the email addresses of guests to invite.
+	Attendees []string `mapstructure:"attendees" json:"attendees"`
+
+	// Reminders holds popup reminders in minutes before the event. Empty
+	// uses the calendar's default reminders.
+	Reminders []int `mapstructure:"reminders" json:"reminders"`
+
+	// Color is an event color name or ID, e.g. "tomato".
+	Color string `mapstructure:"color" json:"color"`
+}
+
+// ResolveTemplate converts the named template into EventParams. The start
+// time is left unset for the caller to fill in.
+func (c *Config) ResolveTemplate(name string) (calendar.EventParams, error) {
+	tmpl, ok := c.Templates[name]
+	if !ok {
+		return calendar.EventParams{}, fmt.Errorf("%w: %q (defined: %s)", ErrUnknownTemplate, name, c.templateNames())
+	}
+
+	minutes := tmpl.Duration
+	if minutes == 0 {
+		minutes = c.DefaultDuration
+	}
+	if minutes < 0 {
+		return calendar.EventParams{}, fmt.Errorf("template %q: duration must be positive", name)
+	}
+
+	params := calendar.EventParams{
+		Title:       tmpl.Title,
+		Duration:    time.Duration(minutes) * time.Minute,
+		Description: tmpl.Description,
+		Location:    tmpl.Location,
+	}
+
+	if len(tmpl.Attendees) > 0 {
+		attendees, err := calendar.ParseAttendees(strings.Join(tmpl.Attendees, ","))
+		if err != nil {
+			return calendar.EventParams{}, fmt.Errorf("template %q: %w", name, err)
+		}
+		params.Attendees = attendees
+	}
+
+	for _, minutes := range tmpl.Reminders {
+		params.Reminders = append(params.Reminders, time.Duration(minutes)*time.Minute)
+	}
+
+	if tmpl.Color != "" {
+		if _, err := calendar.ResolveColorID(tmpl.Color); err != nil {
+			return calendar.EventParams{}, fmt.Errorf("template %q: %w", name, err)
+		}
+		params.Color = tmpl.Color
+	}
+
+	if tmpl.Recurrence != "" {
+		rule := tmpl.Recurrence
+		if !strings.HasPrefix(strings.ToUpper(rule), "RRULE:") {
+			parsed, err := calendar.ParseRecurrencePhrase(rule)
+			if err != nil {
+				return calendar.EventParams{}, fmt.Errorf("template %q: %w", name, err)
+			}
+			rule = parsed
+		}
+		params.Recurrence = []string{rule}
+	}
+
+	return params, nil
+}
+
+// templateNames returns the defined template names, sorted, for error messages.
+func (c *Config) templateNames() string {
+	names := make([]string, 0, len(c.Templates))
+	for name := range c.Templates {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	if len(names) == 0 {
+		return "none"
+	}
+	return strings.Join(names, ", ")
+}
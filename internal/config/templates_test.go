@@ -0,0 +1,121 @@
+package config
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/ezer/calgo/internal/calendar"
+)
+
+func TestLoadTemplates(t *testing.T) {
+	tmpDir := t.TempDir()
+	configPath := filepath.Join(tmpDir, "config.yaml")
+
+	configContent := `
+templates:
+  standup:
+    title: Standup
+    duration: 15
+    recurrence: every weekday
+`
+	if err := os.WriteFile(configPath, []byte(configContent), 0644); err != nil {
+		t.Fatalf("Failed to write config file: %v", err)
+	}
+
+	cfg, err := Load(configPath, nil)
+	if err != nil {
+		t.Fatalf("Load failed: %v", err)
+	}
+
+	tmpl, ok := cfg.Templates["standup"]
+	if !ok {
+		t.Fatalf("Expected 'standup' template, got %v", cfg.Templates)
+	}
+
+	if tmpl.Title != "Standup" {
+		t.Errorf("Expected Title 'Standup', got '%s'", tmpl.Title)
+	}
+	if tmpl.Duration != 15 {
+		t.Errorf("Expected Duration 15, got %d", tmpl.Duration)
+	}
+	if tmpl.Recurrence != "every weekday" {
+		t.Errorf("Expected Recurrence 'every weekday', got '%s'", tmpl.Recurrence)
+	}
+}
+
+func TestResolveTemplate(t *testing.T) {
+	cfg := DefaultConfig()
+	cfg.Templates = map[string]EventTemplate{
+		"standup": {Title: "Standup", Duration: 15, Recurrence: "every weekday"},
+		"review":  {Title: "Review", Recurrence: "RRULE:FREQ=MONTHLY"},
+	}
+
+	params, err := cfg.ResolveTemplate("standup")
+	if err != nil {
+		t.Fatalf("ResolveTemplate failed: %v", err)
+	}
+
+	if params.Title != "Standup" {
+		t.Errorf("Expected Title 'Standup', got '%s'", params.Title)
+	}
+	if params.Duration != 15*time.Minute {
+		t.Errorf("Expected Duration 15m, got %v", params.Duration)
+	}
+	if len(params.Recurrence) != 1 || params.Recurrence[0] != "RRULE:FREQ=WEEKLY;BYDAY=MO,TU,WE,TH,FR" {
+		t.Errorf("Unexpected Recurrence %v", params.Recurrence)
+	}
+
+	params, err = cfg.ResolveTemplate("review")
+	if err != nil {
+		t.Fatalf("ResolveTemplate failed: %v", err)
+	}
+	if params.Duration != 30*time.Minute {
+		t.Errorf("Expected default Duration 30m, got %v", params.Duration)
+	}
+	if params.Recurrence[0] != "RRULE:FREQ=MONTHLY" {
+		t.Errorf("Expected raw RRULE to pass through, got %v", params.Recurrence)
+	}
+}
+
+func TestResolveTemplate_GuestsRemindersColor(t *testing.T) {
+	cfg := DefaultConfig()
+	cfg.Templates = map[string]EventTemplate{
+		"sync":  {Title: "Sync", Attendees: []string{"a@example.com", "A@example.com, b@example.com"}, Reminders: []int{10, 0}, Color: "Tomato"},
+		"bad":   {Title: "Bad", Color: "chartreuse"},
+		"guest": {Title: "Guest", Attendees: []string{"not-an-email"}},
+	}
+
+	params, err := cfg.ResolveTemplate("sync")
+	if err != nil {
+		t.Fatalf("ResolveTemplate failed: %v", err)
+	}
+	if want := []string{"a@example.com", "b@example.com"}; !reflect.DeepEqual(params.Attendees, want) {
+		t.Errorf("Expected Attendees %v, got %v", want, params.Attendees)
+	}
+	if want := []time.Duration{10 * time.Minute, 0}; !reflect.DeepEqual(params.Reminders, want) {
+		t.Errorf("Expected Reminders %v, got %v", want, params.Reminders)
+	}
+	if params.Color != "Tomato" {
+		t.Errorf("Expected Color 'Tomato', got '%s'", params.Color)
+	}
+
+	if _, err := cfg.ResolveTemplate("bad"); !errors.Is(err, calendar.ErrUnknownColor) {
+		t.Errorf("Expected ErrUnknownColor, got %v", err)
+	}
+	if _, err := cfg.ResolveTemplate("guest"); !errors.Is(err, calendar.ErrInvalidAttendee) {
+		t.Errorf("Expected ErrInvalidAttendee, got %v", err)
+	}
+}
+
+func TestResolveTemplate_Unknown(t *testing.T) {
+	cfg := DefaultConfig()
+
+	_, err := cfg.ResolveTemplate("missing")
+	if !errors.Is(err, ErrUnknownTemplate) {
+		t.Errorf("Expected ErrUnknownTemplate, got %v", err)
+	}
+}
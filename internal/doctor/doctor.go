@@ -0,0 +1,129 @@
+// Package doctor runs read-only health checks backing the doctor command.
+package doctor
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"time"
+)
+
+// CheckResult is the outcome of a single health check.
+type CheckResult struct {
+	// Name identifies the check, e.g. "credentials".
+	Name string
+
+	// OK reports whether the check passed.
+	OK bool
+
+	// Detail explains the outcome, including how to fix a failure.
+	Detail string
+}
+
+// Authenticator is the subset of *auth.Authenticator used by SelfTest.
+type Authenticator interface {
+	LoadCredentials() error
+	CheckToken(ctx context.Context) error
+}
+
+// CalendarChecker is the subset of *calendar.Client used by SelfTest.
+type CalendarChecker interface {
+	CheckAccess(ctx context.Context) error
+}
+
+// Doctor holds what SelfTest needs to check.
+type Doctor struct {
+	CredentialsPath string
+	TokenPath       string
+	Timezone        string
+
+	Auth Authenticator
+
+	// Calendar may be nil when no client could be built, in which case the
+	// calendar check is reported as failed.
+	Calendar CalendarChecker
+}
+
+// SelfTest runs every check in order and returns one result per check. A
+// failing check doesn't stop later ones. Nothing is modified: the browser
+// flow is never started and the token file is never rewritten.
+func (d *Doctor) SelfTest(ctx context.Context) []CheckResult {
+	return []CheckResult{
+		d.checkCredentials(),
+		d.checkToken(ctx),
+		d.checkCalendar(ctx),
+		d.checkTimezone(),
+	}
+}
+
+// checkCredentials verifies that the credentials file exists and parses.
+func (d *Doctor) checkCredentials() CheckResult {
+	result := CheckResult{Name: "credentials"}
+
+	if _, err := os.Stat(d.CredentialsPath); err != nil {
+		result.Detail = fmt.Sprintf("credentials file not readable: %v", err)
+		return result
+	}
+
+	if err := d.Auth.LoadCredentials(); err != nil {
+		result.Detail = err.Error()
+		return result
+	}
+
+	result.OK = true
+	result.Detail = d.CredentialsPath
+	return result
+}
+
+// checkToken verifies that a saved token exists and is valid or refreshable.
+func (d *Doctor) checkToken(ctx context.Context) CheckResult {
+	result := CheckResult{Name: "token"}
+
+	if err := d.Auth.CheckToken(ctx); err != nil {
+		result.Detail = fmt.Sprintf("%v (run calgo auth login to re-authenticate)", err)
+		return result
+	}
+
+	result.OK = true
+	result.Detail = d.TokenPath
+	return result
+}
+
+// checkCalendar verifies that the configured calendar is readable.
+func (d *Doctor) checkCalendar(ctx context.Context) CheckResult {
+	result := CheckResult{Name: "calendar"}
+
+	if d.Calendar == nil {
+		result.Detail = "not checked: no calendar client available"
+		return result
+	}
+
+	if err := d.Calendar.CheckAccess(ctx); err != nil {
+		result.Detail = err.Error()
+		return result
+	}
+
+	result.OK = true
+	result.Detail = "calendar is accessible"
+	return result
+}
+
+// checkTimezone verifies that the configured timezone, if any, is known.
+func (d *Doctor) checkTimezone() CheckResult {
+	result := CheckResult{Name: "timezone"}
+
+	if d.Timezone == "" {
+		result.OK = true
+		result.Detail = fmt.Sprintf("not set, using system timezone %s", time.Local)
+		return result
+	}
+
+	if _, err := time.LoadLocation(d.Timezone); err != nil {
+		result.Detail = fmt.Sprintf("invalid timezone %q: %v", d.Timezone, err)
+		return result
+	}
+
+	result.OK = true
+	result.Detail = d.Timezone
+	return result
+}
@@ -0,0 +1,106 @@
+package doctor
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+type fakeAuth struct {
+	credentialsErr error
+	tokenErr       error
+}
+
+func (f *fakeAuth) LoadCredentials() error               { return f.credentialsErr }
+func (f *fakeAuth) CheckToken(ctx context.Context) error { return f.tokenErr }
+
+type fakeCalendar struct {
+	err error
+}
+
+func (f *fakeCalendar) CheckAccess(ctx context.Context) error { return f.err }
+
+func writeCredentials(t *testing.T) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "credentials.json")
+	if err := os.WriteFile(path, []byte("{}"), 0600); err != nil {
+		t.Fatalf("Failed to write credentials: %v", err)
+	}
+	return path
+}
+
+func TestSelfTest_AllPass(t *testing.T) {
+	d := &Doctor{
+		CredentialsPath: writeCredentials(t),
+		TokenPath:       "/tmp/token.json",
+		Timezone:        "America/New_York",
+		Auth:            &fakeAuth{},
+		Calendar:        &fakeCalendar{},
+	}
+
+	results := d.SelfTest(context.Background())
+
+	wantNames := []string{"credentials", "token", "calendar", "timezone"}
+	if len(results) != len(wantNames) {
+		t.Fatalf("Expected %d results, got %d", len(wantNames), len(results))
+	}
+	for i, result := range results {
+		if result.Name != wantNames[i] {
+			t.Errorf("result %d Name = %q, want %q", i, result.Name, wantNames[i])
+		}
+		if !result.OK {
+			t.Errorf("check %q failed: %s", result.Name, result.Detail)
+		}
+	}
+}
+
+func TestSelfTest_Failures(t *testing.T) {
+	d := &Doctor{
+		CredentialsPath: writeCredentials(t),
+		Timezone:        "Mars/Olympus_Mons",
+		Auth: &fakeAuth{
+			credentialsErr: errors.New("invalid credentials file format"),
+			tokenErr:       errors.New("failed to load token"),
+		},
+		Calendar: &fakeCalendar{err: errors.New("permission denied")},
+	}
+
+	results := d.SelfTest(context.Background())
+
+	wantDetails := map[string]string{
+		"credentials": "invalid credentials file format",
+		"token":       "re-authenticate",
+		"calendar":    "permission denied",
+		"timezone":    "Mars/Olympus_Mons",
+	}
+	for _, result := range results {
+		if result.OK {
+			t.Errorf("check %q should have failed", result.Name)
+		}
+		if !strings.Contains(result.Detail, wantDetails[result.Name]) {
+			t.Errorf("check %q Detail = %q, want it to contain %q", result.Name, result.Detail, wantDetails[result.Name])
+		}
+	}
+}
+
+func TestSelfTest_MissingCredentialsAndClient(t *testing.T) {
+	d := &Doctor{
+		CredentialsPath: filepath.Join(t.TempDir(), "missing.json"),
+		Auth:            &fakeAuth{},
+	}
+
+	results := d.SelfTest(context.Background())
+
+	if results[0].OK || !strings.Contains(results[0].Detail, "not readable") {
+		t.Errorf("credentials result = %+v, want missing file failure", results[0])
+	}
+	if results[2].OK || !strings.Contains(results[2].Detail, "not checked") {
+		t.Errorf("calendar result = %+v, want not-checked failure", results[2])
+	}
+	if !results[3].OK {
+		t.Errorf("empty timezone should fall back to system timezone, got %+v", results[3])
+	}
+}